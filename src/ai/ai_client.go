@@ -2,214 +2,157 @@ package ai
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
 	"strings"
+	"time"
 
-	"courses-service/src/config"
 	"courses-service/src/model"
 	"courses-service/src/schemas"
-
-	"google.golang.org/genai"
 )
 
-type AiClient struct {
-	context      context.Context
-	GeminiApiKey string
-	Client       *genai.Client
-}
-
-const aiModel = "gemini-2.0-flash"
-
-func generateStudentFeedbacksPrompt(feedbacks []*model.StudentFeedback) string {
-	prompt := SummarizeStudentFeedbacksPrompt
-	for _, feedback := range feedbacks {
-		prompt += fmt.Sprintf("Puntuacion: %d\n", feedback.Score)
-		prompt += fmt.Sprintf("Tipo: %s\n", feedback.FeedbackType)
-		prompt += fmt.Sprintf("Feedback: %s\n", feedback.Feedback)
-	}
-	return prompt
-}
-
-func generateCourseFeedbacksPrompt(feedbacks []*model.CourseFeedback) string {
-	prompt := SummarizeCourseFeedbacksPrompt
-	for _, feedback := range feedbacks {
-		prompt += fmt.Sprintf("Puntuacion: %d\n", feedback.Score)
-		prompt += fmt.Sprintf("Tipo: %s\n", feedback.FeedbackType)
-		prompt += fmt.Sprintf("Feedback: %s\n", feedback.Feedback)
-	}
-	return prompt
+// textModel sends a prompt to a language model and returns the generated text.
+// When a schema is given the model must answer with JSON following it.
+type textModel interface {
+	name() string
+	generateText(ctx context.Context, prompt string, schema *responseSchema) (string, TokenUsage, error)
 }
 
-func generateSubmissionFeedbackPrompt(score *float64, feedback string) string {
-	prompt := SummarizeSubmissionFeedbackPrompt
-	if score != nil {
-		prompt += fmt.Sprintf("Puntuacion: %.2f\n", *score)
-	} else {
-		prompt += "Puntuacion: No asignada\n"
-	}
-	prompt += fmt.Sprintf("Feedback: %s\n", feedback)
-	return prompt
+// AiClient builds the prompts of the service and parses the answers of a language model.
+// The providers backed by a real model embed it and supply the text generation.
+type AiClient struct {
+	model    textModel
+	settings Settings
+	hooks    Hooks
+	breaker  *circuitBreaker
 }
 
-func generateSubmissionCorrectionPrompt(assignment *model.Assignment, submission *model.Submission) string {
-	prompt := CorrectSubmissionPrompt
-
-	// Add assignment info
-	prompt += "ASSIGNMENT INFO:\n"
-	prompt += fmt.Sprintf("Título: %s\n", assignment.Title)
-	prompt += fmt.Sprintf("Puntaje Máximo: %.2f\n", assignment.TotalPoints)
-	prompt += fmt.Sprintf("Tipo: %s\n\n", assignment.Type)
-
-	// Create a map of assignment questions for easy lookup
-	questionMap := make(map[string]model.Question)
-	for _, question := range assignment.Questions {
-		questionMap[question.ID] = question
-	}
-
-	// Add questions and student answers
-	for _, answer := range submission.Answers {
-		if question, exists := questionMap[answer.QuestionID]; exists {
-			prompt += fmt.Sprintf("ID: %s\n", question.ID)
-			prompt += fmt.Sprintf("Pregunta: %s\n", question.Text)
-			prompt += fmt.Sprintf("Tipo: %s\n", question.Type)
-			prompt += fmt.Sprintf("Puntaje: %.2f\n", question.Points)
-
-			// Add correct answers
-			if len(question.CorrectAnswers) > 0 {
-				prompt += fmt.Sprintf("Respuestas Correctas: %v\n", question.CorrectAnswers)
-			}
-
-			// Add student answer
-			prompt += fmt.Sprintf("Respuesta del Estudiante: %v\n", answer.Content)
-			prompt += "\n---\n\n"
-		}
+func newAiClient(model textModel, settings Settings, hooks Hooks) *AiClient {
+	return &AiClient{
+		model:    model,
+		settings: settings,
+		hooks:    hooks,
+		breaker:  newCircuitBreaker(settings.BreakerThreshold, settings.BreakerCooldown),
 	}
-
-	return prompt
 }
 
-func NewAiClient(config *config.Config) *AiClient {
+// The prompts are written in the language of the scope of the context.
+// The feedback summaries are cached, they only change when new feedback is written
 
-	if config.Environment == "test" {
-		return &AiClient{
-			context:      context.Background(),
-			GeminiApiKey: "",
-			Client:       nil,
-		}
-	}
-	geminiApiKey := config.GeminiApiKey
-	ctx := context.Background()
-	client, err := genai.NewClient(ctx, &genai.ClientConfig{
-		APIKey: geminiApiKey,
-	})
+func (c *AiClient) SummarizeCourseFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (string, error) {
+	prompt, err := generateCourseFeedbacksPrompt(ScopeFrom(ctx).Language, feedbacks)
 	if err != nil {
-		log.Fatal("Failed to create Gemini client", err)
-		return nil
-	}
-	log.Println("Gemini client created")
-	return &AiClient{
-		context:      ctx,
-		GeminiApiKey: geminiApiKey,
-		Client:       client,
+		return "", err
 	}
+	return c.cachedGenerate(ctx, OperationCourseFeedbackSummary, prompt)
 }
 
-func (c *AiClient) SummarizeCourseFeedbacks(feedbacks []*model.CourseFeedback) (string, error) {
-	prompt := generateCourseFeedbacksPrompt(feedbacks)
-	response, err := c.Client.Models.GenerateContent(c.context, aiModel, genai.Text(prompt), nil)
+func (c *AiClient) SummarizeStudentFeedbacks(ctx context.Context, feedbacks []*model.StudentFeedback) (string, error) {
+	prompt, err := generateStudentFeedbacksPrompt(ScopeFrom(ctx).Language, feedbacks)
 	if err != nil {
-		log.Fatal("Failed to generate content", err)
 		return "", err
 	}
-	return obtainAnswerFromModel(response)
+	return c.cachedGenerate(ctx, OperationStudentFeedbackSummary, prompt)
 }
 
-func (c *AiClient) SummarizeStudentFeedbacks(feedbacks []*model.StudentFeedback) (string, error) {
-	prompt := generateStudentFeedbacksPrompt(feedbacks)
-	response, err := c.Client.Models.GenerateContent(c.context, aiModel, genai.Text(prompt), nil)
+func (c *AiClient) SummarizeSubmissionFeedback(ctx context.Context, score *float64, feedback string) (string, error) {
+	prompt, err := generateSubmissionFeedbackPrompt(ScopeFrom(ctx).Language, score, feedback)
 	if err != nil {
-		log.Fatal("Failed to generate content", err)
 		return "", err
 	}
-	return obtainAnswerFromModel(response)
+	return c.generate(ctx, OperationSubmissionFeedbackSummary, prompt, nil)
 }
 
-func (c *AiClient) SummarizeSubmissionFeedback(score *float64, feedback string) (string, error) {
-	if c.Client == nil {
-		// Return a mock response for test environment
-		return "Resumen de retroalimentación generado por IA (entorno de test)", nil
+// CorrectSubmission asks the model for a correction following the correction schema.
+// Answers that don't pass the validation return ErrInvalidCorrection.
+func (c *AiClient) CorrectSubmission(ctx context.Context, assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
+	prompt, err := generateSubmissionCorrectionPrompt(ScopeFrom(ctx).Language, assignment, submission)
+	if err != nil {
+		return nil, err
+	}
+	rawResponse, err := c.generate(ctx, OperationSubmissionCorrection, prompt, correctionSchema)
+	if err != nil {
+		log.Printf("Failed to generate correction content: %v", err)
+		return nil, err
 	}
 
-	prompt := generateSubmissionFeedbackPrompt(score, feedback)
-	response, err := c.Client.Models.GenerateContent(c.context, aiModel, genai.Text(prompt), nil)
+	correction, err := parseCorrection(rawResponse)
+	if err == nil {
+		err = validateCorrection(correction, assignment, submission)
+	}
 	if err != nil {
-		log.Fatal("Failed to generate content", err)
-		return "", err
+		log.Printf("Invalid AI correction response: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCorrection, err)
 	}
-	return obtainAnswerFromModel(response)
+	correction.PromptVersion = prompt.version
+	return correction, nil
 }
 
-func (c *AiClient) CorrectSubmission(assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
-	if c.Client == nil {
-		// Return a mock response for test environment
-		return &schemas.AiCorrectionResponse{
-			AIScore:           assignment.TotalPoints * 0.8, // Mock score
-			AIFeedback:        "Corrección automática realizada en entorno de test",
-			NeedsManualReview: false,
-		}, nil
+// cachedGenerate answers from the cache when the same prompt was already run on the model
+func (c *AiClient) cachedGenerate(ctx context.Context, operation string, prompt renderedPrompt) (string, error) {
+	if c.hooks.Cache == nil {
+		return c.generate(ctx, operation, prompt, nil)
 	}
 
-	prompt := generateSubmissionCorrectionPrompt(assignment, submission)
-	response, err := c.Client.Models.GenerateContent(c.context, aiModel, genai.Text(prompt), nil)
-	if err != nil {
-		log.Printf("Failed to generate correction content: %v", err)
-		return nil, err
+	key := cacheKey(operation, c.settings.Model, prompt.text)
+	if answer, found := c.hooks.Cache.Get(ctx, key); found {
+		c.recordUsage(ctx, Usage{Operation: operation, PromptVersion: prompt.version, Cached: true, Success: true})
+		return answer, nil
 	}
 
-	rawResponse, err := obtainAnswerFromModel(response)
+	answer, err := c.generate(ctx, operation, prompt, nil)
 	if err != nil {
-		return nil, err
+		return "", err
 	}
+	c.hooks.Cache.Set(ctx, key, operation, answer)
+	return answer, nil
+}
 
-	// Parse the JSON response from AI
-	var correctionResponse schemas.AiCorrectionResponse
-	// Clean the response to extract only the JSON part
-	cleanedResponse := strings.TrimSpace(rawResponse)
-	if strings.Contains(cleanedResponse, "{") {
-		// Find the first { and last }
-		start := strings.Index(cleanedResponse, "{")
-		end := strings.LastIndex(cleanedResponse, "}")
-		if start != -1 && end != -1 && end > start {
-			jsonStr := cleanedResponse[start : end+1]
-			err = json.Unmarshal([]byte(jsonStr), &correctionResponse)
-			if err != nil {
-				log.Printf("Failed to parse AI correction response: %v", err)
-				// Return a fallback response
-				return &schemas.AiCorrectionResponse{
-					AIScore:           0,
-					AIFeedback:        "Error al procesar la corrección automática. Requiere revisión manual.",
-					NeedsManualReview: true,
-				}, nil
-			}
+// generate runs the prompt on the model, retrying transient errors. Errors that survive the retries
+// count as failures of the circuit breaker, while it's open the model isn't called at all.
+func (c *AiClient) generate(ctx context.Context, operation string, prompt renderedPrompt, schema *responseSchema) (string, error) {
+	if !c.breaker.allow() {
+		return "", fmt.Errorf("%w: circuit breaker is open", ErrUnavailable)
+	}
+
+	start := time.Now()
+	var tokens TokenUsage
+	answer, err := retry(ctx, c.settings, func(ctx context.Context) (string, error) {
+		answer, usage, err := c.model.generateText(ctx, prompt.text, schema)
+		// Failed attempts may also be billed
+		tokens.PromptTokens += usage.PromptTokens
+		tokens.CompletionTokens += usage.CompletionTokens
+		return answer, err
+	})
+	c.recordUsage(ctx, Usage{Operation: operation, PromptVersion: prompt.version, TokenUsage: tokens, Latency: time.Since(start), Success: err == nil})
+
+	if err != nil {
+		if ctx.Err() != nil {
+			// The caller gave up, that says nothing about the health of the model
+			c.breaker.abandon()
+			return "", fmt.Errorf("failed to generate content with %s: %w", c.settings.Model, err)
 		}
+		if !isRetryable(err) {
+			c.breaker.success()
+			return "", fmt.Errorf("failed to generate content with %s: %w", c.settings.Model, err)
+		}
+		c.breaker.failure()
+		return "", fmt.Errorf("%w: failed to generate content with %s: %v", ErrUnavailable, c.settings.Model, err)
 	}
+	c.breaker.success()
 
-	return &correctionResponse, nil
-}
-
-func obtainAnswerFromModel(result *genai.GenerateContentResponse) (string, error) {
-	if len(result.Candidates) == 0 {
+	if strings.TrimSpace(answer) == "" {
 		return "", errors.New("no answer found")
 	}
-	answer := result.Candidates[0]
+	return answer, nil
+}
 
-	for _, part := range answer.Content.Parts {
-		if len(part.Text) > 0 {
-			return part.Text, nil
-		}
+func (c *AiClient) recordUsage(ctx context.Context, usage Usage) {
+	if c.hooks.Usage == nil {
+		return
 	}
-	return "", errors.New("no answer found")
+	usage.Provider = c.model.name()
+	usage.Model = c.settings.Model
+	c.hooks.Usage.RecordUsage(ctx, usage)
 }
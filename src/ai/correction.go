@@ -0,0 +1,174 @@
+package ai
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+// ErrInvalidCorrection is returned when the answer of the model doesn't follow the correction schema
+// or its scores are out of range. These corrections can't be trusted and need manual review.
+var ErrInvalidCorrection = errors.New("invalid AI correction")
+
+// scoreTolerance absorbs the rounding of the scores written by the model
+const scoreTolerance = 0.01
+
+// responseSchema describes the JSON answer expected from the model, it's the subset of JSON Schema
+// supported by the structured output of every provider
+type responseSchema struct {
+	Type                 string                     `json:"type"`
+	Description          string                     `json:"description,omitempty"`
+	Properties           map[string]*responseSchema `json:"properties,omitempty"`
+	Required             []string                   `json:"required,omitempty"`
+	Items                *responseSchema            `json:"items,omitempty"`
+	Enum                 []string                   `json:"enum,omitempty"`
+	AdditionalProperties *bool                      `json:"additionalProperties,omitempty"`
+}
+
+// objectSchema builds an object schema where every property is required and no others are allowed
+func objectSchema(names []string, properties ...*responseSchema) *responseSchema {
+	closed := false
+	schema := &responseSchema{
+		Type:                 "object",
+		Properties:           make(map[string]*responseSchema),
+		Required:             names,
+		AdditionalProperties: &closed,
+	}
+	for i, name := range names {
+		schema.Properties[name] = properties[i]
+	}
+	return schema
+}
+
+var correctionSchema = objectSchema(
+	[]string{"ai_score", "ai_feedback", "needs_manual_review", "questions"},
+	&responseSchema{Type: "number", Description: "Puntaje total, entre 0 y el puntaje máximo del assignment"},
+	&responseSchema{Type: "string", Description: "Feedback consolidado de toda la entrega, en el idioma que piden las instrucciones"},
+	&responseSchema{Type: "boolean"},
+	&responseSchema{Type: "array", Items: objectSchema(
+		[]string{"question_id", "score", "feedback"},
+		&responseSchema{Type: "string"},
+		&responseSchema{Type: "number", Description: "Puntaje de la pregunta, entre 0 y su puntaje máximo"},
+		&responseSchema{Type: "string", Description: "Comentario breve, en el idioma que piden las instrucciones"},
+	)},
+)
+
+// rawCorrection uses pointers to tell missing fields from zero values
+type rawCorrection struct {
+	AIScore           *float64                 `json:"ai_score"`
+	AIFeedback        *string                  `json:"ai_feedback"`
+	NeedsManualReview *bool                    `json:"needs_manual_review"`
+	Questions         *[]rawQuestionCorrection `json:"questions"`
+}
+
+type rawQuestionCorrection struct {
+	QuestionID *string  `json:"question_id"`
+	Score      *float64 `json:"score"`
+	Feedback   *string  `json:"feedback"`
+}
+
+// decodeAnswer decodes an answer of the model that must be a single JSON object with no fields
+// other than the ones of target
+func decodeAnswer(rawResponse string, target any) error {
+	// Models without structured output tend to wrap the JSON in a markdown code block
+	cleaned := strings.TrimSpace(rawResponse)
+	if strings.HasPrefix(cleaned, "```") && strings.HasSuffix(cleaned, "```") {
+		cleaned = strings.TrimPrefix(strings.TrimPrefix(cleaned, "```"), "json")
+		cleaned = strings.TrimSpace(strings.TrimSuffix(cleaned, "```"))
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(cleaned)))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("response is not valid JSON: %v", err)
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return errors.New("unexpected content after the JSON object")
+	}
+	return nil
+}
+
+// parseCorrection decodes the answer of the model, which must be a single JSON object with every
+// field of the correction schema and nothing else
+func parseCorrection(rawResponse string) (*schemas.AiCorrectionResponse, error) {
+	var raw rawCorrection
+	if err := decodeAnswer(rawResponse, &raw); err != nil {
+		return nil, err
+	}
+	if raw.AIScore == nil || raw.AIFeedback == nil || raw.NeedsManualReview == nil || raw.Questions == nil {
+		return nil, errors.New("missing required fields")
+	}
+
+	correction := &schemas.AiCorrectionResponse{
+		AIScore:           *raw.AIScore,
+		AIFeedback:        *raw.AIFeedback,
+		NeedsManualReview: *raw.NeedsManualReview,
+	}
+	for i, question := range *raw.Questions {
+		if question.QuestionID == nil || question.Score == nil || question.Feedback == nil {
+			return nil, fmt.Errorf("missing required fields in question %d", i)
+		}
+		correction.Questions = append(correction.Questions, schemas.AiQuestionCorrection{
+			QuestionID: *question.QuestionID,
+			Score:      *question.Score,
+			Feedback:   *question.Feedback,
+		})
+	}
+	return correction, nil
+}
+
+// validateCorrection checks the scores against the assignment. Every answered question must be
+// corrected once, within its points, and the breakdown must add up to the total score.
+func validateCorrection(correction *schemas.AiCorrectionResponse, assignment *model.Assignment, submission *model.Submission) error {
+	if !validScore(correction.AIScore, assignment.TotalPoints) {
+		return fmt.Errorf("score %v is out of range [0, %v]", correction.AIScore, assignment.TotalPoints)
+	}
+	if strings.TrimSpace(correction.AIFeedback) == "" {
+		return errors.New("feedback is empty")
+	}
+
+	// Only the answered questions are sent to the model
+	questionMap := make(map[string]model.Question)
+	for _, question := range assignment.Questions {
+		questionMap[question.ID] = question
+	}
+	pending := make(map[string]bool)
+	for _, answer := range submission.Answers {
+		if _, exists := questionMap[answer.QuestionID]; exists {
+			pending[answer.QuestionID] = true
+		}
+	}
+
+	var total float64
+	for _, questionCorrection := range correction.Questions {
+		question, exists := questionMap[questionCorrection.QuestionID]
+		if !exists || !pending[question.ID] {
+			return fmt.Errorf("unexpected or repeated question %s", questionCorrection.QuestionID)
+		}
+		delete(pending, question.ID)
+
+		if !validScore(questionCorrection.Score, question.Points) {
+			return fmt.Errorf("score %v of question %s is out of range [0, %v]", questionCorrection.Score, question.ID, question.Points)
+		}
+		total += questionCorrection.Score
+	}
+	for questionID := range pending {
+		return fmt.Errorf("question %s was not corrected", questionID)
+	}
+
+	if len(correction.Questions) > 0 && math.Abs(total-correction.AIScore) > scoreTolerance {
+		return fmt.Errorf("question scores add up to %v instead of %v", total, correction.AIScore)
+	}
+	return nil
+}
+
+func validScore(score, maxScore float64) bool {
+	return !math.IsNaN(score) && score >= 0 && score <= maxScore+scoreTolerance
+}
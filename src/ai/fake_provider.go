@@ -0,0 +1,106 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+// FakePromptVersion is recorded on the corrections of the fake provider, it uses no prompt
+const FakePromptVersion = "fake"
+
+// FakeProvider answers without calling any model, the answers only depend on the input
+type FakeProvider struct{}
+
+var _ Provider = (*FakeProvider)(nil)
+
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+func (p *FakeProvider) SummarizeCourseFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (string, error) {
+	return fmt.Sprintf("Resumen de %d feedbacks del curso generado por IA (entorno de test)", len(feedbacks)), nil
+}
+
+func (p *FakeProvider) SummarizeStudentFeedbacks(ctx context.Context, feedbacks []*model.StudentFeedback) (string, error) {
+	return fmt.Sprintf("Resumen de %d feedbacks del estudiante generado por IA (entorno de test)", len(feedbacks)), nil
+}
+
+func (p *FakeProvider) SummarizeSubmissionFeedback(ctx context.Context, score *float64, feedback string) (string, error) {
+	return "Resumen de retroalimentación generado por IA (entorno de test)", nil
+}
+
+// CorrectSubmission gives 80% of the points of the assignment and of each of its questions
+func (p *FakeProvider) CorrectSubmission(ctx context.Context, assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
+	response := &schemas.AiCorrectionResponse{
+		AIScore:           assignment.TotalPoints * 0.8,
+		AIFeedback:        "Corrección automática realizada en entorno de test",
+		NeedsManualReview: false,
+		PromptVersion:     FakePromptVersion,
+	}
+	for _, question := range assignment.Questions {
+		response.Questions = append(response.Questions, schemas.AiQuestionCorrection{
+			QuestionID: question.ID,
+			Score:      question.Points * 0.8,
+			Feedback:   "Respuesta corregida en entorno de test",
+		})
+	}
+	return response, nil
+}
+
+// GenerateQuestions writes the requested amount of placeholder questions about the module
+func (p *FakeProvider) GenerateQuestions(ctx context.Context, module *model.Module, resources []string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	response := &schemas.GeneratedQuestionsResponse{PromptVersion: FakePromptVersion}
+	for i := range request.MultipleChoiceCount {
+		response.Questions = append(response.Questions, model.Question{
+			Text:           fmt.Sprintf("Pregunta de opción múltiple %d sobre %s (entorno de test)", i+1, module.Title),
+			Type:           model.QuestionTypeMultipleChoice,
+			Options:        []string{"Opción correcta", "Opción incorrecta"},
+			CorrectAnswers: []string{"Opción correcta"},
+		})
+	}
+	for i := range request.TextCount {
+		response.Questions = append(response.Questions, model.Question{
+			Text:           fmt.Sprintf("Pregunta de texto %d sobre %s (entorno de test)", i+1, module.Title),
+			Type:           model.QuestionTypeText,
+			CorrectAnswers: []string{"Respuesta de referencia"},
+		})
+	}
+	return response, nil
+}
+
+func (p *FakeProvider) SuggestForumAnswer(ctx context.Context, question *model.ForumQuestion, modules []model.Module, resolved []model.ForumQuestion) (*schemas.ForumAnswerSuggestion, error) {
+	return &schemas.ForumAnswerSuggestion{
+		Content:       fmt.Sprintf("Respuesta sugerida a %s generada por IA (entorno de test)", question.Title),
+		PromptVersion: FakePromptVersion,
+	}, nil
+}
+
+// AnalyzeFeedbacks takes the sentiment from the type chosen by the student and its score, without topics
+func (p *FakeProvider) AnalyzeFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (*schemas.FeedbackAnalysisResponse, error) {
+	response := &schemas.FeedbackAnalysisResponse{PromptVersion: FakePromptVersion}
+	now := time.Now()
+	for _, feedback := range feedbacks {
+		sentiment := model.FeedbackSentimentNeutral
+		switch feedback.FeedbackType {
+		case model.FeedbackTypePositive:
+			sentiment = model.FeedbackSentimentPositive
+		case model.FeedbackTypeNegative:
+			sentiment = model.FeedbackSentimentNegative
+		}
+		response.Analyses = append(response.Analyses, model.FeedbackAnalysis{
+			FeedbackID:        feedback.ID.Hex(),
+			Sentiment:         sentiment,
+			SentimentScore:    float64(feedback.Score-3) / 2,
+			Topics:            []model.FeedbackTopic{},
+			Method:            model.FeedbackAnalysisMethodAI,
+			PromptVersion:     FakePromptVersion,
+			FeedbackCreatedAt: feedback.CreatedAt,
+			AnalyzedAt:        now,
+		})
+	}
+	return response, nil
+}
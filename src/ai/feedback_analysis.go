@@ -0,0 +1,152 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+// ErrInvalidFeedbackAnalysis is returned when the analysis written by the model doesn't follow
+// the schema or doesn't cover every feedback of the batch
+var ErrInvalidFeedbackAnalysis = errors.New("invalid AI feedback analysis")
+
+var feedbackAnalysisSchema = objectSchema(
+	[]string{"feedbacks"},
+	&responseSchema{Type: "array", Items: objectSchema(
+		[]string{"index", "sentiment", "sentiment_score", "topics"},
+		&responseSchema{Type: "integer", Description: "Número del feedback analizado"},
+		&responseSchema{Type: "string", Enum: feedbackSentimentValues()},
+		&responseSchema{Type: "number", Description: "Sentimiento entre -1, muy negativo, y 1, muy positivo"},
+		&responseSchema{Type: "array", Items: &responseSchema{Type: "string", Enum: feedbackTopicValues()}},
+	)},
+)
+
+type rawFeedbackAnalysis struct {
+	Feedbacks *[]rawFeedbackAnalysisItem `json:"feedbacks"`
+}
+
+type rawFeedbackAnalysisItem struct {
+	Index          *int      `json:"index"`
+	Sentiment      *string   `json:"sentiment"`
+	SentimentScore *float64  `json:"sentiment_score"`
+	Topics         *[]string `json:"topics"`
+}
+
+type feedbackAnalysisPromptData struct {
+	Topics    []model.FeedbackTopic
+	Feedbacks []feedbackPromptData
+}
+
+type feedbackPromptData struct {
+	Index int
+	*model.CourseFeedback
+}
+
+// AnalyzeFeedbacks asks the model for the sentiment and the topics of each feedback of the batch.
+// The analyses come in the order of the feedbacks and without course, answers that don't pass the
+// validation return ErrInvalidFeedbackAnalysis.
+func (c *AiClient) AnalyzeFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (*schemas.FeedbackAnalysisResponse, error) {
+	data := feedbackAnalysisPromptData{Topics: model.FeedbackTopics}
+	for i, feedback := range feedbacks {
+		data.Feedbacks = append(data.Feedbacks, feedbackPromptData{Index: i + 1, CourseFeedback: feedback})
+	}
+	prompt, err := renderPrompt(OperationFeedbackAnalysis, ScopeFrom(ctx).Language, data)
+	if err != nil {
+		return nil, err
+	}
+
+	rawResponse, err := c.generate(ctx, OperationFeedbackAnalysis, prompt, feedbackAnalysisSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	analyses, err := parseFeedbackAnalysis(rawResponse, feedbacks)
+	if err != nil {
+		log.Printf("Invalid AI feedback analysis: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidFeedbackAnalysis, err)
+	}
+	for i := range analyses {
+		analyses[i].PromptVersion = prompt.version
+	}
+	return &schemas.FeedbackAnalysisResponse{Analyses: analyses, PromptVersion: prompt.version}, nil
+}
+
+// parseFeedbackAnalysis matches the answer to the feedbacks by their number, every feedback must be
+// analyzed exactly once with a known sentiment, a score in range and known topics
+func parseFeedbackAnalysis(rawResponse string, feedbacks []*model.CourseFeedback) ([]model.FeedbackAnalysis, error) {
+	var raw rawFeedbackAnalysis
+	if err := decodeAnswer(rawResponse, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Feedbacks == nil {
+		return nil, errors.New("missing required fields")
+	}
+	if len(*raw.Feedbacks) != len(feedbacks) {
+		return nil, fmt.Errorf("got %d analyses for %d feedbacks", len(*raw.Feedbacks), len(feedbacks))
+	}
+
+	analyses := make([]model.FeedbackAnalysis, len(feedbacks))
+	analyzed := make([]bool, len(feedbacks))
+	now := time.Now()
+	for i, item := range *raw.Feedbacks {
+		if item.Index == nil || item.Sentiment == nil || item.SentimentScore == nil || item.Topics == nil {
+			return nil, fmt.Errorf("missing required fields in analysis %d", i)
+		}
+		index := *item.Index - 1
+		if index < 0 || index >= len(feedbacks) || analyzed[index] {
+			return nil, fmt.Errorf("analysis %d has an unexpected feedback number %d", i, *item.Index)
+		}
+		sentiment := model.FeedbackSentiment(*item.Sentiment)
+		if !slices.Contains(model.FeedbackSentiments, sentiment) {
+			return nil, fmt.Errorf("analysis %d has an unexpected sentiment %s", i, sentiment)
+		}
+		if *item.SentimentScore < -1 || *item.SentimentScore > 1 {
+			return nil, fmt.Errorf("analysis %d has a sentiment score %.2f out of range", i, *item.SentimentScore)
+		}
+		topics := []model.FeedbackTopic{}
+		for _, name := range *item.Topics {
+			topic := model.FeedbackTopic(name)
+			if !slices.Contains(model.FeedbackTopics, topic) {
+				return nil, fmt.Errorf("analysis %d has an unexpected topic %s", i, topic)
+			}
+			if !slices.Contains(topics, topic) {
+				topics = append(topics, topic)
+			}
+		}
+
+		feedback := feedbacks[index]
+		analyzed[index] = true
+		analyses[index] = model.FeedbackAnalysis{
+			FeedbackID:        feedback.ID.Hex(),
+			Sentiment:         sentiment,
+			SentimentScore:    *item.SentimentScore,
+			Topics:            topics,
+			Method:            model.FeedbackAnalysisMethodAI,
+			FeedbackCreatedAt: feedback.CreatedAt,
+			AnalyzedAt:        now,
+		}
+	}
+	return analyses, nil
+}
+
+func feedbackSentimentValues() []string {
+	var values []string
+	for _, sentiment := range model.FeedbackSentiments {
+		values = append(values, string(sentiment))
+	}
+	return values
+}
+
+func feedbackTopicValues() []string {
+	var values []string
+	for _, topic := range model.FeedbackTopics {
+		values = append(values, string(topic))
+	}
+	return values
+}
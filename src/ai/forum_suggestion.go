@@ -0,0 +1,62 @@
+package ai
+
+import (
+	"context"
+	"strings"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+type forumSuggestionPromptData struct {
+	Question *model.ForumQuestion
+	Modules  []model.Module
+	Resolved []resolvedQuestionPromptData
+}
+
+// resolvedQuestionPromptData is a previous question of the course with the answer its author accepted
+type resolvedQuestionPromptData struct {
+	Title       string
+	Description string
+	Answer      string
+}
+
+// SuggestForumAnswer asks the model for a draft answer to the forum question based on the modules of the course
+// and the answers accepted in previous questions. The draft is reviewed by a teacher before the students see it.
+func (c *AiClient) SuggestForumAnswer(ctx context.Context, question *model.ForumQuestion, modules []model.Module, resolved []model.ForumQuestion) (*schemas.ForumAnswerSuggestion, error) {
+	prompt, err := renderPrompt(OperationForumAnswerSuggestion, ScopeFrom(ctx).Language, forumSuggestionPromptData{
+		Question: question,
+		Modules:  modules,
+		Resolved: generateResolvedQuestionsPromptData(resolved),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := c.generate(ctx, OperationForumAnswerSuggestion, prompt, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.ForumAnswerSuggestion{Content: strings.TrimSpace(answer), PromptVersion: prompt.version}, nil
+}
+
+// generateResolvedQuestionsPromptData keeps the questions that have an accepted answer visible to the students
+func generateResolvedQuestionsPromptData(questions []model.ForumQuestion) []resolvedQuestionPromptData {
+	var data []resolvedQuestionPromptData
+	for _, question := range questions {
+		if question.AcceptedAnswerID == nil {
+			continue
+		}
+		for _, answer := range question.Answers {
+			if answer.ID == *question.AcceptedAnswerID && !answer.IsPendingSuggestion() {
+				data = append(data, resolvedQuestionPromptData{
+					Title:       question.Title,
+					Description: question.Description,
+					Answer:      answer.Content,
+				})
+				break
+			}
+		}
+	}
+	return data
+}
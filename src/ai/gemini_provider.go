@@ -0,0 +1,104 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+
+	"google.golang.org/genai"
+)
+
+// GeminiProvider generates the answers with the Gemini API
+type GeminiProvider struct {
+	*AiClient
+	client *genai.Client
+}
+
+var _ Provider = (*GeminiProvider)(nil)
+
+func NewGeminiProvider(apiKey string, settings Settings, hooks Hooks) (*GeminiProvider, error) {
+	client, err := genai.NewClient(context.Background(), &genai.ClientConfig{
+		APIKey: apiKey,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create Gemini client: %w", err)
+	}
+	log.Println("Gemini client created")
+
+	provider := &GeminiProvider{client: client}
+	provider.AiClient = newAiClient(provider, settings, hooks)
+	return provider, nil
+}
+
+func (p *GeminiProvider) name() string {
+	return ProviderGemini
+}
+
+func (p *GeminiProvider) generateText(ctx context.Context, prompt string, schema *responseSchema) (string, TokenUsage, error) {
+	config := &genai.GenerateContentConfig{}
+	if p.settings.Temperature != nil {
+		temperature := float32(*p.settings.Temperature)
+		config.Temperature = &temperature
+	}
+	if schema != nil {
+		config.ResponseMIMEType = "application/json"
+		config.ResponseSchema = geminiSchema(schema)
+	}
+
+	response, err := p.client.Models.GenerateContent(ctx, p.settings.Model, genai.Text(prompt), config)
+	if err != nil {
+		var apiErr genai.APIError
+		if errors.As(err, &apiErr) {
+			return "", TokenUsage{}, &statusError{StatusCode: apiErr.Code, Message: apiErr.Message}
+		}
+		return "", TokenUsage{}, err
+	}
+
+	var usage TokenUsage
+	if response.UsageMetadata != nil {
+		usage.PromptTokens = int(response.UsageMetadata.PromptTokenCount)
+		usage.CompletionTokens = int(response.UsageMetadata.CandidatesTokenCount)
+	}
+	answer, err := obtainAnswerFromModel(response)
+	return answer, usage, err
+}
+
+// geminiSchema converts the schema to the OpenAPI subset understood by Gemini
+func geminiSchema(schema *responseSchema) *genai.Schema {
+	converted := &genai.Schema{
+		Type:             genai.Type(strings.ToUpper(schema.Type)),
+		Description:      schema.Description,
+		Required:         schema.Required,
+		PropertyOrdering: schema.Required,
+		Enum:             schema.Enum,
+	}
+	if len(schema.Enum) > 0 {
+		converted.Format = "enum"
+	}
+	if schema.Items != nil {
+		converted.Items = geminiSchema(schema.Items)
+	}
+	if len(schema.Properties) > 0 {
+		converted.Properties = make(map[string]*genai.Schema)
+		for name, property := range schema.Properties {
+			converted.Properties[name] = geminiSchema(property)
+		}
+	}
+	return converted
+}
+
+func obtainAnswerFromModel(result *genai.GenerateContentResponse) (string, error) {
+	if len(result.Candidates) == 0 || result.Candidates[0].Content == nil {
+		return "", errors.New("no answer found")
+	}
+	answer := result.Candidates[0]
+
+	for _, part := range answer.Content.Parts {
+		if len(part.Text) > 0 {
+			return part.Text, nil
+		}
+	}
+	return "", errors.New("no answer found")
+}
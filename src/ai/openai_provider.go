@@ -0,0 +1,118 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// OpenAIProvider generates the answers with the chat completions endpoint of an OpenAI compatible API
+type OpenAIProvider struct {
+	*AiClient
+	baseURL    string
+	apiKey     string
+	httpClient *http.Client
+}
+
+var _ Provider = (*OpenAIProvider)(nil)
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIChatRequest struct {
+	Model          string                `json:"model"`
+	Messages       []openAIMessage       `json:"messages"`
+	Temperature    *float64              `json:"temperature,omitempty"`
+	ResponseFormat *openAIResponseFormat `json:"response_format,omitempty"`
+}
+
+type openAIResponseFormat struct {
+	Type       string            `json:"type"`
+	JSONSchema *openAIJSONSchema `json:"json_schema,omitempty"`
+}
+
+type openAIJSONSchema struct {
+	Name   string          `json:"name"`
+	Strict bool            `json:"strict"`
+	Schema *responseSchema `json:"schema"`
+}
+
+type openAIChatResponse struct {
+	Choices []struct {
+		Message openAIMessage `json:"message"`
+	} `json:"choices"`
+	Usage struct {
+		PromptTokens     int `json:"prompt_tokens"`
+		CompletionTokens int `json:"completion_tokens"`
+	} `json:"usage"`
+}
+
+func NewOpenAIProvider(baseURL, apiKey string, settings Settings, hooks Hooks) *OpenAIProvider {
+	provider := &OpenAIProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		apiKey:     apiKey,
+		httpClient: &http.Client{},
+	}
+	provider.AiClient = newAiClient(provider, settings, hooks)
+	return provider
+}
+
+func (p *OpenAIProvider) name() string {
+	return ProviderOpenAI
+}
+
+func (p *OpenAIProvider) generateText(ctx context.Context, prompt string, schema *responseSchema) (string, TokenUsage, error) {
+	chatRequest := openAIChatRequest{
+		Model:       p.settings.Model,
+		Messages:    []openAIMessage{{Role: "user", Content: prompt}},
+		Temperature: p.settings.Temperature,
+	}
+	if schema != nil {
+		chatRequest.ResponseFormat = &openAIResponseFormat{
+			Type:       "json_schema",
+			JSONSchema: &openAIJSONSchema{Name: "response", Strict: true, Schema: schema},
+		}
+	}
+
+	body, err := json.Marshal(chatRequest)
+	if err != nil {
+		return "", TokenUsage{}, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/chat/completions", bytes.NewReader(body))
+	if err != nil {
+		return "", TokenUsage{}, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+p.apiKey)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return "", TokenUsage{}, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		message, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", TokenUsage{}, &statusError{StatusCode: resp.StatusCode, Message: string(message)}
+	}
+
+	var chatResponse openAIChatResponse
+	if err := json.NewDecoder(resp.Body).Decode(&chatResponse); err != nil {
+		return "", TokenUsage{}, fmt.Errorf("invalid response: %v", err)
+	}
+	usage := TokenUsage{PromptTokens: chatResponse.Usage.PromptTokens, CompletionTokens: chatResponse.Usage.CompletionTokens}
+	if len(chatResponse.Choices) == 0 {
+		return "", usage, errors.New("no answer found")
+	}
+	return chatResponse.Choices[0].Message.Content, usage, nil
+}
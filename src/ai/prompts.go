@@ -1,84 +1,214 @@
 package ai
 
-const (
-	SummarizeCourseFeedbacksPrompt = `
-Sos un asistente que resume los comentarios de los usuarios.
-Recibirás un texto de comentarios de los usuarios.
-Cada usuario puede tener varios comentarios hechos por alumnos en el contexto de un curso al que todos ellos asisten.
-Debes resumir el texto en un formato fácil de entender, y que sea útil para el docente que lo este viendo.
-Cada comentario tiene una puntuacion de 1 a 5, un tipo de comentario que puede ser "POSITIVO", "NEGATIVO" o "NEUTRO", y un feedback en texto.
-Debes devolver un texto que resuma los comentarios de los usuarios, asi como la tendencia general del tipo de feedback y la puntuacion promedio.
-El texto debe ser en español.
-El texto debe ser corto y conciso.
-El texto tiene que destacar los puntos clave de los feedbacks recibidos (fortalezas, áreas de mejora, tendencias generales), presentándolo de manera clara y accesible.
-El formato de todos los feedbacks es el siguiente:
-Puntuacion: <puntuacion>
-Tipo: <tipo>
-Feedback: <feedback>
-
-Luego de esta linea vas a tener todos los feedbacks con el formato anterior.
-
-`
-
-	SummarizeStudentFeedbacksPrompt = `
-Sos un asistente que resume los comentarios hechos hacia alumnos por parte de docentes.
-Recibirás un texto de comentarios de los docentes.
-Cada docente puede tener varios comentarios hechos hacia un alumno en el contexto de un curso al que este esta asistneidn.
-Debes resumir el texto en un formato fácil de entender, y que sea útil para el alumno.
-El texto debe ser en español.
-El texto debe ser corto y conciso.
-El texto tiene que destacar los puntos clave de los feedbacks recibidos (fortalezas, áreas de mejora, tendencias generales), presentándolo de manera clara y accesible.
-El formato de todos los feedbacks es el siguiente:
-Puntuacion: <puntuacion>
-Tipo: <tipo>
-Feedback: <feedback>
-
-Luego de esta linea vas a tener todos los feedbacks con el formato anterior.
-`
-
-	SummarizeSubmissionFeedbackPrompt = `
-Sos un asistente que resume el feedback de una entrega específica.
-Recibirás la puntuación y el comentario de feedback que un docente le dio a un alumno por una entrega/assignment.
-Debes crear un resumen conciso y útil del feedback recibido.
-El resumen debe ser fácil de entender tanto para el alumno como para otros docentes.
-El texto debe ser en español.
-El texto debe ser muy breve y directo, máximo 2-3 oraciones.
-Debe destacar los puntos más importantes del feedback: qué se hizo bien, qué se puede mejorar, y la evaluación general.
-El formato del feedback es el siguiente:
-Puntuacion: <puntuacion>
-Feedback: <feedback>
-
-Luego de esta linea vas a tener el feedback con el formato anterior.
-`
-
-	CorrectSubmissionPrompt = `
-Sos un asistente que corrige automáticamente entregas/assignments de estudiantes.
-Recibirás las preguntas de un assignment con sus respuestas correctas y las respuestas del estudiante.
-Debes evaluar cada respuesta y generar:
-1. Un puntaje total (entre 0 y el puntaje máximo del assignment)
-2. Feedback constructivo en español que resuma toda la entrega
-3. Indicar si alguna respuesta necesita revisión manual
-
-Para preguntas de múltiple choice: compara directamente con las respuestas correctas.
-Para preguntas de texto libre: evalúa si la respuesta demuestra comprensión del concepto, aunque no sea exacta.
-
-Si encuentras respuestas muy ambiguas, poco claras, o que requieren interpretación subjetiva, marca como "NECESITA_REVISION_MANUAL".
-
-El formato de las preguntas es:
-ID: <question_id>
-Pregunta: <question_text>
-Tipo: <question_type>
-Puntaje: <points>
-Respuestas Correctas: <correct_answers>
-Respuesta del Estudiante: <student_answer>
-
-Tu respuesta debe ser EXACTAMENTE en este formato JSON:
-{
-  "ai_score": <puntaje_numerico_total>,
-  "ai_feedback": "<feedback_consolidado_en_español_de_toda_la_entrega>",
-  "needs_manual_review": <true_o_false>
-}
-
-Luego de esta línea vas a recibir las preguntas y respuestas:
-`
+import (
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"courses-service/src/model"
 )
+
+// The prompts live in templates/<language>/<operation>.tmpl. Every operation must have a Spanish
+// template, the other languages fall back to it when they don't have their own.
+//
+//go:embed templates
+var templateFiles embed.FS
+
+// promptTemplate is a parsed prompt with the version of its source
+type promptTemplate struct {
+	template *template.Template
+	version  string
+}
+
+// renderedPrompt is the text sent to the model and the version of the template it came from
+type renderedPrompt struct {
+	text    string
+	version string
+}
+
+var promptFuncs = template.FuncMap{
+	"points": func(points float64) string { return fmt.Sprintf("%.2f", points) },
+}
+
+// promptTemplates holds the templates by language and operation
+var promptTemplates = loadPromptTemplates()
+
+func loadPromptTemplates() map[string]map[string]promptTemplate {
+	templates := make(map[string]map[string]promptTemplate)
+	err := fs.WalkDir(templateFiles, "templates", func(file string, entry fs.DirEntry, err error) error {
+		if err != nil || entry.IsDir() || path.Ext(file) != ".tmpl" {
+			return err
+		}
+		source, err := templateFiles.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		language := path.Base(path.Dir(file))
+		operation := strings.TrimSuffix(path.Base(file), ".tmpl")
+		parsed, err := template.New(operation).Funcs(promptFuncs).Option("missingkey=error").Parse(string(source))
+		if err != nil {
+			return err
+		}
+		if templates[language] == nil {
+			templates[language] = make(map[string]promptTemplate)
+		}
+		templates[language][operation] = promptTemplate{template: parsed, version: promptVersion(language, operation, source)}
+		return nil
+	})
+	if err != nil {
+		panic(fmt.Sprintf("failed to load prompt templates: %v", err))
+	}
+	return templates
+}
+
+// promptVersion identifies a template by its language, operation and a hash of its source,
+// so any edit of a prompt leads to a new version without having to bump it by hand
+func promptVersion(language, operation string, source []byte) string {
+	hash := sha256.Sum256(source)
+	return fmt.Sprintf("%s/%s@%s", language, operation, hex.EncodeToString(hash[:4]))
+}
+
+// lookupPrompt returns the template of the operation in the given language, Spanish when there is none
+func lookupPrompt(operation, language string) (promptTemplate, bool) {
+	if prompt, found := promptTemplates[language][operation]; found {
+		return prompt, true
+	}
+	prompt, found := promptTemplates[model.DefaultCourseLanguage][operation]
+	return prompt, found
+}
+
+// PromptVersion returns the version of the template used for the operation in the given language
+func PromptVersion(operation, language string) string {
+	prompt, _ := lookupPrompt(operation, language)
+	return prompt.version
+}
+
+func renderPrompt(operation, language string, data any) (renderedPrompt, error) {
+	prompt, found := lookupPrompt(operation, language)
+	if !found {
+		return renderedPrompt{}, fmt.Errorf("no prompt template for %s", operation)
+	}
+	var text strings.Builder
+	if err := prompt.template.Execute(&text, data); err != nil {
+		return renderedPrompt{}, fmt.Errorf("failed to render prompt %s: %v", prompt.version, err)
+	}
+	return renderedPrompt{text: text.String(), version: prompt.version}, nil
+}
+
+type submissionFeedbackPromptData struct {
+	Scored   bool
+	Score    float64
+	Feedback string
+}
+
+type correctionPromptData struct {
+	Assignment *model.Assignment
+	Rubric     *rubricPromptData
+	Questions  []questionPromptData
+}
+
+type questionPromptData struct {
+	Question model.Question
+	Rubric   *rubricPromptData
+	Answer   string
+}
+
+// rubricPromptData is a rubric with the points of its levels scaled to the points of the question
+type rubricPromptData struct {
+	Criteria []rubricCriterionPromptData
+}
+
+type rubricCriterionPromptData struct {
+	ID          string
+	Title       string
+	Description string
+	Levels      []rubricLevelPromptData
+}
+
+type rubricLevelPromptData struct {
+	ID          string
+	Title       string
+	Description string
+	Points      float64
+}
+
+func generateStudentFeedbacksPrompt(language string, feedbacks []*model.StudentFeedback) (renderedPrompt, error) {
+	return renderPrompt(OperationStudentFeedbackSummary, language, feedbacks)
+}
+
+func generateCourseFeedbacksPrompt(language string, feedbacks []*model.CourseFeedback) (renderedPrompt, error) {
+	return renderPrompt(OperationCourseFeedbackSummary, language, feedbacks)
+}
+
+func generateSubmissionFeedbackPrompt(language string, score *float64, feedback string) (renderedPrompt, error) {
+	data := submissionFeedbackPromptData{Feedback: feedback}
+	if score != nil {
+		data.Scored = true
+		data.Score = *score
+	}
+	return renderPrompt(OperationSubmissionFeedbackSummary, language, data)
+}
+
+func generateSubmissionCorrectionPrompt(language string, assignment *model.Assignment, submission *model.Submission) (renderedPrompt, error) {
+	data := correctionPromptData{
+		Assignment: assignment,
+		Rubric:     generateRubricPromptData(assignment.Rubric, assignment.TotalPoints),
+	}
+
+	// Create a map of assignment questions for easy lookup
+	questionMap := make(map[string]model.Question)
+	for _, question := range assignment.Questions {
+		questionMap[question.ID] = question
+	}
+
+	for _, answer := range submission.Answers {
+		if question, exists := questionMap[answer.QuestionID]; exists {
+			data.Questions = append(data.Questions, questionPromptData{
+				Question: question,
+				Rubric:   generateRubricPromptData(question.Rubric, question.Points),
+				Answer:   fmt.Sprint(answer.Content),
+			})
+		}
+	}
+
+	return renderPrompt(OperationSubmissionCorrection, language, data)
+}
+
+// generateRubricPromptData scales the points of each level of the rubric to maxPoints.
+// It returns nil when there is no rubric to show.
+func generateRubricPromptData(rubric *model.Rubric, maxPoints float64) *rubricPromptData {
+	if rubric == nil {
+		return nil
+	}
+	var rubricMax float64
+	for _, criterion := range rubric.Criteria {
+		var best float64
+		for _, level := range criterion.Levels {
+			best = max(best, level.Points)
+		}
+		rubricMax += best
+	}
+	if rubricMax <= 0 {
+		return nil
+	}
+
+	data := &rubricPromptData{}
+	for _, criterion := range rubric.Criteria {
+		criterionData := rubricCriterionPromptData{ID: criterion.ID, Title: criterion.Title, Description: criterion.Description}
+		for _, level := range criterion.Levels {
+			criterionData.Levels = append(criterionData.Levels, rubricLevelPromptData{
+				ID:          level.ID,
+				Title:       level.Title,
+				Description: level.Description,
+				Points:      maxPoints * level.Points / rubricMax,
+			})
+		}
+		data.Criteria = append(data.Criteria, criterionData)
+	}
+	return data
+}
@@ -0,0 +1,111 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+// Provider is a language model backend able to summarize and analyze feedback, correct submissions,
+// write questions and draft answers to the forum questions
+type Provider interface {
+	SummarizeCourseFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (string, error)
+	SummarizeStudentFeedbacks(ctx context.Context, feedbacks []*model.StudentFeedback) (string, error)
+	SummarizeSubmissionFeedback(ctx context.Context, score *float64, feedback string) (string, error)
+	CorrectSubmission(ctx context.Context, assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error)
+	GenerateQuestions(ctx context.Context, module *model.Module, resources []string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error)
+	SuggestForumAnswer(ctx context.Context, question *model.ForumQuestion, modules []model.Module, resolved []model.ForumQuestion) (*schemas.ForumAnswerSuggestion, error)
+	AnalyzeFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (*schemas.FeedbackAnalysisResponse, error)
+}
+
+const (
+	ProviderGemini = "gemini"
+	ProviderOpenAI = "openai" // Any OpenAI compatible API, e.g. llama.cpp or Ollama servers
+	ProviderFake   = "fake"   // Deterministic answers, used in tests
+
+	defaultGeminiModel = "gemini-2.0-flash"
+	defaultOpenAIModel = "gpt-4o-mini"
+	defaultOpenAIURL   = "https://api.openai.com/v1"
+	defaultAiTimeout   = 60 * time.Second
+
+	defaultAiMaxRetries       = 2
+	defaultAiRetryBaseDelay   = 500 * time.Millisecond
+	defaultAiBreakerThreshold = 5
+	defaultAiBreakerCooldown  = 30 * time.Second
+)
+
+// Settings holds the model options shared by every provider
+type Settings struct {
+	Model       string
+	Temperature *float64      // nil keeps the default of the model
+	Timeout     time.Duration // Of each attempt
+	// Transient errors are retried with exponential backoff and jitter
+	MaxRetries     int
+	RetryBaseDelay time.Duration
+	// Consecutive failures that open the circuit breaker and how long it stays open
+	BreakerThreshold int
+	BreakerCooldown  time.Duration
+}
+
+// NewSettings reads the model options from the config, using the defaults of the provider for the missing values
+func NewSettings(config *config.Config, provider string) Settings {
+	settings := Settings{
+		Model:            config.AiModel,
+		Timeout:          defaultAiTimeout,
+		MaxRetries:       defaultAiMaxRetries,
+		RetryBaseDelay:   defaultAiRetryBaseDelay,
+		BreakerThreshold: defaultAiBreakerThreshold,
+		BreakerCooldown:  defaultAiBreakerCooldown,
+	}
+	if settings.Model == "" {
+		settings.Model = defaultGeminiModel
+		if provider == ProviderOpenAI {
+			settings.Model = defaultOpenAIModel
+		}
+	}
+	if temperature, err := strconv.ParseFloat(config.AiTemperature, 64); err == nil && temperature >= 0 {
+		settings.Temperature = &temperature
+	}
+	if timeout, err := strconv.Atoi(config.AiTimeoutSeconds); err == nil && timeout > 0 {
+		settings.Timeout = time.Duration(timeout) * time.Second
+	}
+	if retries, err := strconv.Atoi(config.AiMaxRetries); err == nil && retries >= 0 {
+		settings.MaxRetries = retries
+	}
+	if threshold, err := strconv.Atoi(config.AiBreakerThreshold); err == nil && threshold > 0 {
+		settings.BreakerThreshold = threshold
+	}
+	if cooldown, err := strconv.Atoi(config.AiBreakerCooldownSeconds); err == nil && cooldown > 0 {
+		settings.BreakerCooldown = time.Duration(cooldown) * time.Second
+	}
+	return settings
+}
+
+// NewProvider creates the provider selected in the config, Gemini by default
+func NewProvider(config *config.Config, hooks Hooks) (Provider, error) {
+	provider := config.AiProvider
+	if provider == "" {
+		provider = ProviderGemini
+	}
+
+	settings := NewSettings(config, provider)
+	switch provider {
+	case ProviderGemini:
+		return NewGeminiProvider(config.GeminiApiKey, settings, hooks)
+	case ProviderOpenAI:
+		baseURL := config.OpenAIBaseURL
+		if baseURL == "" {
+			baseURL = defaultOpenAIURL
+		}
+		return NewOpenAIProvider(baseURL, config.OpenAIApiKey, settings, hooks), nil
+	case ProviderFake:
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown AI provider %s", provider)
+	}
+}
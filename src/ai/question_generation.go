@@ -0,0 +1,139 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+)
+
+// ErrInvalidGeneratedQuestions is returned when the questions written by the model don't follow
+// the generation schema or don't match the requested amounts
+var ErrInvalidGeneratedQuestions = errors.New("invalid AI generated questions")
+
+var questionGenerationSchema = objectSchema(
+	[]string{"questions"},
+	&responseSchema{Type: "array", Items: objectSchema(
+		[]string{"type", "text", "options", "correct_answers"},
+		&responseSchema{Type: "string", Enum: []string{string(model.QuestionTypeMultipleChoice), string(model.QuestionTypeText)}},
+		&responseSchema{Type: "string", Description: "Enunciado de la pregunta"},
+		&responseSchema{Type: "array", Items: &responseSchema{Type: "string"}, Description: "Opciones, vacío en las preguntas de texto"},
+		&responseSchema{Type: "array", Items: &responseSchema{Type: "string"}, Description: "Opciones correctas o respuesta de referencia de las preguntas de texto"},
+	)},
+)
+
+type rawGeneratedQuestions struct {
+	Questions *[]rawGeneratedQuestion `json:"questions"`
+}
+
+type rawGeneratedQuestion struct {
+	Type           *string   `json:"type"`
+	Text           *string   `json:"text"`
+	Options        *[]string `json:"options"`
+	CorrectAnswers *[]string `json:"correct_answers"`
+}
+
+type questionGenerationPromptData struct {
+	Module              *model.Module
+	Resources           []string
+	MultipleChoiceCount int
+	TextCount           int
+}
+
+// GenerateQuestions asks the model for draft questions about the module and the text of its resources.
+// The questions come without ID nor points, answers that don't pass the validation return ErrInvalidGeneratedQuestions.
+func (c *AiClient) GenerateQuestions(ctx context.Context, module *model.Module, resources []string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	prompt, err := renderPrompt(OperationQuestionGeneration, ScopeFrom(ctx).Language, questionGenerationPromptData{
+		Module:              module,
+		Resources:           resources,
+		MultipleChoiceCount: request.MultipleChoiceCount,
+		TextCount:           request.TextCount,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rawResponse, err := c.generate(ctx, OperationQuestionGeneration, prompt, questionGenerationSchema)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := parseGeneratedQuestions(rawResponse)
+	if err == nil {
+		err = validateGeneratedQuestions(questions, request)
+	}
+	if err != nil {
+		log.Printf("Invalid AI generated questions: %v", err)
+		return nil, fmt.Errorf("%w: %v", ErrInvalidGeneratedQuestions, err)
+	}
+	return &schemas.GeneratedQuestionsResponse{Questions: questions, PromptVersion: prompt.version}, nil
+}
+
+func parseGeneratedQuestions(rawResponse string) ([]model.Question, error) {
+	var raw rawGeneratedQuestions
+	if err := decodeAnswer(rawResponse, &raw); err != nil {
+		return nil, err
+	}
+	if raw.Questions == nil {
+		return nil, errors.New("missing required fields")
+	}
+
+	questions := make([]model.Question, 0, len(*raw.Questions))
+	for i, question := range *raw.Questions {
+		if question.Type == nil || question.Text == nil || question.Options == nil || question.CorrectAnswers == nil {
+			return nil, fmt.Errorf("missing required fields in question %d", i)
+		}
+		questions = append(questions, model.Question{
+			Text:           strings.TrimSpace(*question.Text),
+			Type:           model.QuestionType(*question.Type),
+			Options:        *question.Options,
+			CorrectAnswers: *question.CorrectAnswers,
+		})
+	}
+	return questions, nil
+}
+
+// validateGeneratedQuestions checks that the model wrote the requested amount of each type and that
+// every question can be graded: multiple choice questions need their correct options among the options
+// and text questions a reference answer
+func validateGeneratedQuestions(questions []model.Question, request schemas.GenerateQuestionsRequest) error {
+	var multipleChoice, text int
+	for i := range questions {
+		question := &questions[i]
+		if question.Text == "" {
+			return fmt.Errorf("question %d has no text", i)
+		}
+		if len(question.CorrectAnswers) == 0 {
+			return fmt.Errorf("question %d has no correct answer", i)
+		}
+
+		switch question.Type {
+		case model.QuestionTypeMultipleChoice:
+			multipleChoice++
+			if len(question.Options) < 2 {
+				return fmt.Errorf("question %d needs at least two options", i)
+			}
+			for _, correctAnswer := range question.CorrectAnswers {
+				if !slices.Contains(question.Options, correctAnswer) {
+					return fmt.Errorf("correct answer %q of question %d is not one of the options", correctAnswer, i)
+				}
+			}
+		case model.QuestionTypeText:
+			text++
+			question.Options = nil
+		default:
+			return fmt.Errorf("question %d has an unexpected type %s", i, question.Type)
+		}
+	}
+
+	if multipleChoice != request.MultipleChoiceCount || text != request.TextCount {
+		return fmt.Errorf("got %d multiple choice and %d text questions instead of %d and %d",
+			multipleChoice, text, request.MultipleChoiceCount, request.TextCount)
+	}
+	return nil
+}
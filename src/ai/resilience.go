@@ -0,0 +1,138 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math/rand/v2"
+	"sync"
+	"time"
+)
+
+// ErrUnavailable is returned when the model can't be reached, either because the circuit breaker
+// is open or because every retry failed. Callers should answer with 503 Service Unavailable.
+var ErrUnavailable = errors.New("AI service unavailable")
+
+// statusError is an error response of the model API
+type statusError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *statusError) Error() string {
+	return fmt.Sprintf("request failed with status %d: %s", e.StatusCode, e.Message)
+}
+
+// isRetryable tells if an error may go away by itself. Rejected requests fail again when retried,
+// but timeouts, network errors, rate limits and server errors are usually transient.
+func isRetryable(err error) bool {
+	var statusErr *statusError
+	if errors.As(err, &statusErr) {
+		return statusErr.StatusCode == 429 || statusErr.StatusCode >= 500
+	}
+	return true
+}
+
+// retry runs the call until it succeeds, fails with an error that can't be retried or runs out of attempts.
+// Each attempt gets its own timeout and waits an exponential backoff with full jitter before starting.
+func retry(ctx context.Context, settings Settings, call func(ctx context.Context) (string, error)) (string, error) {
+	var err error
+	for attempt := range settings.MaxRetries + 1 {
+		if attempt > 0 {
+			backoff := settings.RetryBaseDelay << (attempt - 1)
+			select {
+			case <-time.After(rand.N(backoff + 1)):
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		var answer string
+		attemptCtx, cancel := context.WithTimeout(ctx, settings.Timeout)
+		answer, err = call(attemptCtx)
+		cancel()
+		if err == nil || !isRetryable(err) {
+			return answer, err
+		}
+		log.Printf("AI request failed (attempt %d of %d): %v", attempt+1, settings.MaxRetries+1, err)
+	}
+	return "", err
+}
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+// circuitBreaker stops calling the model after too many consecutive failures. Once the cooldown
+// passes a single trial request is let through, closing the breaker again if it succeeds.
+type circuitBreaker struct {
+	mu        sync.Mutex
+	state     breakerState
+	failures  int
+	openedAt  time.Time
+	threshold int
+	cooldown  time.Duration
+}
+
+func newCircuitBreaker(threshold int, cooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{threshold: max(threshold, 1), cooldown: cooldown}
+}
+
+// allow reports if a request can be sent to the model
+func (b *circuitBreaker) allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Since(b.openedAt) < b.cooldown {
+			return false
+		}
+		b.state = breakerHalfOpen
+		return true
+	case breakerHalfOpen:
+		// The trial request is still running
+		return false
+	default:
+		return true
+	}
+}
+
+func (b *circuitBreaker) success() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.state = breakerClosed
+	b.failures = 0
+}
+
+// abandon ends a request the caller gave up on. That says nothing about the health of the model, but a
+// cancelled trial request must end the half-open state: the breaker goes back to open with the cooldown
+// already passed, so the next request is the new trial.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state == breakerHalfOpen {
+		b.state = breakerOpen
+	}
+}
+
+func (b *circuitBreaker) failure() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		if b.state != breakerOpen {
+			log.Printf("AI circuit breaker opened after %d failures", b.failures)
+		}
+		b.state = breakerOpen
+		b.openedAt = time.Now()
+	}
+}
@@ -0,0 +1,83 @@
+package ai
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// Operations reported in the usage records
+const (
+	OperationCourseFeedbackSummary     = "course_feedback_summary"
+	OperationStudentFeedbackSummary    = "student_feedback_summary"
+	OperationSubmissionFeedbackSummary = "submission_feedback_summary"
+	OperationSubmissionCorrection      = "submission_correction"
+	OperationQuestionGeneration        = "question_generation"
+	OperationForumAnswerSuggestion     = "forum_answer_suggestion"
+	OperationFeedbackAnalysis          = "feedback_analysis"
+)
+
+// Scope tells who a call to the model is made for, the usage is accounted to it
+// and the cached answers are invalidated by it. The prompts are written in its language.
+type Scope struct {
+	CourseID    string
+	TeacherUUID string
+	StudentUUID string
+	Language    string // Language of the prompts, Spanish when empty
+}
+
+type scopeKey struct{}
+
+// WithScope returns a context that accounts the calls to the model to the given scope
+func WithScope(ctx context.Context, scope Scope) context.Context {
+	return context.WithValue(ctx, scopeKey{}, scope)
+}
+
+// ScopeFrom returns the scope of the context, empty if it was not set
+func ScopeFrom(ctx context.Context) Scope {
+	scope, _ := ctx.Value(scopeKey{}).(Scope)
+	return scope
+}
+
+// TokenUsage is the amount of tokens reported by the model API for a request
+type TokenUsage struct {
+	PromptTokens     int
+	CompletionTokens int
+}
+
+// Usage describes a single call to the model
+type Usage struct {
+	Operation     string
+	Provider      string
+	Model         string
+	PromptVersion string
+	TokenUsage
+	Latency time.Duration
+	Cached  bool // Answered from the cache without calling the model
+	Success bool
+}
+
+// UsageRecorder stores the usage of the model, the scope of the call is taken from the context
+type UsageRecorder interface {
+	RecordUsage(ctx context.Context, usage Usage)
+}
+
+// ResponseCache keeps the answers of the model by a hash of their prompt
+type ResponseCache interface {
+	Get(ctx context.Context, key string) (string, bool)
+	Set(ctx context.Context, key, operation, response string)
+}
+
+// Hooks are the optional collaborators of the providers
+type Hooks struct {
+	Cache ResponseCache
+	Usage UsageRecorder
+}
+
+// cacheKey identifies an answer by the operation, the model and the prompt, so any change
+// in the inputs of the prompt leads to a different key
+func cacheKey(operation, model, prompt string) string {
+	hash := sha256.Sum256([]byte(operation + "\x00" + model + "\x00" + prompt))
+	return hex.EncodeToString(hash[:])
+}
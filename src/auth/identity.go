@@ -0,0 +1,45 @@
+package auth
+
+import "slices"
+
+// Roles of the users, given by the users service in the token
+const (
+	RoleTeacher = "teacher"
+	RoleStudent = "student"
+	RoleAdmin   = "admin"
+)
+
+// Roles of the users in a course, resolved from the course and its enrollments
+const (
+	RoleTitularTeacher  = "titular_teacher"
+	RoleAuxTeacher      = "aux_teacher"
+	RoleEnrolledStudent = "enrolled_student"
+)
+
+// Identity is the user that made a request, taken from the claims of a verified token
+type Identity struct {
+	UserID string
+	Name   string
+	Roles  []string
+}
+
+func (i *Identity) HasRole(role string) bool {
+	return slices.Contains(i.Roles, role)
+}
+
+// Membership holds the roles of a user in a course. The admins get the admin role in every course.
+type Membership struct {
+	CourseID     string
+	UserID       string
+	Roles        []string
+	CourseStatus string // Moderation status of the course, the admins can suspend or archive it
+}
+
+func (m *Membership) HasRole(role string) bool {
+	return slices.Contains(m.Roles, role)
+}
+
+// HasAnyRole returns true if the user has at least one of the roles in the course
+func (m *Membership) HasAnyRole(roles ...string) bool {
+	return slices.ContainsFunc(roles, m.HasRole)
+}
@@ -0,0 +1,153 @@
+package auth
+
+import (
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	jwksRefreshInterval = time.Minute // Minimum time between downloads, so unknown key IDs can't flood the issuer
+	jwksRequestTimeout  = 10 * time.Second
+)
+
+type jsonWebKey struct {
+	KeyType   string `json:"kty"`
+	KeyID     string `json:"kid"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	Modulus   string `json:"n"`
+	Exponent  string `json:"e"`
+}
+
+type jsonWebKeySet struct {
+	Keys []jsonWebKey `json:"keys"`
+}
+
+// keySet holds the RSA public keys of a JWKS. The keys of a file are read once, the keys of a URL are
+// downloaded when they are older than the lifetime or a token is signed with a key ID they don't have,
+// which is how the issuer rotates its keys.
+type keySet struct {
+	url      string
+	lifetime time.Duration
+	client   *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	fetchedAt time.Time
+}
+
+func newKeySet(file, url string, lifetime time.Duration) (*keySet, error) {
+	set := &keySet{url: url, lifetime: lifetime, client: &http.Client{Timeout: jwksRequestTimeout}, keys: map[string]*rsa.PublicKey{}}
+	if file != "" {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read JWKS file: %v", err)
+		}
+		keys, err := parseJWKS(data)
+		if err != nil {
+			return nil, err
+		}
+		set.keys = keys
+	}
+	return set, nil
+}
+
+// key returns the key with the ID. A token without key ID is accepted when the set has a single key.
+func (s *keySet) key(keyID string) (*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	key := s.find(keyID)
+	stale := s.url != "" && time.Since(s.fetchedAt) > s.lifetime
+	if (key == nil || stale) && s.url != "" && time.Since(s.fetchedAt) > jwksRefreshInterval {
+		if err := s.fetch(); err != nil && key == nil {
+			return nil, err
+		}
+		key = s.find(keyID)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown signing key %q", keyID)
+	}
+	return key, nil
+}
+
+func (s *keySet) find(keyID string) *rsa.PublicKey {
+	if keyID == "" && len(s.keys) == 1 {
+		for _, key := range s.keys {
+			return key
+		}
+	}
+	return s.keys[keyID]
+}
+
+func (s *keySet) fetch() error {
+	s.fetchedAt = time.Now()
+	response, err := s.client.Get(s.url)
+	if err != nil {
+		return fmt.Errorf("failed to download JWKS: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to download JWKS: status %d", response.StatusCode)
+	}
+	data, err := io.ReadAll(io.LimitReader(response.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to download JWKS: %v", err)
+	}
+	keys, err := parseJWKS(data)
+	if err != nil {
+		return err
+	}
+	s.keys = keys
+	return nil
+}
+
+// parseJWKS returns the RSA signing keys of the set by key ID, the other keys are ignored
+func parseJWKS(data []byte) (map[string]*rsa.PublicKey, error) {
+	var set jsonWebKeySet
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, fmt.Errorf("failed to parse JWKS: %v", err)
+	}
+	keys := make(map[string]*rsa.PublicKey)
+	for _, jwk := range set.Keys {
+		if jwk.KeyType != "RSA" || (jwk.Use != "" && jwk.Use != "sig") || (jwk.Algorithm != "" && jwk.Algorithm != AlgorithmRS256) {
+			continue
+		}
+		key, err := rsaPublicKey(jwk)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse JWKS key %q: %v", jwk.KeyID, err)
+		}
+		keys[jwk.KeyID] = key
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("failed to parse JWKS: no RSA signing keys")
+	}
+	return keys, nil
+}
+
+func rsaPublicKey(jwk jsonWebKey) (*rsa.PublicKey, error) {
+	modulus, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+	if err != nil {
+		return nil, err
+	}
+	exponent, err := base64.RawURLEncoding.DecodeString(jwk.Exponent)
+	if err != nil {
+		return nil, err
+	}
+	if len(modulus) == 0 || len(exponent) == 0 || len(exponent) > 4 {
+		return nil, errors.New("invalid modulus or exponent")
+	}
+	return &rsa.PublicKey{
+		N: new(big.Int).SetBytes(modulus),
+		E: int(new(big.Int).SetBytes(exponent).Int64()),
+	}, nil
+}
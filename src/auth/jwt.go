@@ -0,0 +1,227 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/hmac"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"courses-service/src/config"
+)
+
+var (
+	ErrInvalidToken = errors.New("invalid token")
+	ErrExpiredToken = errors.New("token is expired")
+)
+
+const (
+	AlgorithmHS256 = "HS256"
+	AlgorithmRS256 = "RS256"
+
+	defaultAudience     = "courses-service"
+	defaultUserIDClaim  = "sub"
+	defaultRolesClaim   = "roles"
+	nameClaim           = "name"
+	clockSkew           = 30 * time.Second // Tolerance for the clocks of the issuer and this service
+	defaultKeysLifetime = time.Hour        // JWKS downloaded from a URL are refreshed after it
+)
+
+// Settings tells how the tokens are verified. Tokens signed with HS256 need the secret and tokens
+// signed with RS256 a JWKS with the public keys, from a file or a URL. At least one of them is required.
+type Settings struct {
+	Secret      string
+	JWKSURL     string
+	JWKSFile    string
+	Audience    string
+	Issuer      string // Not checked when empty
+	UserIDClaim string
+	RolesClaim  string
+}
+
+// NewSettings reads the JWT settings from the config, with defaults for the audience and the claims
+func NewSettings(config *config.Config) Settings {
+	settings := Settings{
+		Secret:      config.JWTSecret,
+		JWKSURL:     config.JWTJWKSURL,
+		JWKSFile:    config.JWTJWKSFile,
+		Audience:    config.JWTAudience,
+		Issuer:      config.JWTIssuer,
+		UserIDClaim: config.JWTUserIDClaim,
+		RolesClaim:  config.JWTRolesClaim,
+	}
+	if settings.Audience == "" {
+		settings.Audience = defaultAudience
+	}
+	if settings.UserIDClaim == "" {
+		settings.UserIDClaim = defaultUserIDClaim
+	}
+	if settings.RolesClaim == "" {
+		settings.RolesClaim = defaultRolesClaim
+	}
+	return settings
+}
+
+// Verifier checks the signature, expiration, audience and issuer of the tokens and extracts the identity of the user
+type Verifier struct {
+	settings Settings
+	keys     *keySet
+	now      func() time.Time
+}
+
+func NewVerifier(settings Settings) (*Verifier, error) {
+	if settings.Secret == "" && settings.JWKSURL == "" && settings.JWKSFile == "" {
+		return nil, errors.New("a JWT secret or a JWKS file or URL is required")
+	}
+	verifier := &Verifier{settings: settings, now: time.Now}
+	if settings.JWKSURL != "" || settings.JWKSFile != "" {
+		keys, err := newKeySet(settings.JWKSFile, settings.JWKSURL, defaultKeysLifetime)
+		if err != nil {
+			return nil, err
+		}
+		verifier.keys = keys
+	}
+	return verifier, nil
+}
+
+type tokenHeader struct {
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+}
+
+// Verify returns the identity in the token. Only the algorithms with a configured key are accepted,
+// so a token can't choose to be verified with a key meant for another algorithm.
+func (v *Verifier) Verify(token string) (*Identity, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+
+	var header tokenHeader
+	if err := decodeSegment(parts[0], &header); err != nil {
+		return nil, fmt.Errorf("%w: malformed header: %v", ErrInvalidToken, err)
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: malformed signature", ErrInvalidToken)
+	}
+	if err := v.verifySignature(header, parts[0]+"."+parts[1], signature); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+
+	var claims map[string]any
+	if err := decodeSegment(parts[1], &claims); err != nil {
+		return nil, fmt.Errorf("%w: malformed claims: %v", ErrInvalidToken, err)
+	}
+	if err := v.validateClaims(claims); err != nil {
+		return nil, err
+	}
+	return v.identity(claims)
+}
+
+func (v *Verifier) verifySignature(header tokenHeader, signed string, signature []byte) error {
+	switch {
+	case header.Algorithm == AlgorithmHS256 && v.settings.Secret != "":
+		if !hmac.Equal(signature, hmacSHA256(v.settings.Secret, signed)) {
+			return errors.New("signature does not match")
+		}
+		return nil
+	case header.Algorithm == AlgorithmRS256 && v.keys != nil:
+		key, err := v.keys.key(header.KeyID)
+		if err != nil {
+			return err
+		}
+		digest := sha256.Sum256([]byte(signed))
+		if err := rsa.VerifyPKCS1v15(key, crypto.SHA256, digest[:], signature); err != nil {
+			return errors.New("signature does not match")
+		}
+		return nil
+	default:
+		return fmt.Errorf("unexpected signing algorithm %q", header.Algorithm)
+	}
+}
+
+// validateClaims requires an expiration and the configured audience and issuer
+func (v *Verifier) validateClaims(claims map[string]any) error {
+	now := v.now()
+	expiration, ok := claims["exp"].(float64)
+	if !ok {
+		return fmt.Errorf("%w: missing expiration", ErrInvalidToken)
+	}
+	if now.After(time.Unix(int64(expiration), 0).Add(clockSkew)) {
+		return ErrExpiredToken
+	}
+	if notBefore, ok := claims["nbf"].(float64); ok && now.Add(clockSkew).Before(time.Unix(int64(notBefore), 0)) {
+		return fmt.Errorf("%w: token is not valid yet", ErrInvalidToken)
+	}
+	if !slices.Contains(stringsClaim(claims["aud"]), v.settings.Audience) {
+		return fmt.Errorf("%w: unexpected audience", ErrInvalidToken)
+	}
+	if v.settings.Issuer != "" && claims["iss"] != v.settings.Issuer {
+		return fmt.Errorf("%w: unexpected issuer", ErrInvalidToken)
+	}
+	return nil
+}
+
+func (v *Verifier) identity(claims map[string]any) (*Identity, error) {
+	userID, _ := claims[v.settings.UserIDClaim].(string)
+	if userID == "" {
+		return nil, fmt.Errorf("%w: missing %s claim", ErrInvalidToken, v.settings.UserIDClaim)
+	}
+	name, _ := claims[nameClaim].(string)
+	return &Identity{UserID: userID, Name: name, Roles: stringsClaim(claims[v.settings.RolesClaim])}, nil
+}
+
+// stringsClaim reads a claim that can be a list of strings or a single string with the values
+// separated by spaces or commas, as some issuers write the roles and the audience
+func stringsClaim(claim any) []string {
+	switch value := claim.(type) {
+	case string:
+		return strings.FieldsFunc(value, func(r rune) bool { return r == ' ' || r == ',' })
+	case []any:
+		var values []string
+		for _, item := range value {
+			if text, ok := item.(string); ok {
+				values = append(values, text)
+			}
+		}
+		return values
+	default:
+		return nil
+	}
+}
+
+// SignHS256 signs the claims with the secret, for calls between services and tests.
+// The tokens of the users are issued by the users service.
+func SignHS256(claims map[string]any, secret string) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": AlgorithmHS256, "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", err
+	}
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(hmacSHA256(secret, signed)), nil
+}
+
+func hmacSHA256(secret, signed string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(signed))
+	return mac.Sum(nil)
+}
+
+func decodeSegment(segment string, target any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, target)
+}
@@ -13,19 +13,94 @@ type Config struct {
 	GeminiApiKey           string
 	RabbitMQURL            string
 	NotificationsQueueName string
+	// File storage
+	StorageBackend   string // local or s3
+	StorageLocalPath string
+	S3Endpoint       string
+	S3Region         string
+	S3Bucket         string
+	S3AccessKey      string
+	S3SecretKey      string
+	FileMaxSizeMB    string
+	FileAllowedTypes string // Comma separated MIME types
+	FileSigningKey   string // Signs the download links
+	FileLinkTTL      string // Minutes a download link is valid
+	// Background AI correction
+	CorrectionQueueBackend string // mongo or rabbitmq
+	CorrectionsQueueName   string
+	CorrectionConcurrency  string
+	CorrectionMaxAttempts  string
+	// Language model provider
+	AiProvider       string // gemini, openai or fake
+	AiModel          string
+	AiTemperature    string
+	AiTimeoutSeconds string
+	OpenAIBaseURL    string // Any OpenAI compatible API
+	OpenAIApiKey     string
+	// Resilience of the AI calls
+	AiMaxRetries             string
+	AiBreakerThreshold       string
+	AiBreakerCooldownSeconds string
+	// Prices of the model in USD per million tokens, used to estimate the cost of the AI usage
+	AiPromptTokenPrice     string
+	AiCompletionTokenPrice string
+	// Feedbacks analyzed in a single call to the model
+	AiFeedbackBatchSize string
+	// Authentication with JWT, signed with HS256 by a shared secret or with RS256 by the keys of a JWKS
+	JWTSecret      string
+	JWTJWKSURL     string
+	JWTJWKSFile    string
+	JWTAudience    string
+	JWTIssuer      string // Not checked when empty
+	JWTUserIDClaim string // sub by default
+	JWTRolesClaim  string // roles by default
 }
 
 func NewConfig() *Config {
 	return &Config{
-		DBUsername:             os.Getenv("DB_USERNAME"),
-		DBPassword:             os.Getenv("DB_PASSWORD"),
-		DBName:                 os.Getenv("DB_NAME"),
-		DBURI:                  os.Getenv("DB_URI"),
-		Host:                   os.Getenv("HOST"),
-		Port:                   os.Getenv("PORT"),
-		Environment:            os.Getenv("ENVIRONMENT"),
-		GeminiApiKey:           os.Getenv("GEMINI_API_KEY"),
-		RabbitMQURL:            os.Getenv("RABBITMQ_URL"),
-		NotificationsQueueName: os.Getenv("NOTIFICATIONS_QUEUE_NAME"),
+		DBUsername:               os.Getenv("DB_USERNAME"),
+		DBPassword:               os.Getenv("DB_PASSWORD"),
+		DBName:                   os.Getenv("DB_NAME"),
+		DBURI:                    os.Getenv("DB_URI"),
+		Host:                     os.Getenv("HOST"),
+		Port:                     os.Getenv("PORT"),
+		Environment:              os.Getenv("ENVIRONMENT"),
+		GeminiApiKey:             os.Getenv("GEMINI_API_KEY"),
+		RabbitMQURL:              os.Getenv("RABBITMQ_URL"),
+		NotificationsQueueName:   os.Getenv("NOTIFICATIONS_QUEUE_NAME"),
+		StorageBackend:           os.Getenv("STORAGE_BACKEND"),
+		StorageLocalPath:         os.Getenv("STORAGE_LOCAL_PATH"),
+		S3Endpoint:               os.Getenv("S3_ENDPOINT"),
+		S3Region:                 os.Getenv("S3_REGION"),
+		S3Bucket:                 os.Getenv("S3_BUCKET"),
+		S3AccessKey:              os.Getenv("S3_ACCESS_KEY"),
+		S3SecretKey:              os.Getenv("S3_SECRET_KEY"),
+		FileMaxSizeMB:            os.Getenv("FILE_MAX_SIZE_MB"),
+		FileAllowedTypes:         os.Getenv("FILE_ALLOWED_TYPES"),
+		FileSigningKey:           os.Getenv("FILE_SIGNING_KEY"),
+		FileLinkTTL:              os.Getenv("FILE_LINK_TTL_MINUTES"),
+		CorrectionQueueBackend:   os.Getenv("CORRECTION_QUEUE_BACKEND"),
+		CorrectionsQueueName:     os.Getenv("CORRECTIONS_QUEUE_NAME"),
+		CorrectionConcurrency:    os.Getenv("CORRECTION_CONCURRENCY"),
+		CorrectionMaxAttempts:    os.Getenv("CORRECTION_MAX_ATTEMPTS"),
+		AiProvider:               os.Getenv("AI_PROVIDER"),
+		AiModel:                  os.Getenv("AI_MODEL"),
+		AiTemperature:            os.Getenv("AI_TEMPERATURE"),
+		AiTimeoutSeconds:         os.Getenv("AI_TIMEOUT_SECONDS"),
+		OpenAIBaseURL:            os.Getenv("OPENAI_BASE_URL"),
+		OpenAIApiKey:             os.Getenv("OPENAI_API_KEY"),
+		AiMaxRetries:             os.Getenv("AI_MAX_RETRIES"),
+		AiBreakerThreshold:       os.Getenv("AI_BREAKER_THRESHOLD"),
+		AiBreakerCooldownSeconds: os.Getenv("AI_BREAKER_COOLDOWN_SECONDS"),
+		AiPromptTokenPrice:       os.Getenv("AI_PROMPT_TOKEN_PRICE"),
+		AiCompletionTokenPrice:   os.Getenv("AI_COMPLETION_TOKEN_PRICE"),
+		AiFeedbackBatchSize:      os.Getenv("AI_FEEDBACK_BATCH_SIZE"),
+		JWTSecret:                os.Getenv("JWT_SECRET"),
+		JWTJWKSURL:               os.Getenv("JWT_JWKS_URL"),
+		JWTJWKSFile:              os.Getenv("JWT_JWKS_FILE"),
+		JWTAudience:              os.Getenv("JWT_AUDIENCE"),
+		JWTIssuer:                os.Getenv("JWT_ISSUER"),
+		JWTUserIDClaim:           os.Getenv("JWT_USER_ID_CLAIM"),
+		JWTRolesClaim:            os.Getenv("JWT_ROLES_CLAIM"),
 	}
 }
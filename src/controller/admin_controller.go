@@ -0,0 +1,344 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminController handles the moderation actions of the backoffice, the admin is the user of the token
+type AdminController struct {
+	adminService service.AdminServiceInterface
+}
+
+func NewAdminController(adminService service.AdminServiceInterface) *AdminController {
+	return &AdminController{
+		adminService: adminService,
+	}
+}
+
+// @Summary Suspend a course
+// @Description Suspend a course, only the admins can access it until it is reactivated (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /backoffice/courses/{id}/suspend [post]
+func (c *AdminController) SuspendCourse(ctx *gin.Context) {
+	c.changeCourseStatus(ctx, c.adminService.SuspendCourse)
+}
+
+// @Summary Archive a course
+// @Description Archive a course, its members can read it but not change it until it is reactivated (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /backoffice/courses/{id}/archive [post]
+func (c *AdminController) ArchiveCourse(ctx *gin.Context) {
+	c.changeCourseStatus(ctx, c.adminService.ArchiveCourse)
+}
+
+// @Summary Reactivate a course
+// @Description Make a suspended or archived course active again (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /backoffice/courses/{id}/reactivate [post]
+func (c *AdminController) ReactivateCourse(ctx *gin.Context) {
+	c.changeCourseStatus(ctx, c.adminService.ReactivateCourse)
+}
+
+func (c *AdminController) changeCourseStatus(ctx *gin.Context, change func(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error)) {
+	slog.Debug("Changing course status", "courseId", ctx.Param("id"), "path", ctx.FullPath())
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	course, err := change(ctx, ctx.Param("id"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error changing course status", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, course)
+}
+
+// @Summary Reassign the titular teacher of a course
+// @Description Replace the titular teacher of a course, the new teacher stops being an aux teacher of the course (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param request body schemas.ReassignTeacherRequest true "New teacher and reason"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /backoffice/courses/{id}/teacher [put]
+func (c *AdminController) ReassignTeacher(ctx *gin.Context) {
+	slog.Debug("Reassigning course teacher", "courseId", ctx.Param("id"))
+
+	var request schemas.ReassignTeacherRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	course, err := c.adminService.ReassignTeacher(ctx, ctx.Param("id"), ctx.GetString("user_uuid"), request)
+	if err != nil {
+		slog.Error("Error reassigning course teacher", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, course)
+}
+
+// @Summary Unenroll a student from a course
+// @Description Drop an active student from a course, the reason is shown to the student (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param studentId path string true "Student UUID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /backoffice/courses/{id}/students/{studentId}/unenroll [post]
+func (c *AdminController) UnenrollStudent(ctx *gin.Context) {
+	slog.Debug("Unenrolling student by an admin", "courseId", ctx.Param("id"), "studentId", ctx.Param("studentId"))
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.adminService.UnenrollStudent(ctx, ctx.Param("id"), ctx.Param("studentId"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error unenrolling student", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Student unenrolled successfully"})
+}
+
+// @Summary Remove a forum question
+// @Description Delete an abusive forum question of any author, with its answers (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /backoffice/forum/questions/{questionId}/remove [post]
+func (c *AdminController) RemoveForumQuestion(ctx *gin.Context) {
+	slog.Debug("Removing forum question", "questionId", ctx.Param("questionId"))
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.adminService.RemoveForumQuestion(ctx, ctx.Param("questionId"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error removing forum question", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Question removed successfully"})
+}
+
+// @Summary Remove a forum answer
+// @Description Delete an abusive forum answer of any author (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Param answerId path string true "Answer ID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /backoffice/forum/questions/{questionId}/answers/{answerId}/remove [post]
+func (c *AdminController) RemoveForumAnswer(ctx *gin.Context) {
+	slog.Debug("Removing forum answer", "questionId", ctx.Param("questionId"), "answerId", ctx.Param("answerId"))
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.adminService.RemoveForumAnswer(ctx, ctx.Param("questionId"), ctx.Param("answerId"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error removing forum answer", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Answer removed successfully"})
+}
+
+// @Summary Block a user
+// @Description Reject every request of the user until an admin unblocks them (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param userId path string true "User UUID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} model.BlockedUser
+// @Failure 400 {object} map[string]string
+// @Router /backoffice/users/{userId}/block [post]
+func (c *AdminController) BlockUser(ctx *gin.Context) {
+	slog.Debug("Blocking user", "userId", ctx.Param("userId"))
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	blocked, err := c.adminService.BlockUser(ctx, ctx.Param("userId"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error blocking user", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, blocked)
+}
+
+// @Summary Unblock a user
+// @Description Let a blocked user make requests again (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param userId path string true "User UUID"
+// @Param request body schemas.AdminActionRequest true "Reason"
+// @Success 200 {object} map[string]string
+// @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /backoffice/users/{userId}/unblock [post]
+func (c *AdminController) UnblockUser(ctx *gin.Context) {
+	slog.Debug("Unblocking user", "userId", ctx.Param("userId"))
+
+	request, ok := bindAdminAction(ctx)
+	if !ok {
+		return
+	}
+
+	err := c.adminService.UnblockUser(ctx, ctx.Param("userId"), ctx.GetString("user_uuid"), request.Reason)
+	if err != nil {
+		slog.Error("Error unblocking user", "error", err)
+		ctx.JSON(adminErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "User unblocked successfully"})
+}
+
+// @Summary Get the blocked users
+// @Description Get the users blocked by the admins, the latest first (for backoffice)
+// @Tags backoffice
+// @Produce json
+// @Success 200 {array} model.BlockedUser
+// @Router /backoffice/blocked-users [get]
+func (c *AdminController) GetBlockedUsers(ctx *gin.Context) {
+	slog.Debug("Getting blocked users")
+
+	users, err := c.adminService.GetBlockedUsers(ctx)
+	if err != nil {
+		slog.Error("Error getting blocked users", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, users)
+}
+
+// @Summary Get the audit trail of the backoffice
+// @Description Get the moderation actions of the admins with their reasons, the latest first (for backoffice)
+// @Tags backoffice
+// @Produce json
+// @Param admin_uuid query string false "Admin that did the action"
+// @Param course_id query string false "Course of the action"
+// @Param target_id query string false "Course, user, question or answer the action was done on"
+// @Param action query string false "Action"
+// @Success 200 {array} model.AdminActionLog
+// @Failure 400 {object} map[string]string
+// @Router /backoffice/audit-logs [get]
+func (c *AdminController) GetActionLogs(ctx *gin.Context) {
+	slog.Debug("Getting admin action logs")
+
+	var filter schemas.AdminActionLogFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	logs, err := c.adminService.GetActionLogs(ctx, filter)
+	if err != nil {
+		slog.Error("Error getting admin action logs", "error", err)
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, logs)
+}
+
+func bindAdminAction(ctx *gin.Context) (*schemas.AdminActionRequest, bool) {
+	var request schemas.AdminActionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	return &request, true
+}
+
+func adminErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrReasonRequired), errors.Is(err, service.ErrInvalidTeacherReassignment),
+		errors.Is(err, service.ErrInvalidUserBlock):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrCourseNotFound), errors.Is(err, service.ErrForumQuestionNotFound),
+		errors.Is(err, service.ErrForumAnswerNotFound), errors.Is(err, service.ErrStudentNotEnrolled),
+		errors.Is(err, service.ErrUserNotBlocked):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrInvalidCourseStatus):
+		return http.StatusConflict
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -0,0 +1,56 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AiUsageController struct {
+	aiUsageService service.AiUsageServiceInterface
+}
+
+func NewAiUsageController(aiUsageService service.AiUsageServiceInterface) *AiUsageController {
+	return &AiUsageController{
+		aiUsageService: aiUsageService,
+	}
+}
+
+// @Summary Get the AI usage report
+// @Description Get the tokens, latency and estimated cost of the AI calls grouped by course and teacher (for backoffice)
+// @Tags backoffice
+// @Accept json
+// @Produce json
+// @Param from query string false "Start date (YYYY-MM-DD), inclusive"
+// @Param to query string false "End date (YYYY-MM-DD), exclusive"
+// @Success 200 {object} schemas.AiUsageReport
+// @Failure 400 {object} schemas.ErrorResponse
+// @Router /backoffice/ai-usage [get]
+func (c *AiUsageController) GetAiUsageReport(ctx *gin.Context) {
+	slog.Debug("Getting AI usage report")
+
+	var request schemas.AiUsageReportRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.aiUsageService.GetUsageReport(ctx, request)
+	if err != nil {
+		slog.Error("Error getting AI usage report", "error", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
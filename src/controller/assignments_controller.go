@@ -1,11 +1,12 @@
 package controller
 
 import (
-	"fmt"
+	"errors"
 	"log"
 	"log/slog"
 	"net/http"
 
+	"courses-service/src/model"
 	"courses-service/src/queues"
 	"courses-service/src/schemas"
 	"courses-service/src/service"
@@ -16,23 +17,20 @@ import (
 type AssignmentsController struct {
 	service            service.AssignmentServiceInterface
 	notificationsQueue queues.NotificationsQueueInterface
-	activityService    service.TeacherActivityServiceInterface
 }
 
 func NewAssignmentsController(
 	service service.AssignmentServiceInterface,
 	notificationsQueue queues.NotificationsQueueInterface,
-	activityService service.TeacherActivityServiceInterface,
 ) *AssignmentsController {
 	return &AssignmentsController{
 		service:            service,
 		notificationsQueue: notificationsQueue,
-		activityService:    activityService,
 	}
 }
 
 // @Summary Get all assignments
-// @Description Get all assignments
+// @Description Get all assignments, without the answer key of their questions
 // @Tags assignments
 // @Accept json
 // @Produce json
@@ -49,7 +47,7 @@ func (c *AssignmentsController) GetAssignments(ctx *gin.Context) {
 	}
 
 	slog.Debug("Assignments retrieved", "assignments", assignments)
-	ctx.JSON(http.StatusOK, assignments)
+	ctx.JSON(http.StatusOK, studentViews(assignments))
 }
 
 // @Summary Create an assignment
@@ -70,24 +68,18 @@ func (c *AssignmentsController) CreateAssignment(ctx *gin.Context) {
 		return
 	}
 
-	createdAssignment, err := c.service.CreateAssignment(assignment)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	createdAssignment, err := c.service.CreateAssignment(teacherUUID, assignment)
 	if err != nil {
 		log.Println("Error creating assignment:", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrInvalidQuestion) || errors.Is(err, service.ErrInvalidRubric) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			createdAssignment.CourseID,
-			teacherUUID,
-			"CREATE_ASSIGNMENT",
-			fmt.Sprintf("Created assignment: %s", createdAssignment.Title),
-		)
-	}
-
 	queueMessage := queues.NewAssignmentCreatedMessage(
 		createdAssignment.CourseID,
 		createdAssignment.ID.Hex(),
@@ -106,7 +98,7 @@ func (c *AssignmentsController) CreateAssignment(ctx *gin.Context) {
 }
 
 // @Summary Get an assignment by ID
-// @Description Get an assignment by ID
+// @Description Get an assignment by ID. Only the teachers of the course get the answer key of its questions.
 // @Tags assignments
 // @Accept json
 // @Produce json
@@ -130,11 +122,14 @@ func (c *AssignmentsController) GetAssignmentById(ctx *gin.Context) {
 	}
 
 	slog.Debug("Assignment retrieved", "assignment", assignment)
+	if !ctx.GetBool("answer_key") {
+		assignment = assignment.StudentView()
+	}
 	ctx.JSON(http.StatusOK, assignment)
 }
 
 // @Summary Get assignments by course ID
-// @Description Get assignments by course ID
+// @Description Get assignments by course ID. Only the teachers of the course get the answer key of their questions.
 // @Tags assignments
 // @Accept json
 // @Produce json
@@ -153,9 +148,21 @@ func (c *AssignmentsController) GetAssignmentsByCourseId(ctx *gin.Context) {
 	}
 
 	slog.Debug("Assignments retrieved", "assignments", assignments)
+	if !ctx.GetBool("answer_key") {
+		assignments = studentViews(assignments)
+	}
 	ctx.JSON(http.StatusOK, assignments)
 }
 
+// studentViews returns the assignments as the students see them
+func studentViews(assignments []*model.Assignment) []*model.Assignment {
+	views := make([]*model.Assignment, len(assignments))
+	for i, assignment := range assignments {
+		views[i] = assignment.StudentView()
+	}
+	return views
+}
+
 // @Summary Update an assignment
 // @Description Update an assignment by ID
 // @Tags assignments
@@ -176,24 +183,18 @@ func (c *AssignmentsController) UpdateAssignment(ctx *gin.Context) {
 		return
 	}
 
-	updatedAssignment, err := c.service.UpdateAssignment(id, updateAssignmentRequest)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	updatedAssignment, err := c.service.UpdateAssignment(id, teacherUUID, updateAssignmentRequest)
 	if err != nil {
 		slog.Error("Error updating assignment", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrInvalidQuestion) || errors.Is(err, service.ErrInvalidRubric) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			updatedAssignment.CourseID,
-			teacherUUID,
-			"UPDATE_ASSIGNMENT",
-			fmt.Sprintf("Updated assignment: %s", updatedAssignment.Title),
-		)
-	}
-
 	slog.Debug("Assignment updated", "assignment", updatedAssignment)
 	ctx.JSON(http.StatusOK, updatedAssignment)
 }
@@ -210,31 +211,13 @@ func (c *AssignmentsController) DeleteAssignment(ctx *gin.Context) {
 	slog.Debug("Deleting assignment")
 	id := ctx.Param("assignmentId")
 
-	// Get assignment info before deleting for logging
-	assignment, err := c.service.GetAssignmentById(id)
-	if err != nil {
-		slog.Error("Error getting assignment for deletion", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	if err := c.service.DeleteAssignment(id); err != nil {
+	teacherUUID := ctx.GetString("teacher_uuid")
+	if err := c.service.DeleteAssignment(id, teacherUUID); err != nil {
 		slog.Error("Error deleting assignment", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && assignment != nil {
-		c.activityService.LogActivityIfAuxTeacher(
-			assignment.CourseID,
-			teacherUUID,
-			"DELETE_ASSIGNMENT",
-			fmt.Sprintf("Deleted assignment: %s", assignment.Title),
-		)
-	}
-
 	slog.Debug("Assignment deleted")
 	ctx.JSON(http.StatusNoContent, nil)
 }
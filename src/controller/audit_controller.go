@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type AuditController struct {
+	auditService service.AuditServiceInterface
+}
+
+func NewAuditController(auditService service.AuditServiceInterface) *AuditController {
+	return &AuditController{
+		auditService: auditService,
+	}
+}
+
+// @Summary Get the audit log
+// @Description Get the changes made to the courses, modules, assignments, submissions, enrollments and forum, the latest first (for backoffice)
+// @Tags backoffice
+// @Produce json
+// @Param actor_uuid query string false "User that made the change"
+// @Param role query string false "Role of the user in the course, as titular_teacher, aux_teacher or enrolled_student"
+// @Param entity query string false "Entity changed, as course, module or assignment"
+// @Param entity_id query string false "ID of the entity changed"
+// @Param course_id query string false "Course of the entity changed"
+// @Param action query string false "create, update or delete"
+// @Param request_id query string false "ID of the request that made the change"
+// @Param from query string false "Start date (YYYY-MM-DD), inclusive"
+// @Param to query string false "End date (YYYY-MM-DD), exclusive"
+// @Param page query int false "Page, from 1"
+// @Param page_size query int false "Changes per page, up to 100"
+// @Success 200 {object} schemas.AuditLogPage
+// @Failure 400 {object} schemas.ErrorResponse
+// @Router /audit-logs [get]
+func (c *AuditController) GetAuditLogs(ctx *gin.Context) {
+	slog.Debug("Getting audit logs")
+
+	var filter schemas.AuditLogFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.getLogs(ctx, filter)
+}
+
+// @Summary Get the audit log of a course
+// @Description Get the changes made to the course and its content, the latest first. Filtering by the aux_teacher role gives the activity of the aux teachers (only for the titular teacher and the admins)
+// @Tags courses
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param actor_uuid query string false "User that made the change"
+// @Param role query string false "Role of the user in the course, as titular_teacher, aux_teacher or enrolled_student"
+// @Param entity query string false "Entity changed, as module or assignment"
+// @Param entity_id query string false "ID of the entity changed"
+// @Param action query string false "create, update or delete"
+// @Param from query string false "Start date (YYYY-MM-DD), inclusive"
+// @Param to query string false "End date (YYYY-MM-DD), exclusive"
+// @Param page query int false "Page, from 1"
+// @Param page_size query int false "Changes per page, up to 100"
+// @Success 200 {object} schemas.AuditLogPage
+// @Failure 400 {object} schemas.ErrorResponse
+// @Router /courses/{id}/audit-logs [get]
+func (c *AuditController) GetCourseAuditLogs(ctx *gin.Context) {
+	slog.Debug("Getting course audit logs", "courseId", ctx.Param("id"))
+
+	var filter schemas.AuditLogFilter
+	if err := ctx.ShouldBindQuery(&filter); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.CourseID = ctx.Param("id")
+
+	c.getLogs(ctx, filter)
+}
+
+func (c *AuditController) getLogs(ctx *gin.Context, filter schemas.AuditLogFilter) {
+	page, err := c.auditService.GetLogs(ctx, filter)
+	if err != nil {
+		slog.Error("Error getting audit logs", "error", err)
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	slog.Debug("Audit logs retrieved", "count", len(page.Logs), "total", page.Total)
+	ctx.JSON(http.StatusOK, page)
+}
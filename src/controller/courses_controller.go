@@ -1,7 +1,7 @@
 package controller
 
 import (
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
 	"slices"
@@ -17,16 +17,16 @@ import (
 
 type CourseController struct {
 	service            service.CourseServiceInterface
-	aiClient           *ai.AiClient
-	activityService    service.TeacherActivityServiceInterface
+	aiClient           ai.Provider
+	aiUsageService     service.AiUsageServiceInterface
 	notificationsQueue queues.NotificationsQueueInterface
 }
 
-func NewCourseController(service service.CourseServiceInterface, aiClient *ai.AiClient, activityService service.TeacherActivityServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *CourseController {
+func NewCourseController(service service.CourseServiceInterface, aiClient ai.Provider, aiUsageService service.AiUsageServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *CourseController {
 	return &CourseController{
 		service:            service,
 		aiClient:           aiClient,
-		activityService:    activityService,
+		aiUsageService:     aiUsageService,
 		notificationsQueue: notificationsQueue,
 	}
 }
@@ -52,7 +52,7 @@ func (c *CourseController) GetCourses(ctx *gin.Context) {
 }
 
 // @Summary Course creation
-// @Description Create a new course
+// @Description Create a new course owned by the authenticated teacher
 // @Tags courses
 // @Accept json
 // @Produce json
@@ -68,6 +68,8 @@ func (c *CourseController) CreateCourse(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	course.TeacherID = ctx.GetString("teacher_uuid")
+	course.TeacherName = ctx.GetString("teacher_name")
 
 	createdCourse, err := c.service.CreateCourse(course)
 	if err != nil {
@@ -102,12 +104,11 @@ func (c *CourseController) GetCourseById(ctx *gin.Context) {
 }
 
 // @Summary Delete a course
-// @Description Delete a course by ID
+// @Description Delete a course by ID, only its titular teacher can delete it
 // @Tags courses
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param teacherId query string true "Teacher ID"
 // @Success 200 {object} schemas.DeleteCourseResponse
 // @Router /courses/{id} [delete]
 func (c *CourseController) DeleteCourse(ctx *gin.Context) {
@@ -119,14 +120,7 @@ func (c *CourseController) DeleteCourse(ctx *gin.Context) {
 		return
 	}
 
-	teacherId := ctx.Query("teacherId")
-	if teacherId == "" {
-		slog.Error("Teacher ID is required")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Teacher ID is required"})
-		return
-	}
-
-	err := c.service.DeleteCourse(id, teacherId)
+	err := c.service.DeleteCourse(id, ctx.GetString("teacher_uuid"))
 	if err != nil {
 		slog.Error("Error deleting course", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -202,6 +196,7 @@ func (c *CourseController) UpdateCourse(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	updateCourseRequest.TeacherID = ctx.GetString("teacher_uuid")
 
 	updatedCourse, err := c.service.UpdateCourse(id, updateCourseRequest)
 	if err != nil {
@@ -261,6 +256,10 @@ func (c *CourseController) GetCoursesByUserId(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
+// @Param request body schemas.AddAuxTeacherToCourseRequest true "Aux teacher and permissions, all of them when empty"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} schemas.ErrorResponse
+// @Router /courses/{id}/aux-teacher/add [post]
 func (c *CourseController) AddAuxTeacherToCourse(ctx *gin.Context) {
 	slog.Debug("Adding aux teacher to course")
 	id := ctx.Param("id")
@@ -277,12 +276,12 @@ func (c *CourseController) AddAuxTeacherToCourse(ctx *gin.Context) {
 		return
 	}
 
-	teacherId := auxTeacherRequest.TeacherID
+	teacherId := ctx.GetString("teacher_uuid")
 	auxTeacherId := auxTeacherRequest.AuxTeacherID
-	course, err := c.service.AddAuxTeacherToCourse(id, teacherId, auxTeacherId)
+	course, err := c.service.AddAuxTeacherToCourse(id, teacherId, auxTeacherId, auxTeacherRequest.Permissions)
 	if err != nil {
 		slog.Error("Error adding aux teacher to course", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(auxTeacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 	slog.Debug("Aux teacher added to course", "course", course)
@@ -304,7 +303,6 @@ func (c *CourseController) AddAuxTeacherToCourse(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param teacherId query string true "Teacher ID"
 // @Param auxTeacherId query string true "Aux teacher ID"
 // @Success 200 {object} model.Course
 // @Router /courses/{id}/aux-teacher/remove [delete]
@@ -317,12 +315,12 @@ func (c *CourseController) RemoveAuxTeacherFromCourse(ctx *gin.Context) {
 		return
 	}
 
-	teacherId := ctx.Query("teacherId")
+	teacherId := ctx.GetString("teacher_uuid")
 	auxTeacherId := ctx.Query("auxTeacherId")
 
-	if teacherId == "" || auxTeacherId == "" {
-		slog.Error("Teacher ID and aux teacher ID are required")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Teacher ID and aux teacher ID are required"})
+	if auxTeacherId == "" {
+		slog.Error("Aux teacher ID is required")
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Aux teacher ID is required"})
 		return
 	}
 
@@ -345,6 +343,37 @@ func (c *CourseController) RemoveAuxTeacherFromCourse(ctx *gin.Context) {
 	ctx.JSON(http.StatusOK, course)
 }
 
+// @Summary Update the permissions of an aux teacher
+// @Description Replace the permissions of an aux teacher of the course (for the titular teacher). Without permissions the aux teacher can only read the course.
+// @Tags courses
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param auxTeacherId path string true "Aux teacher ID"
+// @Param request body schemas.UpdateAuxTeacherPermissionsRequest true "Permissions"
+// @Success 200 {object} model.Course
+// @Failure 400 {object} schemas.ErrorResponse
+// @Router /courses/{id}/aux-teacher/{auxTeacherId}/permissions [put]
+func (c *CourseController) UpdateAuxTeacherPermissions(ctx *gin.Context) {
+	slog.Debug("Updating aux teacher permissions", "id", ctx.Param("id"), "auxTeacherId", ctx.Param("auxTeacherId"))
+
+	var request schemas.UpdateAuxTeacherPermissionsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	course, err := c.service.UpdateAuxTeacherPermissions(ctx.Param("id"), ctx.GetString("teacher_uuid"), ctx.Param("auxTeacherId"), request.Permissions)
+	if err != nil {
+		slog.Error("Error updating aux teacher permissions", "error", err)
+		ctx.JSON(auxTeacherErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	slog.Debug("Aux teacher permissions updated", "course", course)
+	ctx.JSON(http.StatusOK, course)
+}
+
 // @Summary Get favourite courses
 // @Description Get favourite courses by student ID
 // @Tags courses
@@ -373,7 +402,7 @@ func (c *CourseController) GetFavouriteCourses(ctx *gin.Context) {
 }
 
 // @Summary Create course feedback
-// @Description Create course feedback by course ID
+// @Description Create course feedback by course ID, written by the authenticated student
 // @Tags courses
 // @Accept json
 // @Produce json
@@ -396,6 +425,7 @@ func (c *CourseController) CreateCourseFeedback(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	feedback.StudentUUID = ctx.GetString("student_uuid")
 
 	if !slices.Contains(model.FeedbackTypes, feedback.FeedbackType) {
 		slog.Error("Invalid feedback type")
@@ -410,15 +440,11 @@ func (c *CourseController) CreateCourseFeedback(ctx *gin.Context) {
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			courseId,
-			teacherUUID,
-			"CREATE_COURSE_FEEDBACK",
-			fmt.Sprintf("Created course feedback of type: %s", feedback.FeedbackType),
-		)
+	// The cached summary no longer includes every feedback
+	if c.aiUsageService != nil {
+		if err := c.aiUsageService.InvalidateCourse(ctx, courseId); err != nil {
+			slog.Error("Error invalidating course feedback summary", "error", err)
+		}
 	}
 
 	slog.Debug("Course feedback created", "feedback", feedbackModel)
@@ -485,6 +511,7 @@ func (c *CourseController) GetCourseFeedback(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Course ID"
 // @Success 200 {object} schemas.AiSummaryResponse
+// @Failure 503 {object} schemas.ErrorResponse
 // @Router /courses/{id}/feedback/summary [get]
 func (c *CourseController) GetCourseFeedbackSummary(ctx *gin.Context) {
 	slog.Debug("Getting course feedback summary")
@@ -508,10 +535,22 @@ func (c *CourseController) GetCourseFeedbackSummary(ctx *gin.Context) {
 		return
 	}
 
-	summary, err := c.aiClient.SummarizeCourseFeedbacks(feedbacks)
+	if c.aiClient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": ai.ErrUnavailable.Error()})
+		return
+	}
+
+	// The AI usage is accounted to the course and its titular teacher, the summary is written in the language of the course
+	scope := ai.Scope{CourseID: courseId}
+	if course, err := c.service.GetCourseById(courseId); err == nil && course != nil {
+		scope.TeacherUUID = course.TeacherUUID
+		scope.Language = course.Language
+	}
+
+	summary, err := c.aiClient.SummarizeCourseFeedbacks(ai.WithScope(ctx, scope), feedbacks)
 	if err != nil {
 		slog.Error("Error getting course feedback summary", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(aiErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -549,3 +588,26 @@ func (c *CourseController) GetCourseMembers(ctx *gin.Context) {
 	slog.Debug("Course members retrieved", "course_id", courseId)
 	ctx.JSON(http.StatusOK, members)
 }
+
+func auxTeacherErrorStatus(err error) int {
+	if errors.Is(err, service.ErrInvalidPermission) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// teacherErrorStatus answers 403 when the teacher is not allowed to do the action, like an aux teacher without the permission
+func teacherErrorStatus(err error) int {
+	if errors.Is(err, service.ErrUnauthorized) {
+		return http.StatusForbidden
+	}
+	return http.StatusInternalServerError
+}
+
+// aiErrorStatus answers 503 when the AI is unavailable so clients know they can try again later
+func aiErrorStatus(err error) int {
+	if errors.Is(err, ai.ErrUnavailable) {
+		return http.StatusServiceUnavailable
+	}
+	return http.StatusInternalServerError
+}
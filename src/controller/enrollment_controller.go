@@ -6,7 +6,7 @@ import (
 	"courses-service/src/queues"
 	"courses-service/src/schemas"
 	"courses-service/src/service"
-	"fmt"
+	"errors"
 	"log/slog"
 	"net/http"
 	"slices"
@@ -17,27 +17,27 @@ import (
 
 type EnrollmentController struct {
 	enrollmentService  service.EnrollmentServiceInterface
-	aiClient           *ai.AiClient
-	activityService    service.TeacherActivityServiceInterface
+	aiClient           ai.Provider
+	aiUsageService     service.AiUsageServiceInterface
 	notificationsQueue queues.NotificationsQueueInterface
 }
 
-func NewEnrollmentController(enrollmentService service.EnrollmentServiceInterface, aiClient *ai.AiClient, activityService service.TeacherActivityServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *EnrollmentController {
+func NewEnrollmentController(enrollmentService service.EnrollmentServiceInterface, aiClient ai.Provider, aiUsageService service.AiUsageServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *EnrollmentController {
 	return &EnrollmentController{
 		enrollmentService:  enrollmentService,
 		aiClient:           aiClient,
-		activityService:    activityService,
+		aiUsageService:     aiUsageService,
 		notificationsQueue: notificationsQueue,
 	}
 }
 
 // @Summary Enroll a student in a course
-// @Description Enroll a student in a course
+// @Description Enroll the authenticated student in a course, the suspended and archived courses don't take enrollments
 // @Tags enrollments
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param enrollmentRequest body schemas.EnrollStudentRequest true "Enrollment request"
+// @Failure 403 {object} map[string]string
 // @Router /courses/{id}/enroll [post]
 func (c *EnrollmentController) EnrollStudent(ctx *gin.Context) {
 	slog.Debug("Enrolling student", "studentId", ctx.Param("studentId"), "courseId", ctx.Param("id"))
@@ -49,23 +49,21 @@ func (c *EnrollmentController) EnrollStudent(ctx *gin.Context) {
 		return
 	}
 
-	var enrollmentRequest schemas.EnrollStudentRequest
-	if err := ctx.ShouldBindJSON(&enrollmentRequest); err != nil {
-		slog.Error("Error binding enrollment request", "error", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	err := c.enrollmentService.EnrollStudent(enrollmentRequest.StudentID, courseID)
+	studentID := ctx.GetString("student_uuid")
+	err := c.enrollmentService.EnrollStudent(studentID, courseID)
 	if err != nil {
 		slog.Error("Error enrolling student", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrCourseNotActive) {
+			status = http.StatusForbidden
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
 		return
 	}
 
-	slog.Debug("Student enrolled in course", "studentId", enrollmentRequest.StudentID, "courseId", courseID)
+	slog.Debug("Student enrolled in course", "studentId", studentID, "courseId", courseID)
 
-	message := queues.NewEnrolledStudentToCourseMessage(courseID, enrollmentRequest.StudentID)
+	message := queues.NewEnrolledStudentToCourseMessage(courseID, studentID)
 	slog.Info("Publishing message", "message", message)
 	err = c.notificationsQueue.Publish(message)
 	if err != nil {
@@ -78,12 +76,11 @@ func (c *EnrollmentController) EnrollStudent(ctx *gin.Context) {
 }
 
 // @Summary Unenroll a student from a course
-// @Description Unenroll a student from a course
+// @Description Unenroll the authenticated student from a course
 // @Tags enrollments
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param studentId query string true "Student ID"
 // @Success 200 {object} schemas.UnenrollStudentResponse
 // @Router /courses/{id}/unenroll [delete]
 func (c *EnrollmentController) UnenrollStudent(ctx *gin.Context) {
@@ -96,12 +93,7 @@ func (c *EnrollmentController) UnenrollStudent(ctx *gin.Context) {
 		return
 	}
 
-	studentId := ctx.Query("studentId")
-	if studentId == "" {
-		slog.Error("Student ID is required")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Student ID is required"})
-		return
-	}
+	studentId := ctx.GetString("student_uuid")
 
 	err := c.enrollmentService.UnenrollStudent(studentId, courseID)
 	if err != nil {
@@ -147,12 +139,11 @@ func (c *EnrollmentController) GetEnrollmentsByCourseId(ctx *gin.Context) {
 }
 
 // @Summary Set a course as favourite
-// @Description Set a course as favourite
+// @Description Set a course as favourite of the authenticated student
 // @Tags enrollments
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param favouriteCourseRequest body schemas.SetFavouriteCourseRequest true "Favourite course request"
 // @Success 200 {object} schemas.SetFavouriteCourseResponse
 // @Router /courses/{id}/favourite [post]
 func (c *EnrollmentController) SetFavouriteCourse(ctx *gin.Context) {
@@ -165,31 +156,24 @@ func (c *EnrollmentController) SetFavouriteCourse(ctx *gin.Context) {
 		return
 	}
 
-	var favouriteCourseRequest schemas.SetFavouriteCourseRequest
-	if err := ctx.ShouldBindJSON(&favouriteCourseRequest); err != nil {
-		slog.Error("Error binding favourite course request", "error", err)
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
-	}
-
-	err := c.enrollmentService.SetFavouriteCourse(favouriteCourseRequest.StudentID, courseID)
+	studentID := ctx.GetString("student_uuid")
+	err := c.enrollmentService.SetFavouriteCourse(studentID, courseID)
 	if err != nil {
 		slog.Error("Error setting favourite course", "error", err)
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	slog.Debug("Favourite course set", "studentId", favouriteCourseRequest.StudentID, "courseId", courseID)
+	slog.Debug("Favourite course set", "studentId", studentID, "courseId", courseID)
 	ctx.JSON(http.StatusOK, gin.H{"message": "Favourite course set"})
 }
 
 // @Summary Unset a course as favourite
-// @Description Unset a course as favourite
+// @Description Unset a course as favourite of the authenticated student
 // @Tags enrollments
 // @Accept json
 // @Produce json
 // @Param id path string true "Course ID"
-// @Param studentId query string true "Student ID"
 // @Success 200 {object} schemas.UnsetFavouriteCourseResponse
 // @Router /courses/{id}/favourite [delete]
 func (c *EnrollmentController) UnsetFavouriteCourse(ctx *gin.Context) {
@@ -202,12 +186,7 @@ func (c *EnrollmentController) UnsetFavouriteCourse(ctx *gin.Context) {
 		return
 	}
 
-	studentId := ctx.Query("studentId")
-	if studentId == "" {
-		slog.Error("Student ID is required")
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": "Student ID is required"})
-		return
-	}
+	studentId := ctx.GetString("student_uuid")
 	err := c.enrollmentService.UnsetFavouriteCourse(studentId, courseID)
 	if err != nil {
 		slog.Error("Error unsetting favourite course", "error", err)
@@ -244,6 +223,7 @@ func (c *EnrollmentController) CreateFeedback(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
+	feedbackRequest.TeacherUUID = ctx.GetString("teacher_uuid")
 
 	if !slices.Contains(model.FeedbackTypes, feedbackRequest.FeedbackType) {
 		slog.Error("Invalid feedback type")
@@ -254,12 +234,19 @@ func (c *EnrollmentController) CreateFeedback(ctx *gin.Context) {
 	err := c.enrollmentService.CreateStudentFeedback(feedbackRequest)
 	if err != nil {
 		slog.Error("Error creating feedback", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	slog.Debug("Feedback created", "studentId", feedbackRequest.StudentUUID, "teacherId", feedbackRequest.TeacherUUID)
 
+	// The cached summary no longer includes every feedback
+	if c.aiUsageService != nil {
+		if err := c.aiUsageService.InvalidateStudent(ctx, feedbackRequest.StudentUUID); err != nil {
+			slog.Error("Error invalidating student feedback summary", "error", err)
+		}
+	}
+
 	message := queues.NewFeedbackCreatedMessage(feedbackRequest.StudentUUID, courseID, "", feedbackRequest.Feedback, feedbackRequest.Score, time.Now())
 	slog.Info("Publishing message", "message", message)
 	err = c.notificationsQueue.Publish(message)
@@ -315,6 +302,7 @@ func (c *EnrollmentController) GetFeedbackByStudentId(ctx *gin.Context) {
 // @Produce json
 // @Param id path string true "Student ID"
 // @Success 200 {object} schemas.AiSummaryResponse
+// @Failure 503 {object} schemas.ErrorResponse
 // @Router /feedback/student/{id}/summary [get]
 func (c *EnrollmentController) GetStudentFeedbackSummary(ctx *gin.Context) {
 	slog.Debug("Getting student feedback summary", "studentId", ctx.Param("id"))
@@ -339,10 +327,15 @@ func (c *EnrollmentController) GetStudentFeedbackSummary(ctx *gin.Context) {
 		return
 	}
 
-	summary, err := c.aiClient.SummarizeStudentFeedbacks(feedbacks)
+	if c.aiClient == nil {
+		ctx.JSON(http.StatusServiceUnavailable, gin.H{"error": ai.ErrUnavailable.Error()})
+		return
+	}
+
+	summary, err := c.aiClient.SummarizeStudentFeedbacks(ai.WithScope(ctx, ai.Scope{StudentUUID: studentID}), feedbacks)
 	if err != nil {
 		slog.Error("Error summarizing student feedback", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(aiErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -377,24 +370,14 @@ func (c *EnrollmentController) ApproveStudent(ctx *gin.Context) {
 		return
 	}
 
-	err := c.enrollmentService.ApproveStudent(studentID, courseID)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	err := c.enrollmentService.ApproveStudent(studentID, courseID, teacherUUID)
 	if err != nil {
 		slog.Error("Error approving student", "error", err, "studentId", studentID, "courseId", courseID)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetString("teacher_uuid")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			courseID,
-			teacherUUID,
-			"APPROVE_STUDENT",
-			fmt.Sprintf("Approved student: %s", studentID),
-		)
-	}
-
 	slog.Debug("Student approved successfully", "studentId", studentID, "courseId", courseID)
 	ctx.JSON(http.StatusOK, schemas.ApproveStudentResponse{
 		Message:   "Student approved successfully",
@@ -438,24 +421,14 @@ func (c *EnrollmentController) DisapproveStudent(ctx *gin.Context) {
 		return
 	}
 
-	err := c.enrollmentService.DisapproveStudent(studentID, courseID, disapproveRequest.Reason)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	err := c.enrollmentService.DisapproveStudent(studentID, courseID, teacherUUID, disapproveRequest.Reason)
 	if err != nil {
 		slog.Error("Error disapproving student", "error", err, "studentId", studentID, "courseId", courseID)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetString("teacher_uuid")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			courseID,
-			teacherUUID,
-			"DISAPPROVE_STUDENT",
-			fmt.Sprintf("Disapproved student: %s (reason: %s)", studentID, disapproveRequest.Reason),
-		)
-	}
-
 	slog.Debug("Student disapproved successfully", "studentId", studentID, "courseId", courseID, "reason", disapproveRequest.Reason)
 	ctx.JSON(http.StatusOK, schemas.DisapproveStudentResponse{
 		Message:   "Student disapproved successfully",
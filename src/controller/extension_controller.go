@@ -0,0 +1,145 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ExtensionController struct {
+	extensionService service.ExtensionServiceInterface
+}
+
+func NewExtensionController(extensionService service.ExtensionServiceInterface) *ExtensionController {
+	return &ExtensionController{
+		extensionService: extensionService,
+	}
+}
+
+// @Summary Grant a deadline extension
+// @Description Grant a new due date for an assignment to one student or a group of students (for teachers)
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param extension body schemas.CreateExtensionRequest true "Extension to grant"
+// @Success 201 {object} model.DeadlineExtension
+// @Router /assignments/{assignmentId}/extensions [post]
+func (c *ExtensionController) CreateExtension(ctx *gin.Context) {
+	slog.Debug("Creating deadline extension")
+	assignmentID := ctx.Param("assignmentId")
+
+	var request schemas.CreateExtensionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	extension, err := c.extensionService.CreateExtension(ctx, assignmentID, teacherUUID, request)
+	if err != nil {
+		slog.Error("Error creating deadline extension", "error", err)
+		ctx.JSON(extensionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	slog.Debug("Deadline extension created", "extension", extension)
+	ctx.JSON(http.StatusCreated, extension)
+}
+
+// @Summary Get deadline extensions
+// @Description Get the deadline extensions of an assignment (for teachers)
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Success 200 {array} model.DeadlineExtension
+// @Router /assignments/{assignmentId}/extensions [get]
+func (c *ExtensionController) GetExtensionsByAssignment(ctx *gin.Context) {
+	slog.Debug("Getting deadline extensions")
+	assignmentID := ctx.Param("assignmentId")
+
+	extensions, err := c.extensionService.GetExtensionsByAssignment(ctx, assignmentID, ctx.GetString("teacher_uuid"))
+	if err != nil {
+		slog.Error("Error getting deadline extensions", "error", err)
+		ctx.JSON(extensionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, extensions)
+}
+
+// @Summary Update a deadline extension
+// @Description Update the students, due date or reason of a deadline extension (for teachers)
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param extensionId path string true "Extension ID"
+// @Param extension body schemas.UpdateExtensionRequest true "Extension fields to update"
+// @Success 200 {object} model.DeadlineExtension
+// @Router /assignments/{assignmentId}/extensions/{extensionId} [put]
+func (c *ExtensionController) UpdateExtension(ctx *gin.Context) {
+	slog.Debug("Updating deadline extension")
+	assignmentID := ctx.Param("assignmentId")
+	extensionID := ctx.Param("extensionId")
+
+	var request schemas.UpdateExtensionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	extension, err := c.extensionService.UpdateExtension(ctx, assignmentID, extensionID, teacherUUID, request)
+	if err != nil {
+		slog.Error("Error updating deadline extension", "error", err)
+		ctx.JSON(extensionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, extension)
+}
+
+// @Summary Delete a deadline extension
+// @Description Revoke a deadline extension (for teachers)
+// @Tags extensions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param extensionId path string true "Extension ID"
+// @Success 204 {string} string "Extension deleted successfully"
+// @Router /assignments/{assignmentId}/extensions/{extensionId} [delete]
+func (c *ExtensionController) DeleteExtension(ctx *gin.Context) {
+	slog.Debug("Deleting deadline extension")
+	assignmentID := ctx.Param("assignmentId")
+	extensionID := ctx.Param("extensionId")
+
+	if err := c.extensionService.DeleteExtension(ctx, assignmentID, extensionID, ctx.GetString("teacher_uuid")); err != nil {
+		slog.Error("Error deleting deadline extension", "error", err)
+		ctx.JSON(extensionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func extensionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAssignmentNotFound), errors.Is(err, service.ErrExtensionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidExtension):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
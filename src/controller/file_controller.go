@@ -0,0 +1,132 @@
+package controller
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FileController struct {
+	fileService service.FileServiceInterface
+}
+
+func NewFileController(fileService service.FileServiceInterface) *FileController {
+	return &FileController{fileService: fileService}
+}
+
+// @Summary Upload a file
+// @Description Upload an answer to a file question (students) or a module resource (teachers) as multipart form data
+// @Tags files
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "File to upload"
+// @Param course_id formData string true "Course ID"
+// @Param purpose formData string true "submission or module_resource"
+// @Param checksum formData string false "Hex encoded SHA-256 of the file"
+// @Success 201 {object} schemas.FileResponse
+// @Router /files [post]
+func (c *FileController) UploadFile(ctx *gin.Context) {
+	slog.Debug("Uploading file")
+
+	var request schemas.UploadFileRequest
+	if err := ctx.ShouldBind(&request); err != nil {
+		slog.Error("Error binding form", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	header, err := ctx.FormFile("file")
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
+	}
+	content, err := header.Open()
+	if err != nil {
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	file, err := c.fileService.UploadFile(ctx, ctx.GetString("user_uuid"), request, header.Filename, header.Size, content)
+	if err != nil {
+		slog.Error("Error uploading file", "error", err)
+		ctx.JSON(fileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, file)
+}
+
+// @Summary Get a file
+// @Description Get the metadata of a file and a temporary download link
+// @Tags files
+// @Accept json
+// @Produce json
+// @Param fileId path string true "File ID"
+// @Success 200 {object} schemas.FileResponse
+// @Router /files/{fileId} [get]
+func (c *FileController) GetFile(ctx *gin.Context) {
+	file, err := c.fileService.GetFile(ctx, ctx.Param("fileId"), ctx.GetString("user_uuid"))
+	if err != nil {
+		slog.Error("Error getting file", "error", err)
+		ctx.JSON(fileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, file)
+}
+
+// @Summary Download a file
+// @Description Download the content of a file using a signed link returned by GET /files/{fileId}
+// @Tags files
+// @Produce octet-stream
+// @Param fileId path string true "File ID"
+// @Param expires query int true "Expiration of the link as a unix timestamp"
+// @Param signature query string true "Signature of the link"
+// @Success 200 {file} file
+// @Router /files/{fileId}/download [get]
+func (c *FileController) DownloadFile(ctx *gin.Context) {
+	expires, err := strconv.ParseInt(ctx.Query("expires"), 10, 64)
+	if err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": service.ErrInvalidFileLink.Error()})
+		return
+	}
+
+	file, content, err := c.fileService.OpenFile(ctx, ctx.Param("fileId"), expires, ctx.Query("signature"))
+	if err != nil {
+		slog.Error("Error downloading file", "error", err)
+		ctx.JSON(fileErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+	defer content.Close()
+
+	ctx.DataFromReader(http.StatusOK, file.Size, file.ContentType, content, map[string]string{
+		"Content-Disposition": fmt.Sprintf("attachment; filename=%q", file.Name),
+		"ETag":                fmt.Sprintf("%q", file.Checksum),
+	})
+}
+
+// fileErrorStatus maps the errors of the file service to an HTTP status
+func fileErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrFileNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUnauthorized), errors.Is(err, service.ErrInvalidFileLink):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrFileTooLarge):
+		return http.StatusRequestEntityTooLarge
+	case errors.Is(err, service.ErrFileTypeNotAllowed):
+		return http.StatusUnsupportedMediaType
+	case errors.Is(err, service.ErrInvalidFile), errors.Is(err, service.ErrFileChecksumMismatch):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
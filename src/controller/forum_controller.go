@@ -1,7 +1,6 @@
 package controller
 
 import (
-	"fmt"
 	"log/slog"
 	"net/http"
 	"time"
@@ -16,14 +15,12 @@ import (
 
 type ForumController struct {
 	service            service.ForumServiceInterface
-	activityService    service.TeacherActivityServiceInterface
 	notificationsQueue queues.NotificationsQueueInterface
 }
 
-func NewForumController(service service.ForumServiceInterface, activityService service.TeacherActivityServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *ForumController {
+func NewForumController(service service.ForumServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *ForumController {
 	return &ForumController{
 		service:            service,
-		activityService:    activityService,
 		notificationsQueue: notificationsQueue,
 	}
 }
@@ -49,6 +46,7 @@ func (c *ForumController) CreateQuestion(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
+	request.AuthorID = ctx.GetString("user_uuid")
 
 	question, err := c.service.CreateQuestion(
 		request.CourseID,
@@ -63,17 +61,6 @@ func (c *ForumController) CreateQuestion(ctx *gin.Context) {
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && teacherUUID == request.AuthorID {
-		c.activityService.LogActivityIfAuxTeacher(
-			request.CourseID,
-			teacherUUID,
-			"CREATE_FORUM_QUESTION",
-			fmt.Sprintf("Created forum question: %s", request.Title),
-		)
-	}
-
 	response := c.mapQuestionToDetailResponse(question)
 	slog.Debug("Question created", "question_id", question.ID.Hex())
 
@@ -145,7 +132,7 @@ func (c *ForumController) GetQuestionsByCourseId(ctx *gin.Context) {
 }
 
 // @Summary Update a question
-// @Description Update a question's title, description, or tags
+// @Description Update a question's title, description, or tags (only by the author or the teachers that moderate the forum)
 // @Tags forum
 // @Accept json
 // @Produce json
@@ -161,6 +148,7 @@ func (c *ForumController) UpdateQuestion(ctx *gin.Context) {
 	slog.Debug("Updating question")
 
 	id := ctx.Param("questionId")
+	userID := ctx.GetString("user_uuid")
 	var request schemas.UpdateQuestionRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
 		slog.Error("Error binding JSON", "error", err)
@@ -168,24 +156,13 @@ func (c *ForumController) UpdateQuestion(ctx *gin.Context) {
 		return
 	}
 
-	question, err := c.service.UpdateQuestion(id, request.Title, request.Description, request.Tags)
+	question, err := c.service.UpdateQuestion(id, userID, request.Title, request.Description, request.Tags)
 	if err != nil {
 		slog.Error("Error updating question", "error", err)
 		ctx.JSON(http.StatusInternalServerError, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && question != nil {
-		c.activityService.LogActivityIfAuxTeacher(
-			question.CourseID,
-			teacherUUID,
-			"UPDATE_FORUM_QUESTION",
-			fmt.Sprintf("Updated forum question: %s", request.Title),
-		)
-	}
-
 	response := c.mapQuestionToDetailResponse(question)
 	slog.Debug("Question updated", "question_id", id)
 
@@ -201,12 +178,11 @@ func (c *ForumController) UpdateQuestion(ctx *gin.Context) {
 }
 
 // @Summary Delete a question
-// @Description Delete a question (only by the author)
+// @Description Delete a question (only by the author or the teachers that moderate the forum)
 // @Tags forum
 // @Accept json
 // @Produce json
 // @Param questionId path string true "Question ID"
-// @Param authorId query string true "Author ID"
 // @Success 200 {object} schemas.MessageResponse
 // @Failure 403 {object} schemas.ErrorResponse
 // @Failure 404 {object} schemas.ErrorResponse
@@ -216,34 +192,15 @@ func (c *ForumController) DeleteQuestion(ctx *gin.Context) {
 	slog.Debug("Deleting question")
 
 	id := ctx.Param("questionId")
-	authorID := ctx.Query("authorId")
+	userID := ctx.GetString("user_uuid")
 
-	if authorID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "authorId query parameter is required"})
-		return
-	}
-
-	// Get question before deleting for logging
-	question, qErr := c.service.GetQuestionById(id)
-
-	err := c.service.DeleteQuestion(id, authorID)
+	err := c.service.DeleteQuestion(id, userID)
 	if err != nil {
 		slog.Error("Error deleting question", "error", err)
 		ctx.JSON(http.StatusInternalServerError, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && teacherUUID == authorID && qErr == nil && question != nil {
-		c.activityService.LogActivityIfAuxTeacher(
-			question.CourseID,
-			teacherUUID,
-			"DELETE_FORUM_QUESTION",
-			fmt.Sprintf("Deleted forum question: %s", question.Title),
-		)
-	}
-
 	slog.Debug("Question deleted", "question_id", id)
 	ctx.JSON(http.StatusOK, schemas.MessageResponse{Message: "Question deleted successfully"})
 }
@@ -272,6 +229,7 @@ func (c *ForumController) AddAnswer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
+	request.AuthorID = ctx.GetString("user_uuid")
 
 	answer, err := c.service.AddAnswer(questionID, request.AuthorID, request.Content)
 	if err != nil {
@@ -280,25 +238,7 @@ func (c *ForumController) AddAnswer(ctx *gin.Context) {
 		return
 	}
 
-	var question *model.ForumQuestion
-	var qErr error
-
-	// Log activity if teacher is auxiliary - we need to get the question to know the course
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && teacherUUID == request.AuthorID {
-		// Get the question to find the course ID
-		question, qErr = c.service.GetQuestionById(questionID)
-		if qErr == nil && question != nil {
-			c.activityService.LogActivityIfAuxTeacher(
-				question.CourseID,
-				teacherUUID,
-				"CREATE_FORUM_ANSWER",
-				"Created forum answer",
-			)
-		}
-	}
-
-	question, qErr = c.service.GetQuestionById(questionID)
+	question, qErr := c.service.GetQuestionById(questionID)
 	if qErr != nil {
 		slog.Error("Error getting question", "error", qErr)
 		ctx.JSON(http.StatusInternalServerError, schemas.ErrorResponse{Error: qErr.Error()})
@@ -327,7 +267,6 @@ func (c *ForumController) AddAnswer(ctx *gin.Context) {
 // @Param questionId path string true "Question ID"
 // @Param answerId path string true "Answer ID"
 // @Param answer body schemas.UpdateAnswerRequest true "Answer update data"
-// @Param authorId query string true "Author ID"
 // @Success 200 {object} schemas.AnswerResponse
 // @Failure 400 {object} schemas.ErrorResponse
 // @Failure 403 {object} schemas.ErrorResponse
@@ -339,12 +278,7 @@ func (c *ForumController) UpdateAnswer(ctx *gin.Context) {
 
 	questionID := ctx.Param("questionId")
 	answerID := ctx.Param("answerId")
-	authorID := ctx.Query("authorId")
-
-	if authorID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "authorId query parameter is required"})
-		return
-	}
+	authorID := ctx.GetString("user_uuid")
 
 	var request schemas.UpdateAnswerRequest
 	if err := ctx.ShouldBindJSON(&request); err != nil {
@@ -366,13 +300,12 @@ func (c *ForumController) UpdateAnswer(ctx *gin.Context) {
 }
 
 // @Summary Delete an answer
-// @Description Delete an answer (only by the author)
+// @Description Delete an answer (only by the author or the teachers that moderate the forum)
 // @Tags forum
 // @Accept json
 // @Produce json
 // @Param questionId path string true "Question ID"
 // @Param answerId path string true "Answer ID"
-// @Param authorId query string true "Author ID"
 // @Success 200 {object} schemas.MessageResponse
 // @Failure 403 {object} schemas.ErrorResponse
 // @Failure 404 {object} schemas.ErrorResponse
@@ -383,14 +316,9 @@ func (c *ForumController) DeleteAnswer(ctx *gin.Context) {
 
 	questionID := ctx.Param("questionId")
 	answerID := ctx.Param("answerId")
-	authorID := ctx.Query("authorId")
+	userID := ctx.GetString("user_uuid")
 
-	if authorID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "authorId query parameter is required"})
-		return
-	}
-
-	err := c.service.DeleteAnswer(questionID, answerID, authorID)
+	err := c.service.DeleteAnswer(questionID, answerID, userID)
 	if err != nil {
 		slog.Error("Error deleting answer", "error", err)
 		ctx.JSON(http.StatusInternalServerError, schemas.ErrorResponse{Error: err.Error()})
@@ -408,7 +336,6 @@ func (c *ForumController) DeleteAnswer(ctx *gin.Context) {
 // @Produce json
 // @Param questionId path string true "Question ID"
 // @Param answerId path string true "Answer ID"
-// @Param authorId query string true "Question Author ID"
 // @Success 200 {object} schemas.MessageResponse
 // @Failure 403 {object} schemas.ErrorResponse
 // @Failure 404 {object} schemas.ErrorResponse
@@ -419,12 +346,7 @@ func (c *ForumController) AcceptAnswer(ctx *gin.Context) {
 
 	questionID := ctx.Param("questionId")
 	answerID := ctx.Param("answerId")
-	authorID := ctx.Query("authorId")
-
-	if authorID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "authorId query parameter is required"})
-		return
-	}
+	authorID := ctx.GetString("user_uuid")
 
 	err := c.service.AcceptAnswer(questionID, answerID, authorID)
 	if err != nil {
@@ -433,21 +355,6 @@ func (c *ForumController) AcceptAnswer(ctx *gin.Context) {
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && teacherUUID == authorID {
-		// Get the question to find the course ID
-		question, qErr := c.service.GetQuestionById(questionID)
-		if qErr == nil && question != nil {
-			c.activityService.LogActivityIfAuxTeacher(
-				question.CourseID,
-				teacherUUID,
-				"ACCEPT_FORUM_ANSWER",
-				"Accepted forum answer",
-			)
-		}
-	}
-
 	slog.Debug("Answer accepted", "question_id", questionID, "answer_id", answerID)
 	ctx.JSON(http.StatusOK, schemas.MessageResponse{Message: "Answer accepted successfully"})
 }
@@ -477,6 +384,7 @@ func (c *ForumController) VoteQuestion(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
+	request.UserID = ctx.GetString("user_uuid")
 
 	err := c.service.VoteQuestion(questionID, request.UserID, request.VoteType)
 	if err != nil {
@@ -536,6 +444,7 @@ func (c *ForumController) VoteAnswer(ctx *gin.Context) {
 		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: err.Error()})
 		return
 	}
+	request.UserID = ctx.GetString("user_uuid")
 
 	err := c.service.VoteAnswer(questionID, answerID, request.UserID, request.VoteType)
 	if err != nil {
@@ -576,7 +485,6 @@ func (c *ForumController) VoteAnswer(ctx *gin.Context) {
 // @Accept json
 // @Produce json
 // @Param questionId path string true "Question ID"
-// @Param userId query string true "User ID"
 // @Success 200 {object} schemas.MessageResponse
 // @Failure 404 {object} schemas.ErrorResponse
 // @Failure 500 {object} schemas.ErrorResponse
@@ -585,12 +493,7 @@ func (c *ForumController) RemoveVoteFromQuestion(ctx *gin.Context) {
 	slog.Debug("Removing vote from question")
 
 	questionID := ctx.Param("questionId")
-	userID := ctx.Query("userId")
-
-	if userID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "userId query parameter is required"})
-		return
-	}
+	userID := ctx.GetString("user_uuid")
 
 	err := c.service.RemoveVoteFromQuestion(questionID, userID)
 	if err != nil {
@@ -610,7 +513,6 @@ func (c *ForumController) RemoveVoteFromQuestion(ctx *gin.Context) {
 // @Produce json
 // @Param questionId path string true "Question ID"
 // @Param answerId path string true "Answer ID"
-// @Param userId query string true "User ID"
 // @Success 200 {object} schemas.MessageResponse
 // @Failure 404 {object} schemas.ErrorResponse
 // @Failure 500 {object} schemas.ErrorResponse
@@ -620,12 +522,7 @@ func (c *ForumController) RemoveVoteFromAnswer(ctx *gin.Context) {
 
 	questionID := ctx.Param("questionId")
 	answerID := ctx.Param("answerId")
-	userID := ctx.Query("userId")
-
-	if userID == "" {
-		ctx.JSON(http.StatusBadRequest, schemas.ErrorResponse{Error: "userId query parameter is required"})
-		return
-	}
+	userID := ctx.GetString("user_uuid")
 
 	err := c.service.RemoveVoteFromAnswer(questionID, answerID, userID)
 	if err != nil {
@@ -771,10 +668,17 @@ func (c *ForumController) mapQuestionToDetailResponse(question *model.ForumQuest
 func (c *ForumController) mapAnswerToResponse(answer *model.ForumAnswer) schemas.AnswerResponse {
 	voteCount := c.calculateVoteCount(answer.Votes)
 
+	answerType := answer.Type
+	if answerType == "" {
+		answerType = model.AnswerTypeUser
+	}
+
 	return schemas.AnswerResponse{
 		ID:         answer.ID,
 		AuthorID:   answer.AuthorID,
 		Content:    answer.Content,
+		Type:       answerType,
+		Suggestion: answer.Suggestion,
 		Votes:      answer.Votes,
 		VoteCount:  voteCount,
 		IsAccepted: answer.IsAccepted,
@@ -0,0 +1,179 @@
+package controller
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ForumSuggestionController struct {
+	forumSuggestionService service.ForumSuggestionServiceInterface
+}
+
+func NewForumSuggestionController(forumSuggestionService service.ForumSuggestionServiceInterface) *ForumSuggestionController {
+	return &ForumSuggestionController{
+		forumSuggestionService: forumSuggestionService,
+	}
+}
+
+// @Summary Suggest an answer to a forum question
+// @Description Draft an answer to an open forum question with AI from the course modules and previously accepted answers (for teachers). The course must have the forum AI suggestions enabled. The suggestion is hidden from students until a teacher approves it.
+// @Tags forum-suggestions
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Success 201 {object} schemas.ForumSuggestionResponse
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /forum/questions/{questionId}/suggestions [post]
+func (c *ForumSuggestionController) SuggestAnswer(ctx *gin.Context) {
+	slog.Debug("Suggesting forum answer", "questionId", ctx.Param("questionId"))
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	suggestion, err := c.forumSuggestionService.SuggestAnswer(ctx, ctx.Param("questionId"), teacherUUID)
+	if err != nil {
+		slog.Error("Error suggesting forum answer", "error", err)
+		ctx.JSON(forumSuggestionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, suggestion)
+}
+
+// @Summary Suggest answers to the unanswered forum questions of a course
+// @Description Draft answers with AI to the open questions of the course that have no answers after the given hours, the oldest first and at most 10 per call (for teachers)
+// @Tags forum-suggestions
+// @Produce json
+// @Param courseId path string true "Course ID"
+// @Param older_than_hours query int false "Hours without answers, 48 by default"
+// @Success 201 {array} schemas.ForumSuggestionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /forum/courses/{courseId}/suggestions [post]
+func (c *ForumSuggestionController) SuggestAnswersForUnanswered(ctx *gin.Context) {
+	slog.Debug("Suggesting answers to unanswered forum questions", "courseId", ctx.Param("courseId"))
+
+	var request schemas.SuggestUnansweredRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	olderThan := service.DefaultUnansweredAge
+	if request.OlderThanHours > 0 {
+		olderThan = time.Duration(request.OlderThanHours) * time.Hour
+	}
+
+	courseID := ctx.Param("courseId")
+	teacherUUID := ctx.GetString("teacher_uuid")
+	suggestions, err := c.forumSuggestionService.SuggestAnswersForUnanswered(ctx, courseID, teacherUUID, olderThan)
+	if err != nil {
+		slog.Error("Error suggesting answers to unanswered forum questions", "error", err)
+		ctx.JSON(forumSuggestionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, suggestions)
+}
+
+// @Summary Get the pending forum answer suggestions of a course
+// @Description Get the forum questions of the course with AI suggested answers waiting for review, including the suggestions (for teachers)
+// @Tags forum-suggestions
+// @Produce json
+// @Param courseId path string true "Course ID"
+// @Success 200 {array} model.ForumQuestion
+// @Failure 403 {object} map[string]string
+// @Router /forum/courses/{courseId}/suggestions [get]
+func (c *ForumSuggestionController) GetPendingSuggestions(ctx *gin.Context) {
+	slog.Debug("Getting pending forum suggestions", "courseId", ctx.Param("courseId"))
+
+	questions, err := c.forumSuggestionService.GetPendingSuggestions(ctx.Param("courseId"), ctx.GetString("teacher_uuid"))
+	if err != nil {
+		slog.Error("Error getting pending forum suggestions", "error", err)
+		ctx.JSON(forumSuggestionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, questions)
+}
+
+// @Summary Approve a forum answer suggestion
+// @Description Publish an AI suggested answer to the students, optionally replacing its content with an edited one (for teachers). The answer stays labelled as an AI suggestion.
+// @Tags forum-suggestions
+// @Accept json
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Param answerId path string true "Answer ID"
+// @Param request body schemas.ApproveSuggestionRequest false "Edited content"
+// @Success 200 {object} schemas.ForumSuggestionResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /forum/questions/{questionId}/suggestions/{answerId}/approve [post]
+func (c *ForumSuggestionController) ApproveSuggestion(ctx *gin.Context) {
+	slog.Debug("Approving forum suggestion", "questionId", ctx.Param("questionId"), "answerId", ctx.Param("answerId"))
+
+	// The body is optional, without it the drafted content is approved as is
+	var request schemas.ApproveSuggestionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	suggestion, err := c.forumSuggestionService.ApproveSuggestion(ctx.Param("questionId"), ctx.Param("answerId"), teacherUUID, request.Content)
+	if err != nil {
+		slog.Error("Error approving forum suggestion", "error", err)
+		ctx.JSON(forumSuggestionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, suggestion)
+}
+
+// @Summary Discard a forum answer suggestion
+// @Description Delete an AI suggested answer that is waiting for review (for teachers)
+// @Tags forum-suggestions
+// @Produce json
+// @Param questionId path string true "Question ID"
+// @Param answerId path string true "Answer ID"
+// @Success 200 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Router /forum/questions/{questionId}/suggestions/{answerId} [delete]
+func (c *ForumSuggestionController) DiscardSuggestion(ctx *gin.Context) {
+	slog.Debug("Discarding forum suggestion", "questionId", ctx.Param("questionId"), "answerId", ctx.Param("answerId"))
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	_, err := c.forumSuggestionService.DiscardSuggestion(ctx.Param("questionId"), ctx.Param("answerId"), teacherUUID)
+	if err != nil {
+		slog.Error("Error discarding forum suggestion", "error", err)
+		ctx.JSON(forumSuggestionErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"message": "Suggestion discarded successfully"})
+}
+
+func forumSuggestionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrUnauthorized), errors.Is(err, service.ErrForumSuggestionsDisabled):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrForumQuestionNotFound), errors.Is(err, service.ErrSuggestionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrForumQuestionNotOpen), errors.Is(err, service.ErrSuggestionAlreadyPending):
+		return http.StatusConflict
+	default:
+		return aiErrorStatus(err)
+	}
+}
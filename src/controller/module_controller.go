@@ -4,7 +4,7 @@ import (
 	"courses-service/src/model"
 	"courses-service/src/schemas"
 	"courses-service/src/service"
-	"fmt"
+	"errors"
 	"log"
 	"log/slog"
 	"net/http"
@@ -13,14 +13,12 @@ import (
 )
 
 type ModuleController struct {
-	service         service.ModuleServiceInterface
-	activityService service.TeacherActivityServiceInterface
+	service service.ModuleServiceInterface
 }
 
-func NewModuleController(service service.ModuleServiceInterface, activityService service.TeacherActivityServiceInterface) *ModuleController {
+func NewModuleController(service service.ModuleServiceInterface) *ModuleController {
 	return &ModuleController{
-		service:         service,
-		activityService: activityService,
+		service: service,
 	}
 }
 
@@ -43,24 +41,14 @@ func (c *ModuleController) CreateModule(ctx *gin.Context) {
 	}
 	log.Printf("module: %v\n", module)
 
-	createdModule, err := c.service.CreateModule(module)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	createdModule, err := c.service.CreateModule(teacherUUID, module)
 	if err != nil {
 		slog.Error("Error creating module", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			createdModule.CourseID,
-			teacherUUID,
-			"CREATE_MODULE",
-			fmt.Sprintf("Created module: %s", createdModule.Title),
-		)
-	}
-
 	slog.Debug("Module created", "module", createdModule)
 	ctx.JSON(http.StatusCreated, createdModule)
 }
@@ -131,24 +119,18 @@ func (c *ModuleController) UpdateModule(ctx *gin.Context) {
 		return
 	}
 
-	updatedModule, err := c.service.UpdateModule(id, module)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	updatedModule, err := c.service.UpdateModule(id, teacherUUID, module)
 	if err != nil {
 		slog.Error("Error updating module", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		if errors.Is(err, service.ErrFileNotFound) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" {
-		c.activityService.LogActivityIfAuxTeacher(
-			updatedModule.CourseID,
-			teacherUUID,
-			"UPDATE_MODULE",
-			fmt.Sprintf("Updated module: %s", updatedModule.Title),
-		)
-	}
-
 	slog.Debug("Module updated", "module", updatedModule)
 	ctx.JSON(http.StatusOK, updatedModule)
 }
@@ -165,32 +147,14 @@ func (c *ModuleController) DeleteModule(ctx *gin.Context) {
 	slog.Debug("Deleting module")
 	id := ctx.Param("id")
 
-	// Get module info before deleting for logging
-	moduleToDelete, err := c.service.GetModuleById(id)
-	if err != nil {
-		slog.Error("Error getting module for deletion", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
-		return
-	}
-
-	err = c.service.DeleteModule(id)
+	teacherUUID := ctx.GetString("teacher_uuid")
+	err := c.service.DeleteModule(id, teacherUUID)
 	if err != nil {
 		slog.Error("Error deleting module", "error", err)
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(teacherErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary
-	teacherUUID := ctx.GetHeader("X-Teacher-UUID")
-	if teacherUUID != "" && moduleToDelete != nil {
-		c.activityService.LogActivityIfAuxTeacher(
-			moduleToDelete.CourseID,
-			teacherUUID,
-			"DELETE_MODULE",
-			fmt.Sprintf("Deleted module: %s", moduleToDelete.Title),
-		)
-	}
-
 	slog.Debug("Module deleted", "id", id)
 	ctx.JSON(http.StatusNoContent, nil)
 }
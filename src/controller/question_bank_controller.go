@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionBankController struct {
+	questionBankService service.QuestionBankServiceInterface
+}
+
+func NewQuestionBankController(questionBankService service.QuestionBankServiceInterface) *QuestionBankController {
+	return &QuestionBankController{
+		questionBankService: questionBankService,
+	}
+}
+
+// @Summary Add a question to the question bank
+// @Description Add a reusable question with tags and difficulty to the question bank of a course (for teachers)
+// @Tags question-bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param question body schemas.CreateBankQuestionRequest true "Question to add"
+// @Success 201 {object} model.BankQuestion
+// @Router /courses/{id}/question-bank [post]
+func (c *QuestionBankController) CreateQuestion(ctx *gin.Context) {
+	slog.Debug("Creating bank question")
+	courseID := ctx.Param("id")
+
+	var request schemas.CreateBankQuestionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	question, err := c.questionBankService.CreateQuestion(ctx, courseID, teacherUUID, request)
+	if err != nil {
+		slog.Error("Error creating bank question", "error", err)
+		ctx.JSON(questionBankErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	slog.Debug("Bank question created", "question", question)
+	ctx.JSON(http.StatusCreated, question)
+}
+
+// @Summary Get the question bank
+// @Description Get the question bank of a course, optionally filtered by tag and difficulty (for teachers)
+// @Tags question-bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param tag query string false "Tag"
+// @Param difficulty query string false "Difficulty (easy, medium, hard)"
+// @Success 200 {array} model.BankQuestion
+// @Router /courses/{id}/question-bank [get]
+func (c *QuestionBankController) GetQuestions(ctx *gin.Context) {
+	slog.Debug("Getting bank questions")
+	courseID := ctx.Param("id")
+	tag := ctx.Query("tag")
+	difficulty := model.QuestionDifficulty(ctx.Query("difficulty"))
+
+	questions, err := c.questionBankService.GetQuestions(ctx, courseID, ctx.GetString("teacher_uuid"), tag, difficulty)
+	if err != nil {
+		slog.Error("Error getting bank questions", "error", err)
+		ctx.JSON(questionBankErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, questions)
+}
+
+// @Summary Get a bank question
+// @Description Get a question of the question bank of a course (for teachers)
+// @Tags question-bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param questionId path string true "Question ID"
+// @Success 200 {object} model.BankQuestion
+// @Router /courses/{id}/question-bank/{questionId} [get]
+func (c *QuestionBankController) GetQuestion(ctx *gin.Context) {
+	slog.Debug("Getting bank question")
+	courseID := ctx.Param("id")
+	questionID := ctx.Param("questionId")
+
+	question, err := c.questionBankService.GetQuestion(ctx, courseID, questionID, ctx.GetString("teacher_uuid"))
+	if err != nil {
+		slog.Error("Error getting bank question", "error", err)
+		ctx.JSON(questionBankErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, question)
+}
+
+// @Summary Update a bank question
+// @Description Update a question of the question bank of a course (for teachers)
+// @Tags question-bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param questionId path string true "Question ID"
+// @Param question body schemas.UpdateBankQuestionRequest true "Question fields to update"
+// @Success 200 {object} model.BankQuestion
+// @Router /courses/{id}/question-bank/{questionId} [put]
+func (c *QuestionBankController) UpdateQuestion(ctx *gin.Context) {
+	slog.Debug("Updating bank question")
+	courseID := ctx.Param("id")
+	questionID := ctx.Param("questionId")
+
+	var request schemas.UpdateBankQuestionRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	question, err := c.questionBankService.UpdateQuestion(ctx, courseID, questionID, teacherUUID, request)
+	if err != nil {
+		slog.Error("Error updating bank question", "error", err)
+		ctx.JSON(questionBankErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, question)
+}
+
+// @Summary Delete a bank question
+// @Description Delete a question of the question bank of a course (for teachers)
+// @Tags question-bank
+// @Accept json
+// @Produce json
+// @Param id path string true "Course ID"
+// @Param questionId path string true "Question ID"
+// @Success 204 {string} string "Question deleted successfully"
+// @Router /courses/{id}/question-bank/{questionId} [delete]
+func (c *QuestionBankController) DeleteQuestion(ctx *gin.Context) {
+	slog.Debug("Deleting bank question")
+	courseID := ctx.Param("id")
+	questionID := ctx.Param("questionId")
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	if err := c.questionBankService.DeleteQuestion(ctx, courseID, questionID, teacherUUID); err != nil {
+		slog.Error("Error deleting bank question", "error", err)
+		ctx.JSON(questionBankErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusNoContent, nil)
+}
+
+func questionBankErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrBankQuestionNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidBankQuestion):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
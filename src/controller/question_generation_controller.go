@@ -0,0 +1,108 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/ai"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type QuestionGenerationController struct {
+	questionGenerationService service.QuestionGenerationServiceInterface
+}
+
+func NewQuestionGenerationController(questionGenerationService service.QuestionGenerationServiceInterface) *QuestionGenerationController {
+	return &QuestionGenerationController{
+		questionGenerationService: questionGenerationService,
+	}
+}
+
+// @Summary Generate questions from a module
+// @Description Generate draft multiple choice and text questions about the description and text resources of a module with AI (for teachers). The questions are not stored.
+// @Tags question-generation
+// @Accept json
+// @Produce json
+// @Param id path string true "Module ID"
+// @Param request body schemas.GenerateQuestionsRequest true "Amount of questions of each type"
+// @Success 200 {object} schemas.GeneratedQuestionsResponse
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 502 {object} map[string]string
+// @Failure 503 {object} map[string]string
+// @Router /modules/{id}/generate-questions [post]
+func (c *QuestionGenerationController) GenerateQuestions(ctx *gin.Context) {
+	slog.Debug("Generating questions", "moduleId", ctx.Param("id"))
+
+	var request schemas.GenerateQuestionsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	generated, err := c.questionGenerationService.GenerateQuestions(ctx, ctx.Param("id"), ctx.GetString("teacher_uuid"), request)
+	if err != nil {
+		slog.Error("Error generating questions", "error", err)
+		ctx.JSON(questionGenerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, generated)
+}
+
+// @Summary Add questions to a draft assignment
+// @Description Append reviewed questions, e.g. the ones generated from a module, to a draft assignment (for teachers). The total points of the assignment grow by the points of the new questions.
+// @Tags question-generation
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param request body schemas.AddQuestionsRequest true "Questions to add"
+// @Success 200 {object} model.Assignment
+// @Failure 400 {object} map[string]string
+// @Failure 403 {object} map[string]string
+// @Failure 404 {object} map[string]string
+// @Failure 409 {object} map[string]string
+// @Router /assignments/{assignmentId}/questions [post]
+func (c *QuestionGenerationController) AddQuestions(ctx *gin.Context) {
+	slog.Debug("Adding questions to assignment", "assignmentId", ctx.Param("assignmentId"))
+
+	var request schemas.AddQuestionsRequest
+	if err := ctx.ShouldBindJSON(&request); err != nil {
+		slog.Error("Error binding JSON", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	assignment, err := c.questionGenerationService.AddQuestions(ctx, ctx.Param("assignmentId"), teacherUUID, request.Questions)
+	if err != nil {
+		slog.Error("Error adding questions to assignment", "error", err)
+		ctx.JSON(questionGenerationErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, assignment)
+}
+
+func questionGenerationErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidQuestionGeneration), errors.Is(err, service.ErrInvalidQuestion):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrAssignmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAssignmentNotDraft):
+		return http.StatusConflict
+	case errors.Is(err, ai.ErrInvalidGeneratedQuestions):
+		// The model answered but its questions can't be used, asking again may work
+		return http.StatusBadGateway
+	default:
+		return aiErrorStatus(err)
+	}
+}
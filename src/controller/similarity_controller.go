@@ -0,0 +1,99 @@
+package controller
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+type SimilarityController struct {
+	similarityService service.SimilarityServiceInterface
+}
+
+func NewSimilarityController(similarityService service.SimilarityServiceInterface) *SimilarityController {
+	return &SimilarityController{
+		similarityService: similarityService,
+	}
+}
+
+// @Summary Get the similarity report of an assignment
+// @Description List the pairs of text answers that look alike between students of the assignment and earlier editions of the course (for teachers)
+// @Tags similarity
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param threshold query number false "Minimum similarity between 0 and 1, defaults to 0.5"
+// @Param include_prior_terms query bool false "Compare with earlier editions of the course, defaults to true"
+// @Success 200 {object} schemas.SimilarityReport
+// @Router /assignments/{assignmentId}/similarity [get]
+func (c *SimilarityController) GetSimilarityReport(ctx *gin.Context) {
+	slog.Debug("Getting similarity report")
+	assignmentID := ctx.Param("assignmentId")
+
+	var request schemas.SimilarityReportRequest
+	if err := ctx.ShouldBindQuery(&request); err != nil {
+		slog.Error("Error binding query", "error", err)
+		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := c.similarityService.GetSimilarityReport(ctx, assignmentID, ctx.GetString("teacher_uuid"), request)
+	if err != nil {
+		slog.Error("Error getting similarity report", "error", err)
+		ctx.JSON(similarityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+// @Summary Flag similar submissions
+// @Description Build the similarity report and mark the submissions found in a suspicious pair as needing manual review (for teachers)
+// @Tags similarity
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param options body schemas.SimilarityReportRequest false "Report options"
+// @Success 200 {object} schemas.SimilarityReport
+// @Router /assignments/{assignmentId}/similarity/flag [post]
+func (c *SimilarityController) FlagSimilarSubmissions(ctx *gin.Context) {
+	slog.Debug("Flagging similar submissions")
+	assignmentID := ctx.Param("assignmentId")
+
+	var request schemas.SimilarityReportRequest
+	if ctx.Request.ContentLength > 0 {
+		if err := ctx.ShouldBindJSON(&request); err != nil {
+			slog.Error("Error binding JSON", "error", err)
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	teacherUUID := ctx.GetString("teacher_uuid")
+	report, err := c.similarityService.FlagSimilarSubmissions(ctx, assignmentID, teacherUUID, request)
+	if err != nil {
+		slog.Error("Error flagging similar submissions", "error", err)
+		ctx.JSON(similarityErrorStatus(err), gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, report)
+}
+
+func similarityErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrAssignmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrUnauthorized):
+		return http.StatusForbidden
+	case errors.Is(err, service.ErrInvalidSimilarityThreshold):
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
@@ -1,7 +1,9 @@
 package controller
 
 import (
+	"courses-service/src/schemas"
 	"courses-service/src/service"
+	"errors"
 	"net/http"
 	"time"
 
@@ -10,17 +12,20 @@ import (
 
 // StatisticsController handles requests for statistics data
 type StatisticsController struct {
-	statisticsService service.StatisticsServiceInterface
+	statisticsService        service.StatisticsServiceInterface
+	feedbackAnalyticsService service.FeedbackAnalyticsServiceInterface
 }
 
 // NewStatisticsController creates a new statistics controller
-func NewStatisticsController(statisticsService service.StatisticsServiceInterface) *StatisticsController {
+func NewStatisticsController(statisticsService service.StatisticsServiceInterface, feedbackAnalyticsService service.FeedbackAnalyticsServiceInterface) *StatisticsController {
 	return &StatisticsController{
-		statisticsService: statisticsService,
+		statisticsService:        statisticsService,
+		feedbackAnalyticsService: feedbackAnalyticsService,
 	}
 }
 
-// GetCourseStatistics returns statistics for a specific course
+// GetCourseStatistics returns statistics for a specific course, with the sentiment and topic trends of its
+// feedback grouped by week or month (?feedback_interval=week|month, month by default)
 func (c *StatisticsController) GetCourseStatistics(ctx *gin.Context) {
 	courseID := ctx.Param("courseId")
 	if courseID == "" {
@@ -57,7 +62,18 @@ func (c *StatisticsController) GetCourseStatistics(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, gin.H{"csv": string(data)})
+	interval := schemas.FeedbackTrendInterval(ctx.Query("feedback_interval"))
+	feedbackTrends, err := c.feedbackAnalyticsService.GetFeedbackTrends(ctx, courseID, from, to, interval)
+	if err != nil {
+		status := http.StatusInternalServerError
+		if errors.Is(err, service.ErrInvalidTrendInterval) || errors.Is(err, service.ErrInvalidDateRange) {
+			status = http.StatusBadRequest
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, gin.H{"csv": string(data), "feedback_trends": feedbackTrends})
 }
 
 // Returns statistics for a specific student
@@ -1,6 +1,7 @@
 package controller
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
@@ -16,16 +17,12 @@ import (
 type SubmissionController struct {
 	submissionService  service.SubmissionServiceInterface
 	notificationsQueue queues.NotificationsQueueInterface
-	activityService    service.TeacherActivityServiceInterface
-	assignmentService  service.AssignmentServiceInterface
 }
 
-func NewSubmissionController(submissionService service.SubmissionServiceInterface, notificationsQueue queues.NotificationsQueueInterface, activityService service.TeacherActivityServiceInterface, assignmentService service.AssignmentServiceInterface) *SubmissionController {
+func NewSubmissionController(submissionService service.SubmissionServiceInterface, notificationsQueue queues.NotificationsQueueInterface) *SubmissionController {
 	return &SubmissionController{
 		submissionService:  submissionService,
 		notificationsQueue: notificationsQueue,
-		activityService:    activityService,
-		assignmentService:  assignmentService,
 	}
 }
 
@@ -56,7 +53,7 @@ func (c *SubmissionController) CreateSubmission(ctx *gin.Context) {
 
 	submission, err := c.submissionService.GetOrCreateSubmission(ctx, assignmentID, studentUUID, studentName)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(submissionErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
@@ -64,11 +61,11 @@ func (c *SubmissionController) CreateSubmission(ctx *gin.Context) {
 	submission.UpdatedAt = time.Now()
 
 	if err := c.submissionService.UpdateSubmission(ctx, submission); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(submissionErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, submission)
+	ctx.JSON(http.StatusOK, submission.StudentView())
 }
 
 // @Summary Get a submission by ID
@@ -101,7 +98,13 @@ func (c *SubmissionController) GetSubmission(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, submission)
+	// Only the student of the submission can read it, the teachers use the submissions of the assignment
+	if submission.StudentUUID != ctx.GetString("student_uuid") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "the submission belongs to another student"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, submission.StudentView())
 }
 
 // @Summary Update a submission
@@ -145,11 +148,11 @@ func (c *SubmissionController) UpdateSubmission(ctx *gin.Context) {
 	}
 
 	if err := c.submissionService.UpdateSubmission(ctx, &submission); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(submissionErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	ctx.JSON(http.StatusOK, submission)
+	ctx.JSON(http.StatusOK, submission.StudentView())
 }
 
 // @Summary Submit a submission
@@ -179,13 +182,17 @@ func (c *SubmissionController) SubmitSubmission(ctx *gin.Context) {
 		ctx.JSON(http.StatusNotFound, gin.H{"error": "submission not found"})
 		return
 	}
+	if submission.StudentUUID != ctx.GetString("student_uuid") {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": "the submission belongs to another student"})
+		return
+	}
 
 	if err := c.submissionService.SubmitSubmission(ctx, id); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(submissionErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
-	// Get the updated submission after auto-correction
+	// Get the updated submission, the AI correction may still be running in the background
 	updatedSubmission, err := c.submissionService.GetSubmission(ctx, id)
 	if err != nil {
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
@@ -195,7 +202,59 @@ func (c *SubmissionController) SubmitSubmission(ctx *gin.Context) {
 	// Send notification about the corrected submission
 	c.sendCorrectionNotification(updatedSubmission, assignmentID)
 
-	ctx.JSON(http.StatusOK, updatedSubmission)
+	ctx.JSON(http.StatusOK, updatedSubmission.StudentView())
+}
+
+// @Summary Get the correction status of a submission
+// @Description Get the state of the background AI correction of a submission (for the student who made it and the teachers of the course)
+// @Tags submissions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param id path string true "Submission ID"
+// @Success 200 {object} model.CorrectionJob
+// @Router /assignments/{assignmentId}/submissions/{id}/correction [get]
+func (c *SubmissionController) GetCorrectionStatus(ctx *gin.Context) {
+	assignmentID := ctx.Param("assignmentId")
+	id := ctx.Param("id")
+
+	job, err := c.submissionService.GetCorrectionStatus(ctx, id, ctx.GetString("user_uuid"))
+	if err != nil {
+		status := http.StatusInternalServerError
+		switch {
+		case errors.Is(err, service.ErrSubmissionNotFound), errors.Is(err, service.ErrCorrectionJobNotFound):
+			status = http.StatusNotFound
+		case errors.Is(err, service.ErrUnauthorized):
+			status = http.StatusForbidden
+		}
+		ctx.JSON(status, gin.H{"error": err.Error()})
+		return
+	}
+
+	// Validate submission belongs to the assignment
+	if job.AssignmentID != assignmentID {
+		ctx.JSON(http.StatusNotFound, gin.H{"error": "submission not found"})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, job)
+}
+
+// submissionErrorStatus maps the errors of a student editing or submitting a submission to an HTTP status
+func submissionErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, service.ErrInvalidAnswer):
+		return http.StatusBadRequest
+	case errors.Is(err, service.ErrSubmissionNotFound), errors.Is(err, service.ErrAssignmentNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, service.ErrAlreadySubmitted), errors.Is(err, service.ErrSubmissionLocked):
+		return http.StatusConflict
+	case errors.Is(err, service.ErrLateSubmission), errors.Is(err, service.ErrExamNotAvailable), errors.Is(err, service.ErrExamTimeExpired),
+		errors.Is(err, service.ErrUnauthorized):
+		return http.StatusForbidden
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 // sendCorrectionNotification sends a notification about the corrected submission
@@ -231,6 +290,68 @@ func (c *SubmissionController) sendCorrectionNotification(submission *model.Subm
 	fmt.Println("queueMessage: ", queueMessage)
 }
 
+// @Summary Start a new attempt
+// @Description Start a new attempt for an assignment once the previous one was submitted
+// @Tags submissions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Success 201 {object} model.Submission
+// @Router /assignments/{assignmentId}/submissions/attempts [post]
+func (c *SubmissionController) StartNewAttempt(ctx *gin.Context) {
+	assignmentID := ctx.Param("assignmentId")
+
+	// Get student info from context
+	studentUUID := ctx.GetString("student_uuid")
+	studentName := ctx.GetString("student_name")
+
+	submission, err := c.submissionService.StartNewAttempt(ctx, assignmentID, studentUUID, studentName)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrAssignmentNotFound):
+			ctx.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrMaxAttemptsReached), errors.Is(err, service.ErrAttemptInProgress):
+			ctx.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		case errors.Is(err, service.ErrExamNotAvailable):
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		default:
+			ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, submission.StudentView())
+}
+
+// @Summary Get attempt history
+// @Description Get every attempt of a student for an assignment and the final score (for teachers)
+// @Tags submissions
+// @Accept json
+// @Produce json
+// @Param assignmentId path string true "Assignment ID"
+// @Param studentUUID path string true "Student ID"
+// @Success 200 {object} schemas.AttemptHistoryResponse
+// @Router /assignments/{assignmentId}/students/{studentUUID}/attempts [get]
+func (c *SubmissionController) GetAttemptHistory(ctx *gin.Context) {
+	assignmentID := ctx.Param("assignmentId")
+	studentUUID := ctx.Param("studentUUID")
+
+	// Validate teacher permissions for this assignment
+	teacherUUID := ctx.GetString("teacher_uuid")
+	if err := c.submissionService.ValidateTeacherPermissions(ctx, assignmentID, teacherUUID); err != nil {
+		ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	history, err := c.submissionService.GetAttemptHistory(ctx, assignmentID, studentUUID)
+	if err != nil {
+		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	ctx.JSON(http.StatusOK, history)
+}
+
 // @Summary Get submissions by assignment ID
 // @Description Get submissions by assignment ID
 // @Tags submissions
@@ -275,11 +396,15 @@ func (c *SubmissionController) GetSubmissionsByStudent(ctx *gin.Context) {
 		return
 	}
 
-	ctx.JSON(http.StatusOK, submissions)
+	views := make([]*model.Submission, len(submissions))
+	for i := range submissions {
+		views[i] = submissions[i].StudentView()
+	}
+	ctx.JSON(http.StatusOK, views)
 }
 
 // @Summary Grade a submission
-// @Description Grade a submission by ID (for teachers)
+// @Description Grade a submission by ID (for teachers). When per-answer grades are sent the score is derived from them
 // @Tags submissions
 // @Accept json
 // @Produce json
@@ -323,25 +448,20 @@ func (c *SubmissionController) GradeSubmission(ctx *gin.Context) {
 	}
 
 	// Grade the submission
-	gradedSubmission, err := c.submissionService.GradeSubmission(ctx, id, gradeRequest.Score, gradeRequest.Feedback)
+	gradedSubmission, err := c.submissionService.GradeSubmission(ctx, id, teacherUUID, gradeRequest)
 	if err != nil {
+		if errors.Is(err, service.ErrInvalidAnswerGrade) {
+			ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		if errors.Is(err, service.ErrUnauthorized) {
+			ctx.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+			return
+		}
 		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	// Log activity if teacher is auxiliary - we need to get the assignment to find the course
-	if c.activityService != nil && c.assignmentService != nil {
-		assignment, err := c.assignmentService.GetAssignmentById(assignmentID)
-		if err == nil && assignment != nil {
-			c.activityService.LogActivityIfAuxTeacher(
-				assignment.CourseID,
-				teacherUUID,
-				"GRADE_SUBMISSION",
-				fmt.Sprintf("Graded submission for student %s", gradedSubmission.StudentName),
-			)
-		}
-	}
-
 	ctx.JSON(http.StatusOK, gradedSubmission)
 }
 
@@ -353,6 +473,7 @@ func (c *SubmissionController) GradeSubmission(ctx *gin.Context) {
 // @Param assignmentId path string true "Assignment ID"
 // @Param id path string true "Submission ID"
 // @Success 200 {object} schemas.AiSummaryResponse
+// @Failure 503 {object} schemas.ErrorResponse
 // @Router /assignments/{assignmentId}/submissions/{id}/feedback-summary [get]
 func (c *SubmissionController) GenerateFeedbackSummary(ctx *gin.Context) {
 	assignmentID := ctx.Param("assignmentId")
@@ -385,7 +506,7 @@ func (c *SubmissionController) GenerateFeedbackSummary(ctx *gin.Context) {
 	// Generate feedback summary
 	summary, err := c.submissionService.GenerateFeedbackSummary(ctx, id)
 	if err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		ctx.JSON(aiErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
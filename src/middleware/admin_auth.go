@@ -0,0 +1,13 @@
+package middleware
+
+import (
+	"courses-service/src/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AdminAuth is a middleware that authenticates the user with the JWT of the Authorization header and
+// requires the admin role, for the routes of the backoffice
+func AdminAuth() gin.HandlerFunc {
+	return requireRole(auth.RoleAdmin)
+}
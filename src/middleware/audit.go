@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Auditor builds the middlewares of the audited routes, which record their changes with the audit service
+type Auditor struct {
+	service service.AuditServiceInterface
+}
+
+func NewAuditor(service service.AuditServiceInterface) *Auditor {
+	return &Auditor{service: service}
+}
+
+// EntityLocator finds the ID of the entity a request changes, empty when the request creates it
+type EntityLocator func(c *gin.Context) string
+
+// EntityParam locates the entity by its ID in the path parameter
+func EntityParam(name string) EntityLocator {
+	return func(c *gin.Context) string {
+		return c.Param(name)
+	}
+}
+
+// CreatedEntity locates the entity the request creates, its ID is read from the id field of the response
+func CreatedEntity(_ *gin.Context) string {
+	return ""
+}
+
+// EnrollmentParams locates the enrollment of the student of a path parameter in the course of another
+func EnrollmentParams(courseParam, studentParam string) EntityLocator {
+	return func(c *gin.Context) string {
+		return enrollmentID(c.Param(courseParam), c.Param(studentParam))
+	}
+}
+
+// OwnEnrollment locates the enrollment of the user of the request in the course of the path parameter
+func OwnEnrollment(courseParam string) EntityLocator {
+	return func(c *gin.Context) string {
+		return enrollmentID(c.Param(courseParam), c.GetString("user_uuid"))
+	}
+}
+
+// EnrollmentBody locates the enrollment of the student of a field of the JSON body in the course of the
+// path parameter. The body is restored so the handler can still bind it.
+func EnrollmentBody(courseParam, studentField string) EntityLocator {
+	return func(c *gin.Context) string {
+		studentUUID, _ := bodyField(c, studentField)
+		return enrollmentID(c.Param(courseParam), studentUUID)
+	}
+}
+
+// LatestSubmission locates the latest attempt of the user of the request at the assignment of the path
+// parameter. Without one the request creates the first attempt and it is located as a created entity.
+func (a *Auditor) LatestSubmission(assignmentParam string) EntityLocator {
+	return func(c *gin.Context) string {
+		return a.service.LatestSubmissionID(c, c.Param(assignmentParam), c.GetString("user_uuid"))
+	}
+}
+
+func enrollmentID(courseID, studentUUID string) string {
+	if courseID == "" || studentUUID == "" {
+		return ""
+	}
+	return courseID + ":" + studentUUID
+}
+
+// Audit records the change the request makes to the entity in the audit log, with the user, their roles
+// and the ID of the request. It goes after the auth middlewares of the route: the entity is read before
+// and after the handler and only the successful requests are recorded. The response is already sent when
+// the change is recorded, so a failure to record it is only logged. Without an audit service the request
+// is rejected, the changes are never made without being recorded.
+func (a *Auditor) Audit(entity model.AuditEntity, locate EntityLocator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if a == nil || a.service == nil {
+			abort(c, http.StatusInternalServerError, "auditing is not configured")
+			return
+		}
+
+		entityID := locate(c)
+		var before map[string]any
+		var response *responseRecorder
+		if entityID != "" {
+			before = a.service.Snapshot(c, entity, entityID)
+		} else {
+			response = &responseRecorder{ResponseWriter: c.Writer}
+			c.Writer = response
+		}
+
+		c.Next()
+
+		if c.IsAborted() || c.Writer.Status() >= http.StatusBadRequest {
+			return
+		}
+		if response != nil {
+			entityID = createdEntityID(response.body.Bytes())
+			if entityID == "" {
+				slog.Error("The created entity has no ID to audit", "entity", entity, "path", c.FullPath())
+				return
+			}
+		}
+
+		record := schemas.AuditRecord{
+			RequestID: GetRequestID(c),
+			Entity:    entity,
+			EntityID:  entityID,
+			Method:    c.Request.Method,
+			Path:      c.Request.URL.Path,
+			Before:    before,
+			After:     a.service.Snapshot(c, entity, entityID),
+		}
+		if identity, ok := GetIdentity(c); ok {
+			record.ActorUUID = identity.UserID
+			record.ActorRoles = identity.Roles
+		}
+		if membership, ok := GetMembership(c); ok {
+			record.ActorRoles = membership.Roles
+			record.CourseID = membership.CourseID
+		}
+		if err := a.service.Record(c, record); err != nil {
+			slog.Error("Error recording the change in the audit log", "error", err, "entity", entity, "entityID", entityID, "requestID", record.RequestID)
+		}
+	}
+}
+
+// responseRecorder keeps a copy of the response body, to read the ID of the created entities
+type responseRecorder struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+}
+
+func (w *responseRecorder) Write(data []byte) (int, error) {
+	w.body.Write(data)
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *responseRecorder) WriteString(s string) (int, error) {
+	w.body.WriteString(s)
+	return w.ResponseWriter.WriteString(s)
+}
+
+func createdEntityID(body []byte) string {
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return ""
+	}
+	id, _ := fields["id"].(string)
+	return id
+}
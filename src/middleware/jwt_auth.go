@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"courses-service/src/auth"
+
+	"github.com/gin-gonic/gin"
+)
+
+// IdentityKey is the context key of the *auth.Identity of the authenticated user
+const IdentityKey = "identity"
+
+var verifier atomic.Pointer[auth.Verifier]
+
+// SetVerifier sets the verifier of the tokens for every auth middleware. Until it's set every request
+// is rejected, so a missing configuration never lets a request through.
+func SetVerifier(v *auth.Verifier) {
+	verifier.Store(v)
+}
+
+// BlockList tells if the admins blocked a user
+type BlockList interface {
+	IsBlocked(userUUID string) (bool, error)
+}
+
+type blockListHolder struct {
+	BlockList
+}
+
+var blockList atomic.Pointer[blockListHolder]
+
+// SetBlockList sets the users blocked by the admins, their requests are rejected with 403 even with a
+// valid token. Until it's set every authenticated request is rejected, so a missing configuration never
+// lets a blocked user through.
+func SetBlockList(b BlockList) {
+	blockList.Store(&blockListHolder{b})
+}
+
+// authenticate verifies the bearer token of the Authorization header and sets the identity of the user in
+// the context. The user is also set by role, as the handlers of the teachers and students expect it.
+// The request is aborted with 401 when the token is missing or invalid and with 403 when the user is
+// blocked. A token already verified by another middleware of the route is not verified again.
+func authenticate(c *gin.Context) (*auth.Identity, bool) {
+	if identity, ok := GetIdentity(c); ok {
+		return identity, true
+	}
+
+	v := verifier.Load()
+	if v == nil {
+		abort(c, http.StatusUnauthorized, "authentication is not configured")
+		return nil, false
+	}
+
+	scheme, token, found := strings.Cut(c.GetHeader("Authorization"), " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		abort(c, http.StatusUnauthorized, "Authorization header with a bearer token is required")
+		return nil, false
+	}
+
+	identity, err := v.Verify(strings.TrimSpace(token))
+	if err != nil {
+		message := "invalid token"
+		if errors.Is(err, auth.ErrExpiredToken) {
+			message = "token is expired"
+		}
+		abort(c, http.StatusUnauthorized, message)
+		return nil, false
+	}
+
+	holder := blockList.Load()
+	if holder == nil || holder.BlockList == nil {
+		abort(c, http.StatusInternalServerError, "the block list is not configured")
+		return nil, false
+	}
+	blocked, err := holder.IsBlocked(identity.UserID)
+	if err != nil {
+		slog.Error("Error checking if the user is blocked", "error", err, "userID", identity.UserID)
+		abort(c, http.StatusInternalServerError, "error checking the user")
+		return nil, false
+	}
+	if blocked {
+		abort(c, http.StatusForbidden, "the user is blocked")
+		return nil, false
+	}
+
+	c.Set(IdentityKey, identity)
+	c.Set("user_uuid", identity.UserID)
+	if identity.HasRole(auth.RoleTeacher) {
+		c.Set("teacher_uuid", identity.UserID)
+		c.Set("teacher_name", identity.Name)
+	}
+	if identity.HasRole(auth.RoleStudent) {
+		c.Set("student_uuid", identity.UserID)
+		c.Set("student_name", identity.Name)
+	}
+	return identity, true
+}
+
+// requireRole authenticates the user and rejects it with 403 when it doesn't have the role
+func requireRole(role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := authenticate(c)
+		if !ok {
+			return
+		}
+		if !identity.HasRole(role) {
+			abort(c, http.StatusForbidden, "the "+role+" role is required")
+			return
+		}
+		c.Next()
+	}
+}
+
+// GetIdentity returns the authenticated user set by the auth middlewares
+func GetIdentity(c *gin.Context) (*auth.Identity, bool) {
+	value, ok := c.Get(IdentityKey)
+	if !ok {
+		return nil, false
+	}
+	identity, ok := value.(*auth.Identity)
+	return identity, ok
+}
+
+func abort(c *gin.Context, status int, message string) {
+	c.JSON(status, gin.H{"error": message})
+	c.Abort()
+}
@@ -0,0 +1,327 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"sync/atomic"
+
+	"courses-service/src/auth"
+	"courses-service/src/model"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+)
+
+// MembershipKey is the context key of the *auth.Membership of the user in the course of the request
+const MembershipKey = "course_membership"
+
+// AnswerKeyKey is the context key that tells the handlers of the public routes if the user can see the
+// answer key of the questions
+const AnswerKeyKey = "answer_key"
+
+var (
+	// errCourseRequired is returned by the locators when the request doesn't say its course
+	errCourseRequired = errors.New("Course ID is required")
+	errInvalidBody    = errors.New("invalid request body")
+)
+
+type membershipServiceHolder struct {
+	service.MembershipServiceInterface
+}
+
+var memberships atomic.Pointer[membershipServiceHolder]
+
+// SetMembershipService sets the service that resolves the course roles for RequireCourseRole. Until it's
+// set every course scoped route is rejected.
+func SetMembershipService(s service.MembershipServiceInterface) {
+	memberships.Store(&membershipServiceHolder{s})
+}
+
+// CourseLocator finds the ID of the course a request refers to
+type CourseLocator func(c *gin.Context, s service.MembershipServiceInterface) (string, error)
+
+// CourseParam locates the course by its ID in the path parameter
+func CourseParam(name string) CourseLocator {
+	return func(c *gin.Context, _ service.MembershipServiceInterface) (string, error) {
+		return required(c.Param(name), nil)
+	}
+}
+
+// ModuleParam locates the course of the module whose ID is in the path parameter
+func ModuleParam(name string) CourseLocator {
+	return func(c *gin.Context, s service.MembershipServiceInterface) (string, error) {
+		return required(s.GetModuleCourseID(c.Param(name)))
+	}
+}
+
+// AssignmentParam locates the course of the assignment whose ID is in the path parameter
+func AssignmentParam(name string) CourseLocator {
+	return func(c *gin.Context, s service.MembershipServiceInterface) (string, error) {
+		return required(s.GetAssignmentCourseID(c.Param(name)))
+	}
+}
+
+// QuestionParam locates the course of the forum question whose ID is in the path parameter
+func QuestionParam(name string) CourseLocator {
+	return func(c *gin.Context, s service.MembershipServiceInterface) (string, error) {
+		return required(s.GetQuestionCourseID(c.Param(name)))
+	}
+}
+
+// CourseQuery locates the course by its ID in the query parameter
+func CourseQuery(name string) CourseLocator {
+	return func(c *gin.Context, _ service.MembershipServiceInterface) (string, error) {
+		return required(c.Query(name), nil)
+	}
+}
+
+// CourseBody locates the course by its ID in a field of the JSON body. The body is restored so the
+// handler can still bind it.
+func CourseBody(field string) CourseLocator {
+	return func(c *gin.Context, _ service.MembershipServiceInterface) (string, error) {
+		return required(bodyField(c, field))
+	}
+}
+
+// bodyField reads a string field of the JSON body and restores the body so the handler can still bind it
+func bodyField(c *gin.Context, field string) (string, error) {
+	if c.Request.Body == nil {
+		return "", errCourseRequired
+	}
+	body, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		return "", err
+	}
+	c.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	var fields map[string]any
+	if err := json.Unmarshal(body, &fields); err != nil {
+		return "", fmt.Errorf("%w: %v", errInvalidBody, err)
+	}
+	value, _ := fields[field].(string)
+	return value, nil
+}
+
+func required(courseID string, err error) (string, error) {
+	if err != nil {
+		return "", err
+	}
+	if strings.TrimSpace(courseID) == "" {
+		return "", errCourseRequired
+	}
+	return courseID, nil
+}
+
+// RequireCourseRole authenticates the user and requires one of the roles in the course the locator finds.
+// The roles are the course roles of auth plus auth.RoleAdmin, which the admins have in every course.
+// The members of a suspended or archived course are also limited by its status, the admins are not.
+// The membership is resolved once per request and kept in the context for the handlers.
+func RequireCourseRole(locate CourseLocator, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := authenticate(c)
+		if !ok {
+			return
+		}
+		holder := memberships.Load()
+		if holder == nil {
+			abort(c, http.StatusInternalServerError, "authorization is not configured")
+			return
+		}
+
+		courseID, err := locate(c, holder)
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		membership, err := resolveMembership(c, holder, courseID, identity)
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		if !membership.HasAnyRole(roles...) {
+			abort(c, http.StatusForbidden, "the user doesn't have the required role in the course")
+			return
+		}
+		if !membership.HasRole(auth.RoleAdmin) && !courseStatusAllows(membership.CourseStatus, c.Request.Method) {
+			abort(c, http.StatusForbidden, "the course is "+membership.CourseStatus)
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireVisibleCourse rejects the requests of the public routes to a suspended course, only the admins access
+// them from the backoffice. As with RequireCourseRole, the archived courses can be read but not changed.
+func RequireVisibleCourse(locate CourseLocator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		holder := memberships.Load()
+		if holder == nil {
+			abort(c, http.StatusInternalServerError, "authorization is not configured")
+			return
+		}
+
+		courseID, err := locate(c, holder)
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		// A membership without user only has the status of the course
+		membership, err := holder.GetMembership(courseID, "")
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		if !courseStatusAllows(membership.CourseStatus, c.Request.Method) {
+			abort(c, http.StatusForbidden, "the course is "+membership.CourseStatus)
+			return
+		}
+		c.Next()
+	}
+}
+
+// AllowAnswerKey lets the users with one of the roles in the course the locator finds see the answer key of
+// the questions of a public route. The route stays public: a request without a token gets the questions
+// without the answer key, while an invalid token or a blocked user is rejected as in the other routes.
+func AllowAnswerKey(locate CourseLocator, roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if c.GetHeader("Authorization") == "" {
+			c.Next()
+			return
+		}
+		identity, ok := authenticate(c)
+		if !ok {
+			return
+		}
+		holder := memberships.Load()
+		if holder == nil {
+			abort(c, http.StatusInternalServerError, "authorization is not configured")
+			return
+		}
+
+		courseID, err := locate(c, holder)
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		membership, err := resolveMembership(c, holder, courseID, identity)
+		if err != nil {
+			abortWithPolicyError(c, err)
+			return
+		}
+		c.Set(AnswerKeyKey, membership.HasAnyRole(roles...))
+		c.Next()
+	}
+}
+
+// RequireStudentAccess authenticates the user and requires them to be the student of the path parameter,
+// a teacher of one of the courses of the student or an admin
+func RequireStudentAccess(param string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := authenticate(c)
+		if !ok {
+			return
+		}
+		studentID := c.Param(param)
+		if identity.UserID == studentID || identity.HasRole(auth.RoleAdmin) {
+			c.Next()
+			return
+		}
+		holder := memberships.Load()
+		if holder == nil {
+			abort(c, http.StatusInternalServerError, "authorization is not configured")
+			return
+		}
+
+		isTeacher, err := holder.IsStudentTeacher(identity.UserID, studentID)
+		if err != nil {
+			slog.Error("Error checking the teachers of the student", "error", err, "studentID", studentID)
+			abort(c, http.StatusInternalServerError, "error checking the permissions of the student")
+			return
+		}
+		if !isTeacher {
+			abort(c, http.StatusForbidden, "only the student, their teachers and the admins can access it")
+			return
+		}
+		c.Next()
+	}
+}
+
+// RequireSelf authenticates the user and requires them to have the role and be the user of the path
+// parameter, or to be an admin
+func RequireSelf(param, role string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		identity, ok := authenticate(c)
+		if !ok {
+			return
+		}
+		if identity.HasRole(auth.RoleAdmin) || (identity.HasRole(role) && identity.UserID == c.Param(param)) {
+			c.Next()
+			return
+		}
+		abort(c, http.StatusForbidden, "only the user and the admins can access it")
+	}
+}
+
+// courseStatusAllows tells if the members of a course in the status can make the request. Only the admins
+// access the suspended courses, and the archived ones can be read but not changed.
+func courseStatusAllows(status, method string) bool {
+	switch status {
+	case string(model.CourseStatusSuspended):
+		return false
+	case string(model.CourseStatusArchived):
+		return method == http.MethodGet || method == http.MethodHead
+	default:
+		return true
+	}
+}
+
+// resolveMembership returns the membership kept in the context for the course and user, or resolves it
+func resolveMembership(c *gin.Context, s service.MembershipServiceInterface, courseID string, identity *auth.Identity) (*auth.Membership, error) {
+	if membership, ok := GetMembership(c); ok && membership.CourseID == courseID && membership.UserID == identity.UserID {
+		return membership, nil
+	}
+
+	membership, err := s.GetMembership(courseID, identity.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if identity.HasRole(auth.RoleAdmin) && !membership.HasRole(auth.RoleAdmin) {
+		membership.Roles = append(membership.Roles, auth.RoleAdmin)
+	}
+	c.Set(MembershipKey, membership)
+	return membership, nil
+}
+
+// GetMembership returns the membership of the user in the course of the request set by RequireCourseRole
+func GetMembership(c *gin.Context) (*auth.Membership, bool) {
+	value, ok := c.Get(MembershipKey)
+	if !ok {
+		return nil, false
+	}
+	membership, ok := value.(*auth.Membership)
+	return membership, ok
+}
+
+// notFoundErrors are the errors of the courses and course items that don't exist, answered with 404
+var notFoundErrors = []error{service.ErrCourseNotFound, service.ErrModuleNotFound, service.ErrAssignmentNotFound, service.ErrForumQuestionNotFound}
+
+func abortWithPolicyError(c *gin.Context, err error) {
+	if errors.Is(err, errCourseRequired) || errors.Is(err, errInvalidBody) {
+		abort(c, http.StatusBadRequest, err.Error())
+		return
+	}
+	for _, notFound := range notFoundErrors {
+		if errors.Is(err, notFound) {
+			abort(c, http.StatusNotFound, notFound.Error())
+			return
+		}
+	}
+	slog.Error("Error resolving the course membership", "error", err)
+	abort(c, http.StatusInternalServerError, "error checking the permissions in the course")
+}
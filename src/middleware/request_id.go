@@ -0,0 +1,49 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// RequestIDHeader is the header of the ID of the request, the response echoes it
+	RequestIDHeader = "X-Request-ID"
+	// RequestIDKey is the context key of the ID of the request
+	RequestIDKey = "request_id"
+
+	maxRequestIDLength = 128
+)
+
+// RequestID keeps the ID the gateway or the client sent for the request, or generates one, so the logs of
+// the request can be correlated with the ones of the other services
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		requestID := GetRequestID(c)
+		c.Header(RequestIDHeader, requestID)
+		c.Next()
+	}
+}
+
+// GetRequestID returns the ID of the request. It's taken from the header or generated the first time it's
+// asked for, so it's the same for every middleware and handler of the request.
+func GetRequestID(c *gin.Context) string {
+	if requestID := c.GetString(RequestIDKey); requestID != "" {
+		return requestID
+	}
+
+	requestID := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+	if requestID == "" || len(requestID) > maxRequestIDLength {
+		requestID = newRequestID()
+	}
+	c.Set(RequestIDKey, requestID)
+	return requestID
+}
+
+func newRequestID() string {
+	id := make([]byte, 16)
+	_, _ = rand.Read(id)
+	return hex.EncodeToString(id)
+}
@@ -0,0 +1,17 @@
+package middleware
+
+import (
+	"github.com/gin-gonic/gin"
+)
+
+// UserAuth is a middleware for routes shared by teachers and students. It authenticates the user with the
+// JWT of the Authorization header and sets user_uuid in the context for handlers to use, and teacher_uuid
+// or student_uuid according to the roles of the user.
+func UserAuth() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if _, ok := authenticate(c); !ok {
+			return
+		}
+		c.Next()
+	}
+}
@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AdminAction is a moderation action of the admins in the backoffice
+type AdminAction string
+
+const (
+	AdminActionSuspendCourse       AdminAction = "suspend_course"
+	AdminActionArchiveCourse       AdminAction = "archive_course"
+	AdminActionReactivateCourse    AdminAction = "reactivate_course"
+	AdminActionReassignTeacher     AdminAction = "reassign_teacher"
+	AdminActionUnenrollStudent     AdminAction = "unenroll_student"
+	AdminActionRemoveForumQuestion AdminAction = "remove_forum_question"
+	AdminActionRemoveForumAnswer   AdminAction = "remove_forum_answer"
+	AdminActionBlockUser           AdminAction = "block_user"
+	AdminActionUnblockUser         AdminAction = "unblock_user"
+)
+
+// AdminActionLog is an entry of the audit trail of the backoffice, with the admin that did the action and why
+type AdminActionLog struct {
+	ID        primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	AdminUUID string             `json:"admin_uuid" bson:"admin_uuid"`
+	Action    AdminAction        `json:"action" bson:"action"`
+	CourseID  string             `json:"course_id,omitempty" bson:"course_id,omitempty"`
+	TargetID  string             `json:"target_id" bson:"target_id"` // The course, user, question or answer the action was done on
+	Reason    string             `json:"reason" bson:"reason"`
+	Details   string             `json:"details,omitempty" bson:"details,omitempty"`
+	Timestamp time.Time          `json:"timestamp" bson:"timestamp"`
+}
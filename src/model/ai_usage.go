@@ -0,0 +1,35 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AiUsage records a call to the language model, with the tokens it consumed and how long it took
+type AiUsage struct {
+	ID               primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Operation        string             `json:"operation" bson:"operation"`
+	Provider         string             `json:"provider" bson:"provider"`
+	Model            string             `json:"model" bson:"model"`
+	PromptVersion    string             `json:"prompt_version,omitempty" bson:"prompt_version,omitempty"`
+	CourseID         string             `json:"course_id,omitempty" bson:"course_id,omitempty"`
+	TeacherUUID      string             `json:"teacher_uuid,omitempty" bson:"teacher_uuid,omitempty"`
+	StudentUUID      string             `json:"student_uuid,omitempty" bson:"student_uuid,omitempty"`
+	PromptTokens     int                `json:"prompt_tokens" bson:"prompt_tokens"`
+	CompletionTokens int                `json:"completion_tokens" bson:"completion_tokens"`
+	LatencyMs        int64              `json:"latency_ms" bson:"latency_ms"`
+	Cached           bool               `json:"cached" bson:"cached"`
+	Success          bool               `json:"success" bson:"success"`
+	CreatedAt        time.Time          `json:"created_at" bson:"created_at"`
+}
+
+// AiCacheEntry is an answer of the language model stored by the hash of its prompt
+type AiCacheEntry struct {
+	Key         string    `json:"key" bson:"_id"`
+	Operation   string    `json:"operation" bson:"operation"`
+	CourseID    string    `json:"course_id,omitempty" bson:"course_id,omitempty"`
+	StudentUUID string    `json:"student_uuid,omitempty" bson:"student_uuid,omitempty"`
+	Response    string    `json:"response" bson:"response"`
+	CreatedAt   time.Time `json:"created_at" bson:"created_at"`
+}
@@ -1,6 +1,7 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -12,8 +13,58 @@ const (
 	QuestionTypeText           QuestionType = "text"
 	QuestionTypeMultipleChoice QuestionType = "multiple_choice"
 	QuestionTypeFile           QuestionType = "file"
+	QuestionTypeNumeric        QuestionType = "numeric"      // Number within a tolerance of numeric_answer
+	QuestionTypeTrueFalse      QuestionType = "true_false"   // correct_answers holds "true" or "false"
+	QuestionTypeMatching       QuestionType = "matching"     // Answer maps each left item to a right item
+	QuestionTypeOrdering       QuestionType = "ordering"     // correct_answers holds the options in the right order
+	QuestionTypeShortAnswer    QuestionType = "short_answer" // Answer must match one of the accepted patterns
 )
 
+var QuestionTypeValues = []QuestionType{
+	QuestionTypeText,
+	QuestionTypeMultipleChoice,
+	QuestionTypeFile,
+	QuestionTypeNumeric,
+	QuestionTypeTrueFalse,
+	QuestionTypeMatching,
+	QuestionTypeOrdering,
+	QuestionTypeShortAnswer,
+}
+
+// MatchingPair is a correct pair of a matching question
+type MatchingPair struct {
+	Left  string `json:"left" bson:"left"`
+	Right string `json:"right" bson:"right"`
+}
+
+type AttemptScoringPolicy string
+
+const (
+	AttemptScoringHighest AttemptScoringPolicy = "highest"
+	AttemptScoringLatest  AttemptScoringPolicy = "latest"
+	AttemptScoringAverage AttemptScoringPolicy = "average"
+)
+
+var AttemptScoringPolicyValues = []AttemptScoringPolicy{
+	AttemptScoringHighest,
+	AttemptScoringLatest,
+	AttemptScoringAverage,
+}
+
+type LatePolicy string
+
+const (
+	LatePolicyAccept  LatePolicy = "accept"  // Late submissions are accepted without penalty
+	LatePolicyPenalty LatePolicy = "penalty" // Late submissions lose a percentage of the score per day
+	LatePolicyReject  LatePolicy = "reject"  // Submissions after the grace period are rejected
+)
+
+var LatePolicyValues = []LatePolicy{
+	LatePolicyAccept,
+	LatePolicyPenalty,
+	LatePolicyReject,
+}
+
 type Question struct {
 	ID             string       `json:"id" bson:"id"`
 	Text           string       `json:"text" bson:"text"`
@@ -22,22 +73,97 @@ type Question struct {
 	CorrectAnswers []string     `json:"correct_answers,omitempty" bson:"correct_answers,omitempty"`
 	Points         float64      `json:"points" bson:"points"`
 	Order          int          `json:"order" bson:"order"`
+	// Multiple choice grading: award a share of the points per correct option selected,
+	// and subtract a fraction of the points when an incorrect option is selected
+	PartialCredit   bool    `json:"partial_credit,omitempty" bson:"partial_credit,omitempty"`
+	NegativeMarking float64 `json:"negative_marking,omitempty" bson:"negative_marking,omitempty"` // Between 0 and 1
+	// Numeric questions: answers within tolerance of the expected value are correct
+	NumericAnswer *float64 `json:"numeric_answer,omitempty" bson:"numeric_answer,omitempty"`
+	Tolerance     float64  `json:"tolerance,omitempty" bson:"tolerance,omitempty"`
+	// Matching questions: the correct pairs, students match every left item with a right item
+	MatchingPairs []MatchingPair `json:"matching_pairs,omitempty" bson:"matching_pairs,omitempty"`
+	// Matching questions as the students see them: the left items and the right items in another order
+	MatchingLeft  []string `json:"matching_left,omitempty" bson:"matching_left,omitempty"`
+	MatchingRight []string `json:"matching_right,omitempty" bson:"matching_right,omitempty"`
+	// Short answer questions: regular expressions matched against the whole answer, ignoring case
+	AcceptedPatterns []string `json:"accepted_patterns,omitempty" bson:"accepted_patterns,omitempty"`
+	// Free-text and file questions can be graded with a rubric instead of a single score
+	Rubric *Rubric `json:"rubric,omitempty" bson:"rubric,omitempty"`
+}
+
+// MatchingItems returns the left and the right items of the matching pairs, in the order of the pairs
+func (q Question) MatchingItems() ([]string, []string) {
+	left := make([]string, len(q.MatchingPairs))
+	right := make([]string, len(q.MatchingPairs))
+	for i, pair := range q.MatchingPairs {
+		left[i] = pair.Left
+		right[i] = pair.Right
+	}
+	return left, right
+}
+
+// WithoutAnswerKey returns the question without the fields that tell the right answer. Matching questions
+// keep their items, the right ones sorted when they were not shuffled yet so their order doesn't give the pairs away.
+func (q Question) WithoutAnswerKey() Question {
+	if len(q.MatchingPairs) > 0 && len(q.MatchingRight) == 0 {
+		q.MatchingLeft, q.MatchingRight = q.MatchingItems()
+		slices.Sort(q.MatchingRight)
+	}
+	q.CorrectAnswers = nil
+	q.NumericAnswer = nil
+	q.Tolerance = 0
+	q.MatchingPairs = nil
+	q.AcceptedPatterns = nil
+	q.Rubric = nil
+	return q
 }
 
+const (
+	AssignmentStatusDraft     = "draft"
+	AssignmentStatusPublished = "published"
+)
+
 type Assignment struct {
-	ID              primitive.ObjectID `json:"id" bson:"_id,omitempty"`
-	Title           string             `json:"title" bson:"title"`
-	Description     string             `json:"description" bson:"description"`
-	Instructions    string             `json:"instructions" bson:"instructions"`
-	Type            string             `json:"type" bson:"type"` // exam, homework, quiz
-	CourseID        string             `json:"course_id" bson:"course_id"`
-	DueDate         time.Time          `json:"due_date" bson:"due_date"`
-	GracePeriod     int                `json:"grace_period" bson:"grace_period"` // Minutes of tolerance after due_date
-	Status          string             `json:"status" bson:"status"`             // draft, published
-	Questions       []Question         `json:"questions" bson:"questions"`
-	TotalPoints     float64            `json:"total_points" bson:"total_points"`
-	PassingScore    float64            `json:"passing_score" bson:"passing_score"`       // Minimum score to pass
-	SubmissionRules []string           `json:"submission_rules" bson:"submission_rules"` // Array of rules for submission
-	CreatedAt       time.Time          `json:"created_at" bson:"created_at"`
-	UpdatedAt       time.Time          `json:"updated_at" bson:"updated_at"`
+	ID              primitive.ObjectID   `json:"id" bson:"_id,omitempty"`
+	Title           string               `json:"title" bson:"title"`
+	Description     string               `json:"description" bson:"description"`
+	Instructions    string               `json:"instructions" bson:"instructions"`
+	Type            string               `json:"type" bson:"type"` // exam, homework, quiz
+	CourseID        string               `json:"course_id" bson:"course_id"`
+	DueDate         time.Time            `json:"due_date" bson:"due_date"`
+	GracePeriod     int                  `json:"grace_period" bson:"grace_period"` // Minutes of tolerance after due_date
+	Status          string               `json:"status" bson:"status"`             // draft, published
+	Questions       []Question           `json:"questions" bson:"questions"`
+	TotalPoints     float64              `json:"total_points" bson:"total_points"`
+	PassingScore    float64              `json:"passing_score" bson:"passing_score"`                       // Minimum score to pass
+	SubmissionRules []string             `json:"submission_rules" bson:"submission_rules"`                 // Array of rules for submission
+	MaxAttempts     int                  `json:"max_attempts,omitempty" bson:"max_attempts,omitempty"`     // 0 means a single attempt
+	ScoringPolicy   AttemptScoringPolicy `json:"scoring_policy,omitempty" bson:"scoring_policy,omitempty"` // How attempts are combined, defaults to latest
+	LatePolicy      LatePolicy           `json:"late_policy,omitempty" bson:"late_policy,omitempty"`       // What happens after due_date + grace_period, defaults to accept
+	LatePenalty     float64              `json:"late_penalty,omitempty" bson:"late_penalty,omitempty"`     // Percentage of the score lost per day late
+	// Timed exams: students can only open the exam inside the availability window
+	// and have time_limit_minutes to answer it from the moment they open it
+	AvailableFrom    *time.Time `json:"available_from,omitempty" bson:"available_from,omitempty"`
+	AvailableUntil   *time.Time `json:"available_until,omitempty" bson:"available_until,omitempty"`
+	TimeLimitMinutes int        `json:"time_limit_minutes,omitempty" bson:"time_limit_minutes,omitempty"`
+	// Randomized assignments: each student gets the questions above plus random questions drawn from the course bank
+	QuestionDraws  []QuestionDraw `json:"question_draws,omitempty" bson:"question_draws,omitempty"`
+	ShuffleOptions bool           `json:"shuffle_options,omitempty" bson:"shuffle_options,omitempty"` // Randomize the option order per student
+	Rubric         *Rubric        `json:"rubric,omitempty" bson:"rubric,omitempty"`                   // Grades the whole assignment
+	CreatedAt      time.Time      `json:"created_at" bson:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at" bson:"updated_at"`
+}
+
+// StudentView returns a copy of the assignment as the students see it: the questions without their answer
+// key and without the rubric, which are only for the teachers of the course
+func (a *Assignment) StudentView() *Assignment {
+	view := *a
+	if a.Questions != nil {
+		view.Questions = make([]Question, len(a.Questions))
+		for i, question := range a.Questions {
+			view.Questions[i] = question.WithoutAnswerKey()
+		}
+	}
+	view.Rubric = nil
+	return &view
 }
@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// AuditAction is the kind of change a request made to an entity
+type AuditAction string
+
+const (
+	AuditActionCreate AuditAction = "create"
+	AuditActionUpdate AuditAction = "update"
+	AuditActionDelete AuditAction = "delete"
+)
+
+// AuditEntity is the kind of entity recorded in the audit log
+type AuditEntity string
+
+const (
+	AuditEntityCourse        AuditEntity = "course"
+	AuditEntityModule        AuditEntity = "module"
+	AuditEntityAssignment    AuditEntity = "assignment"
+	AuditEntitySubmission    AuditEntity = "submission"
+	AuditEntityEnrollment    AuditEntity = "enrollment" // Its ID is "<course ID>:<student UUID>"
+	AuditEntityForumQuestion AuditEntity = "forum_question"
+	AuditEntityExtension     AuditEntity = "extension"
+	AuditEntityBankQuestion  AuditEntity = "bank_question"
+)
+
+// AuditActorSystem is the actor and the role of the changes the server makes on its own, as the AI
+// correction or the submission of the expired exams
+const AuditActorSystem = "system"
+
+// AuditChange is a top level field of the entity that changed, the before of a created entity and the
+// after of a deleted one are empty
+type AuditChange struct {
+	Field  string `json:"field" bson:"field"`
+	Before any    `json:"before,omitempty" bson:"before,omitempty"`
+	After  any    `json:"after,omitempty" bson:"after,omitempty"`
+}
+
+// AuditLog is a change made to an entity by a request, with the user that made it
+type AuditLog struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	RequestID  string             `json:"request_id" bson:"request_id"`
+	ActorUUID  string             `json:"actor_uuid" bson:"actor_uuid"`
+	ActorRoles []string           `json:"actor_roles" bson:"actor_roles"` // The roles in the course, or of the token outside of a course
+	Action     AuditAction        `json:"action" bson:"action"`
+	Entity     AuditEntity        `json:"entity" bson:"entity"`
+	EntityID   string             `json:"entity_id" bson:"entity_id"`
+	CourseID   string             `json:"course_id,omitempty" bson:"course_id,omitempty"`
+	Method     string             `json:"method" bson:"method"`
+	Path       string             `json:"path" bson:"path"`
+	Changes    []AuditChange      `json:"changes" bson:"changes"`
+	Timestamp  time.Time          `json:"timestamp" bson:"timestamp"`
+}
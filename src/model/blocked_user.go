@@ -0,0 +1,11 @@
+package model
+
+import "time"
+
+// BlockedUser is a user blocked by the admins, the requests of the user are rejected until an admin unblocks them
+type BlockedUser struct {
+	UserUUID  string    `json:"user_uuid" bson:"_id"`
+	Reason    string    `json:"reason" bson:"reason"`
+	BlockedBy string    `json:"blocked_by" bson:"blocked_by"`
+	BlockedAt time.Time `json:"blocked_at" bson:"blocked_at"`
+}
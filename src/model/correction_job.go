@@ -0,0 +1,34 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CorrectionJobStatus string
+
+const (
+	CorrectionJobPending CorrectionJobStatus = "pending" // Waiting for a worker, also between retries
+	CorrectionJobRunning CorrectionJobStatus = "running"
+	CorrectionJobDone    CorrectionJobStatus = "done"
+	CorrectionJobFailed  CorrectionJobStatus = "failed" // Every attempt failed, the submission needs manual review
+)
+
+// CorrectionJob is the automatic correction of a submitted work, processed in the background
+type CorrectionJob struct {
+	ID           primitive.ObjectID  `json:"id" bson:"_id,omitempty"`
+	SubmissionID string              `json:"submission_id" bson:"submission_id"`
+	AssignmentID string              `json:"assignment_id" bson:"assignment_id"`
+	StudentUUID  string              `json:"student_uuid" bson:"student_uuid"`
+	Status       CorrectionJobStatus `json:"status" bson:"status"`
+	Attempts     int                 `json:"attempts" bson:"attempts"`
+	MaxAttempts  int                 `json:"max_attempts" bson:"max_attempts"`
+	LastError    string              `json:"last_error,omitempty" bson:"last_error,omitempty"`
+	NextRunAt    time.Time           `json:"next_run_at" bson:"next_run_at"`                       // A pending job is not run before this time
+	LockedUntil  *time.Time          `json:"-" bson:"locked_until,omitempty"`                      // Running jobs whose lock expired are retried
+	ClaimToken   string              `json:"-" bson:"claim_token,omitempty"`                       // New on every claim, only its holder updates the job
+	CompletedAt  *time.Time          `json:"completed_at,omitempty" bson:"completed_at,omitempty"` // When the job was done or failed
+	CreatedAt    time.Time           `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time           `json:"updated_at" bson:"updated_at"`
+}
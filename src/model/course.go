@@ -1,17 +1,57 @@
 package model
 
 import (
+	"slices"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Languages a course can be given in, the AI prompts of the course are written in its language
+const (
+	CourseLanguageSpanish    = "es"
+	CourseLanguageEnglish    = "en"
+	CourseLanguagePortuguese = "pt"
+
+	DefaultCourseLanguage = CourseLanguageSpanish
+)
+
+// AuxTeacherPermission is an action on the course the titular teacher can allow to each aux teacher
+type AuxTeacherPermission string
+
+const (
+	PermissionGradeSubmissions  AuxTeacherPermission = "grade_submissions"
+	PermissionEditModules       AuxTeacherPermission = "edit_modules"
+	PermissionCreateAssignments AuxTeacherPermission = "create_assignments"
+	PermissionModerateForum     AuxTeacherPermission = "moderate_forum"
+	PermissionApproveStudents   AuxTeacherPermission = "approve_students"
+)
+
+// AllAuxTeacherPermissions are the permissions an aux teacher gets when added without a permission set
+var AllAuxTeacherPermissions = []AuxTeacherPermission{
+	PermissionGradeSubmissions,
+	PermissionEditModules,
+	PermissionCreateAssignments,
+	PermissionModerateForum,
+	PermissionApproveStudents,
+}
+
+// CourseStatus is the moderation status of a course, only the admins change it from the backoffice
+type CourseStatus string
+
+const (
+	CourseStatusActive    CourseStatus = "active"
+	CourseStatusSuspended CourseStatus = "suspended" // Only the admins can access the course
+	CourseStatusArchived  CourseStatus = "archived"  // The course can be read but not changed
+)
+
 type Course struct {
 	ID             primitive.ObjectID `json:"id" bson:"_id,omitempty"`
 	Title          string             `json:"title" bson:"title"`
 	Description    string             `json:"description" bson:"description"`
 	TeacherUUID    string             `json:"teacher_uuid" bson:"teacher_uuid"`
 	TeacherName    string             `json:"teacher_name" bson:"teacher_name"`
+	Language       string             `json:"language" bson:"language"`
 	Capacity       int                `json:"capacity" bson:"capacity"`
 	StudentsAmount int                `json:"students_amount" bson:"students_amount"`
 	Modules        []Module           `json:"modules" bson:"modules"`
@@ -21,4 +61,51 @@ type Course struct {
 	Feedback       []CourseFeedback   `json:"feedback" bson:"feedback"`
 	CreatedAt      time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt      time.Time          `json:"updated_at" bson:"updated_at"`
+
+	// Opt-in, the teachers can ask the AI to draft answers to the forum questions.
+	// A pointer so the update can turn it off, the json tag is the key set by the update.
+	ForumAiSuggestions *bool `json:"forum_ai_suggestions" bson:"forum_ai_suggestions,omitempty"`
+
+	// Permissions of each aux teacher by their UUID. The aux teachers added before the
+	// permission sets existed have no entry and keep all the permissions.
+	AuxTeacherPermissions map[string][]AuxTeacherPermission `json:"aux_teacher_permissions" bson:"aux_teacher_permissions,omitempty"`
+
+	// The courses created before the moderation existed have no status and are active
+	Status CourseStatus `json:"status" bson:"status,omitempty"`
+}
+
+// CurrentStatus returns the moderation status of the course, active when it was never moderated
+func (c *Course) CurrentStatus() CourseStatus {
+	if c.Status == "" {
+		return CourseStatusActive
+	}
+	return c.Status
+}
+
+// ForumAiSuggestionsEnabled tells if the teachers of the course opted in to the AI drafted forum answers
+func (c *Course) ForumAiSuggestionsEnabled() bool {
+	return c.ForumAiSuggestions != nil && *c.ForumAiSuggestions
+}
+
+// IsTeacher tells if the user is the titular or an aux teacher of the course
+func (c *Course) IsTeacher(userUUID string) bool {
+	return c.TeacherUUID == userUUID || slices.Contains(c.AuxTeachers, userUUID)
+}
+
+// PermissionsOf returns the permissions of an aux teacher of the course, nil if the user is not one
+func (c *Course) PermissionsOf(auxTeacherUUID string) []AuxTeacherPermission {
+	if !slices.Contains(c.AuxTeachers, auxTeacherUUID) {
+		return nil
+	}
+	permissions, ok := c.AuxTeacherPermissions[auxTeacherUUID]
+	if !ok {
+		return AllAuxTeacherPermissions
+	}
+	return permissions
+}
+
+// TeacherCan tells if the user is a teacher of the course allowed to do the action.
+// The titular teacher can do everything, the aux teachers only what their permissions allow.
+func (c *Course) TeacherCan(userUUID string, permission AuxTeacherPermission) bool {
+	return c.TeacherUUID == userUUID || slices.Contains(c.PermissionsOf(userUUID), permission)
 }
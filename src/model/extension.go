@@ -0,0 +1,21 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// DeadlineExtension overrides the due date of an assignment for a student or a group of students
+type DeadlineExtension struct {
+	ID           primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	AssignmentID string             `json:"assignment_id" bson:"assignment_id"`
+	CourseID     string             `json:"course_id" bson:"course_id"`
+	StudentUUIDs []string           `json:"student_uuids" bson:"student_uuids"`
+	GroupName    string             `json:"group_name,omitempty" bson:"group_name,omitempty"` // Optional label for group extensions
+	DueDate      time.Time          `json:"due_date" bson:"due_date"`                         // Replaces the assignment due date, grace period still applies
+	Reason       string             `json:"reason,omitempty" bson:"reason,omitempty"`
+	GrantedBy    string             `json:"granted_by" bson:"granted_by"`
+	CreatedAt    time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt    time.Time          `json:"updated_at" bson:"updated_at"`
+}
@@ -0,0 +1,67 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FeedbackSentiment string
+
+const (
+	FeedbackSentimentPositive FeedbackSentiment = "positive"
+	FeedbackSentimentNeutral  FeedbackSentiment = "neutral"
+	FeedbackSentimentNegative FeedbackSentiment = "negative"
+)
+
+var FeedbackSentiments = []FeedbackSentiment{FeedbackSentimentPositive, FeedbackSentimentNeutral, FeedbackSentimentNegative}
+
+// FeedbackTopic is one of the fixed topics the course feedback is classified in, the same for
+// the AI and the keyword analysis so their results can be aggregated together
+type FeedbackTopic string
+
+const (
+	FeedbackTopicContent      FeedbackTopic = "content"      // Contents and topics of the course
+	FeedbackTopicTeaching     FeedbackTopic = "teaching"     // Explanations and attention of the teachers
+	FeedbackTopicMaterials    FeedbackTopic = "materials"    // Slides, notes, videos and other resources
+	FeedbackTopicAssignments  FeedbackTopic = "assignments"  // Homeworks and practical work
+	FeedbackTopicExams        FeedbackTopic = "exams"        // Exams and grading
+	FeedbackTopicDifficulty   FeedbackTopic = "difficulty"   // How hard the course is
+	FeedbackTopicWorkload     FeedbackTopic = "workload"     // Amount of work and pace
+	FeedbackTopicOrganization FeedbackTopic = "organization" // Schedule, deadlines and communication
+	FeedbackTopicPlatform     FeedbackTopic = "platform"     // The platform and its tools
+	FeedbackTopicForum        FeedbackTopic = "forum"        // Forum and questions
+)
+
+var FeedbackTopics = []FeedbackTopic{
+	FeedbackTopicContent,
+	FeedbackTopicTeaching,
+	FeedbackTopicMaterials,
+	FeedbackTopicAssignments,
+	FeedbackTopicExams,
+	FeedbackTopicDifficulty,
+	FeedbackTopicWorkload,
+	FeedbackTopicOrganization,
+	FeedbackTopicPlatform,
+	FeedbackTopicForum,
+}
+
+// How a feedback was analyzed
+const (
+	FeedbackAnalysisMethodAI       = "ai"
+	FeedbackAnalysisMethodKeywords = "keywords"
+)
+
+// FeedbackAnalysis holds the sentiment and topics extracted from a course feedback
+type FeedbackAnalysis struct {
+	ID                primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	FeedbackID        string             `json:"feedback_id" bson:"feedback_id"`
+	CourseID          string             `json:"course_id" bson:"course_id"`
+	Sentiment         FeedbackSentiment  `json:"sentiment" bson:"sentiment"`
+	SentimentScore    float64            `json:"sentiment_score" bson:"sentiment_score"` // From -1, very negative, to 1, very positive
+	Topics            []FeedbackTopic    `json:"topics" bson:"topics"`
+	Method            string             `json:"method" bson:"method"`
+	PromptVersion     string             `json:"prompt_version,omitempty" bson:"prompt_version,omitempty"`
+	FeedbackCreatedAt time.Time          `json:"feedback_created_at" bson:"feedback_created_at"`
+	AnalyzedAt        time.Time          `json:"analyzed_at" bson:"analyzed_at"`
+}
@@ -0,0 +1,33 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FilePurpose string
+
+const (
+	FilePurposeSubmission     FilePurpose = "submission"      // Answer to a file question, uploaded by a student
+	FilePurposeModuleResource FilePurpose = "module_resource" // Resource of a course module, uploaded by a teacher
+)
+
+var FilePurposeValues = []FilePurpose{
+	FilePurposeSubmission,
+	FilePurposeModuleResource,
+}
+
+// StoredFile is the metadata of an uploaded file, the content is kept in the file storage
+type StoredFile struct {
+	ID          primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	Name        string             `json:"name" bson:"name"` // Original file name
+	ContentType string             `json:"content_type" bson:"content_type"`
+	Size        int64              `json:"size" bson:"size"`         // Bytes
+	Checksum    string             `json:"checksum" bson:"checksum"` // Hex encoded SHA-256 of the content
+	StorageKey  string             `json:"-" bson:"storage_key"`
+	Purpose     FilePurpose        `json:"purpose" bson:"purpose"`
+	CourseID    string             `json:"course_id" bson:"course_id"`
+	OwnerUUID   string             `json:"owner_uuid" bson:"owner_uuid"`
+	CreatedAt   time.Time          `json:"created_at" bson:"created_at"`
+}
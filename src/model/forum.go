@@ -42,6 +42,21 @@ var QuestionStatusValues = []QuestionStatus{
 	QuestionStatusClosed,
 }
 
+// AnswerType tells who wrote an answer, the AI suggestions stay hidden from students until a teacher approves them
+type AnswerType string
+
+const (
+	AnswerTypeUser         AnswerType = "user"
+	AnswerTypeAiSuggestion AnswerType = "ai_suggestion"
+)
+
+type SuggestionStatus string
+
+const (
+	SuggestionStatusPending  SuggestionStatus = "pending_review"
+	SuggestionStatusApproved SuggestionStatus = "approved"
+)
+
 const (
 	VoteTypeUp   = 1
 	VoteTypeDown = -1
@@ -65,13 +80,28 @@ type ForumQuestion struct {
 
 // ForumAnswer represents a forum answer to a question
 type ForumAnswer struct {
-	ID         string    `json:"id" bson:"id"`
-	AuthorID   string    `json:"author_id" bson:"author_id"`
-	Content    string    `json:"content" bson:"content"`
-	Votes      []Vote    `json:"votes" bson:"votes"`
-	IsAccepted bool      `json:"is_accepted" bson:"is_accepted"`
-	CreatedAt  time.Time `json:"created_at" bson:"created_at"`
-	UpdatedAt  time.Time `json:"updated_at" bson:"updated_at"`
+	ID         string            `json:"id" bson:"id"`
+	AuthorID   string            `json:"author_id" bson:"author_id"`
+	Content    string            `json:"content" bson:"content"`
+	Type       AnswerType        `json:"type,omitempty" bson:"type,omitempty"` // Empty in the answers written before the AI suggestions
+	Suggestion *AnswerSuggestion `json:"suggestion,omitempty" bson:"suggestion,omitempty"`
+	Votes      []Vote            `json:"votes" bson:"votes"`
+	IsAccepted bool              `json:"is_accepted" bson:"is_accepted"`
+	CreatedAt  time.Time         `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at" bson:"updated_at"`
+}
+
+// AnswerSuggestion holds the review of an answer drafted by the AI
+type AnswerSuggestion struct {
+	Status        SuggestionStatus `json:"status" bson:"status"`
+	PromptVersion string           `json:"prompt_version" bson:"prompt_version"`
+	ReviewedBy    string           `json:"reviewed_by,omitempty" bson:"reviewed_by,omitempty"`
+	ReviewedAt    *time.Time       `json:"reviewed_at,omitempty" bson:"reviewed_at,omitempty"`
+}
+
+// IsPendingSuggestion tells if the answer is an AI suggestion that no teacher approved yet
+func (a *ForumAnswer) IsPendingSuggestion() bool {
+	return a.Type == AnswerTypeAiSuggestion && (a.Suggestion == nil || a.Suggestion.Status != SuggestionStatusApproved)
 }
 
 // Vote represents a vote on a question or answer
@@ -18,7 +18,8 @@ type Module struct {
 }
 
 type ModuleResource struct {
-	Id   uint64 `json:"id" bson:"id"` // this comes from the frontend so it's a number
-	Name string `json:"name" bson:"name"`
-	Url  string `json:"url" bson:"url"`
+	Id     uint64 `json:"id" bson:"id"` // this comes from the frontend so it's a number
+	Name   string `json:"name" bson:"name"`
+	Url    string `json:"url" bson:"url"`                             // External links only, uploaded files use file_id
+	FileID string `json:"file_id,omitempty" bson:"file_id,omitempty"` // Stored file uploaded through /files
 }
@@ -0,0 +1,40 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type QuestionDifficulty string
+
+const (
+	QuestionDifficultyEasy   QuestionDifficulty = "easy"
+	QuestionDifficultyMedium QuestionDifficulty = "medium"
+	QuestionDifficultyHard   QuestionDifficulty = "hard"
+)
+
+var QuestionDifficultyValues = []QuestionDifficulty{
+	QuestionDifficultyEasy,
+	QuestionDifficultyMedium,
+	QuestionDifficultyHard,
+}
+
+// BankQuestion is a reusable question of a course question bank
+type BankQuestion struct {
+	ID         primitive.ObjectID `json:"id" bson:"_id,omitempty"`
+	CourseID   string             `json:"course_id" bson:"course_id"`
+	Question   Question           `json:"question" bson:"question"`
+	Tags       []string           `json:"tags" bson:"tags"`
+	Difficulty QuestionDifficulty `json:"difficulty,omitempty" bson:"difficulty,omitempty"`
+	CreatedBy  string             `json:"created_by" bson:"created_by"`
+	CreatedAt  time.Time          `json:"created_at" bson:"created_at"`
+	UpdatedAt  time.Time          `json:"updated_at" bson:"updated_at"`
+}
+
+// QuestionDraw picks random questions of a tag from the course question bank for each student
+type QuestionDraw struct {
+	Tag        string             `json:"tag" bson:"tag"`
+	Count      int                `json:"count" bson:"count"`
+	Difficulty QuestionDifficulty `json:"difficulty,omitempty" bson:"difficulty,omitempty"` // Any difficulty when empty
+}
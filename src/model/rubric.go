@@ -0,0 +1,27 @@
+package model
+
+// Rubric grades an answer or a whole assignment by choosing one level for each criterion.
+// The points of the chosen levels are scaled to the points of the question or assignment.
+type Rubric struct {
+	Criteria []RubricCriterion `json:"criteria" bson:"criteria"`
+}
+
+type RubricCriterion struct {
+	ID          string        `json:"id" bson:"id"`
+	Title       string        `json:"title" bson:"title"`
+	Description string        `json:"description,omitempty" bson:"description,omitempty"`
+	Levels      []RubricLevel `json:"levels" bson:"levels"`
+}
+
+type RubricLevel struct {
+	ID          string  `json:"id" bson:"id"`
+	Title       string  `json:"title" bson:"title"` // e.g. excellent, satisfactory, insufficient
+	Description string  `json:"description,omitempty" bson:"description,omitempty"`
+	Points      float64 `json:"points" bson:"points"`
+}
+
+// RubricSelection is the level chosen by the grader for a criterion of a rubric
+type RubricSelection struct {
+	CriterionID string `json:"criterion_id" bson:"criterion_id"`
+	LevelID     string `json:"level_id" bson:"level_id"`
+}
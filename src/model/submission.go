@@ -16,8 +16,24 @@ const (
 
 type Answer struct {
 	QuestionID string      `json:"question_id" bson:"question_id"`
-	Content    interface{} `json:"content" bson:"content"` // Can be string, []string for multiple choice, or file URL
-	Type       string      `json:"type" bson:"type"`       // text, multiple_choice, file
+	Content    interface{} `json:"content" bson:"content"` // string, []string for multiple choice and ordering, number, bool or a left to right object for matching
+	Type       string      `json:"type" bson:"type"`       // Type of the question, set when the answer is saved
+}
+
+const (
+	GraderAI        = "ai"   // Graded by the AI correction
+	GraderAutomatic = "auto" // Graded deterministically, e.g. multiple choice questions
+)
+
+// AnswerGrade holds the grading of a single answer. GradedBy is GraderAI, GraderAutomatic or the teacher UUID
+type AnswerGrade struct {
+	QuestionID       string            `json:"question_id" bson:"question_id"`
+	PointsAwarded    float64           `json:"points_awarded" bson:"points_awarded"`
+	MaxPoints        float64           `json:"max_points" bson:"max_points"`
+	Comment          string            `json:"comment,omitempty" bson:"comment,omitempty"`
+	GradedBy         string            `json:"graded_by" bson:"graded_by"`
+	GradedAt         time.Time         `json:"graded_at" bson:"graded_at"`
+	RubricSelections []RubricSelection `json:"rubric_selections,omitempty" bson:"rubric_selections,omitempty"` // Levels chosen when the question has a rubric
 }
 
 type Submission struct {
@@ -25,14 +41,36 @@ type Submission struct {
 	AssignmentID      string             `json:"assignment_id" bson:"assignment_id"`
 	StudentUUID       string             `json:"student_uuid" bson:"student_uuid"`
 	StudentName       string             `json:"student_name" bson:"student_name"`
+	Attempt           int                `json:"attempt" bson:"attempt"` // Starts at 1, each attempt is a separate submission
 	Status            SubmissionStatus   `json:"status" bson:"status"`
+	Questions         []Question         `json:"questions,omitempty" bson:"questions,omitempty"` // Questions the student received when the assignment is randomized
 	Answers           []Answer           `json:"answers" bson:"answers"`
+	AnswerGrades      []AnswerGrade      `json:"answer_grades,omitempty" bson:"answer_grades,omitempty"`
 	Score             *float64           `json:"score,omitempty" bson:"score,omitempty"`
 	Feedback          string             `json:"feedback,omitempty" bson:"feedback,omitempty"`
+	RubricSelections  []RubricSelection  `json:"rubric_selections,omitempty" bson:"rubric_selections,omitempty"` // Levels chosen for the assignment rubric
 	AIScore           *float64           `json:"ai_score,omitempty" bson:"ai_score,omitempty"`
 	AIFeedback        string             `json:"ai_feedback,omitempty" bson:"ai_feedback,omitempty"`
+	AIPromptVersion   string             `json:"ai_prompt_version,omitempty" bson:"ai_prompt_version,omitempty"` // Prompt template the AI feedback was generated with
 	NeedsManualReview *bool              `json:"needs_manual_review,omitempty" bson:"needs_manual_review,omitempty"`
 	SubmittedAt       *time.Time         `json:"submitted_at,omitempty" bson:"submitted_at,omitempty"`
+	LatePenalty       float64            `json:"late_penalty,omitempty" bson:"late_penalty,omitempty"`     // Percentage deducted from computed scores
+	StartedAt         *time.Time         `json:"started_at,omitempty" bson:"started_at,omitempty"`         // When the student opened a timed exam
+	ExpiresAt         *time.Time         `json:"expires_at,omitempty" bson:"expires_at,omitempty"`         // Deadline of the student for a timed exam
+	AutoSubmitted     bool               `json:"auto_submitted,omitempty" bson:"auto_submitted,omitempty"` // Submitted by the server when the time ran out
 	CreatedAt         time.Time          `json:"created_at" bson:"created_at"`
 	UpdatedAt         time.Time          `json:"updated_at" bson:"updated_at"`
 }
+
+// StudentView returns a copy of the submission as its student sees it: the drawn questions without the
+// correct answers, the expected values, the matching pairs and the rubrics, which are kept for grading
+func (s *Submission) StudentView() *Submission {
+	view := *s
+	if s.Questions != nil {
+		view.Questions = make([]Question, len(s.Questions))
+		for i, question := range s.Questions {
+			view.Questions[i] = question.WithoutAnswerKey()
+		}
+	}
+	return &view
+}
@@ -0,0 +1,102 @@
+package queues
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	amqp "github.com/rabbitmq/amqp091-go"
+)
+
+const defaultCorrectionsQueueName = "corrections"
+
+// CorrectionsQueue announces the AI correction jobs through RabbitMQ. The jobs themselves are stored in Mongo,
+// the messages only wake the consumers up so they don't have to wait for the next poll.
+type CorrectionsQueue struct {
+	channel   *amqp.Channel
+	queueName string
+}
+
+type correctionJobMessage struct {
+	JobID string `json:"job_id"`
+}
+
+// NewCorrectionsQueue opens a channel on an existing connection and declares the corrections queue.
+// prefetch limits the unacknowledged messages delivered to this consumer.
+func NewCorrectionsQueue(conn *amqp.Connection, queueName string, prefetch int) (*CorrectionsQueue, error) {
+	if queueName == "" {
+		queueName = defaultCorrectionsQueueName
+	}
+
+	ch, err := conn.Channel()
+	if err != nil {
+		return nil, fmt.Errorf("failed to open a channel: %w", err)
+	}
+
+	_, err = ch.QueueDeclare(
+		queueName,
+		true, // Jobs must survive a broker restart
+		false,
+		false,
+		false,
+		nil,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to declare queue: %w", err)
+	}
+
+	if err := ch.Qos(prefetch, 0, false); err != nil {
+		return nil, fmt.Errorf("failed to set prefetch: %w", err)
+	}
+
+	return &CorrectionsQueue{
+		channel:   ch,
+		queueName: queueName,
+	}, nil
+}
+
+func (q *CorrectionsQueue) NotifyCorrectionJob(jobID string) error {
+	body, err := json.Marshal(correctionJobMessage{JobID: jobID})
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+
+	return q.channel.Publish(
+		"",
+		q.queueName,
+		false,
+		false,
+		amqp.Publishing{
+			ContentType:  "application/json",
+			DeliveryMode: amqp.Persistent,
+			Body:         body,
+		},
+	)
+}
+
+// Consume hands every announced job to the handler until the context is done. Messages are acknowledged
+// once handled, a job that fails is retried from Mongo with its backoff.
+func (q *CorrectionsQueue) Consume(ctx context.Context, handler func(ctx context.Context, jobID string) error) error {
+	deliveries, err := q.channel.ConsumeWithContext(ctx, q.queueName, "", false, false, false, false, nil)
+	if err != nil {
+		return fmt.Errorf("failed to consume queue: %w", err)
+	}
+
+	for delivery := range deliveries {
+		go func(delivery amqp.Delivery) {
+			var message correctionJobMessage
+			if err := json.Unmarshal(delivery.Body, &message); err != nil {
+				log.Printf("invalid correction message: %v", err)
+				_ = delivery.Nack(false, false)
+				return
+			}
+
+			if err := handler(ctx, message.JobID); err != nil {
+				log.Printf("error processing correction job %s: %v", message.JobID, err)
+			}
+			_ = delivery.Ack(false)
+		}(delivery)
+	}
+	return nil
+}
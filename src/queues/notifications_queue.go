@@ -14,6 +14,7 @@ type NotificationsQueueInterface interface {
 }
 
 type NotificationsQueue struct {
+	conn      *amqp.Connection
 	channel   *amqp.Channel
 	queueName string
 }
@@ -46,11 +47,17 @@ func NewNotificationsQueue(config *config.Config) (*NotificationsQueue, error) {
 	}
 
 	return &NotificationsQueue{
+		conn:      conn,
 		channel:   ch,
 		queueName: queueName,
 	}, nil
 }
 
+// Connection returns the RabbitMQ connection so other queues can open their channels on it
+func (q *NotificationsQueue) Connection() *amqp.Connection {
+	return q.conn
+}
+
 func (q *NotificationsQueue) Publish(message QueueMessage) error {
 	if q.channel == nil {
 		return nil // testing purposes
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminActionLogRepository struct {
+	logCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ AdminActionLogRepositoryInterface = (*AdminActionLogRepository)(nil)
+
+func NewAdminActionLogRepository(client *mongo.Client, dbName string) *AdminActionLogRepository {
+	return &AdminActionLogRepository{
+		logCollection: client.Database(dbName).Collection("admin_action_logs"),
+	}
+}
+
+func (r *AdminActionLogRepository) Create(ctx context.Context, log *model.AdminActionLog) error {
+	result, err := r.logCollection.InsertOne(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to create admin action log: %v", err)
+	}
+	log.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetLogs returns the logs that match the filter, the latest first
+func (r *AdminActionLogRepository) GetLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error) {
+	query := bson.M{}
+	if filter.AdminUUID != "" {
+		query["admin_uuid"] = filter.AdminUUID
+	}
+	if filter.CourseID != "" {
+		query["course_id"] = filter.CourseID
+	}
+	if filter.TargetID != "" {
+		query["target_id"] = filter.TargetID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+
+	cursor, err := r.logCollection.Find(ctx, query, options.Find().SetSort(bson.D{{Key: "timestamp", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get admin action logs: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]model.AdminActionLog, 0)
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, fmt.Errorf("failed to decode admin action logs: %v", err)
+	}
+	return logs, nil
+}
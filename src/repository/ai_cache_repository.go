@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AiCacheRepository struct {
+	cacheCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ AiCacheRepositoryInterface = (*AiCacheRepository)(nil)
+
+func NewAiCacheRepository(client *mongo.Client, dbName string) *AiCacheRepository {
+	return &AiCacheRepository{
+		cacheCollection: client.Database(dbName).Collection("ai_cache"),
+	}
+}
+
+// Get returns the cached answer with the given key, nil if there is none
+func (r *AiCacheRepository) Get(ctx context.Context, key string) (*model.AiCacheEntry, error) {
+	var entry model.AiCacheEntry
+	err := r.cacheCollection.FindOne(ctx, bson.M{"_id": key}).Decode(&entry)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get AI cache entry: %v", err)
+	}
+	return &entry, nil
+}
+
+func (r *AiCacheRepository) Set(ctx context.Context, entry *model.AiCacheEntry) error {
+	opts := options.Replace().SetUpsert(true)
+	_, err := r.cacheCollection.ReplaceOne(ctx, bson.M{"_id": entry.Key}, entry, opts)
+	if err != nil {
+		return fmt.Errorf("failed to set AI cache entry: %v", err)
+	}
+	return nil
+}
+
+func (r *AiCacheRepository) DeleteByCourse(ctx context.Context, courseID string) error {
+	_, err := r.cacheCollection.DeleteMany(ctx, bson.M{"course_id": courseID})
+	if err != nil {
+		return fmt.Errorf("failed to delete AI cache entries: %v", err)
+	}
+	return nil
+}
+
+func (r *AiCacheRepository) DeleteByStudent(ctx context.Context, studentUUID string) error {
+	_, err := r.cacheCollection.DeleteMany(ctx, bson.M{"student_uuid": studentUUID})
+	if err != nil {
+		return fmt.Errorf("failed to delete AI cache entries: %v", err)
+	}
+	return nil
+}
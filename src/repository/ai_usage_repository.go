@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type AiUsageRepository struct {
+	usageCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ AiUsageRepositoryInterface = (*AiUsageRepository)(nil)
+
+func NewAiUsageRepository(client *mongo.Client, dbName string) *AiUsageRepository {
+	return &AiUsageRepository{
+		usageCollection: client.Database(dbName).Collection("ai_usage"),
+	}
+}
+
+func (r *AiUsageRepository) Create(ctx context.Context, usage *model.AiUsage) error {
+	result, err := r.usageCollection.InsertOne(ctx, usage)
+	if err != nil {
+		return fmt.Errorf("failed to create AI usage: %v", err)
+	}
+	usage.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetUsageByCourse adds up the usage of every course in the period, the bounds are optional
+func (r *AiUsageRepository) GetUsageByCourse(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error) {
+	return r.getUsage(ctx, "course_id", from, to)
+}
+
+// GetUsageByTeacher adds up the usage of every teacher in the period, the bounds are optional
+func (r *AiUsageRepository) GetUsageByTeacher(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error) {
+	return r.getUsage(ctx, "teacher_uuid", from, to)
+}
+
+func (r *AiUsageRepository) getUsage(ctx context.Context, field string, from, to *time.Time) ([]schemas.AiUsageSummary, error) {
+	createdAt := bson.M{}
+	if from != nil {
+		createdAt["$gte"] = *from
+	}
+	if to != nil {
+		createdAt["$lt"] = *to
+	}
+	match := bson.M{}
+	if len(createdAt) > 0 {
+		match["created_at"] = createdAt
+	}
+
+	pipeline := []bson.M{
+		{"$match": match},
+		{"$group": bson.M{
+			"_id":               "$" + field,
+			"requests":          bson.M{"$sum": 1},
+			"cached_requests":   bson.M{"$sum": bson.M{"$cond": bson.A{"$cached", 1, 0}}},
+			"failed_requests":   bson.M{"$sum": bson.M{"$cond": bson.A{"$success", 0, 1}}},
+			"prompt_tokens":     bson.M{"$sum": "$prompt_tokens"},
+			"completion_tokens": bson.M{"$sum": "$completion_tokens"},
+			"avg_latency_ms":    bson.M{"$avg": bson.M{"$cond": bson.A{"$cached", nil, "$latency_ms"}}},
+			"max_latency_ms":    bson.M{"$max": "$latency_ms"},
+		}},
+		{"$project": bson.M{
+			"_id":               0,
+			field:               "$_id",
+			"requests":          1,
+			"cached_requests":   1,
+			"failed_requests":   1,
+			"prompt_tokens":     1,
+			"completion_tokens": 1,
+			"total_tokens":      bson.M{"$add": bson.A{"$prompt_tokens", "$completion_tokens"}},
+			"avg_latency_ms":    bson.M{"$ifNull": bson.A{"$avg_latency_ms", 0}},
+			"max_latency_ms":    1,
+		}},
+		{"$sort": bson.M{"total_tokens": -1, field: 1}},
+	}
+
+	cursor, err := r.usageCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get AI usage: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	usage := []schemas.AiUsageSummary{}
+	if err = cursor.All(ctx, &usage); err != nil {
+		return nil, fmt.Errorf("failed to decode AI usage: %v", err)
+	}
+	return usage, nil
+}
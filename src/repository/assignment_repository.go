@@ -128,6 +128,36 @@ func filterEmptyAssignmentFields(assignment model.Assignment) bson.M {
 	if assignment.PassingScore > 0 {
 		update["passing_score"] = assignment.PassingScore
 	}
+	if assignment.MaxAttempts > 0 {
+		update["max_attempts"] = assignment.MaxAttempts
+	}
+	if assignment.ScoringPolicy != "" {
+		update["scoring_policy"] = assignment.ScoringPolicy
+	}
+	if assignment.LatePolicy != "" {
+		update["late_policy"] = assignment.LatePolicy
+	}
+	if assignment.LatePenalty > 0 {
+		update["late_penalty"] = assignment.LatePenalty
+	}
+	if assignment.AvailableFrom != nil {
+		update["available_from"] = assignment.AvailableFrom
+	}
+	if assignment.AvailableUntil != nil {
+		update["available_until"] = assignment.AvailableUntil
+	}
+	if assignment.TimeLimitMinutes > 0 {
+		update["time_limit_minutes"] = assignment.TimeLimitMinutes
+	}
+	if len(assignment.QuestionDraws) > 0 {
+		update["question_draws"] = assignment.QuestionDraws
+	}
+	if assignment.ShuffleOptions {
+		update["shuffle_options"] = assignment.ShuffleOptions
+	}
+	if assignment.Rubric != nil {
+		update["rubric"] = assignment.Rubric
+	}
 	update["updated_at"] = primitive.NewDateTimeFromTime(time.Now())
 
 	return update
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditLogRepository struct {
+	logCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ AuditLogRepositoryInterface = (*AuditLogRepository)(nil)
+
+func NewAuditLogRepository(client *mongo.Client, dbName string) *AuditLogRepository {
+	return &AuditLogRepository{
+		logCollection: client.Database(dbName).Collection("audit_logs"),
+	}
+}
+
+func (r *AuditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	result, err := r.logCollection.InsertOne(ctx, log)
+	if err != nil {
+		return fmt.Errorf("failed to create audit log: %v", err)
+	}
+	log.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetLogs returns the page of the logs that match the filter, the latest first, and how many match.
+// The page and its size are expected to be already validated.
+func (r *AuditLogRepository) GetLogs(ctx context.Context, filter schemas.AuditLogFilter) ([]model.AuditLog, int64, error) {
+	query := bson.M{}
+	if filter.ActorUUID != "" {
+		query["actor_uuid"] = filter.ActorUUID
+	}
+	if filter.Role != "" {
+		query["actor_roles"] = filter.Role
+	}
+	if filter.Entity != "" {
+		query["entity"] = filter.Entity
+	}
+	if filter.EntityID != "" {
+		query["entity_id"] = filter.EntityID
+	}
+	if filter.CourseID != "" {
+		query["course_id"] = filter.CourseID
+	}
+	if filter.Action != "" {
+		query["action"] = filter.Action
+	}
+	if filter.RequestID != "" {
+		query["request_id"] = filter.RequestID
+	}
+	timestamp := bson.M{}
+	if filter.From != nil {
+		timestamp["$gte"] = *filter.From
+	}
+	if filter.To != nil {
+		timestamp["$lt"] = *filter.To
+	}
+	if len(timestamp) > 0 {
+		query["timestamp"] = timestamp
+	}
+
+	total, err := r.logCollection.CountDocuments(ctx, query)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count audit logs: %v", err)
+	}
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "timestamp", Value: -1}, {Key: "_id", Value: -1}}).
+		SetSkip(int64((filter.Page - 1) * filter.PageSize)).
+		SetLimit(int64(filter.PageSize))
+	cursor, err := r.logCollection.Find(ctx, query, opts)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit logs: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	logs := make([]model.AuditLog, 0)
+	if err := cursor.All(ctx, &logs); err != nil {
+		return nil, 0, fmt.Errorf("failed to decode audit logs: %v", err)
+	}
+	return logs, total, nil
+}
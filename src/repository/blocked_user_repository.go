@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type BlockedUserRepository struct {
+	blockedCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ BlockedUserRepositoryInterface = (*BlockedUserRepository)(nil)
+
+func NewBlockedUserRepository(client *mongo.Client, dbName string) *BlockedUserRepository {
+	return &BlockedUserRepository{
+		blockedCollection: client.Database(dbName).Collection("blocked_users"),
+	}
+}
+
+// Block stores the block of the user, replacing the previous one if the user was already blocked
+func (r *BlockedUserRepository) Block(ctx context.Context, user *model.BlockedUser) error {
+	_, err := r.blockedCollection.ReplaceOne(ctx, bson.M{"_id": user.UserUUID}, user, options.Replace().SetUpsert(true))
+	if err != nil {
+		return fmt.Errorf("failed to block user: %v", err)
+	}
+	return nil
+}
+
+// Unblock deletes the block of the user and tells if there was one
+func (r *BlockedUserRepository) Unblock(ctx context.Context, userUUID string) (bool, error) {
+	result, err := r.blockedCollection.DeleteOne(ctx, bson.M{"_id": userUUID})
+	if err != nil {
+		return false, fmt.Errorf("failed to unblock user: %v", err)
+	}
+	return result.DeletedCount > 0, nil
+}
+
+// GetByUser returns the block of the user, nil if the user is not blocked
+func (r *BlockedUserRepository) GetByUser(ctx context.Context, userUUID string) (*model.BlockedUser, error) {
+	var user model.BlockedUser
+	err := r.blockedCollection.FindOne(ctx, bson.M{"_id": userUUID}).Decode(&user)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get blocked user: %v", err)
+	}
+	return &user, nil
+}
+
+func (r *BlockedUserRepository) GetAll(ctx context.Context) ([]model.BlockedUser, error) {
+	cursor, err := r.blockedCollection.Find(ctx, bson.M{}, options.Find().SetSort(bson.D{{Key: "blocked_at", Value: -1}}))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blocked users: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	users := make([]model.BlockedUser, 0)
+	if err := cursor.All(ctx, &users); err != nil {
+		return nil, fmt.Errorf("failed to decode blocked users: %v", err)
+	}
+	return users, nil
+}
@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type CorrectionJobRepository struct {
+	jobCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ CorrectionJobRepositoryInterface = (*CorrectionJobRepository)(nil)
+
+func NewCorrectionJobRepository(client *mongo.Client, dbName string) *CorrectionJobRepository {
+	return &CorrectionJobRepository{
+		jobCollection: client.Database(dbName).Collection("correction_jobs"),
+	}
+}
+
+func (r *CorrectionJobRepository) Create(ctx context.Context, job *model.CorrectionJob) error {
+	result, err := r.jobCollection.InsertOne(ctx, job)
+	if err != nil {
+		return fmt.Errorf("failed to create correction job: %v", err)
+	}
+	job.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// Update saves the job claimed by the worker. It returns false without saving it when the lock of the worker
+// expired and another worker claimed the job since.
+func (r *CorrectionJobRepository) Update(ctx context.Context, job *model.CorrectionJob) (bool, error) {
+	result, err := r.jobCollection.ReplaceOne(ctx, bson.M{"_id": job.ID, "claim_token": job.ClaimToken}, job)
+	if err != nil {
+		return false, fmt.Errorf("failed to update correction job: %v", err)
+	}
+	return result.MatchedCount > 0, nil
+}
+
+// HoldsClaim tells if the job is still held by the worker that claimed it, no other worker claimed it since
+func (r *CorrectionJobRepository) HoldsClaim(ctx context.Context, job *model.CorrectionJob) (bool, error) {
+	count, err := r.jobCollection.CountDocuments(ctx, bson.M{"_id": job.ID, "claim_token": job.ClaimToken})
+	if err != nil {
+		return false, fmt.Errorf("failed to check correction job claim: %v", err)
+	}
+	return count > 0, nil
+}
+
+// GetLatestBySubmission returns the last correction job of the submission, nil if it was never queued
+func (r *CorrectionJobRepository) GetLatestBySubmission(ctx context.Context, submissionID string) (*model.CorrectionJob, error) {
+	opts := options.FindOne().SetSort(bson.D{{Key: "created_at", Value: -1}})
+
+	var job model.CorrectionJob
+	err := r.jobCollection.FindOne(ctx, bson.M{"submission_id": submissionID}, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get correction job: %v", err)
+	}
+	return &job, nil
+}
+
+// ClaimNext atomically takes the oldest job that is ready to run and marks it as running until lockedUntil.
+// Running jobs whose lock expired, e.g. because the worker crashed, are taken again with a new claim token.
+// It returns nil when there are no jobs ready.
+func (r *CorrectionJobRepository) ClaimNext(ctx context.Context, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	return r.claim(ctx, bson.M{}, now, lockedUntil)
+}
+
+// ClaimByID takes the given job if it is ready to run, returning nil otherwise
+func (r *CorrectionJobRepository) ClaimByID(ctx context.Context, id string, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+	return r.claim(ctx, bson.M{"_id": objectID}, now, lockedUntil)
+}
+
+func (r *CorrectionJobRepository) claim(ctx context.Context, filter bson.M, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	filter["$or"] = bson.A{
+		bson.M{"status": model.CorrectionJobPending, "next_run_at": bson.M{"$lte": now}},
+		bson.M{"status": model.CorrectionJobRunning, "locked_until": bson.M{"$lte": now}},
+	}
+	update := bson.M{
+		"$set": bson.M{
+			"status":       model.CorrectionJobRunning,
+			"locked_until": lockedUntil,
+			"claim_token":  primitive.NewObjectID().Hex(),
+			"updated_at":   now,
+		},
+		"$inc": bson.M{"attempts": 1},
+	}
+	opts := options.FindOneAndUpdate().
+		SetSort(bson.D{{Key: "next_run_at", Value: 1}}).
+		SetReturnDocument(options.After)
+
+	var job model.CorrectionJob
+	err := r.jobCollection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&job)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to claim correction job: %v", err)
+	}
+	return &job, nil
+}
@@ -240,12 +240,14 @@ func (r *CourseRepository) AddAuxTeacherToCourse(course *model.Course, auxTeache
 	course.UpdatedAt = time.Now()
 
 	// Direct MongoDB update to ensure we can set the exact AuxTeachers array
-	update := bson.M{
-		"$set": bson.M{
-			"aux_teachers": course.AuxTeachers,
-			"updated_at":   course.UpdatedAt,
-		},
+	set := bson.M{
+		"aux_teachers": course.AuxTeachers,
+		"updated_at":   course.UpdatedAt,
+	}
+	if permissions, ok := course.AuxTeacherPermissions[auxTeacherId]; ok {
+		set["aux_teacher_permissions."+auxTeacherId] = permissions
 	}
+	update := bson.M{"$set": set}
 
 	_, err := r.courseCollection.UpdateOne(context.TODO(), bson.M{"_id": course.ID}, update)
 	if err != nil {
@@ -265,6 +267,7 @@ func (r *CourseRepository) RemoveAuxTeacherFromCourse(course *model.Course, auxT
 		}
 	}
 
+	delete(course.AuxTeacherPermissions, auxTeacherId)
 	course.UpdatedAt = time.Now()
 
 	// Direct MongoDB update to ensure we can set empty arrays
@@ -273,6 +276,9 @@ func (r *CourseRepository) RemoveAuxTeacherFromCourse(course *model.Course, auxT
 			"aux_teachers": course.AuxTeachers,
 			"updated_at":   course.UpdatedAt,
 		},
+		"$unset": bson.M{
+			"aux_teacher_permissions." + auxTeacherId: "",
+		},
 	}
 
 	_, err := r.courseCollection.UpdateOne(context.TODO(), bson.M{"_id": course.ID}, update)
@@ -283,6 +289,79 @@ func (r *CourseRepository) RemoveAuxTeacherFromCourse(course *model.Course, auxT
 	return r.GetCourseById(course.ID.Hex())
 }
 
+func (r *CourseRepository) UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	course.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"aux_teacher_permissions." + auxTeacherId: permissions,
+			"updated_at": course.UpdatedAt,
+		},
+	}
+
+	_, err := r.courseCollection.UpdateOne(context.TODO(), bson.M{"_id": course.ID}, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update aux teacher permissions: %v", err)
+	}
+
+	return r.GetCourseById(course.ID.Hex())
+}
+
+func (r *CourseRepository) UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error) {
+	course.Status = status
+	course.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"status":     course.Status,
+			"updated_at": course.UpdatedAt,
+		},
+	}
+
+	_, err := r.courseCollection.UpdateOne(context.TODO(), bson.M{"_id": course.ID}, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update course status: %v", err)
+	}
+
+	return r.GetCourseById(course.ID.Hex())
+}
+
+// ReassignTeacher replaces the titular teacher of the course. A new teacher that was an aux teacher of the
+// course stops being one, with their permissions.
+func (r *CourseRepository) ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error) {
+	auxTeachers := []string{}
+	for _, auxTeacher := range course.AuxTeachers {
+		if auxTeacher != teacherUUID {
+			auxTeachers = append(auxTeachers, auxTeacher)
+		}
+	}
+
+	course.TeacherUUID = teacherUUID
+	course.TeacherName = teacherName
+	course.AuxTeachers = auxTeachers
+	delete(course.AuxTeacherPermissions, teacherUUID)
+	course.UpdatedAt = time.Now()
+
+	update := bson.M{
+		"$set": bson.M{
+			"teacher_uuid": course.TeacherUUID,
+			"teacher_name": course.TeacherName,
+			"aux_teachers": course.AuxTeachers,
+			"updated_at":   course.UpdatedAt,
+		},
+		"$unset": bson.M{
+			"aux_teacher_permissions." + teacherUUID: "",
+		},
+	}
+
+	_, err := r.courseCollection.UpdateOne(context.TODO(), bson.M{"_id": course.ID}, update)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reassign course teacher: %v", err)
+	}
+
+	return r.GetCourseById(course.ID.Hex())
+}
+
 func (r *CourseRepository) UpdateStudentsAmount(courseID string, newStudentsAmount int) error {
 	objectId, err := primitive.ObjectIDFromHex(courseID)
 	if err != nil {
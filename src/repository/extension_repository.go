@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ExtensionRepository struct {
+	extensionCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ ExtensionRepositoryInterface = (*ExtensionRepository)(nil)
+
+func NewExtensionRepository(client *mongo.Client, dbName string) *ExtensionRepository {
+	return &ExtensionRepository{
+		extensionCollection: client.Database(dbName).Collection("deadline_extensions"),
+	}
+}
+
+func (r *ExtensionRepository) Create(ctx context.Context, extension *model.DeadlineExtension) error {
+	result, err := r.extensionCollection.InsertOne(ctx, extension)
+	if err != nil {
+		return fmt.Errorf("failed to create extension: %v", err)
+	}
+	extension.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *ExtensionRepository) Update(ctx context.Context, extension *model.DeadlineExtension) error {
+	_, err := r.extensionCollection.ReplaceOne(ctx, bson.M{"_id": extension.ID}, extension)
+	if err != nil {
+		return fmt.Errorf("failed to update extension: %v", err)
+	}
+	return nil
+}
+
+func (r *ExtensionRepository) GetByID(ctx context.Context, id string) (*model.DeadlineExtension, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extension: %v", err)
+	}
+
+	var extension model.DeadlineExtension
+	err = r.extensionCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&extension)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get extension: %v", err)
+	}
+	return &extension, nil
+}
+
+func (r *ExtensionRepository) GetByAssignment(ctx context.Context, assignmentID string) ([]model.DeadlineExtension, error) {
+	return r.find(ctx, bson.M{"assignment_id": assignmentID})
+}
+
+func (r *ExtensionRepository) GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.DeadlineExtension, error) {
+	return r.find(ctx, bson.M{"assignment_id": assignmentID, "student_uuids": studentUUID})
+}
+
+func (r *ExtensionRepository) GetByCourse(ctx context.Context, courseID string) ([]model.DeadlineExtension, error) {
+	return r.find(ctx, bson.M{"course_id": courseID})
+}
+
+func (r *ExtensionRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete extension: %v", err)
+	}
+
+	_, err = r.extensionCollection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete extension: %v", err)
+	}
+	return nil
+}
+
+func (r *ExtensionRepository) find(ctx context.Context, filter bson.M) ([]model.DeadlineExtension, error) {
+	cursor, err := r.extensionCollection.Find(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get extensions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	extensions := make([]model.DeadlineExtension, 0)
+	if err := cursor.All(ctx, &extensions); err != nil {
+		return nil, fmt.Errorf("failed to decode extensions: %v", err)
+	}
+	return extensions, nil
+}
@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type FeedbackAnalysisRepository struct {
+	analysisCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ FeedbackAnalysisRepositoryInterface = (*FeedbackAnalysisRepository)(nil)
+
+func NewFeedbackAnalysisRepository(client *mongo.Client, dbName string) *FeedbackAnalysisRepository {
+	return &FeedbackAnalysisRepository{
+		analysisCollection: client.Database(dbName).Collection("course_feedback_analysis"),
+	}
+}
+
+// SaveMany stores the analyses replacing the previous analysis of the same feedback, if any
+func (r *FeedbackAnalysisRepository) SaveMany(ctx context.Context, analyses []model.FeedbackAnalysis) error {
+	if len(analyses) == 0 {
+		return nil
+	}
+
+	writes := make([]mongo.WriteModel, 0, len(analyses))
+	for _, analysis := range analyses {
+		writes = append(writes, mongo.NewReplaceOneModel().
+			SetFilter(bson.M{"feedback_id": analysis.FeedbackID}).
+			SetReplacement(analysis).
+			SetUpsert(true))
+	}
+	_, err := r.analysisCollection.BulkWrite(ctx, writes, options.BulkWrite().SetOrdered(false))
+	if err != nil {
+		return fmt.Errorf("failed to save feedback analyses: %v", err)
+	}
+	return nil
+}
+
+// GetByCourse returns the analyses of the feedback of the course, the oldest feedback first
+func (r *FeedbackAnalysisRepository) GetByCourse(ctx context.Context, courseID string) ([]model.FeedbackAnalysis, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "feedback_created_at", Value: 1}})
+	cursor, err := r.analysisCollection.Find(ctx, bson.M{"course_id": courseID}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feedback analyses: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	analyses := []model.FeedbackAnalysis{}
+	if err = cursor.All(ctx, &analyses); err != nil {
+		return nil, fmt.Errorf("failed to decode feedback analyses: %v", err)
+	}
+	return analyses, nil
+}
@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type FileRepository struct {
+	fileCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ FileRepositoryInterface = (*FileRepository)(nil)
+
+func NewFileRepository(client *mongo.Client, dbName string) *FileRepository {
+	return &FileRepository{
+		fileCollection: client.Database(dbName).Collection("files"),
+	}
+}
+
+func (r *FileRepository) Create(ctx context.Context, file *model.StoredFile) error {
+	result, err := r.fileCollection.InsertOne(ctx, file)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %v", err)
+	}
+	file.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+// GetByID returns nil when the file doesn't exist or the id is not a valid file id
+func (r *FileRepository) GetByID(ctx context.Context, id string) (*model.StoredFile, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var file model.StoredFile
+	err = r.fileCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&file)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get file: %v", err)
+	}
+	return &file, nil
+}
+
+func (r *FileRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+
+	_, err = r.fileCollection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete file: %v", err)
+	}
+	return nil
+}
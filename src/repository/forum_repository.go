@@ -292,6 +292,49 @@ func (r *ForumRepository) AcceptAnswer(questionID string, answerID string) error
 	return nil
 }
 
+// ReviewAnswerSuggestion stores the review of an AI suggested answer, an empty content keeps the drafted one
+func (r *ForumRepository) ReviewAnswerSuggestion(questionID string, answerID string, content string, suggestion model.AnswerSuggestion) (*model.ForumAnswer, error) {
+	questionUUID, err := primitive.ObjectIDFromHex(questionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid question ID: %v", err)
+	}
+
+	filter := bson.M{
+		"_id":        questionUUID,
+		"answers.id": answerID,
+	}
+	fields := bson.M{
+		"answers.$.suggestion": suggestion,
+		"answers.$.updated_at": time.Now(),
+		"updated_at":           time.Now(),
+	}
+	if content != "" {
+		fields["answers.$.content"] = content
+	}
+
+	result, err := r.questionCollection.UpdateOne(context.TODO(), filter, bson.M{"$set": fields})
+	if err != nil {
+		return nil, fmt.Errorf("failed to review answer suggestion: %v", err)
+	}
+
+	if result.MatchedCount == 0 {
+		return nil, fmt.Errorf("question or answer not found")
+	}
+
+	question, err := r.GetQuestionById(questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, ans := range question.Answers {
+		if ans.ID == answerID {
+			return &ans, nil
+		}
+	}
+
+	return nil, fmt.Errorf("answer not found after review")
+}
+
 // Vote operations
 
 func (r *ForumRepository) AddVoteToQuestion(questionID string, userID string, voteType int) error {
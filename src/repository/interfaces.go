@@ -4,6 +4,7 @@ import (
 	"context"
 	"courses-service/src/model"
 	"courses-service/src/schemas"
+	"time"
 )
 
 type CourseRepositoryInterface interface {
@@ -18,6 +19,9 @@ type CourseRepositoryInterface interface {
 	UpdateCourse(id string, updateCourseRequest model.Course) (*model.Course, error)
 	AddAuxTeacherToCourse(course *model.Course, auxTeacherId string) (*model.Course, error)
 	RemoveAuxTeacherFromCourse(course *model.Course, auxTeacherId string) (*model.Course, error)
+	UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error)
+	UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error)
+	ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error)
 	UpdateStudentsAmount(courseID string, newStudentsAmount int) error
 	CreateCourseFeedback(courseID string, feedback model.CourseFeedback) (*model.CourseFeedback, error)
 	GetCourseFeedback(courseID string, getCourseFeedbackRequest schemas.GetCourseFeedbackRequest) ([]*model.CourseFeedback, error)
@@ -87,8 +91,10 @@ type SubmissionRepositoryInterface interface {
 	Update(ctx context.Context, submission *model.Submission) error
 	GetByID(ctx context.Context, id string) (*model.Submission, error)
 	GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) (*model.Submission, error)
+	GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error)
 	GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error)
 	GetByStudent(ctx context.Context, studentUUID string) ([]model.Submission, error)
+	GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error)
 	DeleteByStudentAndCourse(ctx context.Context, studentUUID, courseID string) error
 
 	// Backoffice statistics methods
@@ -110,6 +116,7 @@ type ForumRepositoryInterface interface {
 	UpdateAnswer(questionID string, answerID string, content string) (*model.ForumAnswer, error)
 	DeleteAnswer(questionID string, answerID string) error
 	AcceptAnswer(questionID string, answerID string) error
+	ReviewAnswerSuggestion(questionID string, answerID string, content string, suggestion model.AnswerSuggestion) (*model.ForumAnswer, error)
 
 	// Vote operations
 	AddVoteToQuestion(questionID string, userID string, voteType int) error
@@ -126,7 +133,72 @@ type ForumRepositoryInterface interface {
 	CountAnswers() (int64, error)
 }
 
-type TeacherActivityLogRepositoryInterface interface {
-	LogActivity(courseID, teacherUUID, activityType, description string) error
-	GetLogsByCourse(courseID string) ([]*model.TeacherActivityLog, error)
+type AuditLogRepositoryInterface interface {
+	Create(ctx context.Context, log *model.AuditLog) error
+	GetLogs(ctx context.Context, filter schemas.AuditLogFilter) ([]model.AuditLog, int64, error)
+}
+
+type AdminActionLogRepositoryInterface interface {
+	Create(ctx context.Context, log *model.AdminActionLog) error
+	GetLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error)
+}
+
+type BlockedUserRepositoryInterface interface {
+	Block(ctx context.Context, user *model.BlockedUser) error
+	Unblock(ctx context.Context, userUUID string) (bool, error)
+	GetByUser(ctx context.Context, userUUID string) (*model.BlockedUser, error)
+	GetAll(ctx context.Context) ([]model.BlockedUser, error)
+}
+
+type QuestionBankRepositoryInterface interface {
+	Create(ctx context.Context, question *model.BankQuestion) error
+	Update(ctx context.Context, question *model.BankQuestion) error
+	GetByID(ctx context.Context, id string) (*model.BankQuestion, error)
+	GetByCourse(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error)
+	GetRandomByTag(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty, count int) ([]model.BankQuestion, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type ExtensionRepositoryInterface interface {
+	Create(ctx context.Context, extension *model.DeadlineExtension) error
+	Update(ctx context.Context, extension *model.DeadlineExtension) error
+	GetByID(ctx context.Context, id string) (*model.DeadlineExtension, error)
+	GetByAssignment(ctx context.Context, assignmentID string) ([]model.DeadlineExtension, error)
+	GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.DeadlineExtension, error)
+	GetByCourse(ctx context.Context, courseID string) ([]model.DeadlineExtension, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type FileRepositoryInterface interface {
+	Create(ctx context.Context, file *model.StoredFile) error
+	GetByID(ctx context.Context, id string) (*model.StoredFile, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type CorrectionJobRepositoryInterface interface {
+	Create(ctx context.Context, job *model.CorrectionJob) error
+	Update(ctx context.Context, job *model.CorrectionJob) (bool, error)
+	GetLatestBySubmission(ctx context.Context, submissionID string) (*model.CorrectionJob, error)
+	ClaimNext(ctx context.Context, now, lockedUntil time.Time) (*model.CorrectionJob, error)
+	ClaimByID(ctx context.Context, id string, now, lockedUntil time.Time) (*model.CorrectionJob, error)
+	HoldsClaim(ctx context.Context, job *model.CorrectionJob) (bool, error)
+}
+
+type AiUsageRepositoryInterface interface {
+	Create(ctx context.Context, usage *model.AiUsage) error
+	// Backoffice statistics methods
+	GetUsageByCourse(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error)
+	GetUsageByTeacher(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error)
+}
+
+type AiCacheRepositoryInterface interface {
+	Get(ctx context.Context, key string) (*model.AiCacheEntry, error)
+	Set(ctx context.Context, entry *model.AiCacheEntry) error
+	DeleteByCourse(ctx context.Context, courseID string) error
+	DeleteByStudent(ctx context.Context, studentUUID string) error
+}
+
+type FeedbackAnalysisRepositoryInterface interface {
+	SaveMany(ctx context.Context, analyses []model.FeedbackAnalysis) error
+	GetByCourse(ctx context.Context, courseID string) ([]model.FeedbackAnalysis, error)
 }
@@ -0,0 +1,118 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type QuestionBankRepository struct {
+	questionBankCollection *mongo.Collection
+}
+
+// Ensure it implements the interface
+var _ QuestionBankRepositoryInterface = (*QuestionBankRepository)(nil)
+
+func NewQuestionBankRepository(client *mongo.Client, dbName string) *QuestionBankRepository {
+	return &QuestionBankRepository{
+		questionBankCollection: client.Database(dbName).Collection("question_bank"),
+	}
+}
+
+func (r *QuestionBankRepository) Create(ctx context.Context, question *model.BankQuestion) error {
+	result, err := r.questionBankCollection.InsertOne(ctx, question)
+	if err != nil {
+		return fmt.Errorf("failed to create bank question: %v", err)
+	}
+	question.ID = result.InsertedID.(primitive.ObjectID)
+	return nil
+}
+
+func (r *QuestionBankRepository) Update(ctx context.Context, question *model.BankQuestion) error {
+	_, err := r.questionBankCollection.ReplaceOne(ctx, bson.M{"_id": question.ID}, question)
+	if err != nil {
+		return fmt.Errorf("failed to update bank question: %v", err)
+	}
+	return nil
+}
+
+func (r *QuestionBankRepository) GetByID(ctx context.Context, id string) (*model.BankQuestion, error) {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank question: %v", err)
+	}
+
+	var question model.BankQuestion
+	err = r.questionBankCollection.FindOne(ctx, bson.M{"_id": objectID}).Decode(&question)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get bank question: %v", err)
+	}
+	return &question, nil
+}
+
+// GetByCourse returns the questions of the course bank, optionally filtered by tag and difficulty
+func (r *QuestionBankRepository) GetByCourse(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error) {
+	cursor, err := r.questionBankCollection.Find(ctx, bankFilter(courseID, tag, difficulty))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get bank questions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	questions := make([]model.BankQuestion, 0)
+	if err := cursor.All(ctx, &questions); err != nil {
+		return nil, fmt.Errorf("failed to decode bank questions: %v", err)
+	}
+	return questions, nil
+}
+
+// GetRandomByTag returns up to count random questions of the course bank with the given tag
+func (r *QuestionBankRepository) GetRandomByTag(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty, count int) ([]model.BankQuestion, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bankFilter(courseID, tag, difficulty)}},
+		{{Key: "$sample", Value: bson.M{"size": count}}},
+	}
+
+	cursor, err := r.questionBankCollection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("failed to draw bank questions: %v", err)
+	}
+	defer cursor.Close(ctx)
+
+	questions := make([]model.BankQuestion, 0)
+	if err := cursor.All(ctx, &questions); err != nil {
+		return nil, fmt.Errorf("failed to decode bank questions: %v", err)
+	}
+	return questions, nil
+}
+
+func (r *QuestionBankRepository) Delete(ctx context.Context, id string) error {
+	objectID, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return fmt.Errorf("failed to delete bank question: %v", err)
+	}
+
+	_, err = r.questionBankCollection.DeleteOne(ctx, bson.M{"_id": objectID})
+	if err != nil {
+		return fmt.Errorf("failed to delete bank question: %v", err)
+	}
+	return nil
+}
+
+func bankFilter(courseID, tag string, difficulty model.QuestionDifficulty) bson.M {
+	filter := bson.M{"course_id": courseID}
+	if tag != "" {
+		filter["tags"] = tag
+	}
+	if difficulty != "" {
+		filter["difficulty"] = difficulty
+	}
+	return filter
+}
@@ -9,6 +9,7 @@ import (
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type MongoSubmissionRepository struct {
@@ -53,12 +54,14 @@ func (r *MongoSubmissionRepository) GetByID(ctx context.Context, id string) (*mo
 	return &submission, nil
 }
 
+// GetByAssignmentAndStudent returns the latest attempt of a student for an assignment
 func (r *MongoSubmissionRepository) GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) (*model.Submission, error) {
 	var submission model.Submission
+	opts := options.FindOne().SetSort(bson.D{{Key: "attempt", Value: -1}, {Key: "created_at", Value: -1}})
 	err := r.collection.FindOne(ctx, bson.M{
 		"assignment_id": assignmentID,
 		"student_uuid":  studentUUID,
-	}).Decode(&submission)
+	}, opts).Decode(&submission)
 	if err != nil {
 		if err == mongo.ErrNoDocuments {
 			return nil, nil
@@ -68,6 +71,25 @@ func (r *MongoSubmissionRepository) GetByAssignmentAndStudent(ctx context.Contex
 	return &submission, nil
 }
 
+// GetAttemptsByAssignmentAndStudent returns every attempt of a student for an assignment, oldest first
+func (r *MongoSubmissionRepository) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "attempt", Value: 1}, {Key: "created_at", Value: 1}})
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"assignment_id": assignmentID,
+		"student_uuid":  studentUUID,
+	}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var submissions []model.Submission = make([]model.Submission, 0)
+	if err = cursor.All(ctx, &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
 func (r *MongoSubmissionRepository) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	cursor, err := r.collection.Find(ctx, bson.M{"assignment_id": assignmentID})
 	if err != nil {
@@ -96,6 +118,24 @@ func (r *MongoSubmissionRepository) GetByStudent(ctx context.Context, studentUUI
 	return submissions, nil
 }
 
+// GetExpiredDrafts returns the drafts of timed exams whose time ran out
+func (r *MongoSubmissionRepository) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	cursor, err := r.collection.Find(ctx, bson.M{
+		"status":     model.SubmissionStatusDraft,
+		"expires_at": bson.M{"$lte": now},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var submissions []model.Submission = make([]model.Submission, 0)
+	if err = cursor.All(ctx, &submissions); err != nil {
+		return nil, err
+	}
+	return submissions, nil
+}
+
 func (r *MongoSubmissionRepository) DeleteByStudentAndCourse(ctx context.Context, studentUUID, courseID string) error {
 	// First, get all assignments for the course
 	assignmentsCursor, err := r.collection.Database().Collection("assignments").Find(ctx, bson.M{"course_id": courseID})
@@ -1,16 +1,21 @@
 package router
 
 import (
+	"context"
 	"courses-service/src/ai"
+	"courses-service/src/auth"
 	"courses-service/src/config"
 	"courses-service/src/controller"
 	"courses-service/src/database"
 	"courses-service/src/middleware"
+	"courses-service/src/model"
 	"courses-service/src/queues"
 	"courses-service/src/repository"
 	"courses-service/src/service"
+	"courses-service/src/storage"
 	"log"
 	"log/slog"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -28,6 +33,7 @@ func createRouterFromConfig(config *config.Config) *gin.Engine {
 	}
 
 	r := gin.Default()
+	r.Use(middleware.RequestID())
 	return r
 }
 
@@ -44,131 +50,265 @@ func addNewRelicMiddleware(r *gin.Engine) {
 	r.Use(nrgin.Middleware(app))
 }
 
-func InitializeCoursesRoutes(r *gin.Engine, controller *controller.CourseController) {
+// Roles required in the course of the course scoped routes, the policy is declared on each route
+var (
+	titularTeacherRoles = []string{auth.RoleTitularTeacher}
+	courseTeacherRoles  = []string{auth.RoleTitularTeacher, auth.RoleAuxTeacher}
+	enrolledRoles       = []string{auth.RoleEnrolledStudent}
+	courseMemberRoles   = []string{auth.RoleTitularTeacher, auth.RoleAuxTeacher, auth.RoleEnrolledStudent, auth.RoleAdmin}
+	courseReportRoles   = []string{auth.RoleTitularTeacher, auth.RoleAuxTeacher, auth.RoleAdmin}
+)
+
+func InitializeCoursesRoutes(r *gin.Engine, controller *controller.CourseController, auditor *middleware.Auditor) {
+	// Las rutas públicas no muestran los cursos suspendidos, los listados los filtra el servicio
+	visibleCourse := middleware.RequireVisibleCourse(middleware.CourseParam("id"))
 	r.GET("/courses", controller.GetCourses)
-	r.POST("/courses", controller.CreateCourse)
 	r.GET("/courses/teacher/:teacherId", controller.GetCourseByTeacherId)
 	r.GET("/courses/student/:studentId", controller.GetCoursesByStudentId)
 	r.GET("/courses/student/:studentId/favourite", controller.GetFavouriteCourses)
 	r.GET("/courses/user/:userId", controller.GetCoursesByUserId)
 	r.GET("/courses/title/:title", controller.GetCourseByTitle)
-	r.GET("/courses/:id", controller.GetCourseById)
-	r.GET("/courses/:id/members", controller.GetCourseMembers)
-	r.DELETE("/courses/:id", controller.DeleteCourse)
-	r.PUT("/courses/:id", controller.UpdateCourse)
-	r.POST("/courses/:id/aux-teacher/add", controller.AddAuxTeacherToCourse)
-	r.DELETE("/courses/:id/aux-teacher/remove", controller.RemoveAuxTeacherFromCourse)
-	r.POST("/courses/:id/feedback", controller.CreateCourseFeedback)
-	r.PUT("/courses/:id/feedback", controller.GetCourseFeedback) // has to be a put because get doesnt receive a body and it was made to receive a body
-	r.GET("/courses/:id/feedback/summary", controller.GetCourseFeedbackSummary)
+	r.GET("/courses/:id", visibleCourse, controller.GetCourseById)
+	r.GET("/courses/:id/members", visibleCourse, controller.GetCourseMembers)
+
+	// Solo los docentes del curso y los administradores leen el feedback del curso y su resumen con IA
+	courseReport := middleware.RequireCourseRole(middleware.CourseParam("id"), courseReportRoles...)
+	r.PUT("/courses/:id/feedback", courseReport, controller.GetCourseFeedback) // has to be a put because get doesnt receive a body and it was made to receive a body
+	r.GET("/courses/:id/feedback/summary", courseReport, controller.GetCourseFeedbackSummary)
+
+	// Aplicar el middleware de autenticación de docentes, el docente es el del token
+	teacherAuthGroup := r.Group("")
+	teacherAuthGroup.Use(middleware.TeacherAuth())
+	teacherAuthGroup.POST("/courses", auditor.Audit(model.AuditEntityCourse, middleware.CreatedEntity), controller.CreateCourse)
+
+	// Solo el docente titular modifica el curso y sus docentes auxiliares
+	titularTeacher := middleware.RequireCourseRole(middleware.CourseParam("id"), titularTeacherRoles...)
+	courseAudit := auditor.Audit(model.AuditEntityCourse, middleware.EntityParam("id"))
+	teacherAuthGroup.DELETE("/courses/:id", titularTeacher, courseAudit, controller.DeleteCourse)
+	teacherAuthGroup.PUT("/courses/:id", titularTeacher, courseAudit, controller.UpdateCourse)
+	teacherAuthGroup.POST("/courses/:id/aux-teacher/add", titularTeacher, courseAudit, controller.AddAuxTeacherToCourse)
+	teacherAuthGroup.DELETE("/courses/:id/aux-teacher/remove", titularTeacher, courseAudit, controller.RemoveAuxTeacherFromCourse)
+	teacherAuthGroup.PUT("/courses/:id/aux-teacher/:auxTeacherId/permissions", titularTeacher, courseAudit, controller.UpdateAuxTeacherPermissions)
+
+	// Aplicar el middleware de autenticación de estudiantes, solo los inscriptos dan feedback del curso
+	studentAuthGroup := r.Group("")
+	studentAuthGroup.Use(middleware.StudentAuth())
+	studentAuthGroup.POST("/courses/:id/feedback", middleware.RequireCourseRole(middleware.CourseParam("id"), enrolledRoles...), courseAudit, controller.CreateCourseFeedback)
 }
 
-func InitializeTeacherActivityRoutes(r *gin.Engine, controller *controller.TeacherActivityController) {
-	r.GET("/activity-logs/course/:courseId", controller.GetCourseActivityLogs)
+// InitializeAuditRoutes sets up the queries of the audit log of the changes
+func InitializeAuditRoutes(r *gin.Engine, controller *controller.AuditController) {
+	// Los administradores consultan todos los cambios
+	r.GET("/audit-logs", middleware.AdminAuth(), controller.GetAuditLogs)
+
+	// Solo el docente titular y los administradores consultan los cambios del curso, la actividad de los
+	// docentes auxiliares es la del rol aux_teacher
+	r.GET("/courses/:id/audit-logs",
+		middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleTitularTeacher, auth.RoleAdmin),
+		controller.GetCourseAuditLogs)
 }
 
-func InitializeModulesRoutes(r *gin.Engine, controller *controller.ModuleController) {
-	r.POST("/modules", controller.CreateModule)
-	r.GET("/modules/course/:courseId", controller.GetModulesByCourseId)
-	r.GET("/modules/:id", controller.GetModuleById)
-	r.DELETE("/modules/:id", controller.DeleteModule)
-	r.PUT("/modules/:id", controller.UpdateModule)
+func InitializeModulesRoutes(r *gin.Engine, controller *controller.ModuleController, auditor *middleware.Auditor) {
+	// Las rutas públicas no muestran los módulos de los cursos suspendidos
+	r.GET("/modules/course/:courseId", middleware.RequireVisibleCourse(middleware.CourseParam("courseId")), controller.GetModulesByCourseId)
+	r.GET("/modules/:id", middleware.RequireVisibleCourse(middleware.ModuleParam("id")), controller.GetModuleById)
+
+	// Aplicar el middleware de autenticación de docentes, solo los docentes del curso gestionan sus módulos
+	teacherAuthGroup := r.Group("")
+	teacherAuthGroup.Use(middleware.TeacherAuth())
+	teacherAuthGroup.POST("/modules", middleware.RequireCourseRole(middleware.CourseBody("course_id"), courseTeacherRoles...),
+		auditor.Audit(model.AuditEntityModule, middleware.CreatedEntity), controller.CreateModule)
+
+	moduleTeacher := middleware.RequireCourseRole(middleware.ModuleParam("id"), courseTeacherRoles...)
+	moduleAudit := auditor.Audit(model.AuditEntityModule, middleware.EntityParam("id"))
+	teacherAuthGroup.DELETE("/modules/:id", moduleTeacher, moduleAudit, controller.DeleteModule)
+	teacherAuthGroup.PUT("/modules/:id", moduleTeacher, moduleAudit, controller.UpdateModule)
 }
 
-func InitializeAssignmentsRoutes(r *gin.Engine, controller *controller.AssignmentsController) {
+func InitializeAssignmentsRoutes(r *gin.Engine, controller *controller.AssignmentsController, auditor *middleware.Auditor) {
+	// Las rutas públicas no muestran los assignments de los cursos suspendidos, el listado lo filtra el servicio.
+	// Tampoco muestran las respuestas correctas ni las rúbricas, salvo a los docentes del curso y a los admins.
 	r.GET("/assignments", controller.GetAssignments)
-	r.POST("/assignments", controller.CreateAssignment)
-	r.GET("/assignments/course/:courseId", controller.GetAssignmentsByCourseId)
-	r.GET("/assignments/:assignmentId", controller.GetAssignmentById)
-	r.PUT("/assignments/:assignmentId", controller.UpdateAssignment)
-	r.DELETE("/assignments/:assignmentId", controller.DeleteAssignment)
+	r.GET("/assignments/course/:courseId", middleware.RequireVisibleCourse(middleware.CourseParam("courseId")),
+		middleware.AllowAnswerKey(middleware.CourseParam("courseId"), courseReportRoles...), controller.GetAssignmentsByCourseId)
+	r.GET("/assignments/:assignmentId", middleware.RequireVisibleCourse(middleware.AssignmentParam("assignmentId")),
+		middleware.AllowAnswerKey(middleware.AssignmentParam("assignmentId"), courseReportRoles...), controller.GetAssignmentById)
+
+	// Aplicar el middleware de autenticación de docentes, solo los docentes del curso gestionan sus assignments
+	teacherAuthGroup := r.Group("")
+	teacherAuthGroup.Use(middleware.TeacherAuth())
+	teacherAuthGroup.POST("/assignments", middleware.RequireCourseRole(middleware.CourseBody("course_id"), courseTeacherRoles...),
+		auditor.Audit(model.AuditEntityAssignment, middleware.CreatedEntity), controller.CreateAssignment)
+
+	assignmentTeacher := middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseTeacherRoles...)
+	assignmentAudit := auditor.Audit(model.AuditEntityAssignment, middleware.EntityParam("assignmentId"))
+	teacherAuthGroup.PUT("/assignments/:assignmentId", assignmentTeacher, assignmentAudit, controller.UpdateAssignment)
+	teacherAuthGroup.DELETE("/assignments/:assignmentId", assignmentTeacher, assignmentAudit, controller.DeleteAssignment)
 }
 
-func InitializeSubmissionRoutes(r *gin.Engine, controller *controller.SubmissionController) {
-	// Aplicar el middleware de autenticación de estudiantes
+func InitializeSubmissionRoutes(r *gin.Engine, controller *controller.SubmissionController, auditor *middleware.Auditor) {
+	// Aplicar el middleware de autenticación de estudiantes, solo los inscriptos en el curso entregan
 	studentAuthGroup := r.Group("")
 	studentAuthGroup.Use(middleware.StudentAuth())
 
-	studentAuthGroup.POST("/assignments/:assignmentId/submissions", controller.CreateSubmission)
-	studentAuthGroup.GET("/assignments/:assignmentId/submissions/:id", controller.GetSubmission)
-	studentAuthGroup.PUT("/assignments/:assignmentId/submissions/:id", controller.UpdateSubmission)
-	studentAuthGroup.POST("/assignments/:assignmentId/submissions/:id/submit", controller.SubmitSubmission)
+	enrolledStudent := middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), enrolledRoles...)
+	createdSubmissionAudit := auditor.Audit(model.AuditEntitySubmission, middleware.CreatedEntity)
+	submissionAudit := auditor.Audit(model.AuditEntitySubmission, middleware.EntityParam("id"))
+	// Guardar las respuestas actualiza el intento en curso, o crea el primero si el estudiante no tiene
+	latestSubmissionAudit := auditor.Audit(model.AuditEntitySubmission, auditor.LatestSubmission("assignmentId"))
+	studentAuthGroup.POST("/assignments/:assignmentId/submissions", enrolledStudent, latestSubmissionAudit, controller.CreateSubmission)
+	studentAuthGroup.POST("/assignments/:assignmentId/submissions/attempts", enrolledStudent, createdSubmissionAudit, controller.StartNewAttempt)
+	studentAuthGroup.GET("/assignments/:assignmentId/submissions/:id", enrolledStudent, controller.GetSubmission)
+	studentAuthGroup.PUT("/assignments/:assignmentId/submissions/:id", enrolledStudent, submissionAudit, controller.UpdateSubmission)
+	studentAuthGroup.POST("/assignments/:assignmentId/submissions/:id/submit", enrolledStudent, submissionAudit, controller.SubmitSubmission)
 	studentAuthGroup.GET("/students/:studentUUID/submissions", controller.GetSubmissionsByStudent)
 
-	// Aplicar el middleware de autenticación de docentes para calificar
+	// Aplicar el middleware de autenticación de docentes para calificar, solo los docentes del curso
 	teacherAuthGroup := r.Group("")
 	teacherAuthGroup.Use(middleware.TeacherAuth())
-	teacherAuthGroup.PUT("/assignments/:assignmentId/submissions/:id/grade", controller.GradeSubmission)
-	teacherAuthGroup.GET("/assignments/:assignmentId/submissions/:id/feedback-summary", controller.GenerateFeedbackSummary)
 
-	// Esta ruta no requiere autenticación de estudiante
-	r.GET("/assignments/:assignmentId/submissions", controller.GetSubmissionsByAssignment)
+	assignmentTeacher := middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseTeacherRoles...)
+	teacherAuthGroup.PUT("/assignments/:assignmentId/submissions/:id/grade", assignmentTeacher, submissionAudit, controller.GradeSubmission)
+	teacherAuthGroup.GET("/assignments/:assignmentId/submissions/:id/feedback-summary", assignmentTeacher, controller.GenerateFeedbackSummary)
+	teacherAuthGroup.GET("/assignments/:assignmentId/students/:studentUUID/attempts", assignmentTeacher, controller.GetAttemptHistory)
+	teacherAuthGroup.GET("/assignments/:assignmentId/submissions", assignmentTeacher, controller.GetSubmissionsByAssignment)
+
+	// El estudiante y los docentes del curso pueden consultar el estado de la corrección automática
+	userAuthGroup := r.Group("")
+	userAuthGroup.Use(middleware.UserAuth())
+	userAuthGroup.GET("/assignments/:assignmentId/submissions/:id/correction",
+		middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseMemberRoles...),
+		controller.GetCorrectionStatus)
+}
+
+func InitializeExtensionRoutes(r *gin.Engine, controller *controller.ExtensionController, auditor *middleware.Auditor) {
+	// Solo los docentes del curso pueden gestionar prórrogas
+	teacherAuthGroup := r.Group("/assignments/:assignmentId/extensions")
+	teacherAuthGroup.Use(middleware.TeacherAuth(), middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseTeacherRoles...))
+	extensionAudit := auditor.Audit(model.AuditEntityExtension, middleware.EntityParam("extensionId"))
+	teacherAuthGroup.POST("", auditor.Audit(model.AuditEntityExtension, middleware.CreatedEntity), controller.CreateExtension)
+	teacherAuthGroup.GET("", controller.GetExtensionsByAssignment)
+	teacherAuthGroup.PUT("/:extensionId", extensionAudit, controller.UpdateExtension)
+	teacherAuthGroup.DELETE("/:extensionId", extensionAudit, controller.DeleteExtension)
+}
+
+func InitializeQuestionBankRoutes(r *gin.Engine, controller *controller.QuestionBankController, auditor *middleware.Auditor) {
+	// Solo los docentes del curso pueden gestionar el banco de preguntas
+	teacherAuthGroup := r.Group("/courses/:id/question-bank")
+	teacherAuthGroup.Use(middleware.TeacherAuth(), middleware.RequireCourseRole(middleware.CourseParam("id"), courseTeacherRoles...))
+	bankQuestionAudit := auditor.Audit(model.AuditEntityBankQuestion, middleware.EntityParam("questionId"))
+	teacherAuthGroup.POST("", auditor.Audit(model.AuditEntityBankQuestion, middleware.CreatedEntity), controller.CreateQuestion)
+	teacherAuthGroup.GET("", controller.GetQuestions)
+	teacherAuthGroup.GET("/:questionId", controller.GetQuestion)
+	teacherAuthGroup.PUT("/:questionId", bankQuestionAudit, controller.UpdateQuestion)
+	teacherAuthGroup.DELETE("/:questionId", bankQuestionAudit, controller.DeleteQuestion)
+}
+
+func InitializeSimilarityRoutes(r *gin.Engine, controller *controller.SimilarityController) {
+	// Solo los docentes del curso pueden ver el reporte de similitud
+	teacherAuthGroup := r.Group("/assignments/:assignmentId/similarity")
+	teacherAuthGroup.Use(middleware.TeacherAuth(), middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseTeacherRoles...))
+	teacherAuthGroup.GET("", controller.GetSimilarityReport)
+	teacherAuthGroup.POST("/flag", controller.FlagSimilarSubmissions)
 }
 
-func InitializeEnrollmentsRoutes(r *gin.Engine, controller *controller.EnrollmentController) {
-	r.GET("/courses/:id/enrollments", controller.GetEnrollmentsByCourseId)
-	r.POST("/courses/:id/enroll", controller.EnrollStudent)
-	r.DELETE("/courses/:id/unenroll", controller.UnenrollStudent)
-	r.POST("/courses/:id/favourite", controller.SetFavouriteCourse)
-	r.DELETE("/courses/:id/favourite", controller.UnsetFavouriteCourse)
-	r.POST("/courses/:id/student-feedback", controller.CreateFeedback)
-	r.PUT("/feedback/student/:id", controller.GetFeedbackByStudentId)
-	r.GET("/feedback/student/:id/summary", controller.GetStudentFeedbackSummary)
+func InitializeFileRoutes(r *gin.Engine, controller *controller.FileController) {
+	// Los links de descarga están firmados, no requieren headers de autenticación
+	r.GET("/files/:fileId/download", controller.DownloadFile)
+
+	// Docentes y estudiantes suben y consultan archivos
+	userAuthGroup := r.Group("/files")
+	userAuthGroup.Use(middleware.UserAuth())
+	userAuthGroup.POST("", controller.UploadFile)
+	userAuthGroup.GET("/:fileId", controller.GetFile)
+}
+
+func InitializeEnrollmentsRoutes(r *gin.Engine, controller *controller.EnrollmentController, auditor *middleware.Auditor) {
+	// El feedback de los docentes al estudiante lo leen el estudiante, sus docentes y los administradores
+	studentAccess := middleware.RequireStudentAccess("id")
+	r.PUT("/feedback/student/:id", studentAccess, controller.GetFeedbackByStudentId)
+	r.GET("/feedback/student/:id/summary", studentAccess, controller.GetStudentFeedbackSummary)
+
+	// Solo los docentes del curso y los administradores ven los inscriptos
+	r.GET("/courses/:id/enrollments", middleware.RequireCourseRole(middleware.CourseParam("id"), courseReportRoles...), controller.GetEnrollmentsByCourseId)
+
+	// Aplicar el middleware de autenticación de estudiantes, el estudiante es el del token
+	studentAuthGroup := r.Group("")
+	studentAuthGroup.Use(middleware.StudentAuth())
+	ownEnrollmentAudit := auditor.Audit(model.AuditEntityEnrollment, middleware.OwnEnrollment("id"))
+	studentAuthGroup.POST("/courses/:id/enroll", ownEnrollmentAudit, controller.EnrollStudent)
+
+	enrolledStudent := middleware.RequireCourseRole(middleware.CourseParam("id"), enrolledRoles...)
+	studentAuthGroup.DELETE("/courses/:id/unenroll", enrolledStudent, ownEnrollmentAudit, controller.UnenrollStudent)
+	studentAuthGroup.POST("/courses/:id/favourite", enrolledStudent, ownEnrollmentAudit, controller.SetFavouriteCourse)
+	studentAuthGroup.DELETE("/courses/:id/favourite", enrolledStudent, ownEnrollmentAudit, controller.UnsetFavouriteCourse)
 
-	// Aplicar el middleware de autenticación de docentes para aprobar estudiantes
+	// Aplicar el middleware de autenticación de docentes para aprobar estudiantes y darles feedback
 	teacherAuthGroup := r.Group("")
-	teacherAuthGroup.Use(middleware.TeacherAuth())
-	teacherAuthGroup.PUT("/courses/:id/students/:studentId/approve", controller.ApproveStudent)
-	teacherAuthGroup.PUT("/courses/:id/students/:studentId/disapprove", controller.DisapproveStudent)
+	teacherAuthGroup.Use(middleware.TeacherAuth(), middleware.RequireCourseRole(middleware.CourseParam("id"), courseTeacherRoles...))
+	studentEnrollmentAudit := auditor.Audit(model.AuditEntityEnrollment, middleware.EnrollmentParams("id", "studentId"))
+	teacherAuthGroup.POST("/courses/:id/student-feedback", auditor.Audit(model.AuditEntityEnrollment, middleware.EnrollmentBody("id", "student_uuid")), controller.CreateFeedback)
+	teacherAuthGroup.PUT("/courses/:id/students/:studentId/approve", studentEnrollmentAudit, controller.ApproveStudent)
+	teacherAuthGroup.PUT("/courses/:id/students/:studentId/disapprove", studentEnrollmentAudit, controller.DisapproveStudent)
 }
 
-func InitializeForumRoutes(r *gin.Engine, controller *controller.ForumController) {
-	// Question endpoints
-	r.POST("/forum/questions", controller.CreateQuestion)
-	r.GET("/forum/questions/:questionId", controller.GetQuestionById)
-	r.GET("/forum/courses/:courseId/questions", controller.GetQuestionsByCourseId)
-	r.PUT("/forum/questions/:questionId", controller.UpdateQuestion)
-	r.DELETE("/forum/questions/:questionId", controller.DeleteQuestion)
+func InitializeForumRoutes(r *gin.Engine, controller *controller.ForumController, auditor *middleware.Auditor) {
+	// Solo los docentes y estudiantes del curso usan su foro, los administradores lo pueden moderar
+	courseMember := middleware.RequireCourseRole(middleware.CourseParam("courseId"), courseMemberRoles...)
+	questionMember := middleware.RequireCourseRole(middleware.QuestionParam("questionId"), courseMemberRoles...)
+
+	// Question endpoints, los autores y votantes son los usuarios del token
+	questionsGroup := r.Group("/forum/questions")
+	questionsGroup.POST("", middleware.RequireCourseRole(middleware.CourseBody("course_id"), courseMemberRoles...),
+		auditor.Audit(model.AuditEntityForumQuestion, middleware.CreatedEntity), controller.CreateQuestion)
+
+	// Las respuestas y los votos son parte de la pregunta, sus cambios se registran como cambios de la pregunta
+	questionAudit := auditor.Audit(model.AuditEntityForumQuestion, middleware.EntityParam("questionId"))
+	questionGroup := questionsGroup.Group("/:questionId")
+	questionGroup.Use(questionMember)
+	questionGroup.GET("", controller.GetQuestionById)
+	questionGroup.PUT("", questionAudit, controller.UpdateQuestion)
+	questionGroup.DELETE("", questionAudit, controller.DeleteQuestion)
 
 	// Answer endpoints
-	r.POST("/forum/questions/:questionId/answers", controller.AddAnswer)
-	r.PUT("/forum/questions/:questionId/answers/:answerId", controller.UpdateAnswer)
-	r.DELETE("/forum/questions/:questionId/answers/:answerId", controller.DeleteAnswer)
-	r.POST("/forum/questions/:questionId/answers/:answerId/accept", controller.AcceptAnswer)
+	questionGroup.POST("/answers", questionAudit, controller.AddAnswer)
+	questionGroup.PUT("/answers/:answerId", questionAudit, controller.UpdateAnswer)
+	questionGroup.DELETE("/answers/:answerId", questionAudit, controller.DeleteAnswer)
+	questionGroup.POST("/answers/:answerId/accept", questionAudit, controller.AcceptAnswer)
 
 	// Vote endpoints
-	r.POST("/forum/questions/:questionId/vote", controller.VoteQuestion)
-	r.POST("/forum/questions/:questionId/answers/:answerId/vote", controller.VoteAnswer)
-	r.DELETE("/forum/questions/:questionId/vote", controller.RemoveVoteFromQuestion)
-	r.DELETE("/forum/questions/:questionId/answers/:answerId/vote", controller.RemoveVoteFromAnswer)
+	questionGroup.POST("/vote", questionAudit, controller.VoteQuestion)
+	questionGroup.POST("/answers/:answerId/vote", questionAudit, controller.VoteAnswer)
+	questionGroup.DELETE("/vote", questionAudit, controller.RemoveVoteFromQuestion)
+	questionGroup.DELETE("/answers/:answerId/vote", questionAudit, controller.RemoveVoteFromAnswer)
+
+	courseGroup := r.Group("/forum/courses/:courseId")
+	courseGroup.Use(courseMember)
+	courseGroup.GET("/questions", controller.GetQuestionsByCourseId)
 
 	// Search endpoints
-	r.GET("/forum/courses/:courseId/search", controller.SearchQuestions)
+	courseGroup.GET("/search", controller.SearchQuestions)
 
 	// Forum participants endpoints
-	r.GET("/forum/courses/:courseId/participants", controller.GetForumParticipants)
+	courseGroup.GET("/participants", controller.GetForumParticipants)
 }
 
 // InitializeStatisticsRoutes sets up all statistics-related routes
 func InitializeStatisticsRoutes(r *gin.Engine, controller *controller.StatisticsController) {
-	// Group all statistics routes
-	statisticsGroup := r.Group("/statistics")
+	// Course statistics endpoints - supports JSON or CSV via ?format=csv, includes the feedback trends.
+	// Only the teachers of the course and the admins can access them
+	r.GET("/statistics/courses/:courseId", middleware.RequireCourseRole(middleware.CourseParam("courseId"), courseReportRoles...), controller.GetCourseStatistics)
 
-	// Apply teacher authentication middleware for all statistics routes
-	// Only teachers should be able to access these analytics
-	statisticsGroup.Use(middleware.TeacherAuth())
+	// Student statistics endpoints - supports JSON or CSV via ?format=csv.
+	// Only the teachers of the course of ?course_id and the admins can access them
+	r.GET("/statistics/students/:studentId", middleware.RequireCourseRole(middleware.CourseQuery("course_id"), courseReportRoles...), controller.GetStudentStatistics)
 
-	// Course statistics endpoints - supports JSON or CSV via ?format=csv
-	statisticsGroup.GET("/courses/:courseId", controller.GetCourseStatistics)
+	// Teacher's courses statistics endpoint, only for the teacher and the admins
+	r.GET("/statistics/teachers/:teacherId/courses", middleware.RequireSelf("teacherId", auth.RoleTeacher), controller.GetTeacherCoursesStatistics)
 
-	// Student statistics endpoints - supports JSON or CSV via ?format=csv
-	statisticsGroup.GET("/students/:studentId", controller.GetStudentStatistics)
-
-	// Teacher's courses statistics endpoint
-	statisticsGroup.GET("/teachers/:teacherId/courses", controller.GetTeacherCoursesStatistics)
-
-	// Backoffice statistics endpoints
+	// Backoffice statistics endpoints, only for the admins
 	backofficeGroup := r.Group("/backoffice/statistics")
+	backofficeGroup.Use(middleware.AdminAuth())
 
 	// General system statistics
 	backofficeGroup.GET("/general", controller.GetBackofficeStatistics)
@@ -180,10 +320,81 @@ func InitializeStatisticsRoutes(r *gin.Engine, controller *controller.Statistics
 	backofficeGroup.GET("/assignments", controller.GetBackofficeAssignmentsStats)
 }
 
+// InitializeQuestionGenerationRoutes sets up the generation of questions from the modules
+func InitializeQuestionGenerationRoutes(r *gin.Engine, controller *controller.QuestionGenerationController, auditor *middleware.Auditor) {
+	// Solo los docentes del curso generan preguntas y las agregan a sus assignments
+	teacherAuthGroup := r.Group("")
+	teacherAuthGroup.Use(middleware.TeacherAuth())
+	teacherAuthGroup.POST("/modules/:id/generate-questions", middleware.RequireCourseRole(middleware.ModuleParam("id"), courseTeacherRoles...), controller.GenerateQuestions)
+	teacherAuthGroup.POST("/assignments/:assignmentId/questions", middleware.RequireCourseRole(middleware.AssignmentParam("assignmentId"), courseTeacherRoles...),
+		auditor.Audit(model.AuditEntityAssignment, middleware.EntityParam("assignmentId")), controller.AddQuestions)
+}
+
+// InitializeForumSuggestionRoutes sets up the review of the AI suggested forum answers
+func InitializeForumSuggestionRoutes(r *gin.Engine, controller *controller.ForumSuggestionController, auditor *middleware.Auditor) {
+	// Solo los docentes del curso piden, aprueban o descartan las respuestas sugeridas
+	questionTeacher := middleware.RequireCourseRole(middleware.QuestionParam("questionId"), courseTeacherRoles...)
+	courseTeacher := middleware.RequireCourseRole(middleware.CourseParam("courseId"), courseTeacherRoles...)
+
+	teacherAuthGroup := r.Group("/forum")
+	teacherAuthGroup.Use(middleware.TeacherAuth())
+	questionAudit := auditor.Audit(model.AuditEntityForumQuestion, middleware.EntityParam("questionId"))
+	teacherAuthGroup.POST("/questions/:questionId/suggestions", questionTeacher, questionAudit, controller.SuggestAnswer)
+	teacherAuthGroup.POST("/questions/:questionId/suggestions/:answerId/approve", questionTeacher, questionAudit, controller.ApproveSuggestion)
+	teacherAuthGroup.DELETE("/questions/:questionId/suggestions/:answerId", questionTeacher, questionAudit, controller.DiscardSuggestion)
+	teacherAuthGroup.POST("/courses/:courseId/suggestions", courseTeacher, controller.SuggestAnswersForUnanswered)
+	teacherAuthGroup.GET("/courses/:courseId/suggestions", courseTeacher, controller.GetPendingSuggestions)
+}
+
+// InitializeAdminRoutes sets up the moderation actions of the backoffice and their audit trail
+func InitializeAdminRoutes(r *gin.Engine, controller *controller.AdminController, auditor *middleware.Auditor) {
+	// Only the admins moderate, the admin of every action is the user of the token
+	backofficeGroup := r.Group("/backoffice")
+	backofficeGroup.Use(middleware.AdminAuth())
+
+	// Course moderation
+	courseAudit := auditor.Audit(model.AuditEntityCourse, middleware.EntityParam("id"))
+	backofficeGroup.POST("/courses/:id/suspend", courseAudit, controller.SuspendCourse)
+	backofficeGroup.POST("/courses/:id/archive", courseAudit, controller.ArchiveCourse)
+	backofficeGroup.POST("/courses/:id/reactivate", courseAudit, controller.ReactivateCourse)
+	backofficeGroup.PUT("/courses/:id/teacher", courseAudit, controller.ReassignTeacher)
+	backofficeGroup.POST("/courses/:id/students/:studentId/unenroll",
+		auditor.Audit(model.AuditEntityEnrollment, middleware.EnrollmentParams("id", "studentId")), controller.UnenrollStudent)
+
+	// Forum moderation
+	questionAudit := auditor.Audit(model.AuditEntityForumQuestion, middleware.EntityParam("questionId"))
+	backofficeGroup.POST("/forum/questions/:questionId/remove", questionAudit, controller.RemoveForumQuestion)
+	backofficeGroup.POST("/forum/questions/:questionId/answers/:answerId/remove", questionAudit, controller.RemoveForumAnswer)
+
+	// User blocking
+	backofficeGroup.POST("/users/:userId/block", controller.BlockUser)
+	backofficeGroup.POST("/users/:userId/unblock", controller.UnblockUser)
+	backofficeGroup.GET("/blocked-users", controller.GetBlockedUsers)
+
+	// Audit trail of the actions
+	backofficeGroup.GET("/audit-logs", controller.GetActionLogs)
+}
+
+// InitializeAiUsageRoutes sets up the AI usage report of the backoffice
+func InitializeAiUsageRoutes(r *gin.Engine, controller *controller.AiUsageController) {
+	r.GET("/backoffice/ai-usage", middleware.AdminAuth(), controller.GetAiUsageReport)
+}
+
+const (
+	examAutoSubmitInterval   = time.Minute
+	feedbackAnalysisInterval = 10 * time.Minute
+)
+
 func NewRouter(config *config.Config) *gin.Engine {
 	r := createRouterFromConfig(config)
 	addNewRelicMiddleware(r)
 
+	verifier, err := auth.NewVerifier(auth.NewSettings(config))
+	if err != nil {
+		log.Fatalf("Failed to create token verifier: %v", err)
+	}
+	middleware.SetVerifier(verifier)
+
 	slog.Debug("Connecting to database")
 
 	dbClient, err := database.NewMongoDBClient(config)
@@ -193,11 +404,14 @@ func NewRouter(config *config.Config) *gin.Engine {
 
 	slog.Debug("Connected to database")
 
-	aiClient := ai.NewAiClient(config)
 	notificationsQueue, err := queues.NewNotificationsQueue(config)
 	if err != nil {
 		log.Fatalf("Failed to create notifications queue: %v", err)
 	}
+	fileStorage, err := storage.NewFileStorage(config)
+	if err != nil {
+		log.Fatalf("Failed to create file storage: %v", err)
+	}
 
 	courseRepo := repository.NewCourseRepository(dbClient, config.DBName)
 	enrollmentRepo := repository.NewEnrollmentRepository(dbClient, config.DBName, courseRepo)
@@ -205,27 +419,97 @@ func NewRouter(config *config.Config) *gin.Engine {
 	submissionRepository := repository.NewMongoSubmissionRepository(dbClient.Database(config.DBName))
 	moduleRepository := repository.NewModuleRepository(dbClient, config.DBName)
 	forumRepository := repository.NewForumRepository(dbClient, config.DBName)
-	activityLogRepo := repository.NewTeacherActivityLogRepository(dbClient, config.DBName)
+	extensionRepository := repository.NewExtensionRepository(dbClient, config.DBName)
+	questionBankRepository := repository.NewQuestionBankRepository(dbClient, config.DBName)
+	fileRepository := repository.NewFileRepository(dbClient, config.DBName)
+	correctionJobRepository := repository.NewCorrectionJobRepository(dbClient, config.DBName)
+	aiUsageRepository := repository.NewAiUsageRepository(dbClient, config.DBName)
+	aiCacheRepository := repository.NewAiCacheRepository(dbClient, config.DBName)
+	feedbackAnalysisRepository := repository.NewFeedbackAnalysisRepository(dbClient, config.DBName)
+	blockedUserRepository := repository.NewBlockedUserRepository(dbClient, config.DBName)
+	adminActionLogRepository := repository.NewAdminActionLogRepository(dbClient, config.DBName)
+	auditLogRepository := repository.NewAuditLogRepository(dbClient, config.DBName)
+
+	// The provider caches the feedback summaries and reports the usage of every call
+	aiUsageService := service.NewAiUsageService(aiUsageRepository, aiCacheRepository, service.NewAiUsageSettings(config))
+	aiClient, err := ai.NewProvider(config, ai.Hooks{Cache: aiUsageService, Usage: aiUsageService})
+	if err != nil {
+		log.Fatalf("Failed to create AI provider: %v", err)
+	}
 
 	courseService := service.NewCourseService(courseRepo, enrollmentRepo)
+	auditService := service.NewAuditService(courseRepo, moduleRepository, assignmentRepository, submissionRepository, enrollmentRepo, forumRepository, extensionRepository, questionBankRepository, auditLogRepository)
 	enrollmentService := service.NewEnrollmentService(enrollmentRepo, courseRepo, submissionRepository)
 	assignmentService := service.NewAssignmentService(assignmentRepository, courseService)
-	submissionService := service.NewSubmissionService(submissionRepository, assignmentRepository, courseService, aiClient)
-	moduleService := service.NewModuleService(moduleRepository)
+
+	// The AI correction runs in background workers, RabbitMQ only announces the jobs stored in Mongo
+	correctionSettings := service.NewCorrectionSettings(config)
+	var correctionsQueue *queues.CorrectionsQueue
+	var correctionNotifier service.CorrectionNotifier
+	if config.CorrectionQueueBackend == "rabbitmq" {
+		correctionsQueue, err = queues.NewCorrectionsQueue(notificationsQueue.Connection(), config.CorrectionsQueueName, correctionSettings.Concurrency)
+		if err != nil {
+			log.Fatalf("Failed to create corrections queue: %v", err)
+		}
+		correctionNotifier = correctionsQueue
+	}
+	correctionQueue := service.NewCorrectionQueue(correctionJobRepository, correctionNotifier, correctionSettings)
+	submissionService := service.NewSubmissionService(submissionRepository, assignmentRepository, extensionRepository, questionBankRepository, fileRepository, courseService, aiClient, correctionQueue, auditService)
+	moduleService := service.NewModuleService(moduleRepository, fileRepository, courseService)
 	forumService := service.NewForumService(forumRepository, courseRepo)
-	statisticsService := service.NewStatisticsService(courseRepo, assignmentRepository, enrollmentRepo, submissionRepository, forumRepository)
-	activityService := service.NewTeacherActivityService(activityLogRepo, courseRepo)
-
-	courseController := controller.NewCourseController(courseService, aiClient, activityService, notificationsQueue)
-	enrollmentController := controller.NewEnrollmentController(enrollmentService, aiClient, activityService, notificationsQueue)
-	assignmentsController := controller.NewAssignmentsController(assignmentService, notificationsQueue, activityService)
-	submissionController := controller.NewSubmissionController(submissionService, notificationsQueue, activityService, assignmentService)
-	moduleController := controller.NewModuleController(moduleService, activityService)
-	forumController := controller.NewForumController(forumService, activityService, notificationsQueue)
-	statisticsController := controller.NewStatisticsController(statisticsService)
-	activityController := controller.NewTeacherActivityController(activityService, courseService)
-
-	InitializeRoutes(r, courseController, assignmentsController, submissionController, enrollmentController, moduleController, forumController, statisticsController, activityController)
+	statisticsService := service.NewStatisticsService(courseRepo, assignmentRepository, enrollmentRepo, submissionRepository, forumRepository, extensionRepository)
+	extensionService := service.NewExtensionService(extensionRepository, assignmentRepository, courseService)
+	questionBankService := service.NewQuestionBankService(questionBankRepository, courseService)
+	similarityService := service.NewSimilarityService(submissionRepository, assignmentRepository, courseService, auditService)
+	fileService := service.NewFileService(fileRepository, fileStorage, courseService, service.NewFileSettings(config))
+	questionGenerationService := service.NewQuestionGenerationService(moduleRepository, assignmentRepository, fileRepository, fileStorage, courseService, aiClient)
+	forumSuggestionService := service.NewForumSuggestionService(forumRepository, moduleRepository, courseService, aiClient, auditService)
+	feedbackAnalyticsService := service.NewFeedbackAnalyticsService(courseRepo, feedbackAnalysisRepository, aiClient, service.NewFeedbackAnalysisSettings(config))
+	adminService := service.NewAdminService(courseRepo, enrollmentRepo, forumRepository, blockedUserRepository, adminActionLogRepository)
+
+	// The requests of the users blocked by the admins are rejected by the auth middlewares
+	middleware.SetBlockList(adminService)
+
+	// The policy of the course scoped routes resolves the roles of the user in the course with it
+	middleware.SetMembershipService(service.NewMembershipService(courseRepo, enrollmentRepo, moduleRepository, assignmentRepository, forumRepository))
+
+	// The audited routes record the changes they make to the entities with it
+	auditor := middleware.NewAuditor(auditService)
+
+	// Submit the timed exams whose time ran out even if the student never comes back
+	go submissionService.RunExamAutoSubmitter(context.Background(), examAutoSubmitInterval)
+
+	// Analyze the sentiment and topics of the new course feedback with the AI in batches
+	go feedbackAnalyticsService.RunAnalyzer(context.Background(), feedbackAnalysisInterval)
+
+	correctionWorker := service.NewCorrectionWorker(correctionQueue, submissionService)
+	go correctionWorker.Run(context.Background())
+	if correctionsQueue != nil {
+		go func() {
+			if err := correctionsQueue.Consume(context.Background(), correctionWorker.ProcessJob); err != nil {
+				log.Printf("Corrections consumer stopped: %v", err)
+			}
+		}()
+	}
+
+	courseController := controller.NewCourseController(courseService, aiClient, aiUsageService, notificationsQueue)
+	enrollmentController := controller.NewEnrollmentController(enrollmentService, aiClient, aiUsageService, notificationsQueue)
+	assignmentsController := controller.NewAssignmentsController(assignmentService, notificationsQueue)
+	submissionController := controller.NewSubmissionController(submissionService, notificationsQueue)
+	moduleController := controller.NewModuleController(moduleService)
+	forumController := controller.NewForumController(forumService, notificationsQueue)
+	statisticsController := controller.NewStatisticsController(statisticsService, feedbackAnalyticsService)
+	extensionController := controller.NewExtensionController(extensionService)
+	questionBankController := controller.NewQuestionBankController(questionBankService)
+	fileController := controller.NewFileController(fileService)
+	similarityController := controller.NewSimilarityController(similarityService)
+	aiUsageController := controller.NewAiUsageController(aiUsageService)
+	questionGenerationController := controller.NewQuestionGenerationController(questionGenerationService)
+	forumSuggestionController := controller.NewForumSuggestionController(forumSuggestionService)
+	adminController := controller.NewAdminController(adminService)
+	auditController := controller.NewAuditController(auditService)
+
+	InitializeRoutes(r, courseController, assignmentsController, submissionController, enrollmentController, moduleController, forumController, statisticsController, auditController, extensionController, questionBankController, fileController, similarityController, aiUsageController, questionGenerationController, forumSuggestionController, adminController, auditor)
 	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler)) // endpoint to consult the swagger documentation
 	return r
 }
@@ -239,14 +523,31 @@ func InitializeRoutes(
 	moduleController *controller.ModuleController,
 	forumController *controller.ForumController,
 	statisticsController *controller.StatisticsController,
-	activityController *controller.TeacherActivityController,
+	auditController *controller.AuditController,
+	extensionController *controller.ExtensionController,
+	questionBankController *controller.QuestionBankController,
+	fileController *controller.FileController,
+	similarityController *controller.SimilarityController,
+	aiUsageController *controller.AiUsageController,
+	questionGenerationController *controller.QuestionGenerationController,
+	forumSuggestionController *controller.ForumSuggestionController,
+	adminController *controller.AdminController,
+	auditor *middleware.Auditor,
 ) {
-	InitializeCoursesRoutes(r, courseController)
-	InitializeSubmissionRoutes(r, submissionController)
-	InitializeAssignmentsRoutes(r, assignmentsController)
-	InitializeEnrollmentsRoutes(r, enrollmentController)
-	InitializeModulesRoutes(r, moduleController)
-	InitializeForumRoutes(r, forumController)
+	InitializeCoursesRoutes(r, courseController, auditor)
+	InitializeSubmissionRoutes(r, submissionController, auditor)
+	InitializeAssignmentsRoutes(r, assignmentsController, auditor)
+	InitializeEnrollmentsRoutes(r, enrollmentController, auditor)
+	InitializeModulesRoutes(r, moduleController, auditor)
+	InitializeForumRoutes(r, forumController, auditor)
 	InitializeStatisticsRoutes(r, statisticsController)
-	InitializeTeacherActivityRoutes(r, activityController)
+	InitializeAuditRoutes(r, auditController)
+	InitializeExtensionRoutes(r, extensionController, auditor)
+	InitializeQuestionBankRoutes(r, questionBankController, auditor)
+	InitializeFileRoutes(r, fileController)
+	InitializeSimilarityRoutes(r, similarityController)
+	InitializeAiUsageRoutes(r, aiUsageController)
+	InitializeQuestionGenerationRoutes(r, questionGenerationController, auditor)
+	InitializeForumSuggestionRoutes(r, forumSuggestionController, auditor)
+	InitializeAdminRoutes(r, adminController, auditor)
 }
@@ -0,0 +1,23 @@
+package schemas
+
+import "courses-service/src/model"
+
+// AdminActionRequest is the reason of a moderation action, kept in the audit trail of the backoffice
+type AdminActionRequest struct {
+	Reason string `json:"reason" binding:"required"`
+}
+
+// ReassignTeacherRequest replaces the titular teacher of a course
+type ReassignTeacherRequest struct {
+	TeacherUUID string `json:"teacher_uuid" binding:"required"`
+	TeacherName string `json:"teacher_name" binding:"required"`
+	Reason      string `json:"reason" binding:"required"`
+}
+
+// AdminActionLogFilter filters the audit trail of the backoffice, every field is optional
+type AdminActionLogFilter struct {
+	AdminUUID string            `form:"admin_uuid"`
+	CourseID  string            `form:"course_id"`
+	TargetID  string            `form:"target_id"`
+	Action    model.AdminAction `form:"action"`
+}
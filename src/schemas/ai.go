@@ -1,5 +1,37 @@
 package schemas
 
+import "courses-service/src/model"
+
 type AiSummaryResponse struct {
 	Summary string `json:"summary"`
 }
+
+// GenerateQuestionsRequest asks for draft questions about the content of a module
+type GenerateQuestionsRequest struct {
+	MultipleChoiceCount int     `json:"multiple_choice_count" binding:"min=0"`
+	TextCount           int     `json:"text_count" binding:"min=0"`
+	Points              float64 `json:"points" binding:"min=0"` // Points of each question, 1 when not given
+}
+
+// GeneratedQuestionsResponse holds the draft questions, they are not stored until the teacher adds them to an assignment
+type GeneratedQuestionsResponse struct {
+	Questions     []model.Question `json:"questions"`
+	PromptVersion string           `json:"prompt_version"`
+}
+
+// AddQuestionsRequest adds reviewed questions to a draft assignment
+type AddQuestionsRequest struct {
+	Questions []model.Question `json:"questions" binding:"required,min=1"`
+}
+
+// ForumAnswerSuggestion is the answer drafted by the AI to a forum question
+type ForumAnswerSuggestion struct {
+	Content       string `json:"content"`
+	PromptVersion string `json:"prompt_version"`
+}
+
+// FeedbackAnalysisResponse holds the analysis of a batch of course feedbacks, in the order of the feedbacks
+type FeedbackAnalysisResponse struct {
+	Analyses      []model.FeedbackAnalysis `json:"analyses"`
+	PromptVersion string                   `json:"prompt_version"`
+}
@@ -0,0 +1,34 @@
+package schemas
+
+import "time"
+
+// AiUsageReportRequest filters the AI usage report by date
+type AiUsageReportRequest struct {
+	From *time.Time `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To   *time.Time `form:"to" time_format:"2006-01-02" time_utc:"1"`
+}
+
+// AiUsageReport is the AI usage of every course and teacher
+type AiUsageReport struct {
+	From        *time.Time       `json:"from,omitempty"`
+	To          *time.Time       `json:"to,omitempty"`
+	Totals      AiUsageSummary   `json:"totals"`
+	Courses     []AiUsageSummary `json:"courses"`
+	Teachers    []AiUsageSummary `json:"teachers"`
+	GeneratedAt time.Time        `json:"generated_at"`
+}
+
+// AiUsageSummary adds up the AI usage of a course or a teacher
+type AiUsageSummary struct {
+	CourseID         string  `json:"course_id,omitempty" bson:"course_id,omitempty"`
+	TeacherUUID      string  `json:"teacher_uuid,omitempty" bson:"teacher_uuid,omitempty"`
+	Requests         int     `json:"requests" bson:"requests"`
+	CachedRequests   int     `json:"cached_requests" bson:"cached_requests"`
+	FailedRequests   int     `json:"failed_requests" bson:"failed_requests"`
+	PromptTokens     int     `json:"prompt_tokens" bson:"prompt_tokens"`
+	CompletionTokens int     `json:"completion_tokens" bson:"completion_tokens"`
+	TotalTokens      int     `json:"total_tokens" bson:"total_tokens"`
+	AvgLatencyMs     float64 `json:"avg_latency_ms" bson:"avg_latency_ms"`
+	MaxLatencyMs     int64   `json:"max_latency_ms" bson:"max_latency_ms"`
+	EstimatedCost    float64 `json:"estimated_cost" bson:"-"`
+}
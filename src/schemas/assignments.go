@@ -6,28 +6,52 @@ import (
 )
 
 type CreateAssignmentRequest struct {
-	Title        string           `json:"title" binding:"required"`
-	Description  string           `json:"description" binding:"required"`
-	Instructions string           `json:"instructions" binding:"required"`
-	Type         string           `json:"type" binding:"required"`
-	CourseID     string           `json:"course_id" binding:"required"`
-	DueDate      time.Time        `json:"due_date" binding:"required"`
-	GracePeriod  int              `json:"grace_period" binding:"required"`
-	Status       string           `json:"status" binding:"required"`
-	Questions    []model.Question `json:"questions" binding:"required"`
-	TotalPoints  float64          `json:"total_points" binding:"required"`
-	PassingScore float64          `json:"passing_score" binding:"required"`
+	Title         string                     `json:"title" binding:"required"`
+	Description   string                     `json:"description" binding:"required"`
+	Instructions  string                     `json:"instructions" binding:"required"`
+	Type          string                     `json:"type" binding:"required"`
+	CourseID      string                     `json:"course_id" binding:"required"`
+	DueDate       time.Time                  `json:"due_date" binding:"required"`
+	GracePeriod   int                        `json:"grace_period" binding:"required"`
+	Status        string                     `json:"status" binding:"required"`
+	Questions     []model.Question           `json:"questions" binding:"required"`
+	TotalPoints   float64                    `json:"total_points" binding:"required"`
+	PassingScore  float64                    `json:"passing_score" binding:"required"`
+	MaxAttempts   int                        `json:"max_attempts"`
+	ScoringPolicy model.AttemptScoringPolicy `json:"scoring_policy"`
+	LatePolicy    model.LatePolicy           `json:"late_policy"`
+	LatePenalty   float64                    `json:"late_penalty"`
+	// Timed exams only
+	AvailableFrom    *time.Time `json:"available_from"`
+	AvailableUntil   *time.Time `json:"available_until"`
+	TimeLimitMinutes int        `json:"time_limit_minutes"`
+	// Questions drawn from the course question bank for each student
+	QuestionDraws  []model.QuestionDraw `json:"question_draws"`
+	ShuffleOptions bool                 `json:"shuffle_options"`
+	Rubric         *model.Rubric        `json:"rubric"`
 }
 
 type UpdateAssignmentRequest struct {
-	Title        string           `json:"title"`
-	Description  string           `json:"description"`
-	Instructions string           `json:"instructions"`
-	Type         string           `json:"type"`
-	DueDate      time.Time        `json:"due_date"`
-	GracePeriod  int              `json:"grace_period"`
-	Status       string           `json:"status"`
-	Questions    []model.Question `json:"questions"`
-	TotalPoints  float64          `json:"total_points"`
-	PassingScore float64          `json:"passing_score"`
+	Title         string                     `json:"title"`
+	Description   string                     `json:"description"`
+	Instructions  string                     `json:"instructions"`
+	Type          string                     `json:"type"`
+	DueDate       time.Time                  `json:"due_date"`
+	GracePeriod   int                        `json:"grace_period"`
+	Status        string                     `json:"status"`
+	Questions     []model.Question           `json:"questions"`
+	TotalPoints   float64                    `json:"total_points"`
+	PassingScore  float64                    `json:"passing_score"`
+	MaxAttempts   int                        `json:"max_attempts"`
+	ScoringPolicy model.AttemptScoringPolicy `json:"scoring_policy"`
+	LatePolicy    model.LatePolicy           `json:"late_policy"`
+	LatePenalty   float64                    `json:"late_penalty"`
+	// Timed exams only
+	AvailableFrom    *time.Time `json:"available_from"`
+	AvailableUntil   *time.Time `json:"available_until"`
+	TimeLimitMinutes int        `json:"time_limit_minutes"`
+	// Questions drawn from the course question bank for each student
+	QuestionDraws  []model.QuestionDraw `json:"question_draws"`
+	ShuffleOptions bool                 `json:"shuffle_options"`
+	Rubric         *model.Rubric        `json:"rubric"`
 }
@@ -0,0 +1,50 @@
+package schemas
+
+import (
+	"time"
+
+	"courses-service/src/model"
+)
+
+const (
+	DefaultAuditPageSize = 20
+	MaxAuditPageSize     = 100
+)
+
+// AuditRecord is a request that changed an entity, with the entity before and after it as JSON objects.
+// The before of a created entity and the after of a deleted one are nil.
+type AuditRecord struct {
+	RequestID  string
+	ActorUUID  string
+	ActorRoles []string
+	Entity     model.AuditEntity
+	EntityID   string
+	CourseID   string
+	Method     string
+	Path       string
+	Before     map[string]any
+	After      map[string]any
+}
+
+// AuditLogFilter filters and pages the audit log, every filter is optional
+type AuditLogFilter struct {
+	ActorUUID string            `form:"actor_uuid"`
+	Role      string            `form:"role"`
+	Entity    model.AuditEntity `form:"entity"`
+	EntityID  string            `form:"entity_id"`
+	CourseID  string            `form:"course_id"`
+	Action    model.AuditAction `form:"action"`
+	RequestID string            `form:"request_id"`
+	From      *time.Time        `form:"from" time_format:"2006-01-02" time_utc:"1"`
+	To        *time.Time        `form:"to" time_format:"2006-01-02" time_utc:"1"`
+	Page      int               `form:"page" binding:"omitempty,min=1"`
+	PageSize  int               `form:"page_size" binding:"omitempty,min=1"`
+}
+
+// AuditLogPage is a page of the audit log, the latest changes first
+type AuditLogPage struct {
+	Logs     []model.AuditLog `json:"logs"`
+	Page     int              `json:"page"`
+	PageSize int              `json:"page_size"`
+	Total    int64            `json:"total"`
+}
@@ -8,11 +8,12 @@ import (
 type CreateCourseRequest struct {
 	Title       string    `json:"title" binding:"required"`
 	Description string    `json:"description" binding:"required"`
-	TeacherID   string    `json:"teacher_id" binding:"required"`
+	TeacherID   string    `json:"-"` // The authenticated teacher
 	Capacity    int       `json:"capacity" binding:"required"`
 	StartDate   time.Time `json:"start_date" binding:"required"`
 	EndDate     time.Time `json:"end_date" binding:"required"`
-	TeacherName string    `json:"teacher_name"` // TODO: this will later be consulted with users service to get the teacher name
+	TeacherName string    `json:"-"`                                           // Name claim of the token of the teacher
+	Language    string    `json:"language" binding:"omitempty,oneof=es en pt"` // Spanish when not given
 }
 
 type CreateCourseResponse struct {
@@ -30,10 +31,13 @@ type CreateCourseResponse struct {
 type UpdateCourseRequest struct {
 	Title       string    `json:"title"`
 	Description string    `json:"description"`
-	TeacherID   string    `json:"teacher_id" binding:"required"`
+	TeacherID   string    `json:"-"` // The authenticated teacher, who must be the owner
 	Capacity    int       `json:"capacity"`
 	StartDate   time.Time `json:"start_date"`
 	EndDate     time.Time `json:"end_date"`
+	Language    string    `json:"language" binding:"omitempty,oneof=es en pt"`
+	// Lets the teachers ask the AI to draft answers to the forum questions, unchanged when not given
+	ForumAiSuggestions *bool `json:"forum_ai_suggestions"`
 }
 
 type UpdateCourseResponse struct {
@@ -55,12 +59,15 @@ type GetCoursesByUserIdResponse struct {
 }
 
 type AddAuxTeacherToCourseRequest struct {
-	TeacherID    string `json:"teacher_id" binding:"required"`
-	AuxTeacherID string `json:"aux_teacher_id" binding:"required"`
+	AuxTeacherID string                       `json:"aux_teacher_id" binding:"required"`
+	Permissions  []model.AuxTeacherPermission `json:"permissions"` // All the permissions when empty
+}
+
+type UpdateAuxTeacherPermissionsRequest struct {
+	Permissions []model.AuxTeacherPermission `json:"permissions" binding:"required"`
 }
 
 type RemoveAuxTeacherFromCourseRequest struct {
-	TeacherID    string `json:"teacher_id" binding:"required"`
 	AuxTeacherID string `json:"aux_teacher_id" binding:"required"`
 }
 
@@ -1,9 +1,5 @@
 package schemas
 
-type EnrollStudentRequest struct {
-	StudentID string `json:"student_id" binding:"required"`
-}
-
 type EnrollStudentResponse struct {
 	Message string `json:"message"`
 }
@@ -12,10 +8,6 @@ type UnenrollStudentResponse struct {
 	Message string `json:"message"`
 }
 
-type SetFavouriteCourseRequest struct {
-	StudentID string `json:"student_id" binding:"required"`
-}
-
 type SetFavouriteCourseResponse struct {
 	Message string `json:"message"`
 }
@@ -0,0 +1,19 @@
+package schemas
+
+import "time"
+
+// CreateExtensionRequest represents the request to grant a deadline extension to one or more students
+type CreateExtensionRequest struct {
+	StudentUUIDs []string  `json:"student_uuids" binding:"required"`
+	GroupName    string    `json:"group_name"`
+	DueDate      time.Time `json:"due_date" binding:"required"`
+	Reason       string    `json:"reason"`
+}
+
+// UpdateExtensionRequest represents the request to update a deadline extension
+type UpdateExtensionRequest struct {
+	StudentUUIDs []string  `json:"student_uuids"`
+	GroupName    string    `json:"group_name"`
+	DueDate      time.Time `json:"due_date"`
+	Reason       string    `json:"reason"`
+}
@@ -7,7 +7,7 @@ import (
 
 type CreateStudentFeedbackRequest struct {
 	StudentUUID  string             `json:"student_uuid" binding:"required"`
-	TeacherUUID  string             `json:"teacher_uuid" binding:"required"`
+	TeacherUUID  string             `json:"-"` // The authenticated teacher
 	CourseID     string             `json:"course_id" binding:"required"`
 	FeedbackType model.FeedbackType `json:"feedback_type" binding:"required"`
 	Score        int                `json:"score" binding:"required"`
@@ -24,7 +24,7 @@ type GetFeedbackByStudentIdRequest struct {
 }
 
 type CreateCourseFeedbackRequest struct {
-	StudentUUID  string             `json:"student_uuid" binding:"required"`
+	StudentUUID  string             `json:"-"` // The authenticated student
 	Score        int                `json:"score" binding:"required"`
 	FeedbackType model.FeedbackType `json:"feedback_type" binding:"required"`
 	Feedback     string             `json:"feedback" binding:"required"`
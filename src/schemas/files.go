@@ -0,0 +1,20 @@
+package schemas
+
+import (
+	"courses-service/src/model"
+	"time"
+)
+
+// UploadFileRequest represents the form fields sent along with an uploaded file
+type UploadFileRequest struct {
+	CourseID string            `form:"course_id" binding:"required"`
+	Purpose  model.FilePurpose `form:"purpose" binding:"required"`
+	Checksum string            `form:"checksum"` // Optional hex encoded SHA-256 computed by the client
+}
+
+// FileResponse represents a stored file along with a temporary download link
+type FileResponse struct {
+	File        *model.StoredFile `json:"file"`
+	DownloadURL string            `json:"download_url"`
+	ExpiresAt   time.Time         `json:"expires_at"`
+}
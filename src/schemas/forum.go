@@ -9,7 +9,7 @@ import (
 
 type CreateQuestionRequest struct {
 	CourseID    string              `json:"course_id" binding:"required"`
-	AuthorID    string              `json:"author_id" binding:"required"`
+	AuthorID    string              `json:"-"` // The authenticated user
 	Title       string              `json:"title" binding:"required"`
 	Description string              `json:"description" binding:"required"`
 	Tags        []model.QuestionTag `json:"tags"`
@@ -56,7 +56,7 @@ type QuestionDetailResponse struct {
 // Answer schemas
 
 type CreateAnswerRequest struct {
-	AuthorID string `json:"author_id" binding:"required"`
+	AuthorID string `json:"-"` // The authenticated user
 	Content  string `json:"content" binding:"required"`
 }
 
@@ -65,20 +65,40 @@ type UpdateAnswerRequest struct {
 }
 
 type AnswerResponse struct {
-	ID         string       `json:"id"`
-	AuthorID   string       `json:"author_id"`
-	Content    string       `json:"content"`
-	Votes      []model.Vote `json:"votes"`
-	VoteCount  int          `json:"vote_count"`
-	IsAccepted bool         `json:"is_accepted"`
-	CreatedAt  time.Time    `json:"created_at"`
-	UpdatedAt  time.Time    `json:"updated_at"`
+	ID         string                  `json:"id"`
+	AuthorID   string                  `json:"author_id"`
+	Content    string                  `json:"content"`
+	Type       model.AnswerType        `json:"type"`
+	Suggestion *model.AnswerSuggestion `json:"suggestion,omitempty"`
+	Votes      []model.Vote            `json:"votes"`
+	VoteCount  int                     `json:"vote_count"`
+	IsAccepted bool                    `json:"is_accepted"`
+	CreatedAt  time.Time               `json:"created_at"`
+	UpdatedAt  time.Time               `json:"updated_at"`
+}
+
+// AI answer suggestion schemas
+
+type SuggestUnansweredRequest struct {
+	OlderThanHours int `form:"older_than_hours" binding:"min=0"` // 48 hours when not given
+}
+
+type ApproveSuggestionRequest struct {
+	Content string `json:"content"` // Edited answer, the drafted one is kept when empty
+}
+
+// ForumSuggestionResponse is an AI suggested answer with the question it answers
+type ForumSuggestionResponse struct {
+	QuestionID    string            `json:"question_id"`
+	CourseID      string            `json:"course_id"`
+	QuestionTitle string            `json:"question_title"`
+	Answer        model.ForumAnswer `json:"answer"`
 }
 
 // Vote schemas
 
 type VoteRequest struct {
-	UserID   string `json:"user_id" binding:"required"`
+	UserID   string `json:"-"` // The authenticated user
 	VoteType int    `json:"vote_type" binding:"required"`
 }
 
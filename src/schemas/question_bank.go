@@ -0,0 +1,41 @@
+package schemas
+
+import "courses-service/src/model"
+
+// CreateBankQuestionRequest represents the request to add a question to the course question bank
+type CreateBankQuestionRequest struct {
+	Text            string                   `json:"text" binding:"required"`
+	Type            model.QuestionType       `json:"type" binding:"required"`
+	Options         []string                 `json:"options"`
+	CorrectAnswers  []string                 `json:"correct_answers"`
+	Points          float64                  `json:"points" binding:"required"`
+	PartialCredit   bool                     `json:"partial_credit"`
+	NegativeMarking float64                  `json:"negative_marking"`
+	Tags            []string                 `json:"tags" binding:"required"`
+	Difficulty      model.QuestionDifficulty `json:"difficulty"`
+	// Numeric, matching and short answer questions
+	NumericAnswer    *float64             `json:"numeric_answer"`
+	Tolerance        float64              `json:"tolerance"`
+	MatchingPairs    []model.MatchingPair `json:"matching_pairs"`
+	AcceptedPatterns []string             `json:"accepted_patterns"`
+	Rubric           *model.Rubric        `json:"rubric"`
+}
+
+// UpdateBankQuestionRequest represents the request to update a question of the course question bank
+type UpdateBankQuestionRequest struct {
+	Text            string                   `json:"text"`
+	Type            model.QuestionType       `json:"type"`
+	Options         []string                 `json:"options"`
+	CorrectAnswers  []string                 `json:"correct_answers"`
+	Points          float64                  `json:"points"`
+	PartialCredit   *bool                    `json:"partial_credit"`
+	NegativeMarking *float64                 `json:"negative_marking"`
+	Tags            []string                 `json:"tags"`
+	Difficulty      model.QuestionDifficulty `json:"difficulty"`
+	// Numeric, matching and short answer questions
+	NumericAnswer    *float64             `json:"numeric_answer"`
+	Tolerance        *float64             `json:"tolerance"`
+	MatchingPairs    []model.MatchingPair `json:"matching_pairs"`
+	AcceptedPatterns []string             `json:"accepted_patterns"`
+	Rubric           *model.Rubric        `json:"rubric"`
+}
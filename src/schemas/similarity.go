@@ -0,0 +1,38 @@
+package schemas
+
+import "time"
+
+// SimilarityReportRequest holds the options of a similarity report
+type SimilarityReportRequest struct {
+	Threshold         float64 `json:"threshold" form:"threshold"`                     // Minimum similarity between 0 and 1, defaults to 0.5
+	IncludePriorTerms *bool   `json:"include_prior_terms" form:"include_prior_terms"` // Also compare with earlier editions of the course, defaults to true
+}
+
+// SimilarityReport lists the pairs of answers of an assignment that look alike
+type SimilarityReport struct {
+	AssignmentID         string           `json:"assignment_id"`
+	CourseID             string           `json:"course_id"`
+	Threshold            float64          `json:"threshold"`
+	ComparedSubmissions  int              `json:"compared_submissions"`
+	PriorTermSubmissions int              `json:"prior_term_submissions"`
+	FlaggedSubmissions   int              `json:"flagged_submissions,omitempty"`
+	Pairs                []SimilarityPair `json:"pairs"`
+	GeneratedAt          time.Time        `json:"generated_at"`
+}
+
+// SimilarityPair is a pair of answers to the same question with their estimated similarity
+type SimilarityPair struct {
+	QuestionID string            `json:"question_id"`
+	Similarity float64           `json:"similarity"`
+	Submission SimilarSubmission `json:"submission"`
+	Other      SimilarSubmission `json:"other"`
+	Excerpts   []string          `json:"excerpts"` // Passages of the submission answer also found in the other answer
+}
+
+type SimilarSubmission struct {
+	SubmissionID string `json:"submission_id"`
+	AssignmentID string `json:"assignment_id"`
+	StudentUUID  string `json:"student_uuid"`
+	StudentName  string `json:"student_name"`
+	PriorTerm    bool   `json:"prior_term,omitempty"` // Submitted in an earlier edition of the course
+}
@@ -1,6 +1,10 @@
 package schemas
 
-import "time"
+import (
+	"time"
+
+	"courses-service/src/model"
+)
 
 // Period represents a time range for filtering statistics
 type Period struct {
@@ -187,3 +191,49 @@ type AssignmentBasicInfo struct {
 	CreatedAt time.Time `json:"created_at"`
 	DueDate   time.Time `json:"due_date"`
 }
+
+// FeedbackTrendInterval is the length of the periods the feedback trends are grouped by
+type FeedbackTrendInterval string
+
+const (
+	FeedbackTrendWeek  FeedbackTrendInterval = "week"
+	FeedbackTrendMonth FeedbackTrendInterval = "month"
+)
+
+// FeedbackTrends represents the sentiment and topics of the feedback of a course over time
+type FeedbackTrends struct {
+	Interval             FeedbackTrendInterval `json:"interval"`
+	TotalFeedbacks       int                   `json:"total_feedbacks"`
+	AnalyzedWithAI       int                   `json:"analyzed_with_ai"`
+	AnalyzedWithKeywords int                   `json:"analyzed_with_keywords"`
+	Sentiment            SentimentSummary      `json:"sentiment"`
+	Topics               []TopicTrend          `json:"topics"`  // Most mentioned first
+	Periods              []FeedbackPeriodTrend `json:"periods"` // Oldest first, only the periods with feedback
+}
+
+// SentimentSummary represents how many feedbacks have each sentiment
+type SentimentSummary struct {
+	Total            int     `json:"total"`
+	Positive         int     `json:"positive"`
+	Neutral          int     `json:"neutral"`
+	Negative         int     `json:"negative"`
+	AverageSentiment float64 `json:"average_sentiment"` // Between -1 and 1
+	AverageRating    float64 `json:"average_rating"`    // Score of 1 to 5 given by the students
+}
+
+// TopicTrend represents the mentions of a topic and the sentiment of the feedbacks mentioning it
+type TopicTrend struct {
+	Topic            model.FeedbackTopic `json:"topic"`
+	Mentions         int                 `json:"mentions"`
+	Positive         int                 `json:"positive"`
+	Neutral          int                 `json:"neutral"`
+	Negative         int                 `json:"negative"`
+	AverageSentiment float64             `json:"average_sentiment"`
+}
+
+// FeedbackPeriodTrend represents the feedback of a week or month
+type FeedbackPeriodTrend struct {
+	PeriodStart time.Time        `json:"period_start"`
+	Sentiment   SentimentSummary `json:"sentiment"`
+	TopTopics   []TopicTrend     `json:"top_topics"`
+}
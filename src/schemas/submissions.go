@@ -1,14 +1,47 @@
 package schemas
 
+import "courses-service/src/model"
+
 // GradeSubmissionRequest represents the request to grade a submission
 type GradeSubmissionRequest struct {
-	Score    *float64 `json:"score" bson:"score"`
-	Feedback string   `json:"feedback" bson:"feedback"`
+	Score        *float64             `json:"score" bson:"score"`
+	Feedback     string               `json:"feedback" bson:"feedback"`
+	AnswerGrades []AnswerGradeRequest `json:"answer_grades,omitempty" bson:"answer_grades,omitempty"`
+	// Levels chosen for the assignment rubric, the score is computed from them
+	RubricSelections []model.RubricSelection `json:"rubric_selections,omitempty" bson:"rubric_selections,omitempty"`
+}
+
+// AnswerGradeRequest represents the grade given by a teacher to a single answer.
+// Points are computed from the rubric selections when the question has a rubric.
+type AnswerGradeRequest struct {
+	QuestionID       string                  `json:"question_id"`
+	Points           float64                 `json:"points"`
+	Comment          string                  `json:"comment"`
+	RubricSelections []model.RubricSelection `json:"rubric_selections,omitempty"`
 }
 
 // AiCorrectionResponse represents the response from AI correction
 type AiCorrectionResponse struct {
-	AIScore           float64 `json:"ai_score"`
-	AIFeedback        string  `json:"ai_feedback"`
-	NeedsManualReview bool    `json:"needs_manual_review"`
+	AIScore           float64                `json:"ai_score"`
+	AIFeedback        string                 `json:"ai_feedback"`
+	NeedsManualReview bool                   `json:"needs_manual_review"`
+	Questions         []AiQuestionCorrection `json:"questions,omitempty"`
+	PromptVersion     string                 `json:"-"` // Version of the prompt template, set by the client
+}
+
+// AiQuestionCorrection represents the AI correction of a single question
+type AiQuestionCorrection struct {
+	QuestionID string  `json:"question_id"`
+	Score      float64 `json:"score"`
+	Feedback   string  `json:"feedback"`
+}
+
+// AttemptHistoryResponse represents every attempt of a student for an assignment
+type AttemptHistoryResponse struct {
+	AssignmentID  string                     `json:"assignment_id"`
+	StudentUUID   string                     `json:"student_uuid"`
+	MaxAttempts   int                        `json:"max_attempts"`
+	ScoringPolicy model.AttemptScoringPolicy `json:"scoring_policy"`
+	FinalScore    *float64                   `json:"final_score,omitempty"`
+	Attempts      []model.Submission         `json:"attempts"`
 }
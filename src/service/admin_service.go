@@ -0,0 +1,291 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// AdminService holds the moderation actions of the backoffice. Every action needs a reason and is recorded
+// in the audit trail with the admin that did it.
+type AdminService struct {
+	courseRepo      repository.CourseRepositoryInterface
+	enrollmentRepo  repository.EnrollmentRepositoryInterface
+	forumRepo       repository.ForumRepositoryInterface
+	blockedUserRepo repository.BlockedUserRepositoryInterface
+	actionLogRepo   repository.AdminActionLogRepositoryInterface
+}
+
+func NewAdminService(
+	courseRepo repository.CourseRepositoryInterface,
+	enrollmentRepo repository.EnrollmentRepositoryInterface,
+	forumRepo repository.ForumRepositoryInterface,
+	blockedUserRepo repository.BlockedUserRepositoryInterface,
+	actionLogRepo repository.AdminActionLogRepositoryInterface,
+) *AdminService {
+	return &AdminService{
+		courseRepo:      courseRepo,
+		enrollmentRepo:  enrollmentRepo,
+		forumRepo:       forumRepo,
+		blockedUserRepo: blockedUserRepo,
+		actionLogRepo:   actionLogRepo,
+	}
+}
+
+// SuspendCourse hides the course from everyone but the admins until it is reactivated
+func (s *AdminService) SuspendCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return s.setCourseStatus(ctx, courseID, adminUUID, reason, model.CourseStatusSuspended, model.AdminActionSuspendCourse)
+}
+
+// ArchiveCourse leaves the course read only until it is reactivated
+func (s *AdminService) ArchiveCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return s.setCourseStatus(ctx, courseID, adminUUID, reason, model.CourseStatusArchived, model.AdminActionArchiveCourse)
+}
+
+// ReactivateCourse makes a suspended or archived course active again
+func (s *AdminService) ReactivateCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return s.setCourseStatus(ctx, courseID, adminUUID, reason, model.CourseStatusActive, model.AdminActionReactivateCourse)
+}
+
+func (s *AdminService) setCourseStatus(ctx context.Context, courseID, adminUUID, reason string, status model.CourseStatus, action model.AdminAction) (*model.Course, error) {
+	if err := checkReason(reason); err != nil {
+		return nil, err
+	}
+	course, err := s.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	previous := course.CurrentStatus()
+	if previous == status {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidCourseStatus, status)
+	}
+
+	updated, err := s.courseRepo.UpdateCourseStatus(course, status)
+	if err != nil {
+		return nil, err
+	}
+	details := fmt.Sprintf("Status changed from %s to %s", previous, status)
+	if err := s.record(ctx, adminUUID, action, courseID, courseID, reason, details); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// ReassignTeacher makes another teacher the titular teacher of the course. The previous titular teacher
+// loses access to the course, the new one can't be enrolled in it as a student.
+func (s *AdminService) ReassignTeacher(ctx context.Context, courseID, adminUUID string, request schemas.ReassignTeacherRequest) (*model.Course, error) {
+	if err := checkReason(request.Reason); err != nil {
+		return nil, err
+	}
+	teacherUUID := strings.TrimSpace(request.TeacherUUID)
+	teacherName := strings.TrimSpace(request.TeacherName)
+	if teacherUUID == "" || teacherName == "" {
+		return nil, fmt.Errorf("%w: the UUID and name of the new teacher are required", ErrInvalidTeacherReassignment)
+	}
+
+	course, err := s.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course.TeacherUUID == teacherUUID {
+		return nil, fmt.Errorf("%w: the teacher is already the titular teacher of the course", ErrInvalidTeacherReassignment)
+	}
+	enrolled, err := s.enrollmentRepo.IsEnrolled(teacherUUID, courseID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return nil, err
+	}
+	if enrolled {
+		return nil, fmt.Errorf("%w: the teacher is enrolled in the course as a student", ErrInvalidTeacherReassignment)
+	}
+
+	previous := course.TeacherUUID
+	updated, err := s.courseRepo.ReassignTeacher(course, teacherUUID, teacherName)
+	if err != nil {
+		return nil, err
+	}
+	details := fmt.Sprintf("Titular teacher changed from %s to %s", previous, teacherUUID)
+	if err := s.record(ctx, adminUUID, model.AdminActionReassignTeacher, courseID, courseID, request.Reason, details); err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// UnenrollStudent drops an active student from the course, the reason is shown to the student
+func (s *AdminService) UnenrollStudent(ctx context.Context, courseID, studentUUID, adminUUID, reason string) error {
+	if err := checkReason(reason); err != nil {
+		return err
+	}
+	if _, err := s.getCourse(courseID); err != nil {
+		return err
+	}
+
+	enrollment, err := s.enrollmentRepo.GetEnrollmentByStudentIdAndCourseId(studentUUID, courseID)
+	if err != nil && !errors.Is(err, mongo.ErrNoDocuments) {
+		return err
+	}
+	if enrollment == nil || enrollment.Status != model.EnrollmentStatusActive {
+		return ErrStudentNotEnrolled
+	}
+
+	if err := s.enrollmentRepo.DisapproveStudent(studentUUID, courseID, reason); err != nil {
+		return err
+	}
+	return s.record(ctx, adminUUID, model.AdminActionUnenrollStudent, courseID, studentUUID, reason, "")
+}
+
+// RemoveForumQuestion deletes a question of any author, with its answers
+func (s *AdminService) RemoveForumQuestion(ctx context.Context, questionID, adminUUID, reason string) error {
+	if err := checkReason(reason); err != nil {
+		return err
+	}
+	question, err := s.getQuestion(questionID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.forumRepo.DeleteQuestion(questionID); err != nil {
+		return err
+	}
+	details := fmt.Sprintf("Question by %s: %s", question.AuthorID, question.Title)
+	return s.record(ctx, adminUUID, model.AdminActionRemoveForumQuestion, question.CourseID, questionID, reason, details)
+}
+
+// RemoveForumAnswer deletes an answer of any author
+func (s *AdminService) RemoveForumAnswer(ctx context.Context, questionID, answerID, adminUUID, reason string) error {
+	if err := checkReason(reason); err != nil {
+		return err
+	}
+	question, err := s.getQuestion(questionID)
+	if err != nil {
+		return err
+	}
+	var answer *model.ForumAnswer
+	for i := range question.Answers {
+		if question.Answers[i].ID == answerID {
+			answer = &question.Answers[i]
+			break
+		}
+	}
+	if answer == nil {
+		return ErrForumAnswerNotFound
+	}
+
+	if err := s.forumRepo.DeleteAnswer(questionID, answerID); err != nil {
+		return err
+	}
+	details := fmt.Sprintf("Answer by %s to the question: %s", answer.AuthorID, question.Title)
+	return s.record(ctx, adminUUID, model.AdminActionRemoveForumAnswer, question.CourseID, answerID, reason, details)
+}
+
+// BlockUser rejects every request of the user until an admin unblocks them
+func (s *AdminService) BlockUser(ctx context.Context, userUUID, adminUUID, reason string) (*model.BlockedUser, error) {
+	if err := checkReason(reason); err != nil {
+		return nil, err
+	}
+	if strings.TrimSpace(userUUID) == "" {
+		return nil, fmt.Errorf("%w: the user UUID is required", ErrInvalidUserBlock)
+	}
+	if userUUID == adminUUID {
+		return nil, fmt.Errorf("%w: an admin can't block themselves", ErrInvalidUserBlock)
+	}
+
+	blocked := &model.BlockedUser{
+		UserUUID:  userUUID,
+		Reason:    reason,
+		BlockedBy: adminUUID,
+		BlockedAt: time.Now(),
+	}
+	if err := s.blockedUserRepo.Block(ctx, blocked); err != nil {
+		return nil, err
+	}
+	if err := s.record(ctx, adminUUID, model.AdminActionBlockUser, "", userUUID, reason, ""); err != nil {
+		return nil, err
+	}
+	return blocked, nil
+}
+
+func (s *AdminService) UnblockUser(ctx context.Context, userUUID, adminUUID, reason string) error {
+	if err := checkReason(reason); err != nil {
+		return err
+	}
+	unblocked, err := s.blockedUserRepo.Unblock(ctx, userUUID)
+	if err != nil {
+		return err
+	}
+	if !unblocked {
+		return ErrUserNotBlocked
+	}
+	return s.record(ctx, adminUUID, model.AdminActionUnblockUser, "", userUUID, reason, "")
+}
+
+func (s *AdminService) GetBlockedUsers(ctx context.Context) ([]model.BlockedUser, error) {
+	return s.blockedUserRepo.GetAll(ctx)
+}
+
+// IsBlocked tells if the admins blocked the user, for the authentication of every request
+func (s *AdminService) IsBlocked(userUUID string) (bool, error) {
+	blocked, err := s.blockedUserRepo.GetByUser(context.TODO(), userUUID)
+	if err != nil {
+		return false, err
+	}
+	return blocked != nil, nil
+}
+
+// GetActionLogs returns the audit trail of the backoffice, the latest actions first
+func (s *AdminService) GetActionLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error) {
+	return s.actionLogRepo.GetLogs(ctx, filter)
+}
+
+// record adds the action to the audit trail. The action is already done when it fails, the error says so.
+func (s *AdminService) record(ctx context.Context, adminUUID string, action model.AdminAction, courseID, targetID, reason, details string) error {
+	err := s.actionLogRepo.Create(ctx, &model.AdminActionLog{
+		AdminUUID: adminUUID,
+		Action:    action,
+		CourseID:  courseID,
+		TargetID:  targetID,
+		Reason:    strings.TrimSpace(reason),
+		Details:   details,
+		Timestamp: time.Now(),
+	})
+	if err != nil {
+		return fmt.Errorf("the %s action was done but couldn't be recorded: %v", action, err)
+	}
+	return nil
+}
+
+func (s *AdminService) getCourse(courseID string) (*model.Course, error) {
+	course, err := s.courseRepo.GetCourseById(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCourseNotFound, err)
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+	return course, nil
+}
+
+func (s *AdminService) getQuestion(questionID string) (*model.ForumQuestion, error) {
+	question, err := s.forumRepo.GetQuestionById(questionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrForumQuestionNotFound, err)
+	}
+	if question == nil {
+		return nil, ErrForumQuestionNotFound
+	}
+	return question, nil
+}
+
+func checkReason(reason string) error {
+	if strings.TrimSpace(reason) == "" {
+		return ErrReasonRequired
+	}
+	return nil
+}
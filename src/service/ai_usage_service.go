@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strconv"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+// AiUsageSettings holds the prices used to estimate the cost of the AI usage, in USD per million tokens
+type AiUsageSettings struct {
+	PromptTokenPrice     float64
+	CompletionTokenPrice float64
+}
+
+// NewAiUsageSettings reads the prices from the config, without prices the estimated cost is zero
+func NewAiUsageSettings(config *config.Config) AiUsageSettings {
+	var settings AiUsageSettings
+	if price, err := strconv.ParseFloat(config.AiPromptTokenPrice, 64); err == nil && price > 0 {
+		settings.PromptTokenPrice = price
+	}
+	if price, err := strconv.ParseFloat(config.AiCompletionTokenPrice, 64); err == nil && price > 0 {
+		settings.CompletionTokenPrice = price
+	}
+	return settings
+}
+
+// AiUsageService accounts the tokens and latency of the calls to the model and caches its answers.
+// It's given to the AI provider, which reports every call to it.
+type AiUsageService struct {
+	usageRepo repository.AiUsageRepositoryInterface
+	cacheRepo repository.AiCacheRepositoryInterface
+	settings  AiUsageSettings
+}
+
+var (
+	_ ai.UsageRecorder = (*AiUsageService)(nil)
+	_ ai.ResponseCache = (*AiUsageService)(nil)
+)
+
+func NewAiUsageService(usageRepo repository.AiUsageRepositoryInterface, cacheRepo repository.AiCacheRepositoryInterface, settings AiUsageSettings) *AiUsageService {
+	return &AiUsageService{
+		usageRepo: usageRepo,
+		cacheRepo: cacheRepo,
+		settings:  settings,
+	}
+}
+
+// RecordUsage stores a call to the model under the scope of the context.
+// Failing to store it must not fail the request that used the model, so errors are only logged.
+func (s *AiUsageService) RecordUsage(ctx context.Context, usage ai.Usage) {
+	scope := ai.ScopeFrom(ctx)
+	record := &model.AiUsage{
+		Operation:        usage.Operation,
+		Provider:         usage.Provider,
+		Model:            usage.Model,
+		PromptVersion:    usage.PromptVersion,
+		CourseID:         scope.CourseID,
+		TeacherUUID:      scope.TeacherUUID,
+		StudentUUID:      scope.StudentUUID,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		LatencyMs:        usage.Latency.Milliseconds(),
+		Cached:           usage.Cached,
+		Success:          usage.Success,
+		CreatedAt:        time.Now(),
+	}
+	// The request may be over by now, the usage is stored anyway
+	if err := s.usageRepo.Create(context.WithoutCancel(ctx), record); err != nil {
+		log.Printf("Failed to record AI usage: %v", err)
+	}
+}
+
+// Get returns the cached answer with the given key, a failing cache is treated as a miss
+func (s *AiUsageService) Get(ctx context.Context, key string) (string, bool) {
+	entry, err := s.cacheRepo.Get(ctx, key)
+	if err != nil {
+		log.Printf("Failed to read AI cache: %v", err)
+		return "", false
+	}
+	if entry == nil {
+		return "", false
+	}
+	return entry.Response, true
+}
+
+// Set caches an answer under the scope of the context, so it can be invalidated when the feedback changes
+func (s *AiUsageService) Set(ctx context.Context, key, operation, response string) {
+	scope := ai.ScopeFrom(ctx)
+	entry := &model.AiCacheEntry{
+		Key:         key,
+		Operation:   operation,
+		CourseID:    scope.CourseID,
+		StudentUUID: scope.StudentUUID,
+		Response:    response,
+		CreatedAt:   time.Now(),
+	}
+	if err := s.cacheRepo.Set(ctx, entry); err != nil {
+		log.Printf("Failed to write AI cache: %v", err)
+	}
+}
+
+// InvalidateCourse drops the cached answers of a course, called when new course feedback is written
+func (s *AiUsageService) InvalidateCourse(ctx context.Context, courseID string) error {
+	return s.cacheRepo.DeleteByCourse(ctx, courseID)
+}
+
+// InvalidateStudent drops the cached answers of a student, called when new feedback of the student is written
+func (s *AiUsageService) InvalidateStudent(ctx context.Context, studentUUID string) error {
+	return s.cacheRepo.DeleteByStudent(ctx, studentUUID)
+}
+
+// GetUsageReport adds up the AI usage of every course and teacher in the period, both bounds are optional
+func (s *AiUsageService) GetUsageReport(ctx context.Context, request schemas.AiUsageReportRequest) (*schemas.AiUsageReport, error) {
+	if request.From != nil && request.To != nil && !request.From.Before(*request.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidDateRange)
+	}
+
+	courses, err := s.usageRepo.GetUsageByCourse(ctx, request.From, request.To)
+	if err != nil {
+		return nil, err
+	}
+	teachers, err := s.usageRepo.GetUsageByTeacher(ctx, request.From, request.To)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &schemas.AiUsageReport{
+		From:        request.From,
+		To:          request.To,
+		Courses:     courses,
+		Teachers:    teachers,
+		GeneratedAt: time.Now(),
+	}
+
+	// Every call has a course, possibly empty, so the totals come from the courses
+	var latencyMs float64
+	for i := range report.Courses {
+		course := &report.Courses[i]
+		course.EstimatedCost = s.estimateCost(*course)
+
+		report.Totals.Requests += course.Requests
+		report.Totals.CachedRequests += course.CachedRequests
+		report.Totals.FailedRequests += course.FailedRequests
+		report.Totals.PromptTokens += course.PromptTokens
+		report.Totals.CompletionTokens += course.CompletionTokens
+		report.Totals.TotalTokens += course.TotalTokens
+		report.Totals.MaxLatencyMs = max(report.Totals.MaxLatencyMs, course.MaxLatencyMs)
+		latencyMs += course.AvgLatencyMs * float64(course.Requests-course.CachedRequests)
+	}
+	for i := range report.Teachers {
+		report.Teachers[i].EstimatedCost = s.estimateCost(report.Teachers[i])
+	}
+	if calls := report.Totals.Requests - report.Totals.CachedRequests; calls > 0 {
+		report.Totals.AvgLatencyMs = latencyMs / float64(calls)
+	}
+	report.Totals.EstimatedCost = s.estimateCost(report.Totals)
+
+	return report, nil
+}
+
+func (s *AiUsageService) estimateCost(usage schemas.AiUsageSummary) float64 {
+	return (float64(usage.PromptTokens)*s.settings.PromptTokenPrice + float64(usage.CompletionTokens)*s.settings.CompletionTokenPrice) / 1e6
+}
@@ -6,6 +6,8 @@ import (
 	"courses-service/src/repository"
 	"courses-service/src/schemas"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 )
 
@@ -18,8 +20,27 @@ func NewAssignmentService(assignmentRepository repository.AssignmentRepositoryIn
 	return &AssignmentService{assignmentRepository: assignmentRepository, courseService: courseService}
 }
 
+// GetAssignments returns the assignments of every course but the suspended ones
 func (s *AssignmentService) GetAssignments() ([]*model.Assignment, error) {
-	return s.assignmentRepository.GetAssignments()
+	assignments, err := s.assignmentRepository.GetAssignments()
+	if err != nil {
+		return nil, err
+	}
+
+	suspended := make(map[string]bool)
+	visible := make([]*model.Assignment, 0, len(assignments))
+	for _, assignment := range assignments {
+		isSuspended, checked := suspended[assignment.CourseID]
+		if !checked {
+			course, err := s.courseService.GetCourseById(assignment.CourseID)
+			isSuspended = err == nil && course != nil && course.CurrentStatus() == model.CourseStatusSuspended
+			suspended[assignment.CourseID] = isSuspended
+		}
+		if !isSuspended {
+			visible = append(visible, assignment)
+		}
+	}
+	return visible, nil
 }
 
 func (s *AssignmentService) GetAssignmentById(id string) (*model.Assignment, error) {
@@ -29,36 +50,61 @@ func (s *AssignmentService) GetAssignmentById(id string) (*model.Assignment, err
 	return s.assignmentRepository.GetByID(context.TODO(), id)
 }
 
-func (s *AssignmentService) CreateAssignment(c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
-	// Validate course exists
-	course, err := s.courseService.GetCourseById(c.CourseID)
-	if err != nil {
+func (s *AssignmentService) CreateAssignment(teacherUUID string, c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
+	// Validate course exists and the teacher can create its assignments
+	if err := s.checkCanEditAssignments(c.CourseID, teacherUUID); err != nil {
 		return nil, err
 	}
-	if course == nil {
-		return nil, errors.New("course not found")
+
+	if err := validateAttemptSettings(c.MaxAttempts, c.ScoringPolicy); err != nil {
+		return nil, err
+	}
+	if err := validateLatePolicy(c.LatePolicy, c.LatePenalty); err != nil {
+		return nil, err
+	}
+	if err := validateExamTiming(c.Type, c.AvailableFrom, c.AvailableUntil, c.TimeLimitMinutes); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionDraws(c.QuestionDraws); err != nil {
+		return nil, err
+	}
+	if err := validateQuestions(c.Questions); err != nil {
+		return nil, err
+	}
+	if err := validateRubric(c.Rubric); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRubric, err)
 	}
 
 	assignment := model.Assignment{
-		Title:        c.Title,
-		Description:  c.Description,
-		Instructions: c.Instructions,
-		Type:         c.Type,
-		CourseID:     c.CourseID,
-		DueDate:      c.DueDate,
-		GracePeriod:  c.GracePeriod,
-		Status:       c.Status,
-		Questions:    c.Questions,
-		TotalPoints:  c.TotalPoints,
-		PassingScore: c.PassingScore,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		Title:            c.Title,
+		Description:      c.Description,
+		Instructions:     c.Instructions,
+		Type:             c.Type,
+		CourseID:         c.CourseID,
+		DueDate:          c.DueDate,
+		GracePeriod:      c.GracePeriod,
+		Status:           c.Status,
+		Questions:        c.Questions,
+		TotalPoints:      c.TotalPoints,
+		PassingScore:     c.PassingScore,
+		MaxAttempts:      c.MaxAttempts,
+		ScoringPolicy:    c.ScoringPolicy,
+		LatePolicy:       c.LatePolicy,
+		LatePenalty:      c.LatePenalty,
+		AvailableFrom:    c.AvailableFrom,
+		AvailableUntil:   c.AvailableUntil,
+		TimeLimitMinutes: c.TimeLimitMinutes,
+		QuestionDraws:    c.QuestionDraws,
+		ShuffleOptions:   c.ShuffleOptions,
+		Rubric:           c.Rubric,
+		CreatedAt:        time.Now(),
+		UpdatedAt:        time.Now(),
 	}
 
 	return s.assignmentRepository.CreateAssignment(assignment)
 }
 
-func (s *AssignmentService) UpdateAssignment(id string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
+func (s *AssignmentService) UpdateAssignment(id, teacherUUID string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
 	if id == "" {
 		return nil, errors.New("id is required")
 	}
@@ -71,35 +117,143 @@ func (s *AssignmentService) UpdateAssignment(id string, updateAssignmentRequest
 	if existingAssignment == nil {
 		return nil, errors.New("assignment not found")
 	}
+	if err := s.checkCanEditAssignments(existingAssignment.CourseID, teacherUUID); err != nil {
+		return nil, err
+	}
+
+	if err := validateAttemptSettings(updateAssignmentRequest.MaxAttempts, updateAssignmentRequest.ScoringPolicy); err != nil {
+		return nil, err
+	}
+	if err := validateLatePolicy(updateAssignmentRequest.LatePolicy, updateAssignmentRequest.LatePenalty); err != nil {
+		return nil, err
+	}
+	assignmentType := updateAssignmentRequest.Type
+	if assignmentType == "" {
+		assignmentType = existingAssignment.Type
+	}
+	if err := validateExamTiming(assignmentType, updateAssignmentRequest.AvailableFrom, updateAssignmentRequest.AvailableUntil, updateAssignmentRequest.TimeLimitMinutes); err != nil {
+		return nil, err
+	}
+	if err := validateQuestionDraws(updateAssignmentRequest.QuestionDraws); err != nil {
+		return nil, err
+	}
+	if err := validateQuestions(updateAssignmentRequest.Questions); err != nil {
+		return nil, err
+	}
+	if err := validateRubric(updateAssignmentRequest.Rubric); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidRubric, err)
+	}
 
 	assignment := model.Assignment{
-		Title:        updateAssignmentRequest.Title,
-		Description:  updateAssignmentRequest.Description,
-		Instructions: updateAssignmentRequest.Instructions,
-		Type:         updateAssignmentRequest.Type,
-		CourseID:     existingAssignment.CourseID,
-		DueDate:      updateAssignmentRequest.DueDate,
-		GracePeriod:  updateAssignmentRequest.GracePeriod,
-		Status:       updateAssignmentRequest.Status,
-		Questions:    updateAssignmentRequest.Questions,
-		TotalPoints:  updateAssignmentRequest.TotalPoints,
-		PassingScore: updateAssignmentRequest.PassingScore,
-		UpdatedAt:    time.Now(),
+		Title:            updateAssignmentRequest.Title,
+		Description:      updateAssignmentRequest.Description,
+		Instructions:     updateAssignmentRequest.Instructions,
+		Type:             updateAssignmentRequest.Type,
+		CourseID:         existingAssignment.CourseID,
+		DueDate:          updateAssignmentRequest.DueDate,
+		GracePeriod:      updateAssignmentRequest.GracePeriod,
+		Status:           updateAssignmentRequest.Status,
+		Questions:        updateAssignmentRequest.Questions,
+		TotalPoints:      updateAssignmentRequest.TotalPoints,
+		PassingScore:     updateAssignmentRequest.PassingScore,
+		MaxAttempts:      updateAssignmentRequest.MaxAttempts,
+		ScoringPolicy:    updateAssignmentRequest.ScoringPolicy,
+		LatePolicy:       updateAssignmentRequest.LatePolicy,
+		LatePenalty:      updateAssignmentRequest.LatePenalty,
+		AvailableFrom:    updateAssignmentRequest.AvailableFrom,
+		AvailableUntil:   updateAssignmentRequest.AvailableUntil,
+		TimeLimitMinutes: updateAssignmentRequest.TimeLimitMinutes,
+		QuestionDraws:    updateAssignmentRequest.QuestionDraws,
+		ShuffleOptions:   updateAssignmentRequest.ShuffleOptions,
+		Rubric:           updateAssignmentRequest.Rubric,
+		UpdatedAt:        time.Now(),
 	}
 
 	return s.assignmentRepository.UpdateAssignment(id, assignment)
 }
 
-func (s *AssignmentService) DeleteAssignment(id string) error {
+func (s *AssignmentService) DeleteAssignment(id, teacherUUID string) error {
 	if id == "" {
 		return errors.New("id is required")
 	}
+
+	assignment, err := s.assignmentRepository.GetByID(context.TODO(), id)
+	if err != nil {
+		return err
+	}
+	if assignment == nil {
+		return ErrAssignmentNotFound
+	}
+	if err := s.checkCanEditAssignments(assignment.CourseID, teacherUUID); err != nil {
+		return err
+	}
 	return s.assignmentRepository.DeleteAssignment(id)
 }
 
+// checkCanEditAssignments returns ErrUnauthorized if the teacher is neither the titular teacher of the course
+// nor an aux teacher allowed to create its assignments
+func (s *AssignmentService) checkCanEditAssignments(courseID, teacherUUID string) error {
+	course, err := s.courseService.GetCourseById(courseID)
+	if err != nil {
+		return err
+	}
+	if course == nil {
+		return errors.New("course not found")
+	}
+	return authorizeTeacher(course, teacherUUID, model.PermissionCreateAssignments)
+}
+
 func (s *AssignmentService) GetAssignmentsByCourseId(courseId string) ([]*model.Assignment, error) {
 	if courseId == "" {
 		return nil, errors.New("course id is required")
 	}
 	return s.assignmentRepository.GetAssignmentsByCourseId(courseId)
 }
+
+func validateAttemptSettings(maxAttempts int, scoringPolicy model.AttemptScoringPolicy) error {
+	if maxAttempts < 0 {
+		return errors.New("max attempts cannot be negative")
+	}
+	if scoringPolicy != "" && !slices.Contains(model.AttemptScoringPolicyValues, scoringPolicy) {
+		return errors.New("invalid scoring policy: " + string(scoringPolicy))
+	}
+	return nil
+}
+
+func validateLatePolicy(latePolicy model.LatePolicy, latePenalty float64) error {
+	if latePolicy != "" && !slices.Contains(model.LatePolicyValues, latePolicy) {
+		return errors.New("invalid late policy: " + string(latePolicy))
+	}
+	if latePenalty < 0 || latePenalty > 100 {
+		return errors.New("late penalty must be a percentage between 0 and 100")
+	}
+	return nil
+}
+
+func validateExamTiming(assignmentType string, availableFrom, availableUntil *time.Time, timeLimitMinutes int) error {
+	if timeLimitMinutes < 0 {
+		return errors.New("time limit cannot be negative")
+	}
+	if (timeLimitMinutes > 0 || availableFrom != nil || availableUntil != nil) && assignmentType != "exam" {
+		return errors.New("only exams can have an availability window or a time limit")
+	}
+	if availableFrom != nil && availableUntil != nil && !availableUntil.After(*availableFrom) {
+		return errors.New("available until must be after available from")
+	}
+	return nil
+}
+
+func validateQuestionDraws(draws []model.QuestionDraw) error {
+	for _, draw := range draws {
+		if draw.Tag == "" {
+			return errors.New("question draws require a tag")
+		}
+		if draw.Count < 1 {
+			return errors.New("question draws must pick at least one question")
+		}
+		if draw.Difficulty != "" && !slices.Contains(model.QuestionDifficultyValues, draw.Difficulty) {
+			return errors.New("invalid question difficulty: " + string(draw.Difficulty))
+		}
+	}
+	return nil
+}
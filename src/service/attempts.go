@@ -0,0 +1,69 @@
+package service
+
+import (
+	"courses-service/src/model"
+)
+
+// maxAttempts returns how many attempts a student has for the assignment, at least one
+func maxAttempts(assignment *model.Assignment) int {
+	if assignment.MaxAttempts < 1 {
+		return 1
+	}
+	return assignment.MaxAttempts
+}
+
+// scoringPolicy returns the attempt scoring policy of the assignment, latest by default
+func scoringPolicy(assignment *model.Assignment) model.AttemptScoringPolicy {
+	if assignment.ScoringPolicy == "" {
+		return model.AttemptScoringLatest
+	}
+	return assignment.ScoringPolicy
+}
+
+// attemptNumber returns the attempt of a submission, submissions created before attempts existed count as the first one
+func attemptNumber(submission *model.Submission) int {
+	if submission.Attempt < 1 {
+		return 1
+	}
+	return submission.Attempt
+}
+
+// finalAttemptScore combines the scores of the graded attempts following the scoring policy.
+// Returns nil when no attempt was graded yet.
+func finalAttemptScore(policy model.AttemptScoringPolicy, attempts []model.Submission) *float64 {
+	var latest *model.Submission
+	var highest, total float64
+	graded := 0
+
+	for i := range attempts {
+		attempt := &attempts[i]
+		if attempt.Status == model.SubmissionStatusDraft || attempt.Score == nil {
+			continue
+		}
+
+		score := *attempt.Score
+		if graded == 0 || score > highest {
+			highest = score
+		}
+		if latest == nil || attemptNumber(attempt) >= attemptNumber(latest) {
+			latest = attempt
+		}
+		total += score
+		graded++
+	}
+
+	if graded == 0 {
+		return nil
+	}
+
+	var finalScore float64
+	switch policy {
+	case model.AttemptScoringHighest:
+		finalScore = highest
+	case model.AttemptScoringAverage:
+		finalScore = total / float64(graded)
+	default:
+		finalScore = *latest.Score
+	}
+	return &finalScore
+}
@@ -0,0 +1,246 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"maps"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+// auditIgnoredFields change on every update, they are left out of the changes
+var auditIgnoredFields = []string{"updated_at"}
+
+// AuditService records the changes the requests make to the entities, with the user that made them. The
+// entities are compared as JSON objects before and after the request, field by field.
+type AuditService struct {
+	courseRepo       repository.CourseRepositoryInterface
+	moduleRepo       repository.ModuleRepositoryInterface
+	assignmentRepo   repository.AssignmentRepositoryInterface
+	submissionRepo   repository.SubmissionRepositoryInterface
+	enrollmentRepo   repository.EnrollmentRepositoryInterface
+	forumRepo        repository.ForumRepositoryInterface
+	extensionRepo    repository.ExtensionRepositoryInterface
+	questionBankRepo repository.QuestionBankRepositoryInterface
+	auditLogRepo     repository.AuditLogRepositoryInterface
+}
+
+func NewAuditService(
+	courseRepo repository.CourseRepositoryInterface,
+	moduleRepo repository.ModuleRepositoryInterface,
+	assignmentRepo repository.AssignmentRepositoryInterface,
+	submissionRepo repository.SubmissionRepositoryInterface,
+	enrollmentRepo repository.EnrollmentRepositoryInterface,
+	forumRepo repository.ForumRepositoryInterface,
+	extensionRepo repository.ExtensionRepositoryInterface,
+	questionBankRepo repository.QuestionBankRepositoryInterface,
+	auditLogRepo repository.AuditLogRepositoryInterface,
+) *AuditService {
+	return &AuditService{
+		courseRepo:       courseRepo,
+		moduleRepo:       moduleRepo,
+		assignmentRepo:   assignmentRepo,
+		submissionRepo:   submissionRepo,
+		enrollmentRepo:   enrollmentRepo,
+		forumRepo:        forumRepo,
+		extensionRepo:    extensionRepo,
+		questionBankRepo: questionBankRepo,
+		auditLogRepo:     auditLogRepo,
+	}
+}
+
+// Snapshot returns the entity as a JSON object, or nil when it doesn't exist. The repositories don't tell
+// a missing entity from a failed lookup, so an entity that can't be read is taken as missing.
+func (s *AuditService) Snapshot(ctx context.Context, entity model.AuditEntity, id string) map[string]any {
+	if strings.TrimSpace(id) == "" {
+		return nil
+	}
+	value, err := s.getEntity(ctx, entity, id)
+	if err != nil {
+		slog.Debug("Audited entity not read", "entity", entity, "id", id, "error", err)
+		return nil
+	}
+	if value == nil || reflect.ValueOf(value).IsNil() {
+		return nil
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		slog.Error("Error encoding audited entity", "entity", entity, "id", id, "error", err)
+		return nil
+	}
+	var snapshot map[string]any
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		slog.Error("Error decoding audited entity", "entity", entity, "id", id, "error", err)
+		return nil
+	}
+	return snapshot
+}
+
+func (s *AuditService) getEntity(ctx context.Context, entity model.AuditEntity, id string) (any, error) {
+	switch entity {
+	case model.AuditEntityCourse:
+		return s.courseRepo.GetCourseById(id)
+	case model.AuditEntityModule:
+		return s.moduleRepo.GetModuleById(id)
+	case model.AuditEntityAssignment:
+		return s.assignmentRepo.GetByID(ctx, id)
+	case model.AuditEntitySubmission:
+		return s.submissionRepo.GetByID(ctx, id)
+	case model.AuditEntityEnrollment:
+		courseID, studentUUID, ok := strings.Cut(id, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid enrollment ID %s", id)
+		}
+		return s.enrollmentRepo.GetEnrollmentByStudentIdAndCourseId(studentUUID, courseID)
+	case model.AuditEntityForumQuestion:
+		return s.forumRepo.GetQuestionById(id)
+	case model.AuditEntityExtension:
+		return s.extensionRepo.GetByID(ctx, id)
+	case model.AuditEntityBankQuestion:
+		return s.questionBankRepo.GetByID(ctx, id)
+	default:
+		return nil, fmt.Errorf("unknown audited entity %s", entity)
+	}
+}
+
+// LatestSubmissionID returns the ID of the latest attempt of the student at the assignment, empty when the
+// student has none or it can't be read
+func (s *AuditService) LatestSubmissionID(ctx context.Context, assignmentID, studentUUID string) string {
+	if assignmentID == "" || studentUUID == "" {
+		return ""
+	}
+	submission, err := s.submissionRepo.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+	if err != nil || submission == nil {
+		return ""
+	}
+	return submission.ID.Hex()
+}
+
+// Record stores the changes of the request to the entity. The entity missing before the request was
+// created by it and missing after it was deleted, a request that changed nothing is not recorded.
+func (s *AuditService) Record(ctx context.Context, record schemas.AuditRecord) error {
+	var action model.AuditAction
+	switch {
+	case record.Before == nil && record.After == nil:
+		return nil
+	case record.Before == nil:
+		action = model.AuditActionCreate
+	case record.After == nil:
+		action = model.AuditActionDelete
+	default:
+		action = model.AuditActionUpdate
+	}
+	changes := diffEntities(record.Before, record.After)
+	if len(changes) == 0 {
+		return nil
+	}
+
+	courseID := record.CourseID
+	if courseID == "" && record.Entity == model.AuditEntityCourse {
+		courseID = record.EntityID
+	}
+	if courseID == "" {
+		courseID = stringField(record.After, "course_id")
+	}
+	if courseID == "" {
+		courseID = stringField(record.Before, "course_id")
+	}
+
+	return s.auditLogRepo.Create(ctx, &model.AuditLog{
+		RequestID:  record.RequestID,
+		ActorUUID:  record.ActorUUID,
+		ActorRoles: record.ActorRoles,
+		Action:     action,
+		Entity:     record.Entity,
+		EntityID:   record.EntityID,
+		CourseID:   courseID,
+		Method:     record.Method,
+		Path:       record.Path,
+		Changes:    changes,
+		Timestamp:  time.Now(),
+	})
+}
+
+// GetLogs returns a page of the audit log, the latest changes first. The first page of 20 logs is
+// returned by default and a page has at most 100.
+func (s *AuditService) GetLogs(ctx context.Context, filter schemas.AuditLogFilter) (*schemas.AuditLogPage, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidDateRange)
+	}
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = schemas.DefaultAuditPageSize
+	}
+	filter.PageSize = min(filter.PageSize, schemas.MaxAuditPageSize)
+
+	logs, total, err := s.auditLogRepo.GetLogs(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	return &schemas.AuditLogPage{Logs: logs, Page: filter.Page, PageSize: filter.PageSize, Total: total}, nil
+}
+
+// recordSystemChange makes a change outside of the audited routes and records it in the audit log with the
+// system as the actor, the operation in place of the path. The change is already made when it's recorded,
+// so a failure to record it is only logged.
+func recordSystemChange(ctx context.Context, auditService AuditServiceInterface, entity model.AuditEntity, id, operation string, change func() error) error {
+	if auditService == nil {
+		return change()
+	}
+
+	before := auditService.Snapshot(ctx, entity, id)
+	if err := change(); err != nil {
+		return err
+	}
+	record := schemas.AuditRecord{
+		ActorUUID:  model.AuditActorSystem,
+		ActorRoles: []string{model.AuditActorSystem},
+		Entity:     entity,
+		EntityID:   id,
+		Path:       operation,
+		Before:     before,
+		After:      auditService.Snapshot(ctx, entity, id),
+	}
+	if err := auditService.Record(ctx, record); err != nil {
+		slog.Error("Error recording the change in the audit log", "error", err, "entity", entity, "entityID", id, "operation", operation)
+	}
+	return nil
+}
+
+// diffEntities returns the top level fields that differ between the entities, sorted by name
+func diffEntities(before, after map[string]any) []model.AuditChange {
+	fields := slices.Collect(maps.Keys(before))
+	for field := range after {
+		if _, ok := before[field]; !ok {
+			fields = append(fields, field)
+		}
+	}
+	slices.Sort(fields)
+
+	changes := []model.AuditChange{}
+	for _, field := range fields {
+		if slices.Contains(auditIgnoredFields, field) {
+			continue
+		}
+		if !reflect.DeepEqual(before[field], after[field]) {
+			changes = append(changes, model.AuditChange{Field: field, Before: before[field], After: after[field]})
+		}
+	}
+	return changes
+}
+
+func stringField(entity map[string]any, field string) string {
+	value, _ := entity[field].(string)
+	return value
+}
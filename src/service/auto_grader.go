@@ -0,0 +1,172 @@
+package service
+
+import (
+	"math"
+	"strings"
+
+	"courses-service/src/model"
+)
+
+// IsAutoGradable reports whether a question can be scored without the AI
+func IsAutoGradable(question model.Question) bool {
+	switch question.Type {
+	case model.QuestionTypeMultipleChoice, model.QuestionTypeTrueFalse, model.QuestionTypeOrdering:
+		return len(question.CorrectAnswers) > 0
+	case model.QuestionTypeNumeric:
+		return question.NumericAnswer != nil
+	case model.QuestionTypeMatching:
+		return len(question.MatchingPairs) > 0
+	case model.QuestionTypeShortAnswer:
+		return len(question.AcceptedPatterns) > 0
+	default:
+		return false
+	}
+}
+
+// AutoGradeAnswer scores an answer against its question deterministically.
+// The returned score may be negative when the question uses negative marking.
+func AutoGradeAnswer(question model.Question, answer model.Answer) float64 {
+	switch question.Type {
+	case model.QuestionTypeMultipleChoice:
+		selected, _ := answerList(answer.Content)
+		return gradeMultipleChoice(question, selected)
+	case model.QuestionTypeNumeric:
+		return gradeNumeric(question, answer.Content)
+	case model.QuestionTypeTrueFalse:
+		return gradeTrueFalse(question, answer.Content)
+	case model.QuestionTypeMatching:
+		return gradeMatching(question, answer.Content)
+	case model.QuestionTypeOrdering:
+		return gradeOrdering(question, answer.Content)
+	case model.QuestionTypeShortAnswer:
+		return gradeShortAnswer(question, answer.Content)
+	default:
+		return 0
+	}
+}
+
+func gradeMultipleChoice(question model.Question, selected []string) float64 {
+	if len(question.CorrectAnswers) == 0 || len(selected) == 0 {
+		return 0
+	}
+
+	correct := make(map[string]bool)
+	for _, option := range question.CorrectAnswers {
+		correct[normalizeOption(option)] = true
+	}
+
+	// Count each distinct selected option once
+	seen := make(map[string]bool)
+	hits, misses := 0, 0
+	for _, option := range selected {
+		option = normalizeOption(option)
+		if option == "" || seen[option] {
+			continue
+		}
+		seen[option] = true
+		if correct[option] {
+			hits++
+		} else {
+			misses++
+		}
+	}
+
+	var score float64
+	if question.PartialCredit {
+		// Each incorrect option cancels one correct one so selecting everything earns nothing
+		if hits > misses {
+			score = question.Points * float64(hits-misses) / float64(len(correct))
+		}
+	} else if hits == len(correct) && misses == 0 {
+		score = question.Points
+	}
+
+	if misses > 0 && question.NegativeMarking > 0 {
+		score -= question.Points * question.NegativeMarking
+	}
+
+	return score
+}
+
+func gradeNumeric(question model.Question, content interface{}) float64 {
+	value, ok := parseNumber(content)
+	if !ok || question.NumericAnswer == nil {
+		return 0
+	}
+	if math.Abs(value-*question.NumericAnswer) <= question.Tolerance {
+		return question.Points
+	}
+	return 0
+}
+
+func gradeTrueFalse(question model.Question, content interface{}) float64 {
+	if len(question.CorrectAnswers) == 0 {
+		return 0
+	}
+	value, ok := parseBool(content)
+	expected, valid := parseBool(question.CorrectAnswers[0])
+	if !ok || !valid {
+		return 0
+	}
+	if value == expected {
+		return question.Points
+	}
+	// Guessing a true/false question is penalized like selecting a wrong option
+	return -question.Points * question.NegativeMarking
+}
+
+// gradeMatching awards the points when every pair is matched, or a share per correct pair with partial credit
+func gradeMatching(question model.Question, content interface{}) float64 {
+	matches, ok := answerMatches(content)
+	if !ok || len(question.MatchingPairs) == 0 {
+		return 0
+	}
+
+	hits := 0
+	for _, pair := range question.MatchingPairs {
+		if matches[normalizeOption(pair.Left)] == normalizeOption(pair.Right) {
+			hits++
+		}
+	}
+	return shareOfPoints(question, hits, len(question.MatchingPairs))
+}
+
+// gradeOrdering awards the points when every option is in place, or a share per option in place with partial credit
+func gradeOrdering(question model.Question, content interface{}) float64 {
+	order, ok := answerList(content)
+	if !ok || len(question.CorrectAnswers) == 0 {
+		return 0
+	}
+
+	hits := 0
+	for i, expected := range question.CorrectAnswers {
+		if i < len(order) && normalizeOption(order[i]) == normalizeOption(expected) {
+			hits++
+		}
+	}
+	return shareOfPoints(question, hits, len(question.CorrectAnswers))
+}
+
+func gradeShortAnswer(question model.Question, content interface{}) float64 {
+	value, ok := content.(string)
+	if !ok {
+		return 0
+	}
+	for _, pattern := range question.AcceptedPatterns {
+		accepted, err := compileAcceptedPattern(pattern)
+		if err == nil && accepted.MatchString(strings.TrimSpace(value)) {
+			return question.Points
+		}
+	}
+	return 0
+}
+
+func shareOfPoints(question model.Question, hits, total int) float64 {
+	if hits == total {
+		return question.Points
+	}
+	if question.PartialCredit {
+		return question.Points * float64(hits) / float64(total)
+	}
+	return 0
+}
@@ -0,0 +1,36 @@
+package service
+
+import (
+	"fmt"
+	"slices"
+
+	"courses-service/src/model"
+)
+
+// validateAuxTeacherPermissions returns ErrInvalidPermission for an unknown permission and drops the repeated ones
+func validateAuxTeacherPermissions(permissions []model.AuxTeacherPermission) ([]model.AuxTeacherPermission, error) {
+	validated := []model.AuxTeacherPermission{}
+	for _, permission := range permissions {
+		if !slices.Contains(model.AllAuxTeacherPermissions, permission) {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidPermission, permission)
+		}
+		if !slices.Contains(validated, permission) {
+			validated = append(validated, permission)
+		}
+	}
+	return validated, nil
+}
+
+// authorizeTeacher returns ErrUnauthorized if the user is not a teacher of the course, or is an aux teacher
+// without the permissions the action needs. The reads need no permission.
+func authorizeTeacher(course *model.Course, teacherUUID string, permissions ...model.AuxTeacherPermission) error {
+	if !course.IsTeacher(teacherUUID) {
+		return ErrUnauthorized
+	}
+	for _, permission := range permissions {
+		if !course.TeacherCan(teacherUUID, permission) {
+			return fmt.Errorf("%w: the aux teacher doesn't have the %s permission", ErrUnauthorized, permission)
+		}
+	}
+	return nil
+}
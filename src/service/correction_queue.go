@@ -0,0 +1,314 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+)
+
+const (
+	defaultCorrectionMaxAttempts  = 5
+	defaultCorrectionConcurrency  = 2
+	defaultCorrectionBaseBackoff  = 30 * time.Second
+	defaultCorrectionMaxBackoff   = 30 * time.Minute
+	defaultCorrectionPollInterval = 5 * time.Second
+	defaultCorrectionLockDuration = 10 * time.Minute
+)
+
+// CorrectionSettings configures the retries and the concurrency of the background AI correction
+type CorrectionSettings struct {
+	MaxAttempts  int
+	Concurrency  int           // Jobs processed at the same time
+	BaseBackoff  time.Duration // Wait before the first retry, doubled on every retry
+	MaxBackoff   time.Duration
+	PollInterval time.Duration // How often the workers look for jobs ready to run
+	LockDuration time.Duration // A running job not finished by then is considered lost and retried
+}
+
+// NewCorrectionSettings reads the correction settings from the config, using the defaults for the missing values
+func NewCorrectionSettings(config *config.Config) CorrectionSettings {
+	settings := CorrectionSettings{
+		MaxAttempts:  defaultCorrectionMaxAttempts,
+		Concurrency:  defaultCorrectionConcurrency,
+		BaseBackoff:  defaultCorrectionBaseBackoff,
+		MaxBackoff:   defaultCorrectionMaxBackoff,
+		PollInterval: defaultCorrectionPollInterval,
+		LockDuration: defaultCorrectionLockDuration,
+	}
+	if maxAttempts, err := strconv.Atoi(config.CorrectionMaxAttempts); err == nil && maxAttempts > 0 {
+		settings.MaxAttempts = maxAttempts
+	}
+	if concurrency, err := strconv.Atoi(config.CorrectionConcurrency); err == nil && concurrency > 0 {
+		settings.Concurrency = concurrency
+	}
+	return settings
+}
+
+// CorrectionNotifier announces new correction jobs to the workers, e.g. publishing them to RabbitMQ
+type CorrectionNotifier interface {
+	NotifyCorrectionJob(jobID string) error
+}
+
+// CorrectionQueue keeps the AI correction jobs in Mongo and moves them through their states
+type CorrectionQueue struct {
+	jobRepo  repository.CorrectionJobRepositoryInterface
+	notifier CorrectionNotifier
+	settings CorrectionSettings
+	ready    chan struct{}
+}
+
+func NewCorrectionQueue(jobRepo repository.CorrectionJobRepositoryInterface, notifier CorrectionNotifier, settings CorrectionSettings) *CorrectionQueue {
+	return &CorrectionQueue{
+		jobRepo:  jobRepo,
+		notifier: notifier,
+		settings: settings,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// Enqueue creates a pending correction job for the submission
+func (q *CorrectionQueue) Enqueue(ctx context.Context, submission *model.Submission) (*model.CorrectionJob, error) {
+	now := time.Now()
+	job := &model.CorrectionJob{
+		SubmissionID: submission.ID.Hex(),
+		AssignmentID: submission.AssignmentID,
+		StudentUUID:  submission.StudentUUID,
+		Status:       model.CorrectionJobPending,
+		MaxAttempts:  q.settings.MaxAttempts,
+		NextRunAt:    now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+	if err := q.jobRepo.Create(ctx, job); err != nil {
+		return nil, err
+	}
+
+	// The job is stored, if the notification is lost the workers still find it when polling
+	if q.notifier != nil {
+		if err := q.notifier.NotifyCorrectionJob(job.ID.Hex()); err != nil {
+			log.Printf("error notifying correction job %s: %v", job.ID.Hex(), err)
+		}
+	} else {
+		q.signalReady()
+	}
+	return job, nil
+}
+
+// GetLatestJob returns the last correction job of the submission, nil if it was never queued
+func (q *CorrectionQueue) GetLatestJob(ctx context.Context, submissionID string) (*model.CorrectionJob, error) {
+	return q.jobRepo.GetLatestBySubmission(ctx, submissionID)
+}
+
+func (q *CorrectionQueue) claimNext(ctx context.Context) (*model.CorrectionJob, error) {
+	now := time.Now()
+	return q.jobRepo.ClaimNext(ctx, now, now.Add(q.settings.LockDuration))
+}
+
+func (q *CorrectionQueue) claimByID(ctx context.Context, jobID string) (*model.CorrectionJob, error) {
+	now := time.Now()
+	return q.jobRepo.ClaimByID(ctx, jobID, now, now.Add(q.settings.LockDuration))
+}
+
+// finish records the result of an attempt. Failed attempts are retried with exponential backoff
+// until the job runs out of attempts. It returns true when the job failed for good.
+func (q *CorrectionQueue) finish(ctx context.Context, job *model.CorrectionJob, result error) (bool, error) {
+	now := time.Now()
+	job.LockedUntil = nil
+	job.UpdatedAt = now
+
+	switch {
+	case result == nil:
+		job.Status = model.CorrectionJobDone
+		job.LastError = ""
+		job.CompletedAt = &now
+	case job.Attempts >= job.MaxAttempts || !retryableCorrectionError(result):
+		job.Status = model.CorrectionJobFailed
+		job.LastError = result.Error()
+		job.CompletedAt = &now
+	default:
+		job.Status = model.CorrectionJobPending
+		job.LastError = result.Error()
+		job.NextRunAt = now.Add(q.backoff(job.Attempts))
+	}
+
+	updated, err := q.jobRepo.Update(ctx, job)
+	if err != nil {
+		return false, err
+	}
+	if !updated {
+		return false, ErrCorrectionLockLost
+	}
+	return job.Status == model.CorrectionJobFailed, nil
+}
+
+type correctionClaimKey struct{}
+
+// withClaim keeps the job claimed by the worker in the context, for the corrector to check the claim
+func (q *CorrectionQueue) withClaim(ctx context.Context, job *model.CorrectionJob) context.Context {
+	return context.WithValue(ctx, correctionClaimKey{}, func(ctx context.Context) (bool, error) {
+		return q.jobRepo.HoldsClaim(ctx, job)
+	})
+}
+
+// CheckCorrectionClaim returns ErrCorrectionLockLost when the lock of the correction job of the context expired
+// and another worker claimed the job since, so only one worker saves its correction. Outside of a correction
+// job it always passes.
+func CheckCorrectionClaim(ctx context.Context) error {
+	holdsClaim, ok := ctx.Value(correctionClaimKey{}).(func(context.Context) (bool, error))
+	if !ok {
+		return nil
+	}
+	held, err := holdsClaim(ctx)
+	if err != nil {
+		return err
+	}
+	if !held {
+		return ErrCorrectionLockLost
+	}
+	return nil
+}
+
+// backoff returns the wait before the next attempt, doubling the base backoff after every failed attempt
+func (q *CorrectionQueue) backoff(attempts int) time.Duration {
+	backoff := q.settings.BaseBackoff
+	for i := 1; i < attempts && backoff < q.settings.MaxBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, q.settings.MaxBackoff)
+}
+
+func (q *CorrectionQueue) signalReady() {
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+}
+
+// retryableCorrectionError tells apart temporary failures from submissions that can never be corrected
+func retryableCorrectionError(err error) bool {
+	return !errors.Is(err, ErrSubmissionNotFound) && !errors.Is(err, ErrAssignmentNotFound)
+}
+
+// SubmissionCorrector corrects the submissions taken from the queue. AutoCorrectSubmission calls
+// CheckCorrectionClaim before saving the correction.
+type SubmissionCorrector interface {
+	AutoCorrectSubmission(ctx context.Context, submissionID string) error
+	MarkCorrectionFailed(ctx context.Context, submissionID string) error
+}
+
+// CorrectionWorker processes the jobs of the correction queue, at most settings.Concurrency at a time
+type CorrectionWorker struct {
+	queue     *CorrectionQueue
+	corrector SubmissionCorrector
+	slots     chan struct{}
+	running   sync.WaitGroup
+}
+
+func NewCorrectionWorker(queue *CorrectionQueue, corrector SubmissionCorrector) *CorrectionWorker {
+	return &CorrectionWorker{
+		queue:     queue,
+		corrector: corrector,
+		slots:     make(chan struct{}, max(queue.settings.Concurrency, 1)),
+	}
+}
+
+// Run polls the queue for jobs ready to run until the context is done, then waits for the running jobs.
+// Polling also picks up the retries and the jobs of a worker that crashed.
+func (w *CorrectionWorker) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.queue.settings.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		w.startReadyJobs(ctx)
+
+		select {
+		case <-ctx.Done():
+			w.running.Wait()
+			return
+		case <-ticker.C:
+		case <-w.queue.ready:
+		}
+	}
+}
+
+// ProcessJob runs the given job if it is ready, waiting for a free slot. Used by the RabbitMQ consumer.
+func (w *CorrectionWorker) ProcessJob(ctx context.Context, jobID string) error {
+	select {
+	case w.slots <- struct{}{}:
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	defer w.release()
+
+	job, err := w.queue.claimByID(ctx, jobID)
+	if err != nil || job == nil {
+		// The job was already taken by another worker or is waiting for a retry
+		return err
+	}
+	w.process(ctx, job)
+	return nil
+}
+
+// startReadyJobs claims jobs while there are free slots and runs each one in its own goroutine
+func (w *CorrectionWorker) startReadyJobs(ctx context.Context) {
+	for {
+		select {
+		case w.slots <- struct{}{}:
+		default:
+			return
+		}
+
+		job, err := w.queue.claimNext(ctx)
+		if err != nil || job == nil {
+			if err != nil {
+				log.Printf("error claiming correction job: %v", err)
+			}
+			<-w.slots
+			return
+		}
+
+		w.running.Add(1)
+		go func() {
+			defer w.running.Done()
+			defer w.release()
+			w.process(ctx, job)
+		}()
+	}
+}
+
+func (w *CorrectionWorker) process(ctx context.Context, job *model.CorrectionJob) {
+	result := w.corrector.AutoCorrectSubmission(w.queue.withClaim(ctx, job), job.SubmissionID)
+	if errors.Is(result, ErrCorrectionLockLost) {
+		// The lock expired and another worker corrects the submission now, this result is dropped
+		log.Printf("correction job %s was claimed by another worker, dropping its result", job.ID.Hex())
+		return
+	}
+	if result != nil {
+		log.Printf("error correcting submission %s (attempt %d of %d): %v", job.SubmissionID, job.Attempts, job.MaxAttempts, result)
+	}
+
+	// A job claimed again by another worker is left to it
+	failed, err := w.queue.finish(ctx, job, result)
+	if err != nil {
+		log.Printf("error updating correction job %s: %v", job.ID.Hex(), err)
+		return
+	}
+	if failed && retryableCorrectionError(result) {
+		if err := w.corrector.MarkCorrectionFailed(ctx, job.SubmissionID); err != nil {
+			log.Printf("error marking submission %s for manual review: %v", job.SubmissionID, err)
+		}
+	}
+}
+
+// release frees a slot and wakes the poller, a finished job may leave room for another one
+func (w *CorrectionWorker) release() {
+	<-w.slots
+	w.queue.signalReady()
+}
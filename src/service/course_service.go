@@ -20,7 +20,7 @@ func NewCourseService(courseRepository repository.CourseRepositoryInterface, enr
 }
 
 func (s *CourseService) GetCourses() ([]*model.Course, error) {
-	return s.courseRepository.GetCourses()
+	return visibleCourses(s.courseRepository.GetCourses())
 }
 
 func (s *CourseService) CreateCourse(c schemas.CreateCourseRequest) (*model.Course, error) {
@@ -28,11 +28,16 @@ func (s *CourseService) CreateCourse(c schemas.CreateCourseRequest) (*model.Cour
 		return nil, errors.New("capacity must be greater than 0")
 	}
 	//TODO: check teacher exists
+	language := c.Language
+	if language == "" {
+		language = model.DefaultCourseLanguage
+	}
 	course := model.Course{
 		Title:       c.Title,
 		Description: c.Description,
 		TeacherUUID: c.TeacherID,
 		Capacity:    c.Capacity,
+		Language:    language,
 		Modules:     []model.Module{},
 		AuxTeachers: []string{},
 		Feedback:    []model.CourseFeedback{},
@@ -72,14 +77,14 @@ func (s *CourseService) GetCourseByTeacherId(teacherId string) ([]*model.Course,
 	if teacherId == "" {
 		return nil, errors.New("teacherId is required")
 	}
-	return s.courseRepository.GetCourseByTeacherId(teacherId)
+	return visibleCourses(s.courseRepository.GetCourseByTeacherId(teacherId))
 }
 
 func (s *CourseService) GetCoursesByStudentId(studentId string) ([]*model.Course, error) {
 	if studentId == "" {
 		return nil, errors.New("studentId is required")
 	}
-	return s.courseRepository.GetCoursesByStudentId(studentId)
+	return visibleCourses(s.courseRepository.GetCoursesByStudentId(studentId))
 }
 
 func (s *CourseService) GetCoursesByUserId(userId string) (*schemas.GetCoursesByUserIdResponse, error) {
@@ -88,18 +93,18 @@ func (s *CourseService) GetCoursesByUserId(userId string) (*schemas.GetCoursesBy
 	}
 	result := schemas.GetCoursesByUserIdResponse{}
 
-	studentCourses, err := s.courseRepository.GetCoursesByStudentId(userId)
+	studentCourses, err := visibleCourses(s.courseRepository.GetCoursesByStudentId(userId))
 	if err != nil {
 		return nil, err
 	}
 
-	teacherCourses, err := s.courseRepository.GetCourseByTeacherId(userId)
+	teacherCourses, err := visibleCourses(s.courseRepository.GetCourseByTeacherId(userId))
 	if err != nil {
 		return nil, err
 	}
 
 	fmt.Printf("ID: %v\n", userId)
-	auxTeacherCourses, err := s.courseRepository.GetCoursesByAuxTeacherId(userId)
+	auxTeacherCourses, err := visibleCourses(s.courseRepository.GetCoursesByAuxTeacherId(userId))
 	if err != nil {
 		return nil, err
 	}
@@ -118,7 +123,18 @@ func (s *CourseService) GetCourseByTitle(title string) ([]*model.Course, error)
 	if title == "" {
 		return nil, errors.New("title is required")
 	}
-	return s.courseRepository.GetCourseByTitle(title)
+	return visibleCourses(s.courseRepository.GetCourseByTitle(title))
+}
+
+// visibleCourses drops the suspended courses from the listings, only the admins access them from the
+// backoffice. The archived courses are still listed, they can be read.
+func visibleCourses(courses []*model.Course, err error) ([]*model.Course, error) {
+	if err != nil {
+		return nil, err
+	}
+	return slices.DeleteFunc(courses, func(course *model.Course) bool {
+		return course.CurrentStatus() == model.CourseStatusSuspended
+	}), nil
 }
 
 func (s *CourseService) UpdateCourse(id string, updateCourseRequest schemas.UpdateCourseRequest) (*model.Course, error) {
@@ -134,16 +150,26 @@ func (s *CourseService) UpdateCourse(id string, updateCourseRequest schemas.Upda
 		return nil, errors.New("the user trying to update the course is not the owner of the course")
 	}
 	courseToUpdate := model.Course{
-		Title:       updateCourseRequest.Title,
-		Description: updateCourseRequest.Description,
-		TeacherUUID: updateCourseRequest.TeacherID,
-		Capacity:    updateCourseRequest.Capacity,
-		UpdatedAt:   time.Now(),
+		Title:              updateCourseRequest.Title,
+		Description:        updateCourseRequest.Description,
+		TeacherUUID:        updateCourseRequest.TeacherID,
+		Capacity:           updateCourseRequest.Capacity,
+		Language:           updateCourseRequest.Language,
+		UpdatedAt:          time.Now(),
+		ForumAiSuggestions: updateCourseRequest.ForumAiSuggestions,
 	}
 	return s.courseRepository.UpdateCourse(id, courseToUpdate)
 }
 
-func (s *CourseService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error) {
+// AddAuxTeacherToCourse adds an aux teacher with the given permissions, all of them when none is given
+func (s *CourseService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	if len(permissions) == 0 {
+		permissions = model.AllAuxTeacherPermissions
+	}
+	permissions, err := validateAuxTeacherPermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
 	course, err := s.courseRepository.GetCourseById(id)
 	if err != nil {
 		return nil, err
@@ -164,6 +190,10 @@ func (s *CourseService) AddAuxTeacherToCourse(id string, titularTeacherId string
 	if enrolled {
 		return nil, errors.New("the aux teacher is already enrolled in the course")
 	}
+	if course.AuxTeacherPermissions == nil {
+		course.AuxTeacherPermissions = map[string][]model.AuxTeacherPermission{}
+	}
+	course.AuxTeacherPermissions[auxTeacherId] = permissions
 	return s.courseRepository.AddAuxTeacherToCourse(course, auxTeacherId)
 }
 
@@ -191,6 +221,25 @@ func (s *CourseService) RemoveAuxTeacherFromCourse(id string, titularTeacherId s
 	return s.courseRepository.RemoveAuxTeacherFromCourse(course, auxTeacherId)
 }
 
+// UpdateAuxTeacherPermissions replaces the permissions of an aux teacher, without any the aux teacher can only read the course
+func (s *CourseService) UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	permissions, err := validateAuxTeacherPermissions(permissions)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.courseRepository.GetCourseById(id)
+	if err != nil {
+		return nil, err
+	}
+	if course.TeacherUUID != titularTeacherId {
+		return nil, errors.New("the teacher trying to update the aux teacher permissions is not the owner of the course")
+	}
+	if !slices.Contains(course.AuxTeachers, auxTeacherId) {
+		return nil, errors.New("aux teacher is not assigned to this course")
+	}
+	return s.courseRepository.UpdateAuxTeacherPermissions(course, auxTeacherId, permissions)
+}
+
 func (s *CourseService) GetFavouriteCourses(studentId string) ([]*model.Course, error) {
 	if studentId == "" {
 		return nil, errors.New("studentId is required")
@@ -201,7 +250,7 @@ func (s *CourseService) GetFavouriteCourses(studentId string) ([]*model.Course,
 		return nil, err
 	}
 
-	courses, err := s.courseRepository.GetCoursesByStudentId(studentId)
+	courses, err := visibleCourses(s.courseRepository.GetCoursesByStudentId(studentId))
 	if err != nil {
 		return nil, err
 	}
@@ -6,7 +6,6 @@ import (
 	"courses-service/src/repository"
 	"courses-service/src/schemas"
 	"fmt"
-	"slices"
 	"strings"
 	"time"
 
@@ -64,6 +63,10 @@ func (s *EnrollmentService) EnrollStudent(studentID, courseID string) error {
 		return fmt.Errorf("teacher %s cannot enroll in course %s", studentID, courseID)
 	}
 
+	if course.CurrentStatus() != model.CourseStatusActive {
+		return fmt.Errorf("%w: course %s doesn't take enrollments", ErrCourseNotActive, courseID)
+	}
+
 	// Check if student has an existing enrollment (active or dropped)
 	existingEnrollment, err := s.enrollmentRepository.GetEnrollmentByStudentIdAndCourseId(studentID, courseID)
 	if err != nil && err != mongo.ErrNoDocuments {
@@ -142,9 +145,9 @@ func (s *EnrollmentService) UnenrollStudent(studentID, courseID string) error {
 		return fmt.Errorf("student %s is not enrolled in course %s", studentID, courseID)
 	}
 
-	// Use DisapproveStudent with the default reason for self-unenrollment
+	// Drop the student like DisapproveStudent with the default reason for self-unenrollment
 	defaultReason := "Te diste de baja del curso"
-	err = s.DisapproveStudent(studentID, courseID, defaultReason)
+	err = s.dropStudent(studentID, courseID, defaultReason)
 	if err != nil {
 		return fmt.Errorf("error unenrolling student %s from course %s: %v", studentID, courseID, err)
 	}
@@ -240,9 +243,12 @@ func (s *EnrollmentService) CreateStudentFeedback(feedbackRequest schemas.Create
 		return fmt.Errorf("error getting course by ID: %v", err)
 	}
 
-	if course.TeacherUUID != feedbackRequest.TeacherUUID && !slices.Contains(course.AuxTeachers, feedbackRequest.TeacherUUID) {
+	if !course.IsTeacher(feedbackRequest.TeacherUUID) {
 		return fmt.Errorf("teacher %s is not the teacher or aux teacher of course %s", feedbackRequest.TeacherUUID, feedbackRequest.CourseID)
 	}
+	if err := authorizeTeacher(course, feedbackRequest.TeacherUUID, model.PermissionGradeSubmissions); err != nil {
+		return err
+	}
 
 	feedback := model.StudentFeedback{
 		StudentUUID:  feedbackRequest.StudentUUID,
@@ -272,11 +278,25 @@ func (s *EnrollmentService) GetFeedbackByStudentId(studentID string, getFeedback
 		return nil, fmt.Errorf("error getting feedback by student ID: %v", err)
 	}
 
-	return feedback, nil
+	// The feedback of the suspended courses is left out, only the admins access them from the backoffice
+	suspended := make(map[string]bool)
+	visible := make([]*model.StudentFeedback, 0, len(feedback))
+	for _, f := range feedback {
+		isSuspended, checked := suspended[f.CourseID]
+		if !checked {
+			course, err := s.courseRepository.GetCourseById(f.CourseID)
+			isSuspended = err == nil && course != nil && course.CurrentStatus() == model.CourseStatusSuspended
+			suspended[f.CourseID] = isSuspended
+		}
+		if !isSuspended {
+			visible = append(visible, f)
+		}
+	}
+	return visible, nil
 }
 
 // ApproveStudent approves a student by changing their enrollment status to completed
-func (s *EnrollmentService) ApproveStudent(studentID, courseID string) error {
+func (s *EnrollmentService) ApproveStudent(studentID, courseID, teacherUUID string) error {
 	if strings.TrimSpace(studentID) == "" {
 		return fmt.Errorf("student ID is required")
 	}
@@ -292,11 +312,14 @@ func (s *EnrollmentService) ApproveStudent(studentID, courseID string) error {
 		return fmt.Errorf("enrollment repository is not available")
 	}
 
-	// Validate that the course exists
-	_, err := s.courseRepository.GetCourseById(courseID)
+	// Validate that the course exists and the teacher can approve its students
+	course, err := s.courseRepository.GetCourseById(courseID)
 	if err != nil {
 		return fmt.Errorf("course not found: %v", err)
 	}
+	if err := authorizeTeacher(course, teacherUUID, model.PermissionApproveStudents); err != nil {
+		return err
+	}
 
 	// Approve the student in the repository
 	err = s.enrollmentRepository.ApproveStudent(studentID, courseID)
@@ -308,7 +331,7 @@ func (s *EnrollmentService) ApproveStudent(studentID, courseID string) error {
 }
 
 // DisapproveStudent disapproves a student by changing their enrollment status to dropped with a reason
-func (s *EnrollmentService) DisapproveStudent(studentID, courseID, reason string) error {
+func (s *EnrollmentService) DisapproveStudent(studentID, courseID, teacherUUID, reason string) error {
 	if strings.TrimSpace(studentID) == "" {
 		return fmt.Errorf("student ID is required")
 	}
@@ -327,12 +350,20 @@ func (s *EnrollmentService) DisapproveStudent(studentID, courseID, reason string
 		return fmt.Errorf("enrollment repository is not available")
 	}
 
-	// Validate that the course exists
-	_, err := s.courseRepository.GetCourseById(courseID)
+	// Validate that the course exists and the teacher can disapprove its students
+	course, err := s.courseRepository.GetCourseById(courseID)
 	if err != nil {
 		return fmt.Errorf("course not found: %v", err)
 	}
+	if err := authorizeTeacher(course, teacherUUID, model.PermissionApproveStudents); err != nil {
+		return err
+	}
+
+	return s.dropStudent(studentID, courseID, reason)
+}
 
+// dropStudent changes the status of an active enrollment to dropped with a reason
+func (s *EnrollmentService) dropStudent(studentID, courseID, reason string) error {
 	// Check if student is enrolled
 	enrollment, err := s.enrollmentRepository.GetEnrollmentByStudentIdAndCourseId(studentID, courseID)
 	if err != nil {
@@ -4,8 +4,53 @@ import "errors"
 
 var (
 	// ... existing code ...
-	ErrSubmissionNotFound = errors.New("submission not found")
-	ErrAssignmentNotFound = errors.New("assignment not found")
-	ErrUnauthorized       = errors.New("unauthorized access")
-	ErrLateSubmission     = errors.New("submission is past due date")
+	ErrSubmissionNotFound         = errors.New("submission not found")
+	ErrAssignmentNotFound         = errors.New("assignment not found")
+	ErrUnauthorized               = errors.New("unauthorized access")
+	ErrLateSubmission             = errors.New("submission is past due date")
+	ErrInvalidAnswerGrade         = errors.New("invalid answer grade")
+	ErrMaxAttemptsReached         = errors.New("maximum number of attempts reached")
+	ErrAttemptInProgress          = errors.New("there is an attempt in progress")
+	ErrAlreadySubmitted           = errors.New("submission was already submitted")
+	ErrSubmissionLocked           = errors.New("submission is locked and can no longer be edited")
+	ErrExtensionNotFound          = errors.New("extension not found")
+	ErrInvalidExtension           = errors.New("invalid extension")
+	ErrExamNotAvailable           = errors.New("exam is not available at this time")
+	ErrExamTimeExpired            = errors.New("exam time has expired")
+	ErrBankQuestionNotFound       = errors.New("bank question not found")
+	ErrInvalidBankQuestion        = errors.New("invalid bank question")
+	ErrNotEnoughBankQuestions     = errors.New("not enough questions in the question bank")
+	ErrInvalidQuestion            = errors.New("invalid question")
+	ErrInvalidAnswer              = errors.New("invalid answer")
+	ErrInvalidRubric              = errors.New("invalid rubric")
+	ErrFileNotFound               = errors.New("file not found")
+	ErrInvalidFile                = errors.New("invalid file")
+	ErrFileTooLarge               = errors.New("file is too large")
+	ErrFileTypeNotAllowed         = errors.New("file type is not allowed")
+	ErrFileChecksumMismatch       = errors.New("file checksum does not match")
+	ErrInvalidFileLink            = errors.New("download link is invalid or expired")
+	ErrInvalidSimilarityThreshold = errors.New("invalid similarity threshold")
+	ErrAICorrectionFailed         = errors.New("automatic correction failed")
+	ErrCorrectionJobNotFound      = errors.New("correction job not found")
+	ErrCorrectionLockLost         = errors.New("correction job was claimed by another worker")
+	ErrInvalidDateRange           = errors.New("invalid date range")
+	ErrInvalidQuestionGeneration  = errors.New("invalid question generation request")
+	ErrAssignmentNotDraft         = errors.New("assignment is not a draft")
+	ErrForumQuestionNotFound      = errors.New("forum question not found")
+	ErrForumSuggestionsDisabled   = errors.New("AI forum suggestions are not enabled for the course")
+	ErrForumQuestionNotOpen       = errors.New("forum question is not open")
+	ErrSuggestionAlreadyPending   = errors.New("forum question already has a suggestion pending review")
+	ErrSuggestionNotFound         = errors.New("answer suggestion not found")
+	ErrInvalidTrendInterval       = errors.New("invalid trend interval, use week or month")
+	ErrCourseNotFound             = errors.New("course not found")
+	ErrModuleNotFound             = errors.New("module not found")
+	ErrInvalidPermission          = errors.New("invalid aux teacher permission")
+	ErrReasonRequired             = errors.New("a reason is required")
+	ErrInvalidCourseStatus        = errors.New("the course already has that status")
+	ErrCourseNotActive            = errors.New("course is suspended or archived")
+	ErrInvalidTeacherReassignment = errors.New("invalid teacher reassignment")
+	ErrStudentNotEnrolled         = errors.New("student is not enrolled in the course")
+	ErrForumAnswerNotFound        = errors.New("forum answer not found")
+	ErrUserNotBlocked             = errors.New("user is not blocked")
+	ErrInvalidUserBlock           = errors.New("invalid user block")
 )
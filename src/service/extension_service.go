@@ -0,0 +1,159 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+type ExtensionService struct {
+	extensionRepo  repository.ExtensionRepositoryInterface
+	assignmentRepo repository.AssignmentRepositoryInterface
+	courseService  CourseServiceInterface
+}
+
+func NewExtensionService(extensionRepo repository.ExtensionRepositoryInterface, assignmentRepo repository.AssignmentRepositoryInterface, courseService CourseServiceInterface) *ExtensionService {
+	return &ExtensionService{
+		extensionRepo:  extensionRepo,
+		assignmentRepo: assignmentRepo,
+		courseService:  courseService,
+	}
+}
+
+// CreateExtension grants a new due date for the assignment to the given students
+func (s *ExtensionService) CreateExtension(ctx context.Context, assignmentID, teacherUUID string, request schemas.CreateExtensionRequest) (*model.DeadlineExtension, error) {
+	assignment, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID, model.PermissionCreateAssignments)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validateExtension(assignment, request.StudentUUIDs, request.DueDate); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	extension := &model.DeadlineExtension{
+		AssignmentID: assignmentID,
+		CourseID:     assignment.CourseID,
+		StudentUUIDs: request.StudentUUIDs,
+		GroupName:    request.GroupName,
+		DueDate:      request.DueDate,
+		Reason:       request.Reason,
+		GrantedBy:    teacherUUID,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	if err := s.extensionRepo.Create(ctx, extension); err != nil {
+		return nil, err
+	}
+	return extension, nil
+}
+
+// GetExtensionsByAssignment returns every extension granted for the assignment
+func (s *ExtensionService) GetExtensionsByAssignment(ctx context.Context, assignmentID, teacherUUID string) ([]model.DeadlineExtension, error) {
+	if _, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID); err != nil {
+		return nil, err
+	}
+	return s.extensionRepo.GetByAssignment(ctx, assignmentID)
+}
+
+// UpdateExtension changes the students, due date or reason of an extension
+func (s *ExtensionService) UpdateExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string, request schemas.UpdateExtensionRequest) (*model.DeadlineExtension, error) {
+	assignment, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID, model.PermissionCreateAssignments)
+	if err != nil {
+		return nil, err
+	}
+
+	extension, err := s.getExtension(ctx, assignmentID, extensionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(request.StudentUUIDs) > 0 {
+		extension.StudentUUIDs = request.StudentUUIDs
+	}
+	if request.GroupName != "" {
+		extension.GroupName = request.GroupName
+	}
+	if !request.DueDate.IsZero() {
+		extension.DueDate = request.DueDate
+	}
+	if request.Reason != "" {
+		extension.Reason = request.Reason
+	}
+
+	if err := validateExtension(assignment, extension.StudentUUIDs, extension.DueDate); err != nil {
+		return nil, err
+	}
+
+	extension.UpdatedAt = time.Now()
+	if err := s.extensionRepo.Update(ctx, extension); err != nil {
+		return nil, err
+	}
+	return extension, nil
+}
+
+// DeleteExtension revokes an extension, the students go back to the assignment due date
+func (s *ExtensionService) DeleteExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string) error {
+	if _, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID, model.PermissionCreateAssignments); err != nil {
+		return err
+	}
+
+	if _, err := s.getExtension(ctx, assignmentID, extensionID); err != nil {
+		return err
+	}
+
+	return s.extensionRepo.Delete(ctx, extensionID)
+}
+
+// getAssignmentForTeacher returns the assignment if the teacher is the titular or an auxiliary teacher of its course
+// with the permissions
+func (s *ExtensionService) getAssignmentForTeacher(ctx context.Context, assignmentID, teacherUUID string, permissions ...model.AuxTeacherPermission) (*model.Assignment, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	course, err := s.courseService.GetCourseById(assignment.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, errors.New("course not found")
+	}
+
+	if err := authorizeTeacher(course, teacherUUID, permissions...); err != nil {
+		return nil, err
+	}
+	return assignment, nil
+}
+
+func (s *ExtensionService) getExtension(ctx context.Context, assignmentID, extensionID string) (*model.DeadlineExtension, error) {
+	extension, err := s.extensionRepo.GetByID(ctx, extensionID)
+	if err != nil {
+		return nil, err
+	}
+	if extension == nil || extension.AssignmentID != assignmentID {
+		return nil, ErrExtensionNotFound
+	}
+	return extension, nil
+}
+
+func validateExtension(assignment *model.Assignment, studentUUIDs []string, dueDate time.Time) error {
+	if len(studentUUIDs) == 0 {
+		return fmt.Errorf("%w: at least one student is required", ErrInvalidExtension)
+	}
+	if !dueDate.After(assignment.DueDate) {
+		return fmt.Errorf("%w: due date must be after the assignment due date", ErrInvalidExtension)
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"slices"
+	"strconv"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+const (
+	defaultFeedbackBatchSize = 20
+	maxPeriodTopics          = 3 // Topics shown in each period of the trends
+)
+
+// FeedbackAnalysisSettings holds how many feedbacks are sent to the model in a single call
+type FeedbackAnalysisSettings struct {
+	BatchSize int
+}
+
+// NewFeedbackAnalysisSettings reads the batch size from the config, with a default for missing or invalid values
+func NewFeedbackAnalysisSettings(config *config.Config) FeedbackAnalysisSettings {
+	settings := FeedbackAnalysisSettings{BatchSize: defaultFeedbackBatchSize}
+	if size, err := strconv.Atoi(config.AiFeedbackBatchSize); err == nil && size > 0 {
+		settings.BatchSize = size
+	}
+	return settings
+}
+
+// FeedbackAnalyticsService extracts the sentiment and topics of the course feedback and aggregates them over time.
+// The feedback is analyzed with the AI in batches in background and the analyses are stored, the feedback
+// the AI couldn't analyze yet is classified by keywords when the trends are requested.
+type FeedbackAnalyticsService struct {
+	courseRepo   repository.CourseRepositoryInterface
+	analysisRepo repository.FeedbackAnalysisRepositoryInterface
+	aiClient     ai.Provider
+	settings     FeedbackAnalysisSettings
+}
+
+func NewFeedbackAnalyticsService(
+	courseRepo repository.CourseRepositoryInterface,
+	analysisRepo repository.FeedbackAnalysisRepositoryInterface,
+	aiClient ai.Provider,
+	settings FeedbackAnalysisSettings,
+) *FeedbackAnalyticsService {
+	return &FeedbackAnalyticsService{
+		courseRepo:   courseRepo,
+		analysisRepo: analysisRepo,
+		aiClient:     aiClient,
+		settings:     settings,
+	}
+}
+
+// AnalyzePendingFeedback sends the feedback of the course without a stored analysis to the AI and returns how many
+// were analyzed. The batches the model answers with an invalid analysis are stored with the keyword analysis so
+// they are not retried forever, any other error leaves the rest of the feedback pending for the next run.
+func (s *FeedbackAnalyticsService) AnalyzePendingFeedback(ctx context.Context, courseID string) (int, error) {
+	course, err := s.getCourse(courseID)
+	if err != nil {
+		return 0, err
+	}
+	stored, err := s.analysisRepo.GetByCourse(ctx, courseID)
+	if err != nil {
+		return 0, err
+	}
+
+	analyzed := make(map[string]bool, len(stored))
+	for _, analysis := range stored {
+		analyzed[analysis.FeedbackID] = true
+	}
+	var pending []*model.CourseFeedback
+	for i := range course.Feedback {
+		feedback := &course.Feedback[i]
+		if !feedback.ID.IsZero() && !analyzed[feedback.ID.Hex()] {
+			pending = append(pending, feedback)
+		}
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+	if s.aiClient == nil {
+		return 0, ai.ErrUnavailable
+	}
+
+	scope := ai.Scope{CourseID: courseID, TeacherUUID: course.TeacherUUID, Language: course.Language}
+	count := 0
+	for batch := range slices.Chunk(pending, s.settings.BatchSize) {
+		var analyses []model.FeedbackAnalysis
+		response, err := s.aiClient.AnalyzeFeedbacks(ai.WithScope(ctx, scope), batch)
+		switch {
+		case errors.Is(err, ai.ErrInvalidFeedbackAnalysis):
+			log.Printf("Analyzing %d feedbacks of course %s by keywords: %v", len(batch), courseID, err)
+			for _, feedback := range batch {
+				analyses = append(analyses, analyzeFeedbackKeywords(courseID, feedback))
+			}
+		case err != nil:
+			return count, err
+		default:
+			analyses = response.Analyses
+		}
+
+		for i := range analyses {
+			analyses[i].CourseID = courseID
+		}
+		if err := s.analysisRepo.SaveMany(ctx, analyses); err != nil {
+			return count, err
+		}
+		count += len(analyses)
+	}
+	return count, nil
+}
+
+// AnalyzeAllCourses analyzes the pending feedback of every course. A course that fails doesn't stop the
+// others unless the AI is unavailable, then the rest waits for the next run.
+func (s *FeedbackAnalyticsService) AnalyzeAllCourses(ctx context.Context) error {
+	courses, err := s.courseRepo.GetCourses()
+	if err != nil {
+		return err
+	}
+	for _, course := range courses {
+		if _, err := s.AnalyzePendingFeedback(ctx, course.ID.Hex()); err != nil {
+			if errors.Is(err, ai.ErrUnavailable) {
+				return err
+			}
+			log.Printf("error analyzing feedback of course %s: %v", course.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// RunAnalyzer analyzes the pending feedback of every course each interval until the context is done
+func (s *FeedbackAnalyticsService) RunAnalyzer(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.AnalyzeAllCourses(ctx); err != nil {
+				log.Printf("error analyzing course feedback: %v", err)
+			}
+		}
+	}
+}
+
+// feedbackEntry is a feedback with its analysis, stored or by keywords
+type feedbackEntry struct {
+	feedback *model.CourseFeedback
+	analysis model.FeedbackAnalysis
+}
+
+// GetFeedbackTrends aggregates the sentiment and topics of the feedback of the course written between from and to,
+// both optional and inclusive, in total and by week or month. The interval is a month when not given.
+func (s *FeedbackAnalyticsService) GetFeedbackTrends(ctx context.Context, courseID string, from, to time.Time, interval schemas.FeedbackTrendInterval) (*schemas.FeedbackTrends, error) {
+	if interval == "" {
+		interval = schemas.FeedbackTrendMonth
+	}
+	if interval != schemas.FeedbackTrendWeek && interval != schemas.FeedbackTrendMonth {
+		return nil, ErrInvalidTrendInterval
+	}
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidDateRange)
+	}
+
+	course, err := s.getCourse(courseID)
+	if err != nil {
+		return nil, err
+	}
+	stored, err := s.analysisRepo.GetByCourse(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+	analyses := make(map[string]model.FeedbackAnalysis, len(stored))
+	for _, analysis := range stored {
+		analyses[analysis.FeedbackID] = analysis
+	}
+
+	trends := &schemas.FeedbackTrends{Interval: interval, Topics: []schemas.TopicTrend{}, Periods: []schemas.FeedbackPeriodTrend{}}
+	var entries []feedbackEntry
+	for i := range course.Feedback {
+		feedback := &course.Feedback[i]
+		if (!from.IsZero() && feedback.CreatedAt.Before(from)) || (!to.IsZero() && !feedback.CreatedAt.Before(to.AddDate(0, 0, 1))) {
+			continue
+		}
+		analysis, ok := analyses[feedback.ID.Hex()]
+		if !ok || feedback.ID.IsZero() {
+			analysis = analyzeFeedbackKeywords(courseID, feedback)
+		}
+		if analysis.Method == model.FeedbackAnalysisMethodAI {
+			trends.AnalyzedWithAI++
+		} else {
+			trends.AnalyzedWithKeywords++
+		}
+		entries = append(entries, feedbackEntry{feedback: feedback, analysis: analysis})
+	}
+
+	trends.TotalFeedbacks = len(entries)
+	trends.Sentiment = summarizeSentiment(entries)
+	trends.Topics = topicTrends(entries)
+
+	periods := make(map[time.Time][]feedbackEntry)
+	for _, entry := range entries {
+		start := periodStart(entry.feedback.CreatedAt, interval)
+		periods[start] = append(periods[start], entry)
+	}
+	starts := make([]time.Time, 0, len(periods))
+	for start := range periods {
+		starts = append(starts, start)
+	}
+	slices.SortFunc(starts, time.Time.Compare)
+	for _, start := range starts {
+		topics := topicTrends(periods[start])
+		trends.Periods = append(trends.Periods, schemas.FeedbackPeriodTrend{
+			PeriodStart: start,
+			Sentiment:   summarizeSentiment(periods[start]),
+			TopTopics:   topics[:min(len(topics), maxPeriodTopics)],
+		})
+	}
+	return trends, nil
+}
+
+func (s *FeedbackAnalyticsService) getCourse(courseID string) (*model.Course, error) {
+	course, err := s.courseRepo.GetCourseById(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, errors.New("course not found")
+	}
+	return course, nil
+}
+
+func summarizeSentiment(entries []feedbackEntry) schemas.SentimentSummary {
+	summary := schemas.SentimentSummary{Total: len(entries)}
+	var sentimentSum, ratingSum float64
+	var ratings int
+	for _, entry := range entries {
+		addSentiment(&summary.Positive, &summary.Neutral, &summary.Negative, entry.analysis.Sentiment)
+		sentimentSum += entry.analysis.SentimentScore
+		if entry.feedback.Score >= 1 && entry.feedback.Score <= 5 {
+			ratingSum += float64(entry.feedback.Score)
+			ratings++
+		}
+	}
+	if summary.Total > 0 {
+		summary.AverageSentiment = roundTwoDecimals(sentimentSum / float64(summary.Total))
+	}
+	if ratings > 0 {
+		summary.AverageRating = roundTwoDecimals(ratingSum / float64(ratings))
+	}
+	return summary
+}
+
+// topicTrends counts the mentions of each topic, the most mentioned first
+func topicTrends(entries []feedbackEntry) []schemas.TopicTrend {
+	trends := []schemas.TopicTrend{}
+	for _, topic := range model.FeedbackTopics {
+		trend := schemas.TopicTrend{Topic: topic}
+		var sentimentSum float64
+		for _, entry := range entries {
+			if slices.Contains(entry.analysis.Topics, topic) {
+				trend.Mentions++
+				addSentiment(&trend.Positive, &trend.Neutral, &trend.Negative, entry.analysis.Sentiment)
+				sentimentSum += entry.analysis.SentimentScore
+			}
+		}
+		if trend.Mentions > 0 {
+			trend.AverageSentiment = roundTwoDecimals(sentimentSum / float64(trend.Mentions))
+			trends = append(trends, trend)
+		}
+	}
+	slices.SortStableFunc(trends, func(a, b schemas.TopicTrend) int {
+		return b.Mentions - a.Mentions
+	})
+	return trends
+}
+
+func addSentiment(positive, neutral, negative *int, sentiment model.FeedbackSentiment) {
+	switch sentiment {
+	case model.FeedbackSentimentPositive:
+		*positive++
+	case model.FeedbackSentimentNegative:
+		*negative++
+	default:
+		*neutral++
+	}
+}
+
+// periodStart returns the start of the week, on Monday, or of the month of the date in UTC
+func periodStart(date time.Time, interval schemas.FeedbackTrendInterval) time.Time {
+	date = date.UTC()
+	if interval == schemas.FeedbackTrendWeek {
+		day := time.Date(date.Year(), date.Month(), date.Day(), 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+	}
+	return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+func roundTwoDecimals(value float64) float64 {
+	return math.Round(value*100) / 100
+}
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	"courses-service/src/model"
+)
+
+// Keyword analysis of the course feedback, used when the AI is not available or its answer was invalid.
+// The feedback can be written in Spanish, English or Portuguese, so the word lists mix the three languages.
+// The words are compared without accents, a stem of five letters or more matches the words starting with it
+// and shorter ones only match the whole word.
+
+const (
+	feedbackSentimentThreshold = 0.2 // Scores above it are positive and below its opposite negative
+	feedbackNegationWindow     = 3   // Words before a sentiment word where a negation inverts it
+)
+
+var feedbackTopicKeywords = map[model.FeedbackTopic][]string{
+	model.FeedbackTopicContent:      {"contenido", "conteudo", "content", "tema", "temas", "temario", "topic", "programa", "syllabus", "conocimiento", "knowledge"},
+	model.FeedbackTopicTeaching:     {"profe", "docente", "teacher", "instructor", "explica", "explain", "ensen", "ensin", "teach", "clase", "class", "aula", "aulas", "lecture"},
+	model.FeedbackTopicMaterials:    {"material", "diapositiva", "slide", "apunte", "apostila", "video", "recurso", "resource", "bibliograf", "libro", "livro", "book", "books", "lectura", "reading"},
+	model.FeedbackTopicAssignments:  {"tarea", "assignment", "homework", "entrega", "ejercicio", "exercicio", "exercise", "practic", "proyecto", "projeto", "project", "atividade", "tp", "tps"},
+	model.FeedbackTopicExams:        {"examen", "exam", "parcial", "prova", "prueba", "quiz", "test", "tests", "evaluac", "avaliac", "calificac", "grade", "grading", "nota", "notas", "correcc"},
+	model.FeedbackTopicDifficulty:   {"dificil", "dificult", "difficult", "facil", "easy", "hard", "complej", "complex", "complicad", "complicated", "confus"},
+	model.FeedbackTopicWorkload:     {"carga", "workload", "tiempo", "tempo", "time", "horas", "hours", "exigen", "demanding", "ritmo", "pace", "rapido", "fast", "sobrecarg", "overload"},
+	model.FeedbackTopicOrganization: {"organiz", "horario", "schedule", "fecha", "deadline", "plazo", "prazo", "cronograma", "comunicac", "communicat", "anuncio", "announcement", "aviso"},
+	model.FeedbackTopicPlatform:     {"plataforma", "platform", "app", "aplicac", "sitio", "site", "web", "pagina", "page", "sistema", "system", "bug", "bugs", "descarg", "download", "link", "links"},
+	model.FeedbackTopicForum:        {"foro", "foros", "forum", "consulta", "duda", "dudas", "duvida", "doubt", "pregunta", "question", "pergunta", "respuesta", "resposta", "answer"},
+}
+
+var positiveFeedbackKeywords = []string{
+	"excelen", "excellen", "buen", "bueno", "buena", "buenisim", "bom", "boa", "good", "great", "genial", "otimo", "perfect", "perfeit",
+	"claro", "clara", "clear", "util", "utiles", "useful", "helpful", "interesant", "interessant", "interest", "recomiend", "recomend", "recommend",
+	"encant", "gusto", "gusta", "gostei", "love", "enjoy", "amazing", "awesome", "aprend", "learn", "dinamic", "organizad", "ameno",
+}
+
+var negativeFeedbackKeywords = []string{
+	"mal", "malo", "malos", "mala", "malas", "bad", "ruim", "poor", "peor", "peores", "worst", "pesim", "pessim", "terrible", "horrible", "horrivel",
+	"aburrid", "boring", "chato", "confus", "desorganiz", "disorganiz", "caotic", "chaotic", "falta", "lack", "missing",
+	"problem", "frustr", "injust", "unfair", "hate", "odio", "decepcion", "disappoint", "lento", "slow",
+}
+
+var feedbackNegations = []string{"no", "nunca", "ni", "sin", "nada", "not", "never", "dont", "didnt", "doesnt", "isnt", "wasnt", "nao", "nem", "sem"}
+
+var accentReplacer = strings.NewReplacer(
+	"á", "a", "à", "a", "ã", "a", "â", "a", "é", "e", "ê", "e", "í", "i", "ó", "o", "õ", "o", "ô", "o",
+	"ú", "u", "ü", "u", "ñ", "n", "ç", "c", "'", "", "’", "",
+)
+
+// analyzeFeedbackKeywords classifies the feedback by the topic and sentiment words it contains.
+// The score given by the student counts as one more sentiment word, so it decides when the text has none.
+func analyzeFeedbackKeywords(courseID string, feedback *model.CourseFeedback) model.FeedbackAnalysis {
+	words := feedbackWords(feedback.Feedback)
+
+	topics := []model.FeedbackTopic{}
+	for _, topic := range model.FeedbackTopics {
+		for _, word := range words {
+			if matchesAnyKeyword(word, feedbackTopicKeywords[topic]) {
+				topics = append(topics, topic)
+				break
+			}
+		}
+	}
+
+	var positive, negative float64
+	for i, word := range words {
+		var polarity float64
+		switch {
+		case matchesAnyKeyword(word, positiveFeedbackKeywords):
+			polarity = 1
+		case matchesAnyKeyword(word, negativeFeedbackKeywords):
+			polarity = -1
+		default:
+			continue
+		}
+		if isNegated(words, i) {
+			polarity = -polarity
+		}
+		if polarity > 0 {
+			positive++
+		} else {
+			negative++
+		}
+	}
+
+	hits := positive + negative
+	prior := ratingSentiment(feedback.Score)
+	score := (positive - negative + prior) / (hits + 1)
+
+	return model.FeedbackAnalysis{
+		FeedbackID:        feedback.ID.Hex(),
+		CourseID:          courseID,
+		Sentiment:         sentimentFromScore(score),
+		SentimentScore:    score,
+		Topics:            topics,
+		Method:            model.FeedbackAnalysisMethodKeywords,
+		FeedbackCreatedAt: feedback.CreatedAt,
+		AnalyzedAt:        time.Now(),
+	}
+}
+
+// ratingSentiment maps the score of 1 to 5 given by the student to a sentiment between -1 and 1
+func ratingSentiment(rating int) float64 {
+	if rating < 1 || rating > 5 {
+		return 0
+	}
+	return float64(rating-3) / 2
+}
+
+func sentimentFromScore(score float64) model.FeedbackSentiment {
+	switch {
+	case score > feedbackSentimentThreshold:
+		return model.FeedbackSentimentPositive
+	case score < -feedbackSentimentThreshold:
+		return model.FeedbackSentimentNegative
+	default:
+		return model.FeedbackSentimentNeutral
+	}
+}
+
+func feedbackWords(text string) []string {
+	normalized := accentReplacer.Replace(strings.ToLower(text))
+	return strings.FieldsFunc(normalized, func(r rune) bool {
+		return !unicode.IsLetter(r)
+	})
+}
+
+func matchesAnyKeyword(word string, keywords []string) bool {
+	for _, keyword := range keywords {
+		if word == keyword || (len(keyword) >= 5 && strings.HasPrefix(word, keyword)) {
+			return true
+		}
+	}
+	return false
+}
+
+func isNegated(words []string, index int) bool {
+	for i := max(0, index-feedbackNegationWindow); i < index; i++ {
+		if slices.Contains(feedbackNegations, words[i]) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+	"courses-service/src/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	defaultFileMaxSizeMB = 20
+	defaultFileLinkTTL   = 15 * time.Minute
+)
+
+var defaultFileAllowedTypes = []string{
+	"application/pdf",
+	"application/zip", // Also docx, xlsx and pptx documents
+	"image/png",
+	"image/jpeg",
+	"image/gif",
+	"image/webp",
+	"text/plain",
+}
+
+// FileSettings holds the limits of the uploaded files and how download links are signed
+type FileSettings struct {
+	MaxSize      int64 // Bytes
+	AllowedTypes []string
+	SigningKey   []byte
+	LinkTTL      time.Duration
+}
+
+// NewFileSettings reads the file settings from the config, using the defaults for the missing values
+func NewFileSettings(config *config.Config) FileSettings {
+	settings := FileSettings{
+		MaxSize:      defaultFileMaxSizeMB << 20,
+		AllowedTypes: defaultFileAllowedTypes,
+		SigningKey:   []byte(config.FileSigningKey),
+		LinkTTL:      defaultFileLinkTTL,
+	}
+
+	if maxSizeMB, err := strconv.Atoi(config.FileMaxSizeMB); err == nil && maxSizeMB > 0 {
+		settings.MaxSize = int64(maxSizeMB) << 20
+	}
+	if config.FileAllowedTypes != "" {
+		settings.AllowedTypes = nil
+		for _, contentType := range strings.Split(config.FileAllowedTypes, ",") {
+			settings.AllowedTypes = append(settings.AllowedTypes, strings.ToLower(strings.TrimSpace(contentType)))
+		}
+	}
+	if ttlMinutes, err := strconv.Atoi(config.FileLinkTTL); err == nil && ttlMinutes > 0 {
+		settings.LinkTTL = time.Duration(ttlMinutes) * time.Minute
+	}
+	if len(settings.SigningKey) == 0 {
+		// Links signed with a random key stop working when the service restarts
+		log.Println("FILE_SIGNING_KEY not set, using a random key to sign download links")
+		settings.SigningKey = make([]byte, 32)
+		_, _ = rand.Read(settings.SigningKey)
+	}
+	return settings
+}
+
+type FileService struct {
+	fileRepo      repository.FileRepositoryInterface
+	fileStorage   storage.FileStorage
+	courseService CourseServiceInterface
+	settings      FileSettings
+}
+
+func NewFileService(fileRepo repository.FileRepositoryInterface, fileStorage storage.FileStorage, courseService CourseServiceInterface, settings FileSettings) *FileService {
+	return &FileService{
+		fileRepo:      fileRepo,
+		fileStorage:   fileStorage,
+		courseService: courseService,
+		settings:      settings,
+	}
+}
+
+// UploadFile stores a file of a course after checking its size, type and checksum.
+// Students upload answers to file questions and teachers upload module resources.
+func (s *FileService) UploadFile(ctx context.Context, userUUID string, request schemas.UploadFileRequest, name string, size int64, content io.Reader) (*schemas.FileResponse, error) {
+	if !slices.Contains(model.FilePurposeValues, request.Purpose) {
+		return nil, fmt.Errorf("%w: invalid purpose %s", ErrInvalidFile, request.Purpose)
+	}
+	if size <= 0 {
+		return nil, fmt.Errorf("%w: file is empty", ErrInvalidFile)
+	}
+	if size > s.settings.MaxSize {
+		return nil, fmt.Errorf("%w: maximum size is %d MB", ErrFileTooLarge, s.settings.MaxSize>>20)
+	}
+
+	members, err := s.courseService.GetCourseMembers(request.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	isTeacher := members.TeacherID == userUUID || slices.Contains(members.AuxTeachersIDs, userUUID)
+	switch request.Purpose {
+	case model.FilePurposeModuleResource:
+		if !isTeacher {
+			return nil, ErrUnauthorized
+		}
+	case model.FilePurposeSubmission:
+		if !isTeacher && !slices.Contains(members.StudentsIDs, userUUID) {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	// The type is detected from the content, the extension or the header sent by the client can't be trusted
+	head := make([]byte, 512)
+	n, err := io.ReadFull(content, head)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return nil, fmt.Errorf("failed to read file: %v", err)
+	}
+	head = head[:n]
+	contentType, _, _ := mime.ParseMediaType(http.DetectContentType(head))
+	if !slices.Contains(s.settings.AllowedTypes, contentType) {
+		return nil, fmt.Errorf("%w: %s", ErrFileTypeNotAllowed, contentType)
+	}
+
+	file := &model.StoredFile{
+		ID:          primitive.NewObjectID(),
+		Name:        filepath.Base(name),
+		ContentType: contentType,
+		Size:        size,
+		Purpose:     request.Purpose,
+		CourseID:    request.CourseID,
+		OwnerUUID:   userUUID,
+		CreatedAt:   time.Now(),
+	}
+	file.StorageKey = request.CourseID + "/" + file.ID.Hex()
+
+	hash := sha256.New()
+	reader := io.TeeReader(io.MultiReader(bytes.NewReader(head), io.LimitReader(content, size-int64(n))), hash)
+	if err := s.fileStorage.Put(ctx, file.StorageKey, reader, size, contentType); err != nil {
+		return nil, err
+	}
+	file.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	if request.Checksum != "" && !strings.EqualFold(request.Checksum, file.Checksum) {
+		_ = s.fileStorage.Delete(ctx, file.StorageKey)
+		return nil, ErrFileChecksumMismatch
+	}
+
+	if err := s.fileRepo.Create(ctx, file); err != nil {
+		_ = s.fileStorage.Delete(ctx, file.StorageKey)
+		return nil, err
+	}
+	return s.fileResponse(file), nil
+}
+
+// GetFile returns the file with a signed download link. Files can be read by their owner and the teachers
+// of the course, module resources also by the students of the course.
+func (s *FileService) GetFile(ctx context.Context, fileID, userUUID string) (*schemas.FileResponse, error) {
+	file, err := s.fileRepo.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, ErrFileNotFound
+	}
+
+	if file.OwnerUUID != userUUID {
+		members, err := s.courseService.GetCourseMembers(file.CourseID)
+		if err != nil {
+			return nil, err
+		}
+		isTeacher := members.TeacherID == userUUID || slices.Contains(members.AuxTeachersIDs, userUUID)
+		isStudent := slices.Contains(members.StudentsIDs, userUUID)
+		if !isTeacher && !(isStudent && file.Purpose == model.FilePurposeModuleResource) {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	return s.fileResponse(file), nil
+}
+
+// OpenFile returns the content of a file after checking the signature and expiration of the download link
+func (s *FileService) OpenFile(ctx context.Context, fileID string, expires int64, signature string) (*model.StoredFile, io.ReadCloser, error) {
+	if time.Now().Unix() > expires || !hmac.Equal([]byte(signature), []byte(s.sign(fileID, expires))) {
+		return nil, nil, ErrInvalidFileLink
+	}
+
+	file, err := s.fileRepo.GetByID(ctx, fileID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if file == nil {
+		return nil, nil, ErrFileNotFound
+	}
+
+	content, err := s.fileStorage.Get(ctx, file.StorageKey)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotFound) {
+			return nil, nil, ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return file, content, nil
+}
+
+func (s *FileService) fileResponse(file *model.StoredFile) *schemas.FileResponse {
+	expiresAt := time.Now().Add(s.settings.LinkTTL)
+	fileID := file.ID.Hex()
+	return &schemas.FileResponse{
+		File:        file,
+		DownloadURL: fmt.Sprintf("/files/%s/download?expires=%d&signature=%s", fileID, expiresAt.Unix(), s.sign(fileID, expiresAt.Unix())),
+		ExpiresAt:   expiresAt,
+	}
+}
+
+func (s *FileService) sign(fileID string, expires int64) string {
+	mac := hmac.New(sha256.New, s.settings.SigningKey)
+	fmt.Fprintf(mac, "%s:%d", fileID, expires)
+	return hex.EncodeToString(mac.Sum(nil))
+}
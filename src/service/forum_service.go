@@ -63,7 +63,7 @@ func (s *ForumService) GetQuestionById(id string) (*model.ForumQuestion, error)
 		return nil, errors.New("question ID is required")
 	}
 
-	return s.forumRepository.GetQuestionById(id)
+	return s.getVisibleQuestion(id)
 }
 
 func (s *ForumService) GetQuestionsByCourseId(courseID string) ([]model.ForumQuestion, error) {
@@ -76,19 +76,30 @@ func (s *ForumService) GetQuestionsByCourseId(courseID string) ([]model.ForumQue
 		return nil, errors.New("course not found")
 	}
 
-	return s.forumRepository.GetQuestionsByCourseId(courseID)
+	questions, err := s.forumRepository.GetQuestionsByCourseId(courseID)
+	if err != nil {
+		return nil, err
+	}
+	return hidePendingSuggestions(questions), nil
 }
 
-func (s *ForumService) UpdateQuestion(id, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error) {
+// UpdateQuestion changes the question, only its author and the teachers that moderate the forum can do it
+func (s *ForumService) UpdateQuestion(id, userID, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error) {
 	if id == "" {
 		return nil, errors.New("question ID is required")
 	}
+	if userID == "" {
+		return nil, errors.New("author ID is required")
+	}
 
-	// Get existing question to validate ownership later if needed
-	existingQuestion, err := s.forumRepository.GetQuestionById(id)
+	// Get existing question to validate ownership
+	existingQuestion, err := s.getVisibleQuestion(id)
 	if err != nil {
 		return nil, err
 	}
+	if err := s.checkAuthorOrModerator(existingQuestion.CourseID, existingQuestion.AuthorID, userID, "you can only update your own questions"); err != nil {
+		return nil, err
+	}
 
 	// Validate fields if provided
 	if title == "" && description == "" && len(tags) == 0 {
@@ -122,22 +133,23 @@ func (s *ForumService) UpdateQuestion(id, title, description string, tags []mode
 	return s.forumRepository.UpdateQuestion(id, updateQuestion)
 }
 
-func (s *ForumService) DeleteQuestion(id, authorID string) error {
+// DeleteQuestion deletes the question, only its author and the teachers that moderate the forum can do it
+func (s *ForumService) DeleteQuestion(id, userID string) error {
 	if id == "" {
 		return errors.New("question ID is required")
 	}
-	if authorID == "" {
+	if userID == "" {
 		return errors.New("author ID is required")
 	}
 
 	// Validate question exists and check ownership
-	question, err := s.forumRepository.GetQuestionById(id)
+	question, err := s.getVisibleQuestion(id)
 	if err != nil {
 		return err
 	}
 
-	if question.AuthorID != authorID {
-		return errors.New("you can only delete your own questions")
+	if err := s.checkAuthorOrModerator(question.CourseID, question.AuthorID, userID, "you can only delete your own questions"); err != nil {
+		return err
 	}
 
 	return s.forumRepository.DeleteQuestion(id)
@@ -157,7 +169,7 @@ func (s *ForumService) AddAnswer(questionID, authorID, content string) (*model.F
 	}
 
 	// Validate question exists
-	_, err := s.forumRepository.GetQuestionById(questionID)
+	_, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return nil, err
 	}
@@ -165,6 +177,7 @@ func (s *ForumService) AddAnswer(questionID, authorID, content string) (*model.F
 	answer := model.ForumAnswer{
 		AuthorID: authorID,
 		Content:  content,
+		Type:     model.AnswerTypeUser,
 	}
 
 	return s.forumRepository.AddAnswer(questionID, answer)
@@ -185,7 +198,7 @@ func (s *ForumService) UpdateAnswer(questionID, answerID, authorID, content stri
 	}
 
 	// Validate question exists and check answer ownership
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return nil, err
 	}
@@ -209,19 +222,20 @@ func (s *ForumService) UpdateAnswer(questionID, answerID, authorID, content stri
 	return s.forumRepository.UpdateAnswer(questionID, answerID, content)
 }
 
-func (s *ForumService) DeleteAnswer(questionID, answerID, authorID string) error {
+// DeleteAnswer deletes the answer, only its author and the teachers that moderate the forum can do it
+func (s *ForumService) DeleteAnswer(questionID, answerID, userID string) error {
 	if questionID == "" {
 		return errors.New("question ID is required")
 	}
 	if answerID == "" {
 		return errors.New("answer ID is required")
 	}
-	if authorID == "" {
+	if userID == "" {
 		return errors.New("author ID is required")
 	}
 
 	// Validate question exists and check answer ownership
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -230,8 +244,8 @@ func (s *ForumService) DeleteAnswer(questionID, answerID, authorID string) error
 	var answerFound bool
 	for _, answer := range question.Answers {
 		if answer.ID == answerID {
-			if answer.AuthorID != authorID {
-				return errors.New("you can only delete your own answers")
+			if err := s.checkAuthorOrModerator(question.CourseID, answer.AuthorID, userID, "you can only delete your own answers"); err != nil {
+				return err
 			}
 			answerFound = true
 			break
@@ -257,7 +271,7 @@ func (s *ForumService) AcceptAnswer(questionID, answerID, authorID string) error
 	}
 
 	// Validate question exists and check question ownership
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -296,7 +310,7 @@ func (s *ForumService) VoteQuestion(questionID, userID string, voteType int) err
 	}
 
 	// Validate question exists
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -324,7 +338,7 @@ func (s *ForumService) VoteAnswer(questionID, answerID, userID string, voteType
 	}
 
 	// Validate question and answer exist
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -357,7 +371,7 @@ func (s *ForumService) RemoveVoteFromQuestion(questionID, userID string) error {
 	}
 
 	// Validate question exists
-	_, err := s.forumRepository.GetQuestionById(questionID)
+	_, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -377,7 +391,7 @@ func (s *ForumService) RemoveVoteFromAnswer(questionID, answerID, userID string)
 	}
 
 	// Validate question and answer exist
-	question, err := s.forumRepository.GetQuestionById(questionID)
+	question, err := s.getVisibleQuestion(questionID)
 	if err != nil {
 		return err
 	}
@@ -423,11 +437,55 @@ func (s *ForumService) SearchQuestions(courseID, query string, tags []model.Ques
 		return nil, errors.New("invalid question status")
 	}
 
-	return s.forumRepository.SearchQuestions(courseID, query, tags, status)
+	questions, err := s.forumRepository.SearchQuestions(courseID, query, tags, status)
+	if err != nil {
+		return nil, err
+	}
+	return hidePendingSuggestions(questions), nil
 }
 
 // Helper methods
 
+// getVisibleQuestion returns the question as the students see it, without the AI suggested answers
+// that wait for the review of a teacher. Those can only be handled through the ForumSuggestionService.
+func (s *ForumService) getVisibleQuestion(id string) (*model.ForumQuestion, error) {
+	question, err := s.forumRepository.GetQuestionById(id)
+	if err != nil || question == nil {
+		return question, err
+	}
+	question.Answers = visibleAnswers(question.Answers)
+	return question, nil
+}
+
+// checkAuthorOrModerator returns the error with the message when the user is not the author, nor a teacher
+// of the course with the permission to moderate the forum
+func (s *ForumService) checkAuthorOrModerator(courseID, authorID, userID, message string) error {
+	if authorID == userID {
+		return nil
+	}
+	course, err := s.courseRepository.GetCourseById(courseID)
+	if err != nil || course == nil {
+		return errors.New("course not found")
+	}
+	if !course.TeacherCan(userID, model.PermissionModerateForum) {
+		return errors.New(message)
+	}
+	return nil
+}
+
+func hidePendingSuggestions(questions []model.ForumQuestion) []model.ForumQuestion {
+	for i := range questions {
+		questions[i].Answers = visibleAnswers(questions[i].Answers)
+	}
+	return questions
+}
+
+func visibleAnswers(answers []model.ForumAnswer) []model.ForumAnswer {
+	return slices.DeleteFunc(answers, func(answer model.ForumAnswer) bool {
+		return answer.IsPendingSuggestion()
+	})
+}
+
 func (s *ForumService) validateTags(tags []model.QuestionTag) error {
 	if len(tags) == 0 {
 		return nil
@@ -465,7 +523,7 @@ func (s *ForumService) GetForumParticipants(courseID string) ([]string, error) {
 	}
 
 	// Extract unique participants using business logic
-	return s.extractParticipantsFromQuestions(questions), nil
+	return s.extractParticipantsFromQuestions(hidePendingSuggestions(questions)), nil
 }
 
 func (s *ForumService) extractParticipantsFromQuestions(questions []model.ForumQuestion) []string {
@@ -0,0 +1,289 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+const (
+	maxSuggestionResolvedQuestions = 20 // Latest questions with an accepted answer sent to the model as examples
+	maxUnansweredSuggestions       = 10 // Questions drafted in a single call, each one is a call to the model
+	DefaultUnansweredAge           = 48 * time.Hour
+)
+
+// ForumSuggestionService drafts answers to the forum questions with the AI. The drafts are stored as
+// answers of type ai_suggestion that the students don't see until a teacher of the course approves them.
+type ForumSuggestionService struct {
+	forumRepo     repository.ForumRepositoryInterface
+	moduleRepo    repository.ModuleRepositoryInterface
+	courseService CourseServiceInterface
+	aiClient      ai.Provider
+	auditService  AuditServiceInterface
+}
+
+func NewForumSuggestionService(
+	forumRepo repository.ForumRepositoryInterface,
+	moduleRepo repository.ModuleRepositoryInterface,
+	courseService CourseServiceInterface,
+	aiClient ai.Provider,
+	auditService AuditServiceInterface,
+) *ForumSuggestionService {
+	return &ForumSuggestionService{
+		forumRepo:     forumRepo,
+		moduleRepo:    moduleRepo,
+		courseService: courseService,
+		aiClient:      aiClient,
+		auditService:  auditService,
+	}
+}
+
+// SuggestAnswer drafts an answer to an open question of a course that opted in to the AI suggestions
+func (s *ForumSuggestionService) SuggestAnswer(ctx context.Context, questionID, teacherUUID string) (*schemas.ForumSuggestionResponse, error) {
+	question, err := s.getQuestion(questionID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.checkSuggestionsEnabled(question.CourseID, teacherUUID)
+	if err != nil {
+		return nil, err
+	}
+	if question.Status != model.QuestionStatusOpen {
+		return nil, ErrForumQuestionNotOpen
+	}
+	if hasPendingSuggestion(question) {
+		return nil, ErrSuggestionAlreadyPending
+	}
+
+	questions, err := s.forumRepo.GetQuestionsByCourseId(question.CourseID)
+	if err != nil {
+		return nil, err
+	}
+	return s.suggest(ctx, course, teacherUUID, question, questions)
+}
+
+// SuggestAnswersForUnanswered drafts answers to the open questions of the course that have been waiting
+// longer than olderThan without any answer nor a pending suggestion, the oldest first. Each draft is recorded
+// in the audit log.
+func (s *ForumSuggestionService) SuggestAnswersForUnanswered(ctx context.Context, courseID, teacherUUID string, olderThan time.Duration) ([]schemas.ForumSuggestionResponse, error) {
+	course, err := s.checkSuggestionsEnabled(courseID, teacherUUID)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := s.forumRepo.GetQuestionsByCourseId(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	var unanswered []*model.ForumQuestion
+	for i := range questions {
+		question := &questions[i]
+		if question.Status == model.QuestionStatusOpen && question.CreatedAt.Before(cutoff) && len(question.Answers) == 0 {
+			unanswered = append(unanswered, question)
+		}
+	}
+	slices.SortFunc(unanswered, func(a, b *model.ForumQuestion) int {
+		return a.CreatedAt.Compare(b.CreatedAt)
+	})
+	if len(unanswered) > maxUnansweredSuggestions {
+		unanswered = unanswered[:maxUnansweredSuggestions]
+	}
+
+	suggestions := []schemas.ForumSuggestionResponse{}
+	for _, question := range unanswered {
+		var suggestion *schemas.ForumSuggestionResponse
+		err := recordSystemChange(ctx, s.auditService, model.AuditEntityForumQuestion, question.ID.Hex(), "forum_suggestions", func() (err error) {
+			suggestion, err = s.suggest(ctx, course, teacherUUID, question, questions)
+			return err
+		})
+		if err != nil {
+			return nil, err
+		}
+		suggestions = append(suggestions, *suggestion)
+	}
+	return suggestions, nil
+}
+
+// GetPendingSuggestions returns the questions of the course with suggestions waiting for review, with all their answers
+func (s *ForumSuggestionService) GetPendingSuggestions(courseID, teacherUUID string) ([]model.ForumQuestion, error) {
+	if _, err := s.checkCourseTeacher(courseID, teacherUUID); err != nil {
+		return nil, err
+	}
+	questions, err := s.forumRepo.GetQuestionsByCourseId(courseID)
+	if err != nil {
+		return nil, err
+	}
+
+	pending := []model.ForumQuestion{}
+	for _, question := range questions {
+		if hasPendingSuggestion(&question) {
+			pending = append(pending, question)
+		}
+	}
+	return pending, nil
+}
+
+// ApproveSuggestion makes the suggestion visible to the students. A non empty content replaces the drafted one.
+// The answer keeps its ai_suggestion type, so it is still shown as written with the AI.
+func (s *ForumSuggestionService) ApproveSuggestion(questionID, answerID, teacherUUID, content string) (*schemas.ForumSuggestionResponse, error) {
+	question, err := s.getQuestion(questionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkCourseTeacher(question.CourseID, teacherUUID, model.PermissionModerateForum); err != nil {
+		return nil, err
+	}
+	answer, err := findPendingSuggestion(question, answerID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewedAt := time.Now()
+	suggestion := model.AnswerSuggestion{
+		Status:     model.SuggestionStatusApproved,
+		ReviewedBy: teacherUUID,
+		ReviewedAt: &reviewedAt,
+	}
+	if answer.Suggestion != nil {
+		suggestion.PromptVersion = answer.Suggestion.PromptVersion
+	}
+	approved, err := s.forumRepo.ReviewAnswerSuggestion(questionID, answerID, strings.TrimSpace(content), suggestion)
+	if err != nil {
+		return nil, err
+	}
+	return newForumSuggestionResponse(question, approved), nil
+}
+
+// DiscardSuggestion deletes a suggestion that is still waiting for review and returns it
+func (s *ForumSuggestionService) DiscardSuggestion(questionID, answerID, teacherUUID string) (*schemas.ForumSuggestionResponse, error) {
+	question, err := s.getQuestion(questionID)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.checkCourseTeacher(question.CourseID, teacherUUID, model.PermissionModerateForum); err != nil {
+		return nil, err
+	}
+	answer, err := findPendingSuggestion(question, answerID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.forumRepo.DeleteAnswer(questionID, answerID); err != nil {
+		return nil, err
+	}
+	return newForumSuggestionResponse(question, answer), nil
+}
+
+// suggest asks the AI for an answer to the question and stores it pending review. The modules of the course
+// and the latest answers accepted in the forum are the material the model answers from.
+func (s *ForumSuggestionService) suggest(ctx context.Context, course *model.Course, teacherUUID string, question *model.ForumQuestion, questions []model.ForumQuestion) (*schemas.ForumSuggestionResponse, error) {
+	if s.aiClient == nil {
+		return nil, ai.ErrUnavailable
+	}
+	modules, err := s.moduleRepo.GetModulesByCourseId(question.CourseID)
+	if err != nil {
+		return nil, err
+	}
+
+	var resolved []model.ForumQuestion
+	for _, previous := range questions {
+		if previous.ID != question.ID && previous.Status == model.QuestionStatusResolved && previous.AcceptedAnswerID != nil {
+			resolved = append(resolved, previous)
+		}
+		if len(resolved) == maxSuggestionResolvedQuestions {
+			break
+		}
+	}
+
+	scope := ai.Scope{CourseID: question.CourseID, TeacherUUID: course.TeacherUUID, Language: course.Language}
+	suggestion, err := s.aiClient.SuggestForumAnswer(ai.WithScope(ctx, scope), question, modules, resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	answer, err := s.forumRepo.AddAnswer(question.ID.Hex(), model.ForumAnswer{
+		AuthorID: teacherUUID,
+		Content:  suggestion.Content,
+		Type:     model.AnswerTypeAiSuggestion,
+		Suggestion: &model.AnswerSuggestion{
+			Status:        model.SuggestionStatusPending,
+			PromptVersion: suggestion.PromptVersion,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return newForumSuggestionResponse(question, answer), nil
+}
+
+func (s *ForumSuggestionService) getQuestion(questionID string) (*model.ForumQuestion, error) {
+	question, err := s.forumRepo.GetQuestionById(questionID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrForumQuestionNotFound, err)
+	}
+	if question == nil {
+		return nil, ErrForumQuestionNotFound
+	}
+	return question, nil
+}
+
+// checkSuggestionsEnabled returns ErrForumSuggestionsDisabled if the course didn't opt in to the AI suggestions
+func (s *ForumSuggestionService) checkSuggestionsEnabled(courseID, teacherUUID string) (*model.Course, error) {
+	course, err := s.checkCourseTeacher(courseID, teacherUUID, model.PermissionModerateForum)
+	if err != nil {
+		return nil, err
+	}
+	if !course.ForumAiSuggestionsEnabled() {
+		return nil, ErrForumSuggestionsDisabled
+	}
+	return course, nil
+}
+
+// checkCourseTeacher returns ErrUnauthorized if the teacher is not the titular or an auxiliary teacher of the course
+// with the permissions
+func (s *ForumSuggestionService) checkCourseTeacher(courseID, teacherUUID string, permissions ...model.AuxTeacherPermission) (*model.Course, error) {
+	course, err := s.courseService.GetCourseById(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, errors.New("course not found")
+	}
+
+	if err := authorizeTeacher(course, teacherUUID, permissions...); err != nil {
+		return nil, err
+	}
+	return course, nil
+}
+
+func newForumSuggestionResponse(question *model.ForumQuestion, answer *model.ForumAnswer) *schemas.ForumSuggestionResponse {
+	return &schemas.ForumSuggestionResponse{
+		QuestionID:    question.ID.Hex(),
+		CourseID:      question.CourseID,
+		QuestionTitle: question.Title,
+		Answer:        *answer,
+	}
+}
+
+func hasPendingSuggestion(question *model.ForumQuestion) bool {
+	return slices.ContainsFunc(question.Answers, func(answer model.ForumAnswer) bool {
+		return answer.IsPendingSuggestion()
+	})
+}
+
+func findPendingSuggestion(question *model.ForumQuestion, answerID string) (*model.ForumAnswer, error) {
+	for i := range question.Answers {
+		if question.Answers[i].ID == answerID && question.Answers[i].IsPendingSuggestion() {
+			return &question.Answers[i], nil
+		}
+	}
+	return nil, ErrSuggestionNotFound
+}
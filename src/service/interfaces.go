@@ -2,8 +2,10 @@ package service
 
 import (
 	"context"
+	"courses-service/src/auth"
 	"courses-service/src/model"
 	"courses-service/src/schemas"
+	"io"
 	"time"
 )
 
@@ -18,8 +20,9 @@ type CourseServiceInterface interface {
 	GetCoursesByUserId(userId string) (*schemas.GetCoursesByUserIdResponse, error)
 	GetCourseByTitle(title string) ([]*model.Course, error)
 	UpdateCourse(id string, updateCourseRequest schemas.UpdateCourseRequest) (*model.Course, error)
-	AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error)
+	AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error)
 	RemoveAuxTeacherFromCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error)
+	UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error)
 	GetFavouriteCourses(studentId string) ([]*model.Course, error)
 	CreateCourseFeedback(courseId string, feedbackRequest schemas.CreateCourseFeedbackRequest) (*model.CourseFeedback, error)
 	GetCourseFeedback(courseId string, getCourseFeedbackRequest schemas.GetCourseFeedbackRequest) ([]*model.CourseFeedback, error)
@@ -27,11 +30,11 @@ type CourseServiceInterface interface {
 }
 
 type ModuleServiceInterface interface {
-	CreateModule(module schemas.CreateModuleRequest) (*model.Module, error)
+	CreateModule(teacherUUID string, module schemas.CreateModuleRequest) (*model.Module, error)
 	GetModuleById(id string) (*model.Module, error)
 	GetModulesByCourseId(courseId string) ([]model.Module, error)
-	UpdateModule(id string, module model.Module) (*model.Module, error)
-	DeleteModule(id string) error
+	UpdateModule(id, teacherUUID string, module model.Module) (*model.Module, error)
+	DeleteModule(id, teacherUUID string) error
 }
 
 // EnrollmentServiceInterface define los métodos que debe implementar un servicio de enrollment
@@ -43,17 +46,17 @@ type EnrollmentServiceInterface interface {
 	UnsetFavouriteCourse(studentID, courseID string) error
 	CreateStudentFeedback(feedbackRequest schemas.CreateStudentFeedbackRequest) error
 	GetFeedbackByStudentId(studentID string, getFeedbackByStudentIdRequest schemas.GetFeedbackByStudentIdRequest) ([]*model.StudentFeedback, error)
-	ApproveStudent(studentID, courseID string) error
-	DisapproveStudent(studentID, courseID, reason string) error
+	ApproveStudent(studentID, courseID, teacherUUID string) error
+	DisapproveStudent(studentID, courseID, teacherUUID, reason string) error
 }
 
 type AssignmentServiceInterface interface {
-	CreateAssignment(c schemas.CreateAssignmentRequest) (*model.Assignment, error)
+	CreateAssignment(teacherUUID string, c schemas.CreateAssignmentRequest) (*model.Assignment, error)
 	GetAssignments() ([]*model.Assignment, error)
 	GetAssignmentById(id string) (*model.Assignment, error)
 	GetAssignmentsByCourseId(courseId string) ([]*model.Assignment, error)
-	UpdateAssignment(id string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error)
-	DeleteAssignment(id string) error
+	UpdateAssignment(id, teacherUUID string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error)
+	DeleteAssignment(id, teacherUUID string) error
 }
 
 type SubmissionServiceInterface interface {
@@ -64,10 +67,63 @@ type SubmissionServiceInterface interface {
 	GetSubmissionsByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error)
 	GetSubmissionsByStudent(ctx context.Context, studentUUID string) ([]model.Submission, error)
 	GetOrCreateSubmission(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error)
-	GradeSubmission(ctx context.Context, submissionID string, score *float64, feedback string) (*model.Submission, error)
+	StartNewAttempt(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error)
+	GetAttemptHistory(ctx context.Context, assignmentID, studentUUID string) (*schemas.AttemptHistoryResponse, error)
+	GradeSubmission(ctx context.Context, submissionID, teacherUUID string, gradeRequest schemas.GradeSubmissionRequest) (*model.Submission, error)
 	ValidateTeacherPermissions(ctx context.Context, assignmentID, teacherUUID string) error
 	GenerateFeedbackSummary(ctx context.Context, submissionID string) (*schemas.AiSummaryResponse, error)
 	AutoCorrectSubmission(ctx context.Context, submissionID string) error
+	GetCorrectionStatus(ctx context.Context, submissionID, userUUID string) (*model.CorrectionJob, error)
+}
+
+type CorrectionQueueInterface interface {
+	Enqueue(ctx context.Context, submission *model.Submission) (*model.CorrectionJob, error)
+	GetLatestJob(ctx context.Context, submissionID string) (*model.CorrectionJob, error)
+}
+
+type QuestionBankServiceInterface interface {
+	CreateQuestion(ctx context.Context, courseID, teacherUUID string, request schemas.CreateBankQuestionRequest) (*model.BankQuestion, error)
+	GetQuestions(ctx context.Context, courseID, teacherUUID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error)
+	GetQuestion(ctx context.Context, courseID, questionID, teacherUUID string) (*model.BankQuestion, error)
+	UpdateQuestion(ctx context.Context, courseID, questionID, teacherUUID string, request schemas.UpdateBankQuestionRequest) (*model.BankQuestion, error)
+	DeleteQuestion(ctx context.Context, courseID, questionID, teacherUUID string) error
+}
+
+type QuestionGenerationServiceInterface interface {
+	GenerateQuestions(ctx context.Context, moduleID, teacherUUID string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error)
+	AddQuestions(ctx context.Context, assignmentID, teacherUUID string, questions []model.Question) (*model.Assignment, error)
+}
+
+type ForumSuggestionServiceInterface interface {
+	SuggestAnswer(ctx context.Context, questionID, teacherUUID string) (*schemas.ForumSuggestionResponse, error)
+	SuggestAnswersForUnanswered(ctx context.Context, courseID, teacherUUID string, olderThan time.Duration) ([]schemas.ForumSuggestionResponse, error)
+	GetPendingSuggestions(courseID, teacherUUID string) ([]model.ForumQuestion, error)
+	ApproveSuggestion(questionID, answerID, teacherUUID, content string) (*schemas.ForumSuggestionResponse, error)
+	DiscardSuggestion(questionID, answerID, teacherUUID string) (*schemas.ForumSuggestionResponse, error)
+}
+
+type ExtensionServiceInterface interface {
+	CreateExtension(ctx context.Context, assignmentID, teacherUUID string, request schemas.CreateExtensionRequest) (*model.DeadlineExtension, error)
+	GetExtensionsByAssignment(ctx context.Context, assignmentID, teacherUUID string) ([]model.DeadlineExtension, error)
+	UpdateExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string, request schemas.UpdateExtensionRequest) (*model.DeadlineExtension, error)
+	DeleteExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string) error
+}
+
+type SimilarityServiceInterface interface {
+	GetSimilarityReport(ctx context.Context, assignmentID, teacherUUID string, request schemas.SimilarityReportRequest) (*schemas.SimilarityReport, error)
+	FlagSimilarSubmissions(ctx context.Context, assignmentID, teacherUUID string, request schemas.SimilarityReportRequest) (*schemas.SimilarityReport, error)
+}
+
+type AiUsageServiceInterface interface {
+	InvalidateCourse(ctx context.Context, courseID string) error
+	InvalidateStudent(ctx context.Context, studentUUID string) error
+	GetUsageReport(ctx context.Context, request schemas.AiUsageReportRequest) (*schemas.AiUsageReport, error)
+}
+
+type FileServiceInterface interface {
+	UploadFile(ctx context.Context, userUUID string, request schemas.UploadFileRequest, name string, size int64, content io.Reader) (*schemas.FileResponse, error)
+	GetFile(ctx context.Context, fileID, userUUID string) (*schemas.FileResponse, error)
+	OpenFile(ctx context.Context, fileID string, expires int64, signature string) (*model.StoredFile, io.ReadCloser, error)
 }
 
 type ForumServiceInterface interface {
@@ -75,13 +131,13 @@ type ForumServiceInterface interface {
 	CreateQuestion(courseID, authorID, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error)
 	GetQuestionById(id string) (*model.ForumQuestion, error)
 	GetQuestionsByCourseId(courseID string) ([]model.ForumQuestion, error)
-	UpdateQuestion(id, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error)
-	DeleteQuestion(id, authorID string) error
+	UpdateQuestion(id, userID, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error)
+	DeleteQuestion(id, userID string) error
 
 	// Answer operations
 	AddAnswer(questionID, authorID, content string) (*model.ForumAnswer, error)
 	UpdateAnswer(questionID, answerID, authorID, content string) (*model.ForumAnswer, error)
-	DeleteAnswer(questionID, answerID, authorID string) error
+	DeleteAnswer(questionID, answerID, userID string) error
 	AcceptAnswer(questionID, answerID, authorID string) error
 
 	// Vote operations
@@ -114,7 +170,41 @@ type StatisticsServiceInterface interface {
 	GetBackofficeAssignmentsStats(ctx context.Context) (*schemas.BackofficeAssignmentsStatsResponse, error)
 }
 
-type TeacherActivityServiceInterface interface {
-	LogActivityIfAuxTeacher(courseID, teacherUUID, activityType, description string)
-	GetCourseActivityLogs(courseID string) ([]*model.TeacherActivityLog, error)
+// FeedbackAnalyticsServiceInterface define los métodos del análisis de sentimiento y temas del feedback de los cursos
+type FeedbackAnalyticsServiceInterface interface {
+	AnalyzePendingFeedback(ctx context.Context, courseID string) (int, error)
+	GetFeedbackTrends(ctx context.Context, courseID string, from, to time.Time, interval schemas.FeedbackTrendInterval) (*schemas.FeedbackTrends, error)
+}
+
+// AuditServiceInterface define los métodos del registro de auditoría de los cambios de las entidades
+type AuditServiceInterface interface {
+	Snapshot(ctx context.Context, entity model.AuditEntity, id string) map[string]any
+	Record(ctx context.Context, record schemas.AuditRecord) error
+	LatestSubmissionID(ctx context.Context, assignmentID, studentUUID string) string
+	GetLogs(ctx context.Context, filter schemas.AuditLogFilter) (*schemas.AuditLogPage, error)
+}
+
+// MembershipServiceInterface define los métodos para resolver los roles de un usuario en un curso
+type MembershipServiceInterface interface {
+	GetMembership(courseID, userID string) (*auth.Membership, error)
+	GetModuleCourseID(moduleID string) (string, error)
+	GetAssignmentCourseID(assignmentID string) (string, error)
+	GetQuestionCourseID(questionID string) (string, error)
+	IsStudentTeacher(teacherID, studentID string) (bool, error)
+}
+
+// AdminServiceInterface define las acciones de moderación del backoffice, registradas con su motivo
+type AdminServiceInterface interface {
+	SuspendCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error)
+	ArchiveCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error)
+	ReactivateCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error)
+	ReassignTeacher(ctx context.Context, courseID, adminUUID string, request schemas.ReassignTeacherRequest) (*model.Course, error)
+	UnenrollStudent(ctx context.Context, courseID, studentUUID, adminUUID, reason string) error
+	RemoveForumQuestion(ctx context.Context, questionID, adminUUID, reason string) error
+	RemoveForumAnswer(ctx context.Context, questionID, answerID, adminUUID, reason string) error
+	BlockUser(ctx context.Context, userUUID, adminUUID, reason string) (*model.BlockedUser, error)
+	UnblockUser(ctx context.Context, userUUID, adminUUID, reason string) error
+	GetBlockedUsers(ctx context.Context) ([]model.BlockedUser, error)
+	IsBlocked(userUUID string) (bool, error)
+	GetActionLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error)
 }
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"math"
+	"slices"
+	"time"
+
+	"courses-service/src/model"
+)
+
+// studentDueDate returns the due date of the assignment for a student, using the latest extension granted to them
+func studentDueDate(assignment *model.Assignment, extensions []model.DeadlineExtension, studentUUID string) time.Time {
+	dueDate := assignment.DueDate
+	for _, extension := range extensions {
+		if extension.AssignmentID != assignment.ID.Hex() || !slices.Contains(extension.StudentUUIDs, studentUUID) {
+			continue
+		}
+		if extension.DueDate.After(dueDate) {
+			dueDate = extension.DueDate
+		}
+	}
+	return dueDate
+}
+
+// hardDeadline returns the moment after which a submission of the student is considered late
+func hardDeadline(assignment *model.Assignment, extensions []model.DeadlineExtension, studentUUID string) time.Time {
+	return studentDueDate(assignment, extensions, studentUUID).Add(time.Duration(assignment.GracePeriod) * time.Minute)
+}
+
+// evaluateLateness returns the status and the penalty percentage of a submission made at the given time.
+// Returns ErrLateSubmission when the assignment rejects late submissions.
+func evaluateLateness(assignment *model.Assignment, deadline time.Time, submittedAt time.Time) (model.SubmissionStatus, float64, error) {
+	if !submittedAt.After(deadline) {
+		return model.SubmissionStatusSubmitted, 0, nil
+	}
+
+	switch assignment.LatePolicy {
+	case model.LatePolicyReject:
+		return "", 0, ErrLateSubmission
+	case model.LatePolicyPenalty:
+		// Every started day after the deadline counts as a full day
+		daysLate := math.Ceil(submittedAt.Sub(deadline).Hours() / 24)
+		return model.SubmissionStatusLate, math.Min(daysLate*assignment.LatePenalty, 100), nil
+	default:
+		return model.SubmissionStatusLate, 0, nil
+	}
+}
+
+// applyLatePenalty deducts the late penalty percentage of the submission from a computed score
+func applyLatePenalty(score float64, submission *model.Submission) float64 {
+	if submission.LatePenalty <= 0 {
+		return score
+	}
+	return score * (100 - submission.LatePenalty) / 100
+}
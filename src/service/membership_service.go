@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"courses-service/src/auth"
+	"courses-service/src/repository"
+)
+
+// MembershipService resolves the roles of a user in a course for the authorization policy of the routes,
+// and the course that a module, assignment or forum question belongs to
+type MembershipService struct {
+	courseRepo     repository.CourseRepositoryInterface
+	enrollmentRepo repository.EnrollmentRepositoryInterface
+	moduleRepo     repository.ModuleRepositoryInterface
+	assignmentRepo repository.AssignmentRepositoryInterface
+	forumRepo      repository.ForumRepositoryInterface
+}
+
+func NewMembershipService(
+	courseRepo repository.CourseRepositoryInterface,
+	enrollmentRepo repository.EnrollmentRepositoryInterface,
+	moduleRepo repository.ModuleRepositoryInterface,
+	assignmentRepo repository.AssignmentRepositoryInterface,
+	forumRepo repository.ForumRepositoryInterface,
+) *MembershipService {
+	return &MembershipService{
+		courseRepo:     courseRepo,
+		enrollmentRepo: enrollmentRepo,
+		moduleRepo:     moduleRepo,
+		assignmentRepo: assignmentRepo,
+		forumRepo:      forumRepo,
+	}
+}
+
+// GetMembership returns the roles of the user in the course: titular or auxiliary teacher and enrolled
+// student, and the status of the course. A user without any of them gets a membership without roles.
+func (s *MembershipService) GetMembership(courseID, userID string) (*auth.Membership, error) {
+	course, err := s.courseRepo.GetCourseById(courseID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCourseNotFound, err)
+	}
+	if course == nil {
+		return nil, ErrCourseNotFound
+	}
+
+	membership := &auth.Membership{CourseID: courseID, UserID: userID, Roles: []string{}, CourseStatus: string(course.CurrentStatus())}
+	if userID == "" {
+		return membership, nil
+	}
+	if course.TeacherUUID == userID {
+		membership.Roles = append(membership.Roles, auth.RoleTitularTeacher)
+	}
+	if slices.Contains(course.AuxTeachers, userID) {
+		membership.Roles = append(membership.Roles, auth.RoleAuxTeacher)
+	}
+	enrolled, err := s.enrollmentRepo.IsEnrolled(userID, courseID)
+	if err != nil {
+		return nil, err
+	}
+	if enrolled {
+		membership.Roles = append(membership.Roles, auth.RoleEnrolledStudent)
+	}
+	return membership, nil
+}
+
+func (s *MembershipService) GetModuleCourseID(moduleID string) (string, error) {
+	module, err := s.moduleRepo.GetModuleById(moduleID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrModuleNotFound, err)
+	}
+	if module == nil {
+		return "", ErrModuleNotFound
+	}
+	return module.CourseID, nil
+}
+
+func (s *MembershipService) GetAssignmentCourseID(assignmentID string) (string, error) {
+	assignment, err := s.assignmentRepo.GetByID(context.TODO(), assignmentID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrAssignmentNotFound, err)
+	}
+	if assignment == nil {
+		return "", ErrAssignmentNotFound
+	}
+	return assignment.CourseID, nil
+}
+
+func (s *MembershipService) GetQuestionCourseID(questionID string) (string, error) {
+	question, err := s.forumRepo.GetQuestionById(questionID)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrForumQuestionNotFound, err)
+	}
+	if question == nil {
+		return "", ErrForumQuestionNotFound
+	}
+	return question.CourseID, nil
+}
+
+// IsStudentTeacher tells if the user is the titular or an aux teacher of a course the student is enrolled in
+func (s *MembershipService) IsStudentTeacher(teacherID, studentID string) (bool, error) {
+	if teacherID == "" || studentID == "" {
+		return false, nil
+	}
+	courses, err := s.courseRepo.GetCoursesByStudentId(studentID)
+	if err != nil {
+		return false, err
+	}
+	for _, course := range courses {
+		if course.TeacherUUID == teacherID || slices.Contains(course.AuxTeachers, teacherID) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -1,6 +1,7 @@
 package service
 
 import (
+	"context"
 	"courses-service/src/model"
 	"courses-service/src/repository"
 	"courses-service/src/schemas"
@@ -12,14 +13,19 @@ import (
 
 type ModuleService struct {
 	moduleRepository repository.ModuleRepositoryInterface
+	fileRepository   repository.FileRepositoryInterface
+	courseService    CourseServiceInterface
 }
 
-func NewModuleService(moduleRepository repository.ModuleRepositoryInterface) *ModuleService {
-	return &ModuleService{moduleRepository: moduleRepository}
+func NewModuleService(moduleRepository repository.ModuleRepositoryInterface, fileRepository repository.FileRepositoryInterface, courseService CourseServiceInterface) *ModuleService {
+	return &ModuleService{moduleRepository: moduleRepository, fileRepository: fileRepository, courseService: courseService}
 }
 
-func (s *ModuleService) CreateModule(module schemas.CreateModuleRequest) (*model.Module, error) {
+func (s *ModuleService) CreateModule(teacherUUID string, module schemas.CreateModuleRequest) (*model.Module, error) {
 	fmt.Printf("Creating module: %v\n", module)
+	if err := s.checkCanEditModules(module.CourseID, teacherUUID); err != nil {
+		return nil, err
+	}
 	if _, err := s.moduleRepository.GetModuleByName(module.CourseID, module.Title); err == nil {
 		return nil, fmt.Errorf("module with title %s already exists in course %s", module.Title, module.CourseID)
 	}
@@ -64,7 +70,7 @@ func (s *ModuleService) GetModuleByOrder(courseID string, order int) (*model.Mod
 	return s.moduleRepository.GetModuleByOrder(courseID, order)
 }
 
-func (s *ModuleService) UpdateModule(id string, module model.Module) (*model.Module, error) {
+func (s *ModuleService) UpdateModule(id, teacherUUID string, module model.Module) (*model.Module, error) {
 	slog.Debug("Updating module", "id", id, "module", module)
 	if id == "" {
 		return nil, errors.New("module id is required")
@@ -75,6 +81,9 @@ func (s *ModuleService) UpdateModule(id string, module model.Module) (*model.Mod
 	if err != nil {
 		return nil, fmt.Errorf("failed to get current module: %v", err)
 	}
+	if err := s.checkCanEditModules(currentModule.CourseID, teacherUUID); err != nil {
+		return nil, err
+	}
 
 	// Only check for duplicate titles if the title is actually changing
 	if module.Title != "" && module.Title != currentModule.Title {
@@ -94,13 +103,58 @@ func (s *ModuleService) UpdateModule(id string, module model.Module) (*model.Mod
 		}
 	}
 
+	if err := s.checkResourceFiles(currentModule.CourseID, module.Resources); err != nil {
+		return nil, err
+	}
+
 	return s.moduleRepository.UpdateModule(id, module)
 }
 
-func (s *ModuleService) DeleteModule(id string) error {
+// checkResourceFiles checks that the resources with a file reference module resources uploaded to the course
+func (s *ModuleService) checkResourceFiles(courseID string, resources []model.ModuleResource) error {
+	if s.fileRepository == nil {
+		return nil
+	}
+	for _, resource := range resources {
+		if resource.FileID == "" {
+			continue
+		}
+		file, err := s.fileRepository.GetByID(context.TODO(), resource.FileID)
+		if err != nil {
+			return err
+		}
+		if file == nil || file.CourseID != courseID || file.Purpose != model.FilePurposeModuleResource {
+			return fmt.Errorf("%w: resource %s references file %s", ErrFileNotFound, resource.Name, resource.FileID)
+		}
+	}
+	return nil
+}
+
+func (s *ModuleService) DeleteModule(id, teacherUUID string) error {
 	slog.Debug("Deleting module", "id", id)
 	if id == "" {
 		return errors.New("module id is required")
 	}
+
+	module, err := s.moduleRepository.GetModuleById(id)
+	if err != nil {
+		return err
+	}
+	if err := s.checkCanEditModules(module.CourseID, teacherUUID); err != nil {
+		return err
+	}
 	return s.moduleRepository.DeleteModule(id)
 }
+
+// checkCanEditModules returns ErrUnauthorized if the teacher is neither the titular teacher of the course
+// nor an aux teacher allowed to edit its modules
+func (s *ModuleService) checkCanEditModules(courseID, teacherUUID string) error {
+	course, err := s.courseService.GetCourseById(courseID)
+	if err != nil {
+		return err
+	}
+	if course == nil {
+		return ErrCourseNotFound
+	}
+	return authorizeTeacher(course, teacherUUID, model.PermissionEditModules)
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+type QuestionBankService struct {
+	questionBankRepo repository.QuestionBankRepositoryInterface
+	courseService    CourseServiceInterface
+}
+
+func NewQuestionBankService(questionBankRepo repository.QuestionBankRepositoryInterface, courseService CourseServiceInterface) *QuestionBankService {
+	return &QuestionBankService{
+		questionBankRepo: questionBankRepo,
+		courseService:    courseService,
+	}
+}
+
+// CreateQuestion adds a question to the question bank of the course
+func (s *QuestionBankService) CreateQuestion(ctx context.Context, courseID, teacherUUID string, request schemas.CreateBankQuestionRequest) (*model.BankQuestion, error) {
+	if err := s.checkCourseTeacher(courseID, teacherUUID, model.PermissionCreateAssignments); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	question := &model.BankQuestion{
+		CourseID: courseID,
+		Question: model.Question{
+			Text:             request.Text,
+			Type:             request.Type,
+			Options:          request.Options,
+			CorrectAnswers:   request.CorrectAnswers,
+			Points:           request.Points,
+			PartialCredit:    request.PartialCredit,
+			NegativeMarking:  request.NegativeMarking,
+			NumericAnswer:    request.NumericAnswer,
+			Tolerance:        request.Tolerance,
+			MatchingPairs:    request.MatchingPairs,
+			AcceptedPatterns: request.AcceptedPatterns,
+			Rubric:           request.Rubric,
+		},
+		Tags:       request.Tags,
+		Difficulty: request.Difficulty,
+		CreatedBy:  teacherUUID,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}
+
+	if err := validateBankQuestion(question); err != nil {
+		return nil, err
+	}
+
+	if err := s.questionBankRepo.Create(ctx, question); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// GetQuestions returns the questions of the course bank, optionally filtered by tag and difficulty
+func (s *QuestionBankService) GetQuestions(ctx context.Context, courseID, teacherUUID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error) {
+	if err := s.checkCourseTeacher(courseID, teacherUUID); err != nil {
+		return nil, err
+	}
+	return s.questionBankRepo.GetByCourse(ctx, courseID, tag, difficulty)
+}
+
+// GetQuestion returns a question of the course bank
+func (s *QuestionBankService) GetQuestion(ctx context.Context, courseID, questionID, teacherUUID string) (*model.BankQuestion, error) {
+	if err := s.checkCourseTeacher(courseID, teacherUUID); err != nil {
+		return nil, err
+	}
+	return s.getQuestion(ctx, courseID, questionID)
+}
+
+// UpdateQuestion updates a question of the course bank, assignments already drawn keep their copy
+func (s *QuestionBankService) UpdateQuestion(ctx context.Context, courseID, questionID, teacherUUID string, request schemas.UpdateBankQuestionRequest) (*model.BankQuestion, error) {
+	if err := s.checkCourseTeacher(courseID, teacherUUID, model.PermissionCreateAssignments); err != nil {
+		return nil, err
+	}
+
+	question, err := s.getQuestion(ctx, courseID, questionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if request.Text != "" {
+		question.Question.Text = request.Text
+	}
+	if request.Type != "" {
+		question.Question.Type = request.Type
+	}
+	if request.Options != nil {
+		question.Question.Options = request.Options
+	}
+	if request.CorrectAnswers != nil {
+		question.Question.CorrectAnswers = request.CorrectAnswers
+	}
+	if request.Points > 0 {
+		question.Question.Points = request.Points
+	}
+	if request.PartialCredit != nil {
+		question.Question.PartialCredit = *request.PartialCredit
+	}
+	if request.NegativeMarking != nil {
+		question.Question.NegativeMarking = *request.NegativeMarking
+	}
+	if request.NumericAnswer != nil {
+		question.Question.NumericAnswer = request.NumericAnswer
+	}
+	if request.Tolerance != nil {
+		question.Question.Tolerance = *request.Tolerance
+	}
+	if request.MatchingPairs != nil {
+		question.Question.MatchingPairs = request.MatchingPairs
+	}
+	if request.AcceptedPatterns != nil {
+		question.Question.AcceptedPatterns = request.AcceptedPatterns
+	}
+	if request.Rubric != nil {
+		question.Question.Rubric = request.Rubric
+	}
+	if len(request.Tags) > 0 {
+		question.Tags = request.Tags
+	}
+	if request.Difficulty != "" {
+		question.Difficulty = request.Difficulty
+	}
+
+	if err := validateBankQuestion(question); err != nil {
+		return nil, err
+	}
+
+	question.UpdatedAt = time.Now()
+	if err := s.questionBankRepo.Update(ctx, question); err != nil {
+		return nil, err
+	}
+	return question, nil
+}
+
+// DeleteQuestion removes a question from the course bank
+func (s *QuestionBankService) DeleteQuestion(ctx context.Context, courseID, questionID, teacherUUID string) error {
+	if err := s.checkCourseTeacher(courseID, teacherUUID, model.PermissionCreateAssignments); err != nil {
+		return err
+	}
+
+	if _, err := s.getQuestion(ctx, courseID, questionID); err != nil {
+		return err
+	}
+
+	return s.questionBankRepo.Delete(ctx, questionID)
+}
+
+// checkCourseTeacher returns ErrUnauthorized if the teacher is not the titular or an auxiliary teacher of the course
+// with the permissions
+func (s *QuestionBankService) checkCourseTeacher(courseID, teacherUUID string, permissions ...model.AuxTeacherPermission) error {
+	course, err := s.courseService.GetCourseById(courseID)
+	if err != nil {
+		return err
+	}
+	if course == nil {
+		return errors.New("course not found")
+	}
+
+	return authorizeTeacher(course, teacherUUID, permissions...)
+}
+
+func (s *QuestionBankService) getQuestion(ctx context.Context, courseID, questionID string) (*model.BankQuestion, error) {
+	question, err := s.questionBankRepo.GetByID(ctx, questionID)
+	if err != nil {
+		return nil, err
+	}
+	if question == nil || question.CourseID != courseID {
+		return nil, ErrBankQuestionNotFound
+	}
+	return question, nil
+}
+
+func validateBankQuestion(question *model.BankQuestion) error {
+	if len(question.Tags) == 0 {
+		return fmt.Errorf("%w: at least one tag is required", ErrInvalidBankQuestion)
+	}
+	if question.Difficulty != "" && !slices.Contains(model.QuestionDifficultyValues, question.Difficulty) {
+		return fmt.Errorf("%w: invalid difficulty %s", ErrInvalidBankQuestion, question.Difficulty)
+	}
+	if question.Question.Points <= 0 {
+		return fmt.Errorf("%w: points must be positive", ErrInvalidBankQuestion)
+	}
+
+	if err := validateQuestion(question.Question); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidBankQuestion, err)
+	}
+	return nil
+}
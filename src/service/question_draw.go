@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"slices"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+)
+
+// isRandomized reports whether each student receives their own set of questions
+func isRandomized(assignment *model.Assignment) bool {
+	return len(assignment.QuestionDraws) > 0 || assignment.ShuffleOptions
+}
+
+// drawQuestions builds the questions a student receives: the questions of the assignment followed by
+// random questions of the course bank for each draw, with the options shuffled when the assignment asks for it
+func drawQuestions(ctx context.Context, questionBankRepo repository.QuestionBankRepositoryInterface, assignment *model.Assignment) ([]model.Question, error) {
+	questions := make([]model.Question, 0, len(assignment.Questions))
+	nextOrder := 1
+	for _, question := range assignment.Questions {
+		questions = append(questions, cloneQuestion(question))
+		nextOrder = max(nextOrder, question.Order+1)
+	}
+
+	// The same bank question can match several draws, each student gets it only once
+	drawn := make(map[string]bool)
+	for _, draw := range assignment.QuestionDraws {
+		if questionBankRepo == nil {
+			return nil, fmt.Errorf("%w: no question bank available", ErrNotEnoughBankQuestions)
+		}
+
+		bankQuestions, err := questionBankRepo.GetRandomByTag(ctx, assignment.CourseID, draw.Tag, draw.Difficulty, draw.Count+len(drawn))
+		if err != nil {
+			return nil, err
+		}
+
+		picked := 0
+		for _, bankQuestion := range bankQuestions {
+			if picked == draw.Count {
+				break
+			}
+			questionID := bankQuestion.ID.Hex()
+			if drawn[questionID] {
+				continue
+			}
+			drawn[questionID] = true
+
+			question := cloneQuestion(bankQuestion.Question)
+			question.ID = questionID
+			question.Order = nextOrder
+			nextOrder++
+			questions = append(questions, question)
+			picked++
+		}
+		if picked < draw.Count {
+			return nil, fmt.Errorf("%w: tag %s needs %d questions", ErrNotEnoughBankQuestions, draw.Tag, draw.Count)
+		}
+	}
+
+	if assignment.ShuffleOptions {
+		for i := range questions {
+			rand.Shuffle(len(questions[i].Options), func(a, b int) {
+				questions[i].Options[a], questions[i].Options[b] = questions[i].Options[b], questions[i].Options[a]
+			})
+		}
+	}
+	for i := range questions {
+		shuffleMatchingItems(&questions[i])
+	}
+	return questions, nil
+}
+
+// shuffleMatchingItems sets the items a student matches: the left items of the pairs in order and the
+// right items shuffled, so the student can answer without receiving the pairs
+func shuffleMatchingItems(question *model.Question) {
+	if len(question.MatchingPairs) == 0 {
+		return
+	}
+	question.MatchingLeft, question.MatchingRight = question.MatchingItems()
+	rand.Shuffle(len(question.MatchingRight), func(a, b int) {
+		question.MatchingRight[a], question.MatchingRight[b] = question.MatchingRight[b], question.MatchingRight[a]
+	})
+}
+
+// studentAssignment returns the assignment as the student received it, using the questions stored on the
+// submission when the assignment is randomized so grading doesn't depend on later changes of the bank
+func studentAssignment(assignment *model.Assignment, submission *model.Submission) *model.Assignment {
+	if len(submission.Questions) == 0 {
+		return assignment
+	}
+
+	received := *assignment
+	received.Questions = submission.Questions
+	received.TotalPoints = 0
+	for _, question := range submission.Questions {
+		received.TotalPoints += question.Points
+	}
+	return &received
+}
+
+func cloneQuestion(question model.Question) model.Question {
+	question.Options = slices.Clone(question.Options)
+	question.CorrectAnswers = slices.Clone(question.CorrectAnswers)
+	question.MatchingPairs = slices.Clone(question.MatchingPairs)
+	question.MatchingLeft = slices.Clone(question.MatchingLeft)
+	question.MatchingRight = slices.Clone(question.MatchingRight)
+	question.AcceptedPatterns = slices.Clone(question.AcceptedPatterns)
+	return question
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+	"courses-service/src/storage"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	maxGeneratedQuestions     = 20
+	defaultGeneratedPoints    = 1
+	maxGenerationContentBytes = 50_000 // Text of the resources sent to the model, the rest is left out
+)
+
+type QuestionGenerationService struct {
+	moduleRepo     repository.ModuleRepositoryInterface
+	assignmentRepo repository.AssignmentRepositoryInterface
+	fileRepo       repository.FileRepositoryInterface
+	fileStorage    storage.FileStorage
+	courseService  CourseServiceInterface
+	aiClient       ai.Provider
+}
+
+func NewQuestionGenerationService(
+	moduleRepo repository.ModuleRepositoryInterface,
+	assignmentRepo repository.AssignmentRepositoryInterface,
+	fileRepo repository.FileRepositoryInterface,
+	fileStorage storage.FileStorage,
+	courseService CourseServiceInterface,
+	aiClient ai.Provider,
+) *QuestionGenerationService {
+	return &QuestionGenerationService{
+		moduleRepo:     moduleRepo,
+		assignmentRepo: assignmentRepo,
+		fileRepo:       fileRepo,
+		fileStorage:    fileStorage,
+		courseService:  courseService,
+		aiClient:       aiClient,
+	}
+}
+
+// GenerateQuestions asks the AI for draft questions about the description and the text resources of a module.
+// The questions are not stored, the teacher reviews them before adding them to an assignment.
+func (s *QuestionGenerationService) GenerateQuestions(ctx context.Context, moduleID, teacherUUID string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	total := request.MultipleChoiceCount + request.TextCount
+	if total <= 0 || total > maxGeneratedQuestions {
+		return nil, fmt.Errorf("%w: between 1 and %d questions can be generated", ErrInvalidQuestionGeneration, maxGeneratedQuestions)
+	}
+	points := request.Points
+	if points <= 0 {
+		points = defaultGeneratedPoints
+	}
+
+	module, err := s.moduleRepo.GetModuleById(moduleID)
+	if err != nil {
+		return nil, err
+	}
+	course, err := s.checkCourseTeacher(module.CourseID, teacherUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.aiClient == nil {
+		return nil, ai.ErrUnavailable
+	}
+	scope := ai.Scope{CourseID: module.CourseID, TeacherUUID: course.TeacherUUID, Language: course.Language}
+	generated, err := s.aiClient.GenerateQuestions(ai.WithScope(ctx, scope), module, s.resourceTexts(ctx, module), request)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range generated.Questions {
+		generated.Questions[i].ID = primitive.NewObjectID().Hex()
+		generated.Questions[i].Points = points
+		generated.Questions[i].Order = i + 1
+	}
+	return generated, nil
+}
+
+// AddQuestions appends reviewed questions to a draft assignment, its total points grow by the points of the new questions.
+// Questions without an ID get a new one.
+func (s *QuestionGenerationService) AddQuestions(ctx context.Context, assignmentID, teacherUUID string, questions []model.Question) (*model.Assignment, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+	if _, err := s.checkCourseTeacher(assignment.CourseID, teacherUUID); err != nil {
+		return nil, err
+	}
+	if assignment.Status != model.AssignmentStatusDraft {
+		return nil, ErrAssignmentNotDraft
+	}
+
+	ids := make(map[string]bool)
+	nextOrder := 1
+	for _, question := range assignment.Questions {
+		ids[question.ID] = true
+		nextOrder = max(nextOrder, question.Order+1)
+	}
+
+	totalPoints := assignment.TotalPoints
+	for i := range questions {
+		question := &questions[i]
+		if question.ID == "" {
+			question.ID = primitive.NewObjectID().Hex()
+		}
+		if ids[question.ID] {
+			return nil, fmt.Errorf("%w: question %s is repeated", ErrInvalidQuestion, question.ID)
+		}
+		ids[question.ID] = true
+		if question.Points <= 0 {
+			return nil, fmt.Errorf("%w: question %s: points must be positive", ErrInvalidQuestion, question.ID)
+		}
+		question.Order = nextOrder
+		nextOrder++
+		totalPoints += question.Points
+	}
+	if err := validateQuestions(questions); err != nil {
+		return nil, err
+	}
+
+	return s.assignmentRepo.UpdateAssignment(assignmentID, model.Assignment{
+		Questions:   append(assignment.Questions, questions...),
+		TotalPoints: totalPoints,
+		UpdatedAt:   time.Now(),
+	})
+}
+
+// resourceTexts reads the text files uploaded as resources of the module. Other files can't be sent
+// to the model and are skipped, as are the resources that fail to load.
+func (s *QuestionGenerationService) resourceTexts(ctx context.Context, module *model.Module) []string {
+	if s.fileRepo == nil || s.fileStorage == nil {
+		return nil
+	}
+
+	var texts []string
+	remaining := int64(maxGenerationContentBytes)
+	for _, resource := range module.Resources {
+		if resource.FileID == "" || remaining <= 0 {
+			continue
+		}
+		file, err := s.fileRepo.GetByID(ctx, resource.FileID)
+		if err != nil || file == nil || file.CourseID != module.CourseID || !strings.HasPrefix(file.ContentType, "text/") {
+			continue
+		}
+
+		text, err := s.readText(ctx, file.StorageKey, remaining)
+		if err != nil {
+			log.Printf("Failed to read resource %s of module %s: %v", file.ID.Hex(), module.ID.Hex(), err)
+			continue
+		}
+		if text = strings.TrimSpace(text); text != "" {
+			texts = append(texts, text)
+			remaining -= int64(len(text))
+		}
+	}
+	return texts
+}
+
+func (s *QuestionGenerationService) readText(ctx context.Context, key string, limit int64) (string, error) {
+	content, err := s.fileStorage.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer content.Close()
+
+	text, err := io.ReadAll(io.LimitReader(content, limit))
+	if err != nil {
+		return "", err
+	}
+	// The limit may cut a character in half
+	return strings.ToValidUTF8(string(text), ""), nil
+}
+
+// checkCourseTeacher returns ErrUnauthorized if the teacher is not the titular or an auxiliary teacher of the course
+// allowed to create assignments
+func (s *QuestionGenerationService) checkCourseTeacher(courseID, teacherUUID string) (*model.Course, error) {
+	course, err := s.courseService.GetCourseById(courseID)
+	if err != nil {
+		return nil, err
+	}
+	if course == nil {
+		return nil, errors.New("course not found")
+	}
+
+	if err := authorizeTeacher(course, teacherUUID, model.PermissionCreateAssignments); err != nil {
+		return nil, err
+	}
+	return course, nil
+}
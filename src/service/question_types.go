@@ -0,0 +1,325 @@
+package service
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"courses-service/src/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// validateQuestions checks that every question is consistent for its type
+func validateQuestions(questions []model.Question) error {
+	for _, question := range questions {
+		if err := validateQuestion(question); err != nil {
+			return fmt.Errorf("%w: question %s: %v", ErrInvalidQuestion, question.ID, err)
+		}
+	}
+	return nil
+}
+
+// validateQuestion checks that the options and correct answers of a question make sense for its type
+func validateQuestion(question model.Question) error {
+	if question.Rubric != nil {
+		if question.Type != model.QuestionTypeText && question.Type != model.QuestionTypeFile {
+			return fmt.Errorf("only text and file questions can have a rubric")
+		}
+		if err := validateRubric(question.Rubric); err != nil {
+			return err
+		}
+	}
+
+	switch question.Type {
+	case model.QuestionTypeText, model.QuestionTypeFile:
+	case model.QuestionTypeMultipleChoice:
+		if len(question.Options) < 2 {
+			return fmt.Errorf("multiple choice questions need at least two options")
+		}
+		for _, correctAnswer := range question.CorrectAnswers {
+			if !slices.Contains(question.Options, correctAnswer) {
+				return fmt.Errorf("correct answer %q is not one of the options", correctAnswer)
+			}
+		}
+	case model.QuestionTypeNumeric:
+		if question.NumericAnswer == nil {
+			return fmt.Errorf("numeric questions need a numeric answer")
+		}
+		if question.Tolerance < 0 {
+			return fmt.Errorf("tolerance cannot be negative")
+		}
+	case model.QuestionTypeTrueFalse:
+		if len(question.CorrectAnswers) != 1 {
+			return fmt.Errorf("true/false questions need exactly one correct answer")
+		}
+		if _, ok := parseBool(question.CorrectAnswers[0]); !ok {
+			return fmt.Errorf("correct answer of a true/false question must be true or false")
+		}
+	case model.QuestionTypeMatching:
+		if len(question.MatchingPairs) < 2 {
+			return fmt.Errorf("matching questions need at least two pairs")
+		}
+		if len(question.MatchingLeft) > 0 || len(question.MatchingRight) > 0 {
+			return fmt.Errorf("the items of a matching question are taken from its pairs")
+		}
+		seen := make(map[string]bool)
+		for _, pair := range question.MatchingPairs {
+			if strings.TrimSpace(pair.Left) == "" || strings.TrimSpace(pair.Right) == "" {
+				return fmt.Errorf("matching pairs can't have empty items")
+			}
+			left := normalizeOption(pair.Left)
+			if seen[left] {
+				return fmt.Errorf("left item %q is repeated", pair.Left)
+			}
+			seen[left] = true
+		}
+	case model.QuestionTypeOrdering:
+		if len(question.Options) < 2 {
+			return fmt.Errorf("ordering questions need at least two options")
+		}
+		if len(question.CorrectAnswers) != len(question.Options) {
+			return fmt.Errorf("the correct order must include every option once")
+		}
+		for _, correctAnswer := range question.CorrectAnswers {
+			if !slices.Contains(question.Options, correctAnswer) {
+				return fmt.Errorf("correct answer %q is not one of the options", correctAnswer)
+			}
+		}
+		if len(distinct(question.CorrectAnswers)) != len(question.CorrectAnswers) {
+			return fmt.Errorf("the correct order must include every option once")
+		}
+	case model.QuestionTypeShortAnswer:
+		if len(question.AcceptedPatterns) == 0 {
+			return fmt.Errorf("short answer questions need at least one accepted pattern")
+		}
+		for _, pattern := range question.AcceptedPatterns {
+			if _, err := compileAcceptedPattern(pattern); err != nil {
+				return fmt.Errorf("invalid accepted pattern %q: %v", pattern, err)
+			}
+		}
+	default:
+		return fmt.Errorf("invalid question type %s", question.Type)
+	}
+	return nil
+}
+
+// validateAnswers checks that every answer refers to a question of the assignment and that its
+// content has the shape expected by the question type. The type of each answer is taken from its question.
+func validateAnswers(assignment *model.Assignment, answers []model.Answer) error {
+	questionMap := make(map[string]model.Question)
+	for _, question := range assignment.Questions {
+		questionMap[question.ID] = question
+	}
+
+	for i := range answers {
+		question, exists := questionMap[answers[i].QuestionID]
+		if !exists {
+			return fmt.Errorf("%w: question %s not found in assignment", ErrInvalidAnswer, answers[i].QuestionID)
+		}
+		if err := validateAnswerContent(question, answers[i].Content); err != nil {
+			return fmt.Errorf("%w: question %s: %v", ErrInvalidAnswer, question.ID, err)
+		}
+		answers[i].Type = string(question.Type)
+	}
+	return nil
+}
+
+func validateAnswerContent(question model.Question, content interface{}) error {
+	// Unanswered questions are allowed while the submission is a draft
+	if content == nil {
+		return nil
+	}
+
+	switch question.Type {
+	case model.QuestionTypeText, model.QuestionTypeShortAnswer:
+		if _, ok := content.(string); !ok {
+			return fmt.Errorf("answer must be a string")
+		}
+	case model.QuestionTypeFile:
+		if fileID, ok := content.(string); !ok || !primitive.IsValidObjectID(fileID) {
+			return fmt.Errorf("answer must be the id of an uploaded file")
+		}
+	case model.QuestionTypeMultipleChoice:
+		selections, ok := answerList(content)
+		if !ok {
+			return fmt.Errorf("answer must be an option or a list of options")
+		}
+		for _, selection := range selections {
+			if !containsOption(question.Options, selection) {
+				return fmt.Errorf("%q is not one of the options", selection)
+			}
+		}
+	case model.QuestionTypeNumeric:
+		if _, ok := parseNumber(content); !ok {
+			return fmt.Errorf("answer must be a number")
+		}
+	case model.QuestionTypeTrueFalse:
+		if _, ok := parseBool(content); !ok {
+			return fmt.Errorf("answer must be true or false")
+		}
+	case model.QuestionTypeMatching:
+		matches, ok := answerMatches(content)
+		if !ok {
+			return fmt.Errorf("answer must map each left item to a right item")
+		}
+		for left := range matches {
+			if !slices.ContainsFunc(question.MatchingPairs, func(pair model.MatchingPair) bool {
+				return normalizeOption(pair.Left) == left
+			}) {
+				return fmt.Errorf("%q is not one of the left items", left)
+			}
+		}
+	case model.QuestionTypeOrdering:
+		order, ok := answerList(content)
+		if !ok {
+			return fmt.Errorf("answer must be a list of options")
+		}
+		for _, item := range order {
+			if !containsOption(question.Options, item) {
+				return fmt.Errorf("%q is not one of the options", item)
+			}
+		}
+		if len(distinct(order)) != len(order) {
+			return fmt.Errorf("options can't be repeated")
+		}
+	}
+	return nil
+}
+
+// answerList converts the stored answer content into a list of strings.
+// Content may arrive as a single string, a []string or a generic array decoded from JSON/BSON.
+func answerList(content interface{}) ([]string, bool) {
+	switch value := content.(type) {
+	case string:
+		return []string{value}, true
+	case []string:
+		return value, true
+	case []interface{}:
+		return stringItems(value)
+	case primitive.A:
+		return stringItems(value)
+	default:
+		return nil, false
+	}
+}
+
+func stringItems(items []interface{}) ([]string, bool) {
+	list := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok {
+			return nil, false
+		}
+		list = append(list, str)
+	}
+	return list, true
+}
+
+// answerMatches converts the answer of a matching question into a map of normalized left to right items.
+// Content may arrive as a JSON object or as a BSON document.
+func answerMatches(content interface{}) (map[string]string, bool) {
+	matches := make(map[string]string)
+	add := func(left string, right interface{}) bool {
+		str, ok := right.(string)
+		if !ok {
+			return false
+		}
+		matches[normalizeOption(left)] = normalizeOption(str)
+		return true
+	}
+
+	switch value := content.(type) {
+	case map[string]string:
+		for left, right := range value {
+			matches[normalizeOption(left)] = normalizeOption(right)
+		}
+	case map[string]interface{}:
+		for left, right := range value {
+			if !add(left, right) {
+				return nil, false
+			}
+		}
+	case primitive.M:
+		for left, right := range value {
+			if !add(left, right) {
+				return nil, false
+			}
+		}
+	case primitive.D:
+		for _, element := range value {
+			if !add(element.Key, element.Value) {
+				return nil, false
+			}
+		}
+	default:
+		return nil, false
+	}
+	return matches, true
+}
+
+// parseNumber accepts numbers decoded from JSON or BSON and numeric strings
+func parseNumber(content interface{}) (float64, bool) {
+	switch value := content.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case int:
+		return float64(value), true
+	case int32:
+		return float64(value), true
+	case int64:
+		return float64(value), true
+	case string:
+		number, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return 0, false
+		}
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+// parseBool accepts booleans and the strings "true" and "false"
+func parseBool(content interface{}) (bool, bool) {
+	switch value := content.(type) {
+	case bool:
+		return value, true
+	case string:
+		switch normalizeOption(value) {
+		case "true":
+			return true, true
+		case "false":
+			return false, true
+		}
+	}
+	return false, false
+}
+
+// compileAcceptedPattern compiles a short answer pattern so it must match the whole answer, ignoring case
+func compileAcceptedPattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile(`^(?i:` + pattern + `)$`)
+}
+
+func containsOption(options []string, option string) bool {
+	return slices.ContainsFunc(options, func(candidate string) bool {
+		return normalizeOption(candidate) == normalizeOption(option)
+	})
+}
+
+func distinct(items []string) map[string]bool {
+	set := make(map[string]bool)
+	for _, item := range items {
+		set[normalizeOption(item)] = true
+	}
+	return set
+}
+
+func normalizeOption(option string) string {
+	return strings.ToLower(strings.TrimSpace(option))
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"fmt"
+
+	"courses-service/src/model"
+)
+
+// validateRubric checks that every criterion has uniquely identified levels and that the rubric awards points
+func validateRubric(rubric *model.Rubric) error {
+	if rubric == nil {
+		return nil
+	}
+	if len(rubric.Criteria) == 0 {
+		return fmt.Errorf("rubrics need at least one criterion")
+	}
+
+	criteria := make(map[string]bool)
+	for _, criterion := range rubric.Criteria {
+		if criterion.ID == "" {
+			return fmt.Errorf("rubric criteria need an id")
+		}
+		if criteria[criterion.ID] {
+			return fmt.Errorf("rubric criterion %s is repeated", criterion.ID)
+		}
+		criteria[criterion.ID] = true
+
+		if len(criterion.Levels) == 0 {
+			return fmt.Errorf("rubric criterion %s needs at least one level", criterion.ID)
+		}
+		levels := make(map[string]bool)
+		for _, level := range criterion.Levels {
+			if level.ID == "" {
+				return fmt.Errorf("levels of rubric criterion %s need an id", criterion.ID)
+			}
+			if levels[level.ID] {
+				return fmt.Errorf("level %s of rubric criterion %s is repeated", level.ID, criterion.ID)
+			}
+			levels[level.ID] = true
+			if level.Points < 0 {
+				return fmt.Errorf("level %s of rubric criterion %s has negative points", level.ID, criterion.ID)
+			}
+		}
+	}
+
+	if rubricMaxPoints(rubric) <= 0 {
+		return fmt.Errorf("rubrics must award points")
+	}
+	return nil
+}
+
+// rubricMaxPoints sums the highest level of every criterion
+func rubricMaxPoints(rubric *model.Rubric) float64 {
+	var total float64
+	for _, criterion := range rubric.Criteria {
+		var best float64
+		for _, level := range criterion.Levels {
+			best = max(best, level.Points)
+		}
+		total += best
+	}
+	return total
+}
+
+// rubricScore scales the points of the chosen levels to maxPoints.
+// Every criterion of the rubric must have exactly one chosen level.
+func rubricScore(rubric *model.Rubric, selections []model.RubricSelection, maxPoints float64) (float64, error) {
+	chosen := make(map[string]string)
+	for _, selection := range selections {
+		if _, repeated := chosen[selection.CriterionID]; repeated {
+			return 0, fmt.Errorf("criterion %s is selected more than once", selection.CriterionID)
+		}
+		chosen[selection.CriterionID] = selection.LevelID
+	}
+
+	var points float64
+	for _, criterion := range rubric.Criteria {
+		levelID, selected := chosen[criterion.ID]
+		if !selected {
+			return 0, fmt.Errorf("criterion %s has no level selected", criterion.ID)
+		}
+		delete(chosen, criterion.ID)
+
+		found := false
+		for _, level := range criterion.Levels {
+			if level.ID == levelID {
+				points += level.Points
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("level %s not found in criterion %s", levelID, criterion.ID)
+		}
+	}
+	for criterionID := range chosen {
+		return 0, fmt.Errorf("criterion %s not found in rubric", criterionID)
+	}
+
+	return maxPoints * points / rubricMaxPoints(rubric), nil
+}
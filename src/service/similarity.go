@@ -0,0 +1,121 @@
+package service
+
+import (
+	"hash/fnv"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+const (
+	shingleSize     = 5   // Words per shingle
+	minHashSize     = 128 // Hash functions of the MinHash signature
+	maxExcerpts     = 3
+	minExcerptWords = shingleSize
+)
+
+// minHashSeeds are the seeds of the hash functions, fixed so signatures are comparable across reports
+var minHashSeeds = func() []uint64 {
+	seeds := make([]uint64, minHashSize)
+	state := uint64(0x9e3779b97f4a7c15)
+	for i := range seeds {
+		state = splitMix64(state)
+		seeds[i] = state
+	}
+	return seeds
+}()
+
+// textFingerprint is the MinHash signature of a text answer, built from its word shingles
+type textFingerprint struct {
+	words     []string         // Original words, used to build the excerpts
+	shingles  map[uint64][]int // Shingle hash to the positions of its first word
+	signature [minHashSize]uint64
+}
+
+// fingerprintText returns the fingerprint of a text, or nil if it's too short to be compared
+func fingerprintText(text string) *textFingerprint {
+	words := strings.FieldsFunc(text, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) < shingleSize {
+		return nil
+	}
+
+	fingerprint := &textFingerprint{words: words, shingles: make(map[uint64][]int)}
+	for i := range fingerprint.signature {
+		fingerprint.signature[i] = math.MaxUint64
+	}
+	for i := 0; i+shingleSize <= len(words); i++ {
+		hash := fnv.New64a()
+		for _, word := range words[i : i+shingleSize] {
+			hash.Write([]byte(strings.ToLower(word)))
+			hash.Write([]byte{' '})
+		}
+		shingle := hash.Sum64()
+		fingerprint.shingles[shingle] = append(fingerprint.shingles[shingle], i)
+
+		for j, seed := range minHashSeeds {
+			if value := splitMix64(shingle ^ seed); value < fingerprint.signature[j] {
+				fingerprint.signature[j] = value
+			}
+		}
+	}
+	return fingerprint
+}
+
+// similarity estimates the Jaccard similarity of the shingles of both texts
+func (f *textFingerprint) similarity(other *textFingerprint) float64 {
+	matches := 0
+	for i := range f.signature {
+		if f.signature[i] == other.signature[i] {
+			matches++
+		}
+	}
+	return float64(matches) / minHashSize
+}
+
+// excerpts returns the longest passages of the text that also appear in the other text
+func (f *textFingerprint) excerpts(other *textFingerprint) []string {
+	covered := make([]bool, len(f.words))
+	for shingle, positions := range f.shingles {
+		if _, shared := other.shingles[shingle]; !shared {
+			continue
+		}
+		for _, position := range positions {
+			for i := position; i < position+shingleSize; i++ {
+				covered[i] = true
+			}
+		}
+	}
+
+	var passages []string
+	for start := 0; start < len(covered); start++ {
+		if !covered[start] {
+			continue
+		}
+		end := start
+		for end < len(covered) && covered[end] {
+			end++
+		}
+		if end-start >= minExcerptWords {
+			passages = append(passages, strings.Join(f.words[start:end], " "))
+		}
+		start = end
+	}
+
+	sort.SliceStable(passages, func(i, j int) bool {
+		return len(passages[i]) > len(passages[j])
+	})
+	if len(passages) > maxExcerpts {
+		passages = passages[:maxExcerpts]
+	}
+	return passages
+}
+
+func splitMix64(x uint64) uint64 {
+	x += 0x9e3779b97f4a7c15
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
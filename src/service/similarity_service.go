@@ -0,0 +1,288 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+)
+
+const defaultSimilarityThreshold = 0.5
+
+type SimilarityService struct {
+	submissionRepo repository.SubmissionRepositoryInterface
+	assignmentRepo repository.AssignmentRepositoryInterface
+	courseService  CourseServiceInterface
+	auditService   AuditServiceInterface
+}
+
+func NewSimilarityService(submissionRepo repository.SubmissionRepositoryInterface, assignmentRepo repository.AssignmentRepositoryInterface, courseService CourseServiceInterface, auditService AuditServiceInterface) *SimilarityService {
+	return &SimilarityService{
+		submissionRepo: submissionRepo,
+		assignmentRepo: assignmentRepo,
+		courseService:  courseService,
+		auditService:   auditService,
+	}
+}
+
+// similarityDocument is a text answer of a submission ready to be compared
+type similarityDocument struct {
+	submission  *model.Submission
+	priorTerm   bool
+	fingerprint *textFingerprint
+}
+
+// GetSimilarityReport compares the text answers of the submitted work of an assignment between students
+// and with the answers to the same questions in earlier editions of the course
+func (s *SimilarityService) GetSimilarityReport(ctx context.Context, assignmentID, teacherUUID string, request schemas.SimilarityReportRequest) (*schemas.SimilarityReport, error) {
+	assignment, course, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	threshold := request.Threshold
+	if threshold == 0 {
+		threshold = defaultSimilarityThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, fmt.Errorf("%w: threshold must be between 0 and 1", ErrInvalidSimilarityThreshold)
+	}
+
+	submissions, err := s.submissionRepo.GetByAssignment(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	current, questionTexts := similarityDocuments(assignment, submissions, false)
+
+	report := &schemas.SimilarityReport{
+		AssignmentID:        assignmentID,
+		CourseID:            assignment.CourseID,
+		Threshold:           threshold,
+		ComparedSubmissions: countSubmissions(current),
+		Pairs:               []schemas.SimilarityPair{},
+		GeneratedAt:         time.Now(),
+	}
+
+	// Prior terms are matched by the text of the question, question ids change between editions
+	prior := make(map[string][]similarityDocument)
+	if request.IncludePriorTerms == nil || *request.IncludePriorTerms {
+		prior, err = s.priorTermDocuments(ctx, assignment, course)
+		if err != nil {
+			return nil, err
+		}
+		report.PriorTermSubmissions = countSubmissions(prior)
+	}
+
+	for questionID, documents := range current {
+		for i := range documents {
+			for j := i + 1; j < len(documents); j++ {
+				if pair, ok := comparePair(questionID, documents[i], documents[j], threshold); ok {
+					report.Pairs = append(report.Pairs, pair)
+				}
+			}
+			for _, other := range prior[questionTexts[questionID]] {
+				if pair, ok := comparePair(questionID, documents[i], other, threshold); ok {
+					report.Pairs = append(report.Pairs, pair)
+				}
+			}
+		}
+	}
+
+	sort.Slice(report.Pairs, func(i, j int) bool {
+		a, b := report.Pairs[i], report.Pairs[j]
+		if a.Similarity != b.Similarity {
+			return a.Similarity > b.Similarity
+		}
+		if a.QuestionID != b.QuestionID {
+			return a.QuestionID < b.QuestionID
+		}
+		return a.Submission.SubmissionID+a.Other.SubmissionID < b.Submission.SubmissionID+b.Other.SubmissionID
+	})
+	return report, nil
+}
+
+// FlagSimilarSubmissions builds the similarity report and marks the submissions of the assignment
+// found in a suspicious pair as needing manual review. Each flag is recorded in the audit log.
+func (s *SimilarityService) FlagSimilarSubmissions(ctx context.Context, assignmentID, teacherUUID string, request schemas.SimilarityReportRequest) (*schemas.SimilarityReport, error) {
+	if _, _, err := s.getAssignmentForTeacher(ctx, assignmentID, teacherUUID, model.PermissionGradeSubmissions); err != nil {
+		return nil, err
+	}
+	report, err := s.GetSimilarityReport(ctx, assignmentID, teacherUUID, request)
+	if err != nil {
+		return nil, err
+	}
+
+	flagged := make(map[string]bool)
+	for _, pair := range report.Pairs {
+		for _, submission := range []schemas.SimilarSubmission{pair.Submission, pair.Other} {
+			if submission.PriorTerm || flagged[submission.SubmissionID] {
+				continue
+			}
+			flagged[submission.SubmissionID] = true
+
+			stored, err := s.submissionRepo.GetByID(ctx, submission.SubmissionID)
+			if err != nil {
+				return nil, err
+			}
+			if stored == nil || (stored.NeedsManualReview != nil && *stored.NeedsManualReview) {
+				continue
+			}
+			needsReview := true
+			stored.NeedsManualReview = &needsReview
+			err = recordSystemChange(ctx, s.auditService, model.AuditEntitySubmission, submission.SubmissionID, "similarity_flag", func() error {
+				return s.submissionRepo.Update(ctx, stored)
+			})
+			if err != nil {
+				return nil, err
+			}
+			report.FlaggedSubmissions++
+		}
+	}
+	return report, nil
+}
+
+// priorTermDocuments returns the text answers to the same assignment in earlier editions of the course,
+// grouped by the normalized text of their question. Earlier editions are the courses of the same teacher
+// with the same title that started before this one.
+func (s *SimilarityService) priorTermDocuments(ctx context.Context, assignment *model.Assignment, course *model.Course) (map[string][]similarityDocument, error) {
+	documents := make(map[string][]similarityDocument)
+
+	courses, err := s.courseService.GetCourseByTeacherId(course.TeacherUUID)
+	if err != nil {
+		return nil, err
+	}
+	for _, priorCourse := range courses {
+		if priorCourse.ID == course.ID || normalizeOption(priorCourse.Title) != normalizeOption(course.Title) || !priorCourse.StartDate.Before(course.StartDate) {
+			continue
+		}
+
+		assignments, err := s.assignmentRepo.GetAssignmentsByCourseId(priorCourse.ID.Hex())
+		if err != nil {
+			return nil, err
+		}
+		for _, priorAssignment := range assignments {
+			if normalizeOption(priorAssignment.Title) != normalizeOption(assignment.Title) {
+				continue
+			}
+			submissions, err := s.submissionRepo.GetByAssignment(ctx, priorAssignment.ID.Hex())
+			if err != nil {
+				return nil, err
+			}
+			byQuestion, questionTexts := similarityDocuments(priorAssignment, submissions, true)
+			for questionID, questionDocuments := range byQuestion {
+				text := questionTexts[questionID]
+				documents[text] = append(documents[text], questionDocuments...)
+			}
+		}
+	}
+	return documents, nil
+}
+
+// getAssignmentForTeacher returns the assignment and its course if the teacher is the titular or an auxiliary
+// teacher of the course with the permissions
+func (s *SimilarityService) getAssignmentForTeacher(ctx context.Context, assignmentID, teacherUUID string, permissions ...model.AuxTeacherPermission) (*model.Assignment, *model.Course, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if assignment == nil {
+		return nil, nil, ErrAssignmentNotFound
+	}
+
+	course, err := s.courseService.GetCourseById(assignment.CourseID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if course == nil {
+		return nil, nil, errors.New("course not found")
+	}
+
+	if err := authorizeTeacher(course, teacherUUID, permissions...); err != nil {
+		return nil, nil, err
+	}
+	return assignment, course, nil
+}
+
+// similarityDocuments fingerprints the text answers of the submitted work, grouped by question id.
+// It also returns the normalized text of each question.
+func similarityDocuments(assignment *model.Assignment, submissions []model.Submission, priorTerm bool) (map[string][]similarityDocument, map[string]string) {
+	documents := make(map[string][]similarityDocument)
+	questionTexts := make(map[string]string)
+
+	for i := range submissions {
+		submission := &submissions[i]
+		if submission.Status == model.SubmissionStatusDraft {
+			continue
+		}
+
+		questions := make(map[string]model.Question)
+		for _, question := range studentAssignment(assignment, submission).Questions {
+			questions[question.ID] = question
+		}
+		for _, answer := range submission.Answers {
+			question, exists := questions[answer.QuestionID]
+			if !exists || question.Type != model.QuestionTypeText {
+				continue
+			}
+			text, ok := answer.Content.(string)
+			if !ok {
+				continue
+			}
+			fingerprint := fingerprintText(text)
+			if fingerprint == nil {
+				continue
+			}
+			questionTexts[question.ID] = normalizeOption(question.Text)
+			documents[question.ID] = append(documents[question.ID], similarityDocument{
+				submission:  submission,
+				priorTerm:   priorTerm,
+				fingerprint: fingerprint,
+			})
+		}
+	}
+	return documents, questionTexts
+}
+
+func comparePair(questionID string, document, other similarityDocument, threshold float64) (schemas.SimilarityPair, bool) {
+	if document.submission.StudentUUID == other.submission.StudentUUID {
+		return schemas.SimilarityPair{}, false
+	}
+	similarity := document.fingerprint.similarity(other.fingerprint)
+	if similarity < threshold {
+		return schemas.SimilarityPair{}, false
+	}
+
+	return schemas.SimilarityPair{
+		QuestionID: questionID,
+		Similarity: math.Round(similarity*100) / 100,
+		Submission: similarSubmission(document),
+		Other:      similarSubmission(other),
+		Excerpts:   document.fingerprint.excerpts(other.fingerprint),
+	}, true
+}
+
+func similarSubmission(document similarityDocument) schemas.SimilarSubmission {
+	return schemas.SimilarSubmission{
+		SubmissionID: document.submission.ID.Hex(),
+		AssignmentID: document.submission.AssignmentID,
+		StudentUUID:  document.submission.StudentUUID,
+		StudentName:  document.submission.StudentName,
+		PriorTerm:    document.priorTerm,
+	}
+}
+
+func countSubmissions(documents map[string][]similarityDocument) int {
+	submissions := make(map[*model.Submission]bool)
+	for _, questionDocuments := range documents {
+		for _, document := range questionDocuments {
+			submissions[document.submission] = true
+		}
+	}
+	return len(submissions)
+}
@@ -19,6 +19,7 @@ type StatisticsService struct {
 	enrollmentRepo repository.EnrollmentRepositoryInterface
 	submissionRepo repository.SubmissionRepositoryInterface
 	forumRepo      repository.ForumRepositoryInterface
+	extensionRepo  repository.ExtensionRepositoryInterface
 }
 
 // NewStatisticsService creates a new instance of StatisticsService
@@ -28,6 +29,7 @@ func NewStatisticsService(
 	enrollmentRepo repository.EnrollmentRepositoryInterface,
 	submissionRepo repository.SubmissionRepositoryInterface,
 	forumRepo repository.ForumRepositoryInterface,
+	extensionRepo repository.ExtensionRepositoryInterface,
 ) StatisticsServiceInterface {
 	return &StatisticsService{
 		courseRepo:     courseRepo,
@@ -35,6 +37,7 @@ func NewStatisticsService(
 		enrollmentRepo: enrollmentRepo,
 		submissionRepo: submissionRepo,
 		forumRepo:      forumRepo,
+		extensionRepo:  extensionRepo,
 	}
 }
 
@@ -113,7 +116,12 @@ func (s *StatisticsService) ExportStudentStatsCSV(
 	if err != nil {
 		return nil, "", err
 	}
-	filteredAssignments, _, _ = s.filterAndSeparateAssignments(assignments, from, to)
+	extensions, err := s.courseExtensions(ctx, courseID)
+	if err != nil {
+		return nil, "", err
+	}
+	// Use the due dates of the student, extensions can move an assignment in or out of the period
+	filteredAssignments, _, _ = s.filterAndSeparateAssignments(assignments, extensions, studentID, from, to)
 	courseTitle = course.Title
 
 	// Get student statistics
@@ -184,6 +192,10 @@ func (s *StatisticsService) ExportTeacherCoursesStatsCSV(
 	writer.Write(header)
 
 	for _, course := range courses {
+		// The suspended courses are only in the backoffice statistics
+		if course.CurrentStatus() == model.CourseStatusSuspended {
+			continue
+		}
 		stats, err := s.GetCourseStatistics(ctx, course.ID.Hex(), from, to)
 		if err != nil {
 			// Optionally skip this course or write an error row; here we skip
@@ -251,13 +263,26 @@ func (s *StatisticsService) GetCourseStatistics(
 		return nil, err
 	}
 
+	extensions, err := s.courseExtensions(ctx, courseID)
+	if err != nil {
+		return nil, err
+	}
+
 	// Filter assignments by date and separate by type
-	filteredAssignments, examAssignments, homeworkAssignments := s.filterAndSeparateAssignments(assignments, from, to)
+	filteredAssignments, examAssignments, homeworkAssignments := s.filterAndSeparateAssignments(assignments, nil, "", from, to)
 
-	// Process each enrollment to gather student data
+	// Process each enrollment to gather student data, each student is evaluated against their own due dates
 	allStudentStats := make([]schemas.StudentStats, 0, len(enrollments))
+	assignedCount := 0
+	assignedExams := 0
+	assignedHomeworks := 0
 	for _, enrollment := range enrollments {
-		studentStats := s.GetStudentStatistics(ctx, enrollment.StudentID, courseID, filteredAssignments)
+		studentAssignments, studentExams, studentHomeworks := s.filterAndSeparateAssignments(assignments, extensions, enrollment.StudentID, from, to)
+		assignedCount += len(studentAssignments)
+		assignedExams += len(studentExams)
+		assignedHomeworks += len(studentHomeworks)
+
+		studentStats := s.GetStudentStatistics(ctx, enrollment.StudentID, courseID, studentAssignments)
 		allStudentStats = append(allStudentStats, studentStats)
 	}
 
@@ -304,20 +329,20 @@ func (s *StatisticsService) GetCourseStatistics(
 
 	// Calculate exam completion rate
 	examCompletionRate := 0.0
-	if len(enrollments)*len(examAssignments) > 0 {
-		examCompletionRate = float64(examCompleted) / float64(len(enrollments)*len(examAssignments)) * 100
+	if assignedExams > 0 {
+		examCompletionRate = float64(examCompleted) / float64(assignedExams) * 100
 	}
 
 	// Calculate homework completion rate
 	homeworkCompletionRate := 0.0
-	if len(enrollments)*len(homeworkAssignments) > 0 {
-		homeworkCompletionRate = float64(homeworkCompleted) / float64(len(enrollments)*len(homeworkAssignments)) * 100
+	if assignedHomeworks > 0 {
+		homeworkCompletionRate = float64(homeworkCompleted) / float64(assignedHomeworks) * 100
 	}
 
 	// Calculate assignment completion rate across all students
 	assignmentCompletionRate := 0.0
-	if len(enrollments)*len(filteredAssignments) > 0 {
-		assignmentCompletionRate = float64(completedCount) / float64(len(enrollments)*len(filteredAssignments)) * 100
+	if assignedCount > 0 {
+		assignmentCompletionRate = float64(completedCount) / float64(assignedCount) * 100
 	}
 
 	// Calculate forum participation rate
@@ -374,37 +399,46 @@ func (s *StatisticsService) GetStudentStatistics(
 		return schemas.StudentStats{}
 	}
 
-	// Calculate student statistics
+	// Group the attempts of each assignment
+	attemptsByAssignment := make(map[string][]model.Submission)
 	for _, submission := range submissions {
-		// Only count submissions for this course's assignments
-		for _, assignment := range filteredAssignments {
-			if submission.AssignmentID == assignment.ID.Hex() {
-				// Count completion (submitted but not necessarily graded)
-				if submission.Status != model.SubmissionStatusDraft {
-					completedAssignments++
-
-					// Count type-specific completion
-					switch assignment.Type {
-					case "exam":
-						examCompleted++
-					case "homework":
-						homeworkCompleted++
-					}
-				}
-
-				// Calculate scores (only for graded submissions)
-				if submission.Score != nil {
-					// Safe handling of nil Score
-					studentScore += *submission.Score
-
-					// Add to type-specific score totals
-					switch assignment.Type {
-					case "exam":
-						examScore += *submission.Score
-					case "homework":
-						homeworkScore += *submission.Score
-					}
-				}
+		attemptsByAssignment[submission.AssignmentID] = append(attemptsByAssignment[submission.AssignmentID], submission)
+	}
+
+	// Calculate student statistics
+	for _, assignment := range filteredAssignments {
+		attempts := attemptsByAssignment[assignment.ID.Hex()]
+
+		// Count completion (submitted but not necessarily graded)
+		completed := false
+		for _, attempt := range attempts {
+			if attempt.Status != model.SubmissionStatusDraft {
+				completed = true
+				break
+			}
+		}
+		if completed {
+			completedAssignments++
+
+			// Count type-specific completion
+			switch assignment.Type {
+			case "exam":
+				examCompleted++
+			case "homework":
+				homeworkCompleted++
+			}
+		}
+
+		// Calculate scores (only for graded submissions), combining attempts with the assignment policy
+		if score := finalAttemptScore(scoringPolicy(assignment), attempts); score != nil {
+			studentScore += *score
+
+			// Add to type-specific score totals
+			switch assignment.Type {
+			case "exam":
+				examScore += *score
+			case "homework":
+				homeworkScore += *score
 			}
 		}
 	}
@@ -472,16 +506,26 @@ func (s *StatisticsService) GetStudentStatistics(
 	}
 }
 
-// filterAndSeparateAssignments filters assignments by date range and separates them by type
-func (s *StatisticsService) filterAndSeparateAssignments(assignments []*model.Assignment, from, to time.Time) ([]*model.Assignment, []*model.Assignment, []*model.Assignment) {
+// courseExtensions returns the deadline extensions granted in the course
+func (s *StatisticsService) courseExtensions(ctx context.Context, courseID string) ([]model.DeadlineExtension, error) {
+	if s.extensionRepo == nil {
+		return nil, nil
+	}
+	return s.extensionRepo.GetByCourse(ctx, courseID)
+}
+
+// filterAndSeparateAssignments filters assignments by date range and separates them by type.
+// The due date of each assignment is the one of the student, including their extensions.
+func (s *StatisticsService) filterAndSeparateAssignments(assignments []*model.Assignment, extensions []model.DeadlineExtension, studentID string, from, to time.Time) ([]*model.Assignment, []*model.Assignment, []*model.Assignment) {
 	filteredAssignments := []*model.Assignment{}
 	examAssignments := []*model.Assignment{}
 	homeworkAssignments := []*model.Assignment{}
 
 	for _, assignment := range assignments {
+		dueDate := studentDueDate(assignment, extensions, studentID)
 		// Check if assignment is within the date range
-		if (assignment.DueDate.After(from) || assignment.DueDate.Equal(from)) &&
-			(assignment.DueDate.Before(to) || assignment.DueDate.Equal(to)) {
+		if (dueDate.After(from) || dueDate.Equal(from)) &&
+			(dueDate.Before(to) || dueDate.Equal(to)) {
 			filteredAssignments = append(filteredAssignments, assignment)
 
 			// Separate by type
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"strings"
 	"time"
 
@@ -15,18 +16,28 @@ import (
 )
 
 type SubmissionService struct {
-	submissionRepo repository.SubmissionRepositoryInterface
-	assignmentRepo repository.AssignmentRepositoryInterface
-	courseService  CourseServiceInterface
-	aiClient       *ai.AiClient
+	submissionRepo   repository.SubmissionRepositoryInterface
+	assignmentRepo   repository.AssignmentRepositoryInterface
+	extensionRepo    repository.ExtensionRepositoryInterface
+	questionBankRepo repository.QuestionBankRepositoryInterface
+	fileRepo         repository.FileRepositoryInterface
+	courseService    CourseServiceInterface
+	aiClient         ai.Provider
+	correctionQueue  CorrectionQueueInterface
+	auditService     AuditServiceInterface // Records the changes made outside of the requests, as the AI correction
 }
 
-func NewSubmissionService(submissionRepo repository.SubmissionRepositoryInterface, assignmentRepo repository.AssignmentRepositoryInterface, courseService CourseServiceInterface, aiClient *ai.AiClient) *SubmissionService {
+func NewSubmissionService(submissionRepo repository.SubmissionRepositoryInterface, assignmentRepo repository.AssignmentRepositoryInterface, extensionRepo repository.ExtensionRepositoryInterface, questionBankRepo repository.QuestionBankRepositoryInterface, fileRepo repository.FileRepositoryInterface, courseService CourseServiceInterface, aiClient ai.Provider, correctionQueue CorrectionQueueInterface, auditService AuditServiceInterface) *SubmissionService {
 	return &SubmissionService{
-		submissionRepo: submissionRepo,
-		assignmentRepo: assignmentRepo,
-		courseService:  courseService,
-		aiClient:       aiClient,
+		submissionRepo:   submissionRepo,
+		assignmentRepo:   assignmentRepo,
+		extensionRepo:    extensionRepo,
+		questionBankRepo: questionBankRepo,
+		fileRepo:         fileRepo,
+		courseService:    courseService,
+		aiClient:         aiClient,
+		correctionQueue:  correctionQueue,
+		auditService:     auditService,
 	}
 }
 
@@ -40,6 +51,13 @@ func (s *SubmissionService) CreateSubmission(ctx context.Context, submission *mo
 		return ErrAssignmentNotFound
 	}
 
+	if err := validateAnswers(studentAssignment(assignment, submission), submission.Answers); err != nil {
+		return err
+	}
+	if err := s.checkAnswerFiles(ctx, submission.StudentUUID, submission.Answers); err != nil {
+		return err
+	}
+
 	// Initialize submission
 	submission.CreatedAt = time.Now()
 	submission.UpdatedAt = submission.CreatedAt
@@ -56,9 +74,59 @@ func (s *SubmissionService) UpdateSubmission(ctx context.Context, submission *mo
 	if existing == nil {
 		return ErrSubmissionNotFound
 	}
+	if existing.StudentUUID != submission.StudentUUID {
+		return fmt.Errorf("%w: the submission belongs to another student", ErrUnauthorized)
+	}
 
-	submission.UpdatedAt = time.Now()
-	return s.submissionRepo.Update(ctx, submission)
+	// Submitted or graded submissions are locked
+	if existing.Status != model.SubmissionStatusDraft || existing.Score != nil {
+		return ErrSubmissionLocked
+	}
+
+	assignment, err := s.assignmentRepo.GetByID(ctx, existing.AssignmentID)
+	if err != nil {
+		return err
+	}
+	if assignment == nil {
+		return ErrAssignmentNotFound
+	}
+
+	extensions, err := s.studentExtensions(ctx, existing.AssignmentID, existing.StudentUUID)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+
+	// Answers sent after the time of a timed exam ran out are rejected, the answers saved until then are submitted
+	if examTimeExpired(existing, now) {
+		if err := s.autoSubmit(ctx, existing, assignment, extensions); err != nil {
+			return err
+		}
+		return ErrExamTimeExpired
+	}
+
+	// Drafts can't be edited once the hard deadline passed if late submissions are rejected
+	if assignment.LatePolicy == model.LatePolicyReject && now.After(hardDeadline(assignment, extensions, existing.StudentUUID)) {
+		return ErrLateSubmission
+	}
+
+	if err := validateAnswers(studentAssignment(assignment, existing), submission.Answers); err != nil {
+		return err
+	}
+	if err := s.checkAnswerFiles(ctx, existing.StudentUUID, submission.Answers); err != nil {
+		return err
+	}
+
+	// Students can only change their answers, grading fields are kept from the stored submission
+	existing.Answers = submission.Answers
+	existing.UpdatedAt = now
+	if err := s.submissionRepo.Update(ctx, existing); err != nil {
+		return err
+	}
+
+	*submission = *existing
+	return nil
 }
 
 func (s *SubmissionService) SubmitSubmission(ctx context.Context, submissionID string) error {
@@ -69,6 +137,9 @@ func (s *SubmissionService) SubmitSubmission(ctx context.Context, submissionID s
 	if submission == nil {
 		return ErrSubmissionNotFound
 	}
+	if submission.Status != model.SubmissionStatusDraft {
+		return ErrAlreadySubmitted
+	}
 
 	assignment, err := s.assignmentRepo.GetByID(ctx, submission.AssignmentID)
 	if err != nil {
@@ -78,39 +149,145 @@ func (s *SubmissionService) SubmitSubmission(ctx context.Context, submissionID s
 		return ErrAssignmentNotFound
 	}
 
+	extensions, err := s.studentExtensions(ctx, submission.AssignmentID, submission.StudentUUID)
+	if err != nil {
+		return err
+	}
+
 	now := time.Now()
-	submission.SubmittedAt = &now
-	submission.UpdatedAt = now
 
-	// Check if submission is late
-	if now.After(assignment.DueDate) {
-		gracePeriodEnd := assignment.DueDate.Add(time.Duration(assignment.GracePeriod) * time.Minute)
-		if now.After(gracePeriodEnd) {
-			submission.Status = model.SubmissionStatusLate
-		} else {
-			submission.Status = model.SubmissionStatusSubmitted
-		}
-	} else {
-		submission.Status = model.SubmissionStatusSubmitted
+	// The time of a timed exam ran out, only the answers saved until then are submitted
+	if examTimeExpired(submission, now) {
+		return s.autoSubmit(ctx, submission, assignment, extensions)
 	}
 
+	return s.finalizeSubmission(ctx, submission, assignment, extensions, now)
+}
+
+// finalizeSubmission marks the submission as submitted at the given time, applying the late policy, and auto corrects it
+func (s *SubmissionService) finalizeSubmission(ctx context.Context, submission *model.Submission, assignment *model.Assignment, extensions []model.DeadlineExtension, submittedAt time.Time) error {
+	// Check if submission is late and apply the assignment late policy
+	status, latePenalty, err := evaluateLateness(assignment, hardDeadline(assignment, extensions, submission.StudentUUID), submittedAt)
+	if err != nil {
+		return err
+	}
+
+	submission.SubmittedAt = &submittedAt
+	submission.UpdatedAt = time.Now()
+	submission.Status = status
+	submission.LatePenalty = latePenalty
+
 	// Update submission status first
 	err = s.submissionRepo.Update(ctx, submission)
 	if err != nil {
 		return err
 	}
 
-	// Attempt automatic correction after submission
-	if err := s.AutoCorrectSubmission(ctx, submissionID); err != nil {
-		// Log the error but don't fail the submission process
-		// The submission is already marked as submitted
-		fmt.Println("error auto correcting submission:", err)
-		_ = err // Ignore auto-correction errors for now
+	// The AI correction runs in the background so a slow AI doesn't block the student
+	if s.correctionQueue != nil {
+		if _, err := s.correctionQueue.Enqueue(ctx, submission); err != nil {
+			// The submission is already marked as submitted, teachers can still grade it manually
+			log.Printf("error queueing correction of submission %s: %v", submission.ID.Hex(), err)
+		}
+		return nil
+	}
+
+	// Without a queue the submission is corrected right away, as part of the change that submitted it
+	if err := s.autoCorrectSubmission(ctx, submission.ID.Hex()); err != nil {
+		log.Printf("error auto correcting submission %s: %v", submission.ID.Hex(), err)
+		if errors.Is(err, ErrAICorrectionFailed) {
+			if err := s.markCorrectionFailed(ctx, submission.ID.Hex()); err != nil {
+				log.Printf("error marking submission %s for manual review: %v", submission.ID.Hex(), err)
+			}
+		}
 	}
 
 	return nil
 }
 
+// GetCorrectionStatus returns the last AI correction job of a submission. Only the student
+// who made the submission and the teachers of the course can see it.
+func (s *SubmissionService) GetCorrectionStatus(ctx context.Context, submissionID, userUUID string) (*model.CorrectionJob, error) {
+	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if submission == nil {
+		return nil, ErrSubmissionNotFound
+	}
+	if submission.StudentUUID != userUUID {
+		if err := s.ValidateTeacherPermissions(ctx, submission.AssignmentID, userUUID); err != nil {
+			return nil, ErrUnauthorized
+		}
+	}
+
+	if s.correctionQueue == nil {
+		return nil, ErrCorrectionJobNotFound
+	}
+	job, err := s.correctionQueue.GetLatestJob(ctx, submissionID)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return nil, ErrCorrectionJobNotFound
+	}
+	return job, nil
+}
+
+// autoSubmit submits a timed exam whose time ran out, as if it was submitted when the time expired
+func (s *SubmissionService) autoSubmit(ctx context.Context, submission *model.Submission, assignment *model.Assignment, extensions []model.DeadlineExtension) error {
+	submission.AutoSubmitted = true
+	return s.finalizeSubmission(ctx, submission, assignment, extensions, *submission.ExpiresAt)
+}
+
+// AutoSubmitExpiredExams submits every draft of a timed exam whose time ran out
+func (s *SubmissionService) AutoSubmitExpiredExams(ctx context.Context) error {
+	drafts, err := s.submissionRepo.GetExpiredDrafts(ctx, time.Now())
+	if err != nil {
+		return err
+	}
+
+	for i := range drafts {
+		submission := &drafts[i]
+		assignment, err := s.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+		if err != nil || assignment == nil {
+			log.Printf("error getting assignment %s to auto submit submission %s: %v", submission.AssignmentID, submission.ID.Hex(), err)
+			continue
+		}
+
+		extensions, err := s.studentExtensions(ctx, submission.AssignmentID, submission.StudentUUID)
+		if err != nil {
+			log.Printf("error getting extensions to auto submit submission %s: %v", submission.ID.Hex(), err)
+			continue
+		}
+
+		err = recordSystemChange(ctx, s.auditService, model.AuditEntitySubmission, submission.ID.Hex(), "exam_auto_submit", func() error {
+			return s.autoSubmit(ctx, submission, assignment, extensions)
+		})
+		if err != nil {
+			log.Printf("error auto submitting submission %s: %v", submission.ID.Hex(), err)
+		}
+	}
+	return nil
+}
+
+// RunExamAutoSubmitter auto submits the expired timed exams every interval until the context is done
+func (s *SubmissionService) RunExamAutoSubmitter(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.AutoSubmitExpiredExams(ctx); err != nil {
+				log.Printf("error auto submitting expired exams: %v", err)
+			}
+		}
+	}
+}
+
 func (s *SubmissionService) GetSubmission(ctx context.Context, id string) (*model.Submission, error) {
 	return s.submissionRepo.GetByID(ctx, id)
 }
@@ -123,27 +300,157 @@ func (s *SubmissionService) GetSubmissionsByStudent(ctx context.Context, student
 	return s.submissionRepo.GetByStudent(ctx, studentUUID)
 }
 
+// GetOrCreateSubmission returns the latest attempt of the student, creating the first one if needed.
+// Creating the attempt of a timed exam starts the countdown of the student.
 func (s *SubmissionService) GetOrCreateSubmission(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
 	submission, err := s.submissionRepo.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
 	if err != nil {
 		return nil, err
 	}
 
 	if submission != nil {
+		// The time ran out before the exam was auto submitted
+		if submission.Status == model.SubmissionStatusDraft && examTimeExpired(submission, time.Now()) {
+			extensions, err := s.studentExtensions(ctx, assignmentID, studentUUID)
+			if err != nil {
+				return nil, err
+			}
+			if err := s.autoSubmit(ctx, submission, assignment, extensions); err != nil {
+				return nil, err
+			}
+		}
 		return submission, nil
 	}
 
-	// Create new submission
+	return s.createAttempt(ctx, assignment, assignmentID, studentUUID, studentName, 1)
+}
+
+// StartNewAttempt creates a new attempt once the previous one was submitted
+func (s *SubmissionService) StartNewAttempt(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	latest, err := s.submissionRepo.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+	if err != nil {
+		return nil, err
+	}
+	if latest == nil {
+		return s.createAttempt(ctx, assignment, assignmentID, studentUUID, studentName, 1)
+	}
+	if latest.Status == model.SubmissionStatusDraft {
+		return nil, ErrAttemptInProgress
+	}
+
+	attempt := attemptNumber(latest) + 1
+	if attempt > maxAttempts(assignment) {
+		return nil, ErrMaxAttemptsReached
+	}
+
+	return s.createAttempt(ctx, assignment, assignmentID, studentUUID, studentName, attempt)
+}
+
+// GetAttemptHistory returns every attempt of a student along with the final score given by the assignment policy
+func (s *SubmissionService) GetAttemptHistory(ctx context.Context, assignmentID, studentUUID string) (*schemas.AttemptHistoryResponse, error) {
+	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment == nil {
+		return nil, ErrAssignmentNotFound
+	}
+
+	attempts, err := s.submissionRepo.GetAttemptsByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &schemas.AttemptHistoryResponse{
+		AssignmentID:  assignmentID,
+		StudentUUID:   studentUUID,
+		MaxAttempts:   maxAttempts(assignment),
+		ScoringPolicy: scoringPolicy(assignment),
+		FinalScore:    finalAttemptScore(scoringPolicy(assignment), attempts),
+		Attempts:      attempts,
+	}, nil
+}
+
+// checkAnswerFiles checks that the answers to file questions reference files uploaded by the student
+func (s *SubmissionService) checkAnswerFiles(ctx context.Context, studentUUID string, answers []model.Answer) error {
+	if s.fileRepo == nil {
+		return nil
+	}
+	for _, answer := range answers {
+		if answer.Type != string(model.QuestionTypeFile) || answer.Content == nil {
+			continue
+		}
+		fileID := answer.Content.(string)
+		file, err := s.fileRepo.GetByID(ctx, fileID)
+		if err != nil {
+			return err
+		}
+		if file == nil || file.OwnerUUID != studentUUID || file.Purpose != model.FilePurposeSubmission {
+			return fmt.Errorf("%w: question %s: file %s not found", ErrInvalidAnswer, answer.QuestionID, fileID)
+		}
+	}
+	return nil
+}
+
+// studentExtensions returns the deadline extensions granted to the student for the assignment
+func (s *SubmissionService) studentExtensions(ctx context.Context, assignmentID, studentUUID string) ([]model.DeadlineExtension, error) {
+	if s.extensionRepo == nil {
+		return nil, nil
+	}
+	return s.extensionRepo.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+}
+
+func (s *SubmissionService) createAttempt(ctx context.Context, assignment *model.Assignment, assignmentID, studentUUID, studentName string, attempt int) (*model.Submission, error) {
+	now := time.Now()
 	newSubmission := &model.Submission{
 		AssignmentID: assignmentID,
 		StudentUUID:  studentUUID,
 		StudentName:  studentName,
+		Attempt:      attempt,
 		Status:       model.SubmissionStatusDraft,
-		CreatedAt:    time.Now(),
-		UpdatedAt:    time.Now(),
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+
+	// Randomized assignments store the questions the student received
+	if isRandomized(assignment) {
+		questions, err := drawQuestions(ctx, s.questionBankRepo, assignment)
+		if err != nil {
+			return nil, err
+		}
+		newSubmission.Questions = questions
 	}
 
-	err = s.submissionRepo.Create(ctx, newSubmission)
+	// Opening a timed exam starts the countdown of the student
+	if isTimedExam(assignment) {
+		if err := checkExamAvailability(assignment, now); err != nil {
+			return nil, err
+		}
+		extensions, err := s.studentExtensions(ctx, assignmentID, studentUUID)
+		if err != nil {
+			return nil, err
+		}
+		newSubmission.StartedAt = &now
+		newSubmission.ExpiresAt = examExpiresAt(assignment, extensions, studentUUID, now)
+	}
+
+	err := s.submissionRepo.Create(ctx, newSubmission)
 	if err != nil {
 		return nil, err
 	}
@@ -151,8 +458,10 @@ func (s *SubmissionService) GetOrCreateSubmission(ctx context.Context, assignmen
 	return newSubmission, nil
 }
 
-// GradeSubmission updates the score and feedback of a submission
-func (s *SubmissionService) GradeSubmission(ctx context.Context, submissionID string, score *float64, feedback string) (*model.Submission, error) {
+// GradeSubmission updates the score and feedback of a submission.
+// Per-answer grades are merged into the submission and the total score is derived from them.
+// Rubric selections, for a question or for the whole assignment, compute the points from the chosen levels.
+func (s *SubmissionService) GradeSubmission(ctx context.Context, submissionID, teacherUUID string, gradeRequest schemas.GradeSubmissionRequest) (*model.Submission, error) {
 	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
 	if err != nil {
 		return nil, err
@@ -160,11 +469,92 @@ func (s *SubmissionService) GradeSubmission(ctx context.Context, submissionID st
 	if submission == nil {
 		return nil, ErrSubmissionNotFound
 	}
+	if err := s.validateCourseTeacher(ctx, submission.AssignmentID, teacherUUID, model.PermissionGradeSubmissions); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var rubricTotal float64
+	if len(gradeRequest.AnswerGrades) > 0 || len(gradeRequest.RubricSelections) > 0 {
+		assignment, err := s.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+		if err != nil {
+			return nil, err
+		}
+		if assignment == nil {
+			return nil, ErrAssignmentNotFound
+		}
+		assignment = studentAssignment(assignment, submission)
+
+		if len(gradeRequest.RubricSelections) > 0 {
+			if assignment.Rubric == nil {
+				return nil, fmt.Errorf("%w: assignment has no rubric", ErrInvalidAnswerGrade)
+			}
+			rubricTotal, err = rubricScore(assignment.Rubric, gradeRequest.RubricSelections, assignment.TotalPoints)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %v", ErrInvalidAnswerGrade, err)
+			}
+			submission.RubricSelections = gradeRequest.RubricSelections
+		}
+
+		questionMap := make(map[string]model.Question)
+		for _, question := range assignment.Questions {
+			questionMap[question.ID] = question
+		}
+
+		for _, answerGrade := range gradeRequest.AnswerGrades {
+			question, exists := questionMap[answerGrade.QuestionID]
+			if !exists {
+				return nil, fmt.Errorf("%w: question %s not found in assignment", ErrInvalidAnswerGrade, answerGrade.QuestionID)
+			}
+
+			// Questions with a rubric take their points from the chosen levels
+			points := answerGrade.Points
+			if len(answerGrade.RubricSelections) > 0 {
+				if question.Rubric == nil {
+					return nil, fmt.Errorf("%w: question %s has no rubric", ErrInvalidAnswerGrade, question.ID)
+				}
+				points, err = rubricScore(question.Rubric, answerGrade.RubricSelections, question.Points)
+				if err != nil {
+					return nil, fmt.Errorf("%w: question %s: %v", ErrInvalidAnswerGrade, question.ID, err)
+				}
+			}
+			if points < 0 || points > question.Points {
+				return nil, fmt.Errorf("%w: points for question %s must be between 0 and %.2f", ErrInvalidAnswerGrade, question.ID, question.Points)
+			}
+
+			setAnswerGrade(submission, model.AnswerGrade{
+				QuestionID:       question.ID,
+				PointsAwarded:    points,
+				MaxPoints:        question.Points,
+				Comment:          answerGrade.Comment,
+				GradedBy:         teacherUUID,
+				GradedAt:         now,
+				RubricSelections: answerGrade.RubricSelections,
+			})
+		}
+	}
 
 	// Update submission with grading information
-	submission.Score = score
-	submission.Feedback = feedback
-	submission.UpdatedAt = time.Now()
+	switch {
+	case len(gradeRequest.AnswerGrades) > 0:
+		score := applyLatePenalty(totalFromAnswerGrades(submission.AnswerGrades), submission)
+		submission.Score = &score
+	case len(gradeRequest.RubricSelections) > 0:
+		// The assignment rubric grades the whole submission
+		score := applyLatePenalty(rubricTotal, submission)
+		submission.Score = &score
+	case gradeRequest.Score != nil:
+		// A global score without per-answer grades overrides the computed total
+		submission.Score = gradeRequest.Score
+	case len(submission.AnswerGrades) > 0:
+		// The teacher accepts the existing per-answer grades
+		score := applyLatePenalty(totalFromAnswerGrades(submission.AnswerGrades), submission)
+		submission.Score = &score
+	default:
+		submission.Score = nil
+	}
+	submission.Feedback = gradeRequest.Feedback
+	submission.UpdatedAt = now
 
 	// Mark as reviewed by teacher (no longer needs manual review)
 	needsReview := false
@@ -178,8 +568,33 @@ func (s *SubmissionService) GradeSubmission(ctx context.Context, submissionID st
 	return submission, nil
 }
 
-// ValidateTeacherPermissions validates if a teacher can grade submissions for a given assignment
+// setAnswerGrade adds the grade to the submission, replacing any previous grade for the same question
+func setAnswerGrade(submission *model.Submission, answerGrade model.AnswerGrade) {
+	for i, existing := range submission.AnswerGrades {
+		if existing.QuestionID == answerGrade.QuestionID {
+			submission.AnswerGrades[i] = answerGrade
+			return
+		}
+	}
+	submission.AnswerGrades = append(submission.AnswerGrades, answerGrade)
+}
+
+// totalFromAnswerGrades sums the points awarded to each answer, never going below zero
+func totalFromAnswerGrades(answerGrades []model.AnswerGrade) float64 {
+	var total float64
+	for _, answerGrade := range answerGrades {
+		total += answerGrade.PointsAwarded
+	}
+	return math.Max(total, 0)
+}
+
+// ValidateTeacherPermissions validates if a teacher is the titular or an aux teacher of the course of the assignment
 func (s *SubmissionService) ValidateTeacherPermissions(ctx context.Context, assignmentID, teacherUUID string) error {
+	return s.validateCourseTeacher(ctx, assignmentID, teacherUUID)
+}
+
+// validateCourseTeacher validates if a teacher of the course of the assignment has the permissions
+func (s *SubmissionService) validateCourseTeacher(ctx context.Context, assignmentID, teacherUUID string, permissions ...model.AuxTeacherPermission) error {
 	// Get assignment
 	assignment, err := s.assignmentRepo.GetByID(ctx, assignmentID)
 	if err != nil {
@@ -198,19 +613,11 @@ func (s *SubmissionService) ValidateTeacherPermissions(ctx context.Context, assi
 		return errors.New("course not found")
 	}
 
-	// Check if teacher is the main teacher
-	if course.TeacherUUID == teacherUUID {
-		return nil
-	}
-
-	// Check if teacher is an auxiliary teacher
-	for _, auxTeacher := range course.AuxTeachers {
-		if auxTeacher == teacherUUID {
-			return nil
-		}
+	// Check if teacher is the main teacher or an auxiliary teacher
+	if !course.IsTeacher(teacherUUID) {
+		return errors.New("teacher not authorized to grade this assignment")
 	}
-
-	return errors.New("teacher not authorized to grade this assignment")
+	return authorizeTeacher(course, teacherUUID, permissions...)
 }
 
 // GenerateFeedbackSummary generates an AI summary of the feedback for a submission
@@ -229,8 +636,19 @@ func (s *SubmissionService) GenerateFeedbackSummary(ctx context.Context, submiss
 		return nil, errors.New("submission has no feedback to summarize")
 	}
 
+	if s.aiClient == nil {
+		return nil, ai.ErrUnavailable
+	}
+
 	// Generate summary using AI
-	summary, err := s.aiClient.SummarizeSubmissionFeedback(submission.Score, submission.Feedback)
+	assignment, err := s.assignmentRepo.GetByID(ctx, submission.AssignmentID)
+	if err != nil {
+		return nil, err
+	}
+	if assignment != nil {
+		ctx = s.aiScope(ctx, assignment.CourseID, submission.StudentUUID)
+	}
+	summary, err := s.aiClient.SummarizeSubmissionFeedback(ctx, submission.Score, submission.Feedback)
 	if err != nil {
 		return nil, err
 	}
@@ -243,35 +661,23 @@ func (s *SubmissionService) GenerateFeedbackSummary(ctx context.Context, submiss
 // isSubmissionAutoCorrectible checks if a submission can be automatically corrected
 func (s *SubmissionService) isSubmissionAutoCorrectible(submission *model.Submission) bool {
 	for _, answer := range submission.Answers {
-		// Check if answer type is file - these need manual review
-		if answer.Type == "file" {
+		// Answers to file questions reference stored files, these need manual review
+		if answer.Type == string(model.QuestionTypeFile) {
 			return false
 		}
-
-		// Check if content is a URL (simple check)
-		if contentStr, ok := answer.Content.(string); ok {
-			contentStr = strings.TrimSpace(strings.ToLower(contentStr))
-			if strings.HasPrefix(contentStr, "http://") ||
-				strings.HasPrefix(contentStr, "https://") ||
-				strings.HasPrefix(contentStr, "www.") ||
-				strings.Contains(contentStr, ".com") ||
-				strings.Contains(contentStr, ".org") ||
-				strings.Contains(contentStr, ".edu") {
-				return false
-			}
-		}
 	}
 	return true
 }
 
-// AutoCorrectSubmission performs automatic correction of a submission using AI
+// AutoCorrectSubmission performs automatic correction of a submission, recorded in the audit log as a change of the system.
+// Multiple choice answers are graded locally and only the remaining answers are sent to the AI.
 func (s *SubmissionService) AutoCorrectSubmission(ctx context.Context, submissionID string) error {
-	// Check if AI client is available
-	if s.aiClient == nil {
-		log.Printf("AI client not available for auto-correction of submission %s", submissionID)
-		return nil // Silently skip auto-correction if AI client is not available
-	}
+	return recordSystemChange(ctx, s.auditService, model.AuditEntitySubmission, submissionID, "ai_correction", func() error {
+		return s.autoCorrectSubmission(ctx, submissionID)
+	})
+}
 
+func (s *SubmissionService) autoCorrectSubmission(ctx context.Context, submissionID string) error {
 	// Get submission
 	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
 	if err != nil {
@@ -289,31 +695,246 @@ func (s *SubmissionService) AutoCorrectSubmission(ctx context.Context, submissio
 	if assignment == nil {
 		return ErrAssignmentNotFound
 	}
+	assignment = studentAssignment(assignment, submission)
 
-	// Check if submission can be auto-corrected
-	if !s.isSubmissionAutoCorrectible(submission) {
-		// Simply ignore submissions that can't be auto-corrected
-		// Leave them untouched for manual review by teachers
-		return nil
+	// Split the submission into locally graded answers and answers that need the AI
+	autoGrades, autoMaxScore, aiAssignment, aiSubmission := splitForAutoGrading(assignment, submission)
+	autoScore := 0.0
+	for _, answerGrade := range autoGrades {
+		autoScore += answerGrade.PointsAwarded
 	}
 
-	// Perform AI correction
-	correctionResult, err := s.aiClient.CorrectSubmission(assignment, submission)
-	if err != nil {
-		// If AI correction fails, mark for manual review
-		needsReview := true
+	if len(aiSubmission.Answers) == 0 {
+		if autoMaxScore == 0 {
+			// Nothing to grade
+			return nil
+		}
+		for _, answerGrade := range autoGrades {
+			setAnswerGrade(submission, answerGrade)
+		}
+		score := applyLatePenalty(math.Max(autoScore, 0), submission)
+		needsReview := false
+		submission.AIScore = &score
+		submission.AIFeedback = autoGradingFeedback(autoScore, autoMaxScore)
 		submission.NeedsManualReview = &needsReview
-		submission.Feedback = "Error en la corrección automática. Requiere revisión manual."
 		submission.UpdatedAt = time.Now()
 
-		return s.submissionRepo.Update(ctx, submission)
+		return s.saveCorrection(ctx, submission)
+	}
+
+	// Check if the remaining answers can be auto-corrected
+	if !s.isSubmissionAutoCorrectible(aiSubmission) {
+		// The remaining answers are left for manual review by teachers, the local grades are kept
+		return s.keepAutoGrades(ctx, submission, autoGrades, autoScore, autoMaxScore)
+	}
+
+	// Check if AI client is available
+	if s.aiClient == nil {
+		log.Printf("AI client not available for auto-correction of submission %s", submissionID)
+		return s.keepAutoGrades(ctx, submission, autoGrades, autoScore, autoMaxScore)
+	}
+
+	// Perform AI correction, failures are retried by the correction queue
+	correctionResult, err := s.aiClient.CorrectSubmission(s.aiScope(ctx, assignment.CourseID, submission.StudentUUID), aiAssignment, aiSubmission)
+	if errors.Is(err, ai.ErrInvalidCorrection) {
+		// Retrying won't fix an answer that doesn't pass the validation, the teacher grades what the AI couldn't
+		log.Printf("Invalid AI correction for submission %s: %v", submissionID, err)
+		return s.markInvalidCorrection(ctx, submission, autoGrades, autoScore, autoMaxScore)
+	}
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrAICorrectionFailed, err)
+	}
+
+	for _, answerGrade := range autoGrades {
+		setAnswerGrade(submission, answerGrade)
+	}
+
+	// Use the per-question breakdown when the AI returns one, otherwise trust the total
+	aiScore := correctionResult.AIScore
+	if aiGrades := aiAnswerGrades(aiAssignment, correctionResult); len(aiGrades) > 0 {
+		aiScore = 0
+		for _, answerGrade := range aiGrades {
+			setAnswerGrade(submission, answerGrade)
+			aiScore += answerGrade.PointsAwarded
+		}
+	}
+
+	// Combine the local score with the AI score
+	score := applyLatePenalty(math.Max(autoScore+aiScore, 0), submission)
+	feedback := correctionResult.AIFeedback
+	if autoMaxScore > 0 {
+		feedback = strings.TrimSpace(feedback + "\n" + autoGradingFeedback(autoScore, autoMaxScore))
 	}
 
 	// Update submission with AI results
-	submission.AIScore = &correctionResult.AIScore
-	submission.AIFeedback = correctionResult.AIFeedback
+	submission.AIScore = &score
+	submission.AIFeedback = feedback
+	submission.AIPromptVersion = correctionResult.PromptVersion
 	submission.NeedsManualReview = &correctionResult.NeedsManualReview
 	submission.UpdatedAt = time.Now()
 
+	return s.saveCorrection(ctx, submission)
+}
+
+// saveCorrection saves the automatic correction of the submission, unless the correction job running it was
+// claimed by another worker after its lock expired
+func (s *SubmissionService) saveCorrection(ctx context.Context, submission *model.Submission) error {
+	if err := CheckCorrectionClaim(ctx); err != nil {
+		return err
+	}
 	return s.submissionRepo.Update(ctx, submission)
 }
+
+// aiScope accounts the AI usage to the course and its titular teacher, the prompts use the language of the course
+func (s *SubmissionService) aiScope(ctx context.Context, courseID, studentUUID string) context.Context {
+	scope := ai.Scope{CourseID: courseID, StudentUUID: studentUUID}
+	if course, err := s.courseService.GetCourseById(courseID); err == nil && course != nil {
+		scope.TeacherUUID = course.TeacherUUID
+		scope.Language = course.Language
+	}
+	return ai.WithScope(ctx, scope)
+}
+
+// keepAutoGrades saves the locally graded answers and their partial score when the other answers can't be
+// corrected automatically, the submission is flagged so the teacher grades the rest
+func (s *SubmissionService) keepAutoGrades(ctx context.Context, submission *model.Submission, autoGrades []model.AnswerGrade, autoScore, autoMaxScore float64) error {
+	if autoMaxScore == 0 {
+		// Nothing was graded locally, the submission is left untouched
+		return nil
+	}
+	for _, answerGrade := range autoGrades {
+		setAnswerGrade(submission, answerGrade)
+	}
+
+	score := applyLatePenalty(math.Max(autoScore, 0), submission)
+	needsReview := true
+	submission.AIScore = &score
+	submission.AIFeedback = autoGradingFeedback(autoScore, autoMaxScore) + "\nEl resto de las respuestas requiere revisión manual."
+	submission.NeedsManualReview = &needsReview
+	submission.UpdatedAt = time.Now()
+
+	return s.saveCorrection(ctx, submission)
+}
+
+// markInvalidCorrection keeps the locally graded answers and flags the submission for manual review
+func (s *SubmissionService) markInvalidCorrection(ctx context.Context, submission *model.Submission, autoGrades []model.AnswerGrade, autoScore, autoMaxScore float64) error {
+	for _, answerGrade := range autoGrades {
+		setAnswerGrade(submission, answerGrade)
+	}
+
+	needsReview := true
+	feedback := "La corrección automática no es válida. Requiere revisión manual."
+	if autoMaxScore > 0 {
+		feedback += "\n" + autoGradingFeedback(autoScore, autoMaxScore)
+	}
+	submission.AIScore = nil
+	submission.AIFeedback = feedback
+	submission.AIPromptVersion = ""
+	submission.NeedsManualReview = &needsReview
+	submission.UpdatedAt = time.Now()
+
+	return s.saveCorrection(ctx, submission)
+}
+
+// MarkCorrectionFailed flags a submission whose automatic correction failed for manual review, recorded in the
+// audit log as a change of the system
+func (s *SubmissionService) MarkCorrectionFailed(ctx context.Context, submissionID string) error {
+	return recordSystemChange(ctx, s.auditService, model.AuditEntitySubmission, submissionID, "ai_correction_failed", func() error {
+		return s.markCorrectionFailed(ctx, submissionID)
+	})
+}
+
+func (s *SubmissionService) markCorrectionFailed(ctx context.Context, submissionID string) error {
+	submission, err := s.submissionRepo.GetByID(ctx, submissionID)
+	if err != nil {
+		return err
+	}
+	if submission == nil {
+		return ErrSubmissionNotFound
+	}
+
+	needsReview := true
+	submission.NeedsManualReview = &needsReview
+	submission.Feedback = "Error en la corrección automática. Requiere revisión manual."
+	submission.UpdatedAt = time.Now()
+
+	return s.submissionRepo.Update(ctx, submission)
+}
+
+// splitForAutoGrading grades the answers that don't need the AI and returns copies of the
+// assignment and submission holding only the questions and answers left for the AI
+func splitForAutoGrading(assignment *model.Assignment, submission *model.Submission) ([]model.AnswerGrade, float64, *model.Assignment, *model.Submission) {
+	questionMap := make(map[string]model.Question)
+	for _, question := range assignment.Questions {
+		questionMap[question.ID] = question
+	}
+
+	var autoMaxScore float64
+	aiAssignment := *assignment
+	aiAssignment.Questions = nil
+	aiAssignment.TotalPoints = 0
+	for _, question := range assignment.Questions {
+		if IsAutoGradable(question) {
+			autoMaxScore += question.Points
+			continue
+		}
+		aiAssignment.Questions = append(aiAssignment.Questions, question)
+		aiAssignment.TotalPoints += question.Points
+	}
+	if len(assignment.Questions) == 0 {
+		// Without questions there is nothing to split, let the AI use the assignment total
+		aiAssignment.TotalPoints = assignment.TotalPoints
+	}
+
+	now := time.Now()
+	var autoGrades []model.AnswerGrade
+	aiSubmission := *submission
+	aiSubmission.Answers = nil
+	for _, answer := range submission.Answers {
+		question, exists := questionMap[answer.QuestionID]
+		if exists && IsAutoGradable(question) {
+			autoGrades = append(autoGrades, model.AnswerGrade{
+				QuestionID:    question.ID,
+				PointsAwarded: AutoGradeAnswer(question, answer),
+				MaxPoints:     question.Points,
+				GradedBy:      model.GraderAutomatic,
+				GradedAt:      now,
+			})
+			continue
+		}
+		aiSubmission.Answers = append(aiSubmission.Answers, answer)
+	}
+
+	return autoGrades, autoMaxScore, &aiAssignment, &aiSubmission
+}
+
+// aiAnswerGrades converts the per-question breakdown returned by the AI into answer grades,
+// ignoring unknown questions and keeping each score within the question points
+func aiAnswerGrades(assignment *model.Assignment, correction *schemas.AiCorrectionResponse) []model.AnswerGrade {
+	questionMap := make(map[string]model.Question)
+	for _, question := range assignment.Questions {
+		questionMap[question.ID] = question
+	}
+
+	now := time.Now()
+	var answerGrades []model.AnswerGrade
+	for _, questionCorrection := range correction.Questions {
+		question, exists := questionMap[questionCorrection.QuestionID]
+		if !exists {
+			continue
+		}
+		answerGrades = append(answerGrades, model.AnswerGrade{
+			QuestionID:    question.ID,
+			PointsAwarded: math.Min(math.Max(questionCorrection.Score, 0), question.Points),
+			MaxPoints:     question.Points,
+			Comment:       questionCorrection.Feedback,
+			GradedBy:      model.GraderAI,
+			GradedAt:      now,
+		})
+	}
+	return answerGrades
+}
+
+func autoGradingFeedback(score, maxScore float64) string {
+	return fmt.Sprintf("Preguntas corregidas automáticamente: %.2f de %.2f puntos.", score, maxScore)
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"time"
+
+	"courses-service/src/model"
+)
+
+// isTimedExam reports whether the assignment is an exam with an availability window or a time limit
+func isTimedExam(assignment *model.Assignment) bool {
+	if assignment.Type != "exam" {
+		return false
+	}
+	return assignment.TimeLimitMinutes > 0 || assignment.AvailableFrom != nil || assignment.AvailableUntil != nil
+}
+
+// checkExamAvailability returns ErrExamNotAvailable when the exam can't be opened at the given time
+func checkExamAvailability(assignment *model.Assignment, now time.Time) error {
+	if assignment.AvailableFrom != nil && now.Before(*assignment.AvailableFrom) {
+		return ErrExamNotAvailable
+	}
+	if assignment.AvailableUntil != nil && !now.Before(*assignment.AvailableUntil) {
+		return ErrExamNotAvailable
+	}
+	return nil
+}
+
+// examExpiresAt returns the deadline of a student that opened the exam at startedAt.
+// The time limit is cut short by the end of the availability window and, when late submissions
+// are rejected, by the hard deadline of the student. Returns nil when the exam has no time limit nor window end.
+func examExpiresAt(assignment *model.Assignment, extensions []model.DeadlineExtension, studentUUID string, startedAt time.Time) *time.Time {
+	var expiresAt *time.Time
+	limit := func(deadline time.Time) {
+		if expiresAt == nil || deadline.Before(*expiresAt) {
+			expiresAt = &deadline
+		}
+	}
+
+	if assignment.TimeLimitMinutes > 0 {
+		limit(startedAt.Add(time.Duration(assignment.TimeLimitMinutes) * time.Minute))
+	}
+	if assignment.AvailableUntil != nil {
+		limit(*assignment.AvailableUntil)
+	}
+	if expiresAt != nil && assignment.LatePolicy == model.LatePolicyReject {
+		limit(hardDeadline(assignment, extensions, studentUUID))
+	}
+	return expiresAt
+}
+
+// examTimeExpired reports whether the time of the student to answer a timed exam ran out
+func examTimeExpired(submission *model.Submission, now time.Time) bool {
+	return submission.ExpiresAt != nil && !now.Before(*submission.ExpiresAt)
+}
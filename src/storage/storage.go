@@ -0,0 +1,37 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"courses-service/src/config"
+)
+
+var ErrObjectNotFound = errors.New("object not found in storage")
+
+// FileStorage stores the content of the uploaded files, the metadata lives in the files collection
+type FileStorage interface {
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+const defaultLocalPath = "uploads"
+
+// NewFileStorage creates the storage backend selected in the config, the local filesystem by default
+func NewFileStorage(config *config.Config) (FileStorage, error) {
+	switch config.StorageBackend {
+	case "", "local":
+		path := config.StorageLocalPath
+		if path == "" {
+			path = defaultLocalPath
+		}
+		return NewLocalStorage(path)
+	case "s3":
+		return NewS3Storage(config.S3Endpoint, config.S3Region, config.S3Bucket, config.S3AccessKey, config.S3SecretKey)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %s", config.StorageBackend)
+	}
+}
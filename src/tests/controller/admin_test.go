@@ -0,0 +1,175 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"courses-service/src/controller"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAdminService records the admin of the last action
+type MockAdminService struct {
+	adminUUID string
+}
+
+func (m *MockAdminService) changeStatus(courseID, adminUUID string, status model.CourseStatus) (*model.Course, error) {
+	m.adminUUID = adminUUID
+	switch courseID {
+	case "missing-course":
+		return nil, service.ErrCourseNotFound
+	case "suspended-course":
+		return nil, service.ErrInvalidCourseStatus
+	}
+	return &model.Course{Title: "Go", Status: status}, nil
+}
+
+func (m *MockAdminService) SuspendCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return m.changeStatus(courseID, adminUUID, model.CourseStatusSuspended)
+}
+
+func (m *MockAdminService) ArchiveCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return m.changeStatus(courseID, adminUUID, model.CourseStatusArchived)
+}
+
+func (m *MockAdminService) ReactivateCourse(ctx context.Context, courseID, adminUUID, reason string) (*model.Course, error) {
+	return m.changeStatus(courseID, adminUUID, model.CourseStatusActive)
+}
+
+func (m *MockAdminService) ReassignTeacher(ctx context.Context, courseID, adminUUID string, request schemas.ReassignTeacherRequest) (*model.Course, error) {
+	if request.TeacherUUID == "teacher123" {
+		return nil, service.ErrInvalidTeacherReassignment
+	}
+	return &model.Course{TeacherUUID: request.TeacherUUID, TeacherName: request.TeacherName}, nil
+}
+
+func (m *MockAdminService) UnenrollStudent(ctx context.Context, courseID, studentUUID, adminUUID, reason string) error {
+	if studentUUID != "enrolled-student" {
+		return service.ErrStudentNotEnrolled
+	}
+	return nil
+}
+
+func (m *MockAdminService) RemoveForumQuestion(ctx context.Context, questionID, adminUUID, reason string) error {
+	if questionID == "missing-question" {
+		return service.ErrForumQuestionNotFound
+	}
+	return nil
+}
+
+func (m *MockAdminService) RemoveForumAnswer(ctx context.Context, questionID, answerID, adminUUID, reason string) error {
+	if answerID == "missing-answer" {
+		return service.ErrForumAnswerNotFound
+	}
+	return nil
+}
+
+func (m *MockAdminService) BlockUser(ctx context.Context, userUUID, adminUUID, reason string) (*model.BlockedUser, error) {
+	if userUUID == adminUUID {
+		return nil, service.ErrInvalidUserBlock
+	}
+	return &model.BlockedUser{UserUUID: userUUID, Reason: reason, BlockedBy: adminUUID}, nil
+}
+
+func (m *MockAdminService) UnblockUser(ctx context.Context, userUUID, adminUUID, reason string) error {
+	if userUUID != "blocked-student" {
+		return service.ErrUserNotBlocked
+	}
+	return nil
+}
+
+func (m *MockAdminService) GetBlockedUsers(ctx context.Context) ([]model.BlockedUser, error) {
+	return []model.BlockedUser{{UserUUID: "blocked-student"}}, nil
+}
+
+func (m *MockAdminService) IsBlocked(userUUID string) (bool, error) {
+	return userUUID == "blocked-student", nil
+}
+
+func (m *MockAdminService) GetActionLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error) {
+	return []model.AdminActionLog{{AdminUUID: "admin1", Action: model.AdminActionSuspendCourse, CourseID: filter.CourseID}}, nil
+}
+
+func TestAdminRoutes(t *testing.T) {
+	r := gin.Default()
+	router.InitializeAdminRoutes(r, controller.NewAdminController(&MockAdminService{}), auditor)
+	reason := `{"reason": "Spam"}`
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		body          string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{name: "suspend course", method: "POST", path: "/backoffice/courses/course123/suspend", body: reason, expectedCode: http.StatusOK, expectedBody: `"status":"suspended"`},
+		{name: "archive course", method: "POST", path: "/backoffice/courses/course123/archive", body: reason, expectedCode: http.StatusOK, expectedBody: `"status":"archived"`},
+		{name: "reactivate course", method: "POST", path: "/backoffice/courses/course123/reactivate", body: reason, expectedCode: http.StatusOK, expectedBody: `"status":"active"`},
+		{name: "suspend a suspended course", method: "POST", path: "/backoffice/courses/suspended-course/suspend", body: reason, expectedCode: http.StatusConflict},
+		{name: "suspend a missing course", method: "POST", path: "/backoffice/courses/missing-course/suspend", body: reason, expectedCode: http.StatusNotFound},
+		{name: "suspend without reason", method: "POST", path: "/backoffice/courses/course123/suspend", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "suspend as teacher", method: "POST", path: "/backoffice/courses/course123/suspend", body: reason, authorization: teacherToken("teacher123"), expectedCode: http.StatusForbidden},
+		{name: "reassign teacher", method: "PUT", path: "/backoffice/courses/course123/teacher", body: `{"teacher_uuid": "teacher456", "teacher_name": "New Teacher", "reason": "The teacher left"}`, expectedCode: http.StatusOK, expectedBody: `"teacher_uuid":"teacher456"`},
+		{name: "reassign the same teacher", method: "PUT", path: "/backoffice/courses/course123/teacher", body: `{"teacher_uuid": "teacher123", "teacher_name": "Teacher", "reason": "Mistake"}`, expectedCode: http.StatusBadRequest},
+		{name: "reassign without teacher", method: "PUT", path: "/backoffice/courses/course123/teacher", body: reason, expectedCode: http.StatusBadRequest},
+		{name: "unenroll student", method: "POST", path: "/backoffice/courses/course123/students/enrolled-student/unenroll", body: reason, expectedCode: http.StatusOK},
+		{name: "unenroll student not enrolled", method: "POST", path: "/backoffice/courses/course123/students/other-student/unenroll", body: reason, expectedCode: http.StatusNotFound},
+		{name: "remove question", method: "POST", path: "/backoffice/forum/questions/question123/remove", body: reason, expectedCode: http.StatusOK},
+		{name: "remove missing question", method: "POST", path: "/backoffice/forum/questions/missing-question/remove", body: reason, expectedCode: http.StatusNotFound},
+		{name: "remove answer", method: "POST", path: "/backoffice/forum/questions/question123/answers/answer123/remove", body: reason, expectedCode: http.StatusOK},
+		{name: "remove missing answer", method: "POST", path: "/backoffice/forum/questions/question123/answers/missing-answer/remove", body: reason, expectedCode: http.StatusNotFound},
+		{name: "block user", method: "POST", path: "/backoffice/users/student123/block", body: reason, expectedCode: http.StatusOK, expectedBody: `"blocked_by":"admin1"`},
+		{name: "block themselves", method: "POST", path: "/backoffice/users/admin1/block", body: reason, expectedCode: http.StatusBadRequest},
+		{name: "unblock user", method: "POST", path: "/backoffice/users/blocked-student/unblock", body: reason, expectedCode: http.StatusOK},
+		{name: "unblock user not blocked", method: "POST", path: "/backoffice/users/student123/unblock", body: reason, expectedCode: http.StatusNotFound},
+		{name: "blocked users", method: "GET", path: "/backoffice/blocked-users", expectedCode: http.StatusOK, expectedBody: `"user_uuid":"blocked-student"`},
+		{name: "audit logs", method: "GET", path: "/backoffice/audit-logs?course_id=course123", expectedCode: http.StatusOK, expectedBody: `"course_id":"course123"`},
+		{name: "audit logs as student", method: "GET", path: "/backoffice/audit-logs", authorization: studentToken("student123"), expectedCode: http.StatusForbidden},
+		{name: "audit logs without token", method: "GET", path: "/backoffice/audit-logs", authorization: "none", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			switch tt.authorization {
+			case "":
+				req.Header.Set("Authorization", adminToken("admin1"))
+			case "none":
+			default:
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestAdminActionIsDoneByTheAdminOfTheToken(t *testing.T) {
+	adminService := &MockAdminService{}
+	r := gin.Default()
+	router.InitializeAdminRoutes(r, controller.NewAdminController(adminService), auditor)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/backoffice/courses/course123/archive", strings.NewReader(`{"reason": "Finished"}`))
+	req.Header.Set("Authorization", adminToken("admin42"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "admin42", adminService.adminUUID)
+}
@@ -0,0 +1,83 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"courses-service/src/controller"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockAiUsageService struct {
+	request schemas.AiUsageReportRequest
+}
+
+func (m *MockAiUsageService) InvalidateCourse(ctx context.Context, courseID string) error {
+	return nil
+}
+
+func (m *MockAiUsageService) InvalidateStudent(ctx context.Context, studentUUID string) error {
+	return nil
+}
+
+func (m *MockAiUsageService) GetUsageReport(ctx context.Context, request schemas.AiUsageReportRequest) (*schemas.AiUsageReport, error) {
+	m.request = request
+	if request.From != nil && request.To != nil && !request.From.Before(*request.To) {
+		return nil, service.ErrInvalidDateRange
+	}
+	return &schemas.AiUsageReport{
+		Courses: []schemas.AiUsageSummary{{CourseID: "course123", Requests: 3, TotalTokens: 1500}},
+	}, nil
+}
+
+func TestGetAiUsageReport(t *testing.T) {
+	aiUsageService := &MockAiUsageService{}
+	r := gin.Default()
+	router.InitializeAiUsageRoutes(r, controller.NewAiUsageController(aiUsageService))
+
+	tests := []struct {
+		name         string
+		query        string
+		expectedCode int
+	}{
+		{name: "without dates", query: "", expectedCode: http.StatusOK},
+		{name: "with dates", query: "?from=2025-06-01&to=2025-07-01", expectedCode: http.StatusOK},
+		{name: "invalid date", query: "?from=junio", expectedCode: http.StatusBadRequest},
+		{name: "invalid range", query: "?from=2025-07-01&to=2025-06-01", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/backoffice/ai-usage"+tt.query, nil)
+			req.Header.Set("Authorization", adminToken("admin123"))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/backoffice/ai-usage?from=2025-06-01", nil)
+	req.Header.Set("Authorization", adminToken("admin123"))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "course123")
+	assert.Equal(t, time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), aiUsageService.request.From.UTC())
+	assert.Nil(t, aiUsageService.request.To)
+
+	// Only the admins see the usage of every course
+	w = httptest.NewRecorder()
+	req, _ = http.NewRequest("GET", "/backoffice/ai-usage", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
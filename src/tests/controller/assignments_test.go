@@ -23,17 +23,16 @@ var (
 	mockAssignmentService      = &MockAssignmentService{}
 	mockAssignmentErrorService = &MockAssignmentServiceWithError{}
 	mockNotificationsQueue     = &MockNotificationsQueue{}
-	mockActivityService        = &MockTeacherActivityService{}
-	normalAssignmentController = controller.NewAssignmentsController(mockAssignmentService, mockNotificationsQueue, mockActivityService)
-	errorAssignmentController  = controller.NewAssignmentsController(mockAssignmentErrorService, mockNotificationsQueue, mockActivityService)
+	normalAssignmentController = controller.NewAssignmentsController(mockAssignmentService, mockNotificationsQueue)
+	errorAssignmentController  = controller.NewAssignmentsController(mockAssignmentErrorService, mockNotificationsQueue)
 	normalAssignmentRouter     = gin.Default()
 	errorAssignmentRouter      = gin.Default()
 )
 
 func init() {
 	gin.SetMode(gin.TestMode)
-	router.InitializeAssignmentsRoutes(normalAssignmentRouter, normalAssignmentController)
-	router.InitializeAssignmentsRoutes(errorAssignmentRouter, errorAssignmentController)
+	router.InitializeAssignmentsRoutes(normalAssignmentRouter, normalAssignmentController, auditor)
+	router.InitializeAssignmentsRoutes(errorAssignmentRouter, errorAssignmentController, auditor)
 }
 
 type MockNotificationsQueue struct{}
@@ -42,19 +41,9 @@ func (m *MockNotificationsQueue) Publish(message queues.QueueMessage) error {
 	return nil
 }
 
-type MockTeacherActivityService struct{}
-
-func (m *MockTeacherActivityService) LogActivityIfAuxTeacher(courseID, teacherUUID, activityType, description string) {
-	// Mock implementation - do nothing
-}
-
-func (m *MockTeacherActivityService) GetCourseActivityLogs(courseID string) ([]*model.TeacherActivityLog, error) {
-	return []*model.TeacherActivityLog{}, nil
-}
-
 type MockAssignmentService struct{}
 
-func (m *MockAssignmentService) CreateAssignment(c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
+func (m *MockAssignmentService) CreateAssignment(teacherUUID string, c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
 	return &model.Assignment{
 		ID:           primitive.NewObjectID(),
 		Title:        c.Title,
@@ -130,10 +119,34 @@ func (m *MockAssignmentService) GetAssignments() ([]*model.Assignment, error) {
 	}, nil
 }
 
+// assignmentWithAnswerKey is an assignment of the course with every kind of answer key and rubric
+func assignmentWithAnswerKey(courseID string) *model.Assignment {
+	pi := 3.14
+	rubric := &model.Rubric{Criteria: []model.RubricCriterion{{ID: "clarity", Title: "Clarity", Levels: []model.RubricLevel{{ID: "good", Title: "Good", Points: 10}}}}}
+	return &model.Assignment{
+		ID:       primitive.NewObjectID(),
+		Title:    "Assignment With Answer Key",
+		CourseID: courseID,
+		Status:   "published",
+		Questions: []model.Question{
+			{ID: "q1", Text: "Pick the prime", Type: model.QuestionTypeMultipleChoice, Options: []string{"4", "7"}, CorrectAnswers: []string{"7"}, Points: 2},
+			{ID: "q2", Text: "Value of pi", Type: model.QuestionTypeNumeric, NumericAnswer: &pi, Tolerance: 0.01, Points: 2},
+			{ID: "q3", Text: "Match the capitals", Type: model.QuestionTypeMatching, MatchingPairs: []model.MatchingPair{{Left: "Italy", Right: "Rome"}, {Left: "France", Right: "Paris"}}, Points: 2},
+			{ID: "q4", Text: "Name a mammal", Type: model.QuestionTypeShortAnswer, AcceptedPatterns: []string{"dog|cat"}, Points: 2},
+			{ID: "q5", Text: "Explain recursion", Type: model.QuestionTypeText, Rubric: rubric, Points: 2},
+		},
+		Rubric:      rubric,
+		TotalPoints: 10,
+	}
+}
+
 func (m *MockAssignmentService) GetAssignmentById(id string) (*model.Assignment, error) {
 	if id == "nonexistent" {
 		return nil, nil
 	}
+	if id == "policy-item" {
+		return assignmentWithAnswerKey("policy-course"), nil
+	}
 	return &model.Assignment{
 		ID:           primitive.NewObjectID(),
 		Title:        "Test Assignment",
@@ -163,6 +176,9 @@ func (m *MockAssignmentService) GetAssignmentById(id string) (*model.Assignment,
 }
 
 func (m *MockAssignmentService) GetAssignmentsByCourseId(courseId string) ([]*model.Assignment, error) {
+	if courseId == "policy-course" {
+		return []*model.Assignment{assignmentWithAnswerKey(courseId)}, nil
+	}
 	return []*model.Assignment{
 		{
 			ID:           primitive.NewObjectID(),
@@ -193,7 +209,7 @@ func (m *MockAssignmentService) GetAssignmentsByCourseId(courseId string) ([]*mo
 	}, nil
 }
 
-func (m *MockAssignmentService) UpdateAssignment(id string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
+func (m *MockAssignmentService) UpdateAssignment(id string, teacherUUID string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
 	return &model.Assignment{
 		ID:           primitive.NewObjectID(),
 		Title:        updateAssignmentRequest.Title,
@@ -212,13 +228,13 @@ func (m *MockAssignmentService) UpdateAssignment(id string, updateAssignmentRequ
 	}, nil
 }
 
-func (m *MockAssignmentService) DeleteAssignment(id string) error {
+func (m *MockAssignmentService) DeleteAssignment(id string, teacherUUID string) error {
 	return nil
 }
 
 type MockAssignmentServiceWithError struct{}
 
-func (m *MockAssignmentServiceWithError) CreateAssignment(c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
+func (m *MockAssignmentServiceWithError) CreateAssignment(teacherUUID string, c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
 	return nil, errors.New("error creating assignment")
 }
 
@@ -234,11 +250,11 @@ func (m *MockAssignmentServiceWithError) GetAssignmentsByCourseId(courseId strin
 	return nil, errors.New("error getting assignments by course id")
 }
 
-func (m *MockAssignmentServiceWithError) UpdateAssignment(id string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
+func (m *MockAssignmentServiceWithError) UpdateAssignment(id string, teacherUUID string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
 	return nil, errors.New("error updating assignment")
 }
 
-func (m *MockAssignmentServiceWithError) DeleteAssignment(id string) error {
+func (m *MockAssignmentServiceWithError) DeleteAssignment(id string, teacherUUID string) error {
 	return errors.New("error deleting assignment")
 }
 
@@ -291,6 +307,7 @@ func TestCreateAssignment(t *testing.T) {
 		"passing_score": 6.0
 	}`
 	req, _ := http.NewRequest("POST", "/assignments", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalAssignmentRouter.ServeHTTP(w, req)
 
@@ -302,6 +319,7 @@ func TestCreateAssignmentWithInvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{"invalid": "body"}`
 	req, _ := http.NewRequest("POST", "/assignments", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalAssignmentRouter.ServeHTTP(w, req)
 
@@ -312,6 +330,7 @@ func TestCreateAssignmentWithMalformedJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `invalid json`
 	req, _ := http.NewRequest("POST", "/assignments", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalAssignmentRouter.ServeHTTP(w, req)
 
@@ -346,6 +365,7 @@ func TestCreateAssignmentWithError(t *testing.T) {
 		"passing_score": 6.0
 	}`
 	req, _ := http.NewRequest("POST", "/assignments", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	errorAssignmentRouter.ServeHTTP(w, req)
 
@@ -428,6 +448,7 @@ func TestUpdateAssignment(t *testing.T) {
 		"passing_score": 9.0
 	}`
 	req, _ := http.NewRequest("PUT", "/assignments/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalAssignmentRouter.ServeHTTP(w, req)
 
@@ -439,6 +460,7 @@ func TestUpdateAssignmentWithInvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `invalid json`
 	req, _ := http.NewRequest("PUT", "/assignments/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalAssignmentRouter.ServeHTTP(w, req)
 
@@ -472,6 +494,7 @@ func TestUpdateAssignmentWithError(t *testing.T) {
 		"passing_score": 9.0
 	}`
 	req, _ := http.NewRequest("PUT", "/assignments/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	errorAssignmentRouter.ServeHTTP(w, req)
 
@@ -483,6 +506,7 @@ func TestUpdateAssignmentWithError(t *testing.T) {
 func TestDeleteAssignment(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/assignments/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalAssignmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNoContent, w.Code)
@@ -491,8 +515,9 @@ func TestDeleteAssignment(t *testing.T) {
 func TestDeleteAssignmentWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/assignments/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorAssignmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
-	assert.Contains(t, w.Body.String(), "error getting assignment by id")
+	assert.Contains(t, w.Body.String(), "error deleting assignment")
 }
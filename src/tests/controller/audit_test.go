@@ -0,0 +1,248 @@
+package controller_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"courses-service/src/auth"
+	"courses-service/src/controller"
+	"courses-service/src/middleware"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAuditService keeps the entities by "<entity>:<id>", the latest submissions by "<assignment>:<student>"
+// and the recorded changes in memory
+type MockAuditService struct {
+	entities    map[string]map[string]any
+	submissions map[string]string
+	records     []schemas.AuditRecord
+}
+
+func (m *MockAuditService) Snapshot(ctx context.Context, entity model.AuditEntity, id string) map[string]any {
+	return m.entities[string(entity)+":"+id]
+}
+
+func (m *MockAuditService) Record(ctx context.Context, record schemas.AuditRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *MockAuditService) LatestSubmissionID(ctx context.Context, assignmentID, studentUUID string) string {
+	return m.submissions[assignmentID+":"+studentUUID]
+}
+
+func (m *MockAuditService) GetLogs(ctx context.Context, filter schemas.AuditLogFilter) (*schemas.AuditLogPage, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, service.ErrInvalidDateRange
+	}
+	logs := []model.AuditLog{{ActorUUID: filter.ActorUUID, ActorRoles: []string{filter.Role}, CourseID: filter.CourseID, Action: model.AuditActionUpdate}}
+	return &schemas.AuditLogPage{Logs: logs, Page: 1, PageSize: schemas.DefaultAuditPageSize, Total: 1}, nil
+}
+
+// auditor records the changes of the audited routes of the controller tests in a mock nobody checks
+var auditor = middleware.NewAuditor(&MockAuditService{})
+
+// newAuditedRouter returns a router with audited routes that record their changes with the audit service
+func newAuditedRouter(auditService *MockAuditService) *gin.Engine {
+	auditor := middleware.NewAuditor(auditService)
+
+	r := gin.Default()
+	r.Use(middleware.RequestID())
+	r.PUT("/courses/:id", middleware.TeacherAuth(),
+		middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleTitularTeacher, auth.RoleAuxTeacher),
+		auditor.Audit(model.AuditEntityCourse, middleware.EntityParam("id")),
+		func(c *gin.Context) {
+			if c.Query("fail") != "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid course"})
+				return
+			}
+			auditService.entities["course:"+c.Param("id")] = map[string]any{"title": "New"}
+			c.JSON(http.StatusOK, gin.H{"title": "New"})
+		})
+	r.POST("/modules", middleware.TeacherAuth(),
+		auditor.Audit(model.AuditEntityModule, middleware.CreatedEntity),
+		func(c *gin.Context) {
+			auditService.entities["module:module123"] = map[string]any{"title": "Intro", "course_id": "course123"}
+			c.JSON(http.StatusCreated, gin.H{"id": "module123", "title": "Intro"})
+		})
+	r.POST("/assignments/:assignmentId/submissions", middleware.StudentAuth(),
+		auditor.Audit(model.AuditEntitySubmission, auditor.LatestSubmission("assignmentId")),
+		func(c *gin.Context) {
+			// Saves the answers in the latest attempt of the student, creating the first one if needed
+			id, ok := auditService.submissions[c.Param("assignmentId")+":"+c.GetString("user_uuid")]
+			if !ok {
+				id = "submission-new"
+			}
+			auditService.entities["submission:"+id] = map[string]any{"answers": "new", "course_id": "course123"}
+			c.JSON(http.StatusOK, gin.H{"id": id})
+		})
+	return r
+}
+
+func TestAuditRecordsTheChangeOfTheRequest(t *testing.T) {
+	auditService := &MockAuditService{entities: map[string]map[string]any{"course:policy-course": {"title": "Old"}}}
+	r := newAuditedRouter(auditService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/courses/policy-course", strings.NewReader(`{"title": "New"}`))
+	req.Header.Set("Authorization", teacherToken("aux-1"))
+	req.Header.Set(middleware.RequestIDHeader, "request123")
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "request123", w.Header().Get(middleware.RequestIDHeader))
+	assert.Len(t, auditService.records, 1)
+	record := auditService.records[0]
+	assert.Equal(t, "request123", record.RequestID)
+	assert.Equal(t, "aux-1", record.ActorUUID)
+	assert.Equal(t, []string{auth.RoleAuxTeacher}, record.ActorRoles)
+	assert.Equal(t, "policy-course", record.CourseID)
+	assert.Equal(t, model.AuditEntityCourse, record.Entity)
+	assert.Equal(t, "policy-course", record.EntityID)
+	assert.Equal(t, "PUT", record.Method)
+	assert.Equal(t, "/courses/policy-course", record.Path)
+	assert.Equal(t, map[string]any{"title": "Old"}, record.Before)
+	assert.Equal(t, map[string]any{"title": "New"}, record.After)
+}
+
+func TestAuditRecordsTheCreatedEntity(t *testing.T) {
+	auditService := &MockAuditService{entities: map[string]map[string]any{}}
+	r := newAuditedRouter(auditService)
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/modules", strings.NewReader(`{"title": "Intro", "course_id": "course123"}`))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"module123"`)
+	assert.Len(t, auditService.records, 1)
+	record := auditService.records[0]
+	assert.NotEmpty(t, record.RequestID)
+	assert.Equal(t, w.Header().Get(middleware.RequestIDHeader), record.RequestID)
+	assert.Equal(t, "teacher123", record.ActorUUID)
+	assert.Equal(t, []string{auth.RoleTeacher}, record.ActorRoles)
+	assert.Equal(t, "module123", record.EntityID)
+	assert.Nil(t, record.Before)
+	assert.Equal(t, "Intro", record.After["title"])
+}
+
+func TestAuditRecordsTheAnswersSavedInTheLatestSubmission(t *testing.T) {
+	auditService := &MockAuditService{
+		entities:    map[string]map[string]any{"submission:submission123": {"answers": "old", "course_id": "course123"}},
+		submissions: map[string]string{"assignment123:student123": "submission123"},
+	}
+	r := newAuditedRouter(auditService)
+
+	for _, student := range []string{"student123", "student456"} {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("POST", "/assignments/assignment123/submissions", strings.NewReader(`{"answers": []}`))
+		req.Header.Set("Authorization", studentToken(student))
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+	}
+
+	assert.Len(t, auditService.records, 2)
+	updated := auditService.records[0]
+	assert.Equal(t, "submission123", updated.EntityID)
+	assert.Equal(t, map[string]any{"answers": "old", "course_id": "course123"}, updated.Before)
+	assert.Equal(t, "new", updated.After["answers"])
+	created := auditService.records[1]
+	assert.Equal(t, "submission-new", created.EntityID)
+	assert.Nil(t, created.Before)
+}
+
+func TestAuditSkipsTheRejectedRequests(t *testing.T) {
+	auditService := &MockAuditService{entities: map[string]map[string]any{"course:policy-course": {"title": "Old"}}}
+	r := newAuditedRouter(auditService)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "handler error", path: "/courses/policy-course?fail=true", authorization: teacherToken("titular-1"), expectedCode: http.StatusBadRequest},
+		{name: "not a teacher of the course", path: "/courses/policy-course", authorization: teacherToken("other-teacher"), expectedCode: http.StatusForbidden},
+		{name: "without token", path: "/courses/policy-course", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("PUT", tt.path, strings.NewReader(`{"title": "New"}`))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+	assert.Empty(t, auditService.records)
+}
+
+func TestAuditWithoutAuditService(t *testing.T) {
+	handled := false
+	r := gin.Default()
+	r.PUT("/courses/:id", middleware.TeacherAuth(),
+		middleware.NewAuditor(nil).Audit(model.AuditEntityCourse, middleware.EntityParam("id")),
+		func(c *gin.Context) {
+			handled = true
+			c.JSON(http.StatusOK, gin.H{"title": "New"})
+		})
+
+	// The change is not made if it can't be recorded
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("PUT", "/courses/policy-course", strings.NewReader(`{"title": "New"}`))
+	req.Header.Set("Authorization", teacherToken("titular-1"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "auditing is not configured")
+	assert.False(t, handled)
+}
+
+func TestAuditLogsRoutes(t *testing.T) {
+	r := gin.Default()
+	router.InitializeAuditRoutes(r, controller.NewAuditController(&MockAuditService{}))
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{name: "audit logs", path: "/audit-logs?actor_uuid=aux-1&page=2", authorization: adminToken("admin1"), expectedCode: http.StatusOK, expectedBody: `"actor_uuid":"aux-1"`},
+		{name: "audit logs as teacher", path: "/audit-logs", authorization: teacherToken("titular-1"), expectedCode: http.StatusForbidden},
+		{name: "invalid page", path: "/audit-logs?page=-1", authorization: adminToken("admin1"), expectedCode: http.StatusBadRequest},
+		{name: "invalid date range", path: "/audit-logs?from=2026-03-01&to=2026-02-01", authorization: adminToken("admin1"), expectedCode: http.StatusBadRequest},
+		{name: "course audit logs", path: "/courses/policy-course/audit-logs?course_id=other-course&role=aux_teacher", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK, expectedBody: `"course_id":"policy-course"`},
+		{name: "course audit logs as admin", path: "/courses/policy-course/audit-logs", authorization: adminToken("admin1"), expectedCode: http.StatusOK, expectedBody: `"course_id":"policy-course"`},
+		{name: "course audit logs as aux teacher", path: "/courses/policy-course/audit-logs", authorization: teacherToken("aux-1"), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.Header.Set("Authorization", tt.authorization)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
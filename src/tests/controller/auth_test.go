@@ -0,0 +1,277 @@
+package controller_test
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"courses-service/src/auth"
+	"courses-service/src/config"
+	"courses-service/src/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+const testJWTSecret = "test-secret"
+
+func init() {
+	verifier, err := auth.NewVerifier(auth.NewSettings(&config.Config{JWTSecret: testJWTSecret}))
+	if err != nil {
+		panic(err)
+	}
+	middleware.SetVerifier(verifier)
+	middleware.SetMembershipService(membershipService)
+	middleware.SetBlockList(&MockBlockList{})
+}
+
+// MockBlockList blocks "blocked-user" and fails to check "block-check-error"
+type MockBlockList struct{}
+
+func (m *MockBlockList) IsBlocked(userUUID string) (bool, error) {
+	switch userUUID {
+	case "blocked-user":
+		return true, nil
+	case "block-check-error":
+		return false, errors.New("database down")
+	}
+	return false, nil
+}
+
+func tokenClaims(userID string, roles ...string) map[string]any {
+	return map[string]any{
+		"sub":   userID,
+		"name":  "Test User",
+		"aud":   "courses-service",
+		"roles": roles,
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func signedToken(claims map[string]any) string {
+	token, err := auth.SignHS256(claims, testJWTSecret)
+	if err != nil {
+		panic(err)
+	}
+	return token
+}
+
+// bearerToken returns the Authorization header of the user with the roles, signed with the test secret
+func bearerToken(userID string, roles ...string) string {
+	return "Bearer " + signedToken(tokenClaims(userID, roles...))
+}
+
+func teacherToken(teacherUUID string) string {
+	return bearerToken(teacherUUID, auth.RoleTeacher)
+}
+
+func studentToken(studentUUID string) string {
+	return bearerToken(studentUUID, auth.RoleStudent)
+}
+
+func adminToken(adminUUID string) string {
+	return bearerToken(adminUUID, auth.RoleAdmin)
+}
+
+// signRS256 signs the claims with the RSA key like an issuer publishing its keys in a JWKS
+func signRS256(t *testing.T, claims map[string]any, key *rsa.PrivateKey, keyID string) string {
+	header, _ := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT", "kid": keyID})
+	payload, _ := json.Marshal(claims)
+	signed := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signed))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	assert.NoError(t, err)
+	return signed + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+func jwks(keys map[string]*rsa.PrivateKey) []byte {
+	var set []map[string]string
+	for keyID, key := range keys {
+		set = append(set, map[string]string{
+			"kty": "RSA",
+			"kid": keyID,
+			"use": "sig",
+			"alg": "RS256",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		})
+	}
+	data, _ := json.Marshal(map[string]any{"keys": set})
+	return data
+}
+
+func authRouter() *gin.Engine {
+	r := gin.New()
+	identity := func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"user": c.GetString("user_uuid"), "teacher": c.GetString("teacher_uuid"), "student": c.GetString("student_uuid")})
+	}
+	r.GET("/teacher", middleware.TeacherAuth(), identity)
+	r.GET("/student", middleware.StudentAuth(), identity)
+	r.GET("/user", middleware.UserAuth(), identity)
+	return r
+}
+
+func TestAuthMiddlewaresWithHS256(t *testing.T) {
+	r := authRouter()
+	expired := tokenClaims("teacher123", auth.RoleTeacher)
+	expired["exp"] = time.Now().Add(-time.Hour).Unix()
+	otherAudience := tokenClaims("teacher123", auth.RoleTeacher)
+	otherAudience["aud"] = []string{"users-service"}
+	notYetValid := tokenClaims("teacher123", auth.RoleTeacher)
+	notYetValid["nbf"] = time.Now().Add(time.Hour).Unix()
+	withoutExpiration := tokenClaims("teacher123", auth.RoleTeacher)
+	delete(withoutExpiration, "exp")
+	withoutSubject := tokenClaims("", auth.RoleTeacher)
+	sign := func(claims map[string]any, secret string) string {
+		token, _ := auth.SignHS256(claims, secret)
+		return "Bearer " + token
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." +
+		base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"teacher123","aud":"courses-service","roles":["teacher"],"exp":9999999999}`)) + "."
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{name: "teacher", path: "/teacher", authorization: teacherToken("teacher123"), expectedCode: http.StatusOK, expectedBody: `"teacher":"teacher123"`},
+		{name: "student", path: "/student", authorization: studentToken("student123"), expectedCode: http.StatusOK, expectedBody: `"student":"student123"`},
+		{name: "user with both roles", path: "/user", authorization: bearerToken("user123", auth.RoleTeacher, auth.RoleStudent), expectedCode: http.StatusOK, expectedBody: `{"student":"user123","teacher":"user123","user":"user123"}`},
+		{name: "student as teacher", path: "/teacher", authorization: studentToken("student123"), expectedCode: http.StatusForbidden},
+		{name: "teacher as student", path: "/student", authorization: teacherToken("teacher123"), expectedCode: http.StatusForbidden},
+		{name: "without token", path: "/user", expectedCode: http.StatusUnauthorized},
+		{name: "not bearer", path: "/user", authorization: "Basic dXNlcjpwYXNz", expectedCode: http.StatusUnauthorized},
+		{name: "malformed token", path: "/user", authorization: "Bearer not-a-token", expectedCode: http.StatusUnauthorized},
+		{name: "other secret", path: "/teacher", authorization: sign(tokenClaims("teacher123", auth.RoleTeacher), "other-secret"), expectedCode: http.StatusUnauthorized},
+		{name: "expired", path: "/teacher", authorization: sign(expired, testJWTSecret), expectedCode: http.StatusUnauthorized, expectedBody: "token is expired"},
+		{name: "other audience", path: "/teacher", authorization: sign(otherAudience, testJWTSecret), expectedCode: http.StatusUnauthorized},
+		{name: "not valid yet", path: "/teacher", authorization: sign(notYetValid, testJWTSecret), expectedCode: http.StatusUnauthorized},
+		{name: "without expiration", path: "/teacher", authorization: sign(withoutExpiration, testJWTSecret), expectedCode: http.StatusUnauthorized},
+		{name: "without subject", path: "/teacher", authorization: sign(withoutSubject, testJWTSecret), expectedCode: http.StatusUnauthorized},
+		{name: "unsigned", path: "/teacher", authorization: "Bearer " + unsigned, expectedCode: http.StatusUnauthorized},
+		{name: "blocked user", path: "/user", authorization: studentToken("blocked-user"), expectedCode: http.StatusForbidden, expectedBody: "the user is blocked"},
+		{name: "error checking blocked user", path: "/user", authorization: studentToken("block-check-error"), expectedCode: http.StatusInternalServerError},
+		// The identity headers are no longer trusted
+		{name: "identity header", path: "/teacher", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			req.Header.Set("X-Teacher-UUID", "teacher123")
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Contains(t, w.Body.String(), tt.expectedBody)
+		})
+	}
+}
+
+func TestAuthWithoutBlockList(t *testing.T) {
+	middleware.SetBlockList(nil)
+	t.Cleanup(func() { middleware.SetBlockList(&MockBlockList{}) })
+
+	// Without the block list a blocked user could get through, so nobody does
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/user", nil)
+	req.Header.Set("Authorization", studentToken("student123"))
+	authRouter().ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "the block list is not configured")
+}
+
+func TestAuthWithCustomClaims(t *testing.T) {
+	verifier, err := auth.NewVerifier(auth.Settings{Secret: testJWTSecret, Audience: "courses", Issuer: "users-service", UserIDClaim: "uid", RolesClaim: "scope"})
+	assert.NoError(t, err)
+
+	claims := map[string]any{"uid": "teacher123", "aud": []string{"web", "courses"}, "iss": "users-service", "scope": "teacher admin", "exp": time.Now().Add(time.Minute).Unix()}
+	token, _ := auth.SignHS256(claims, testJWTSecret)
+	identity, err := verifier.Verify(token)
+	assert.NoError(t, err)
+	assert.Equal(t, &auth.Identity{UserID: "teacher123", Roles: []string{"teacher", "admin"}}, identity)
+
+	claims["iss"] = "other-service"
+	token, _ = auth.SignHS256(claims, testJWTSecret)
+	_, err = verifier.Verify(token)
+	assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+	_, err = auth.NewVerifier(auth.Settings{Audience: "courses"})
+	assert.Error(t, err)
+}
+
+func TestAuthWithRS256(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rotated, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	claims := tokenClaims("teacher123", auth.RoleTeacher)
+
+	t.Run("JWKS file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "jwks.json")
+		assert.NoError(t, os.WriteFile(file, jwks(map[string]*rsa.PrivateKey{"key-1": key}), 0o600))
+		verifier, err := auth.NewVerifier(auth.NewSettings(&config.Config{JWTJWKSFile: file}))
+		assert.NoError(t, err)
+
+		identity, err := verifier.Verify(signRS256(t, claims, key, "key-1"))
+		assert.NoError(t, err)
+		assert.Equal(t, "teacher123", identity.UserID)
+		assert.True(t, identity.HasRole(auth.RoleTeacher))
+
+		// A single key is used for the tokens without key ID
+		_, err = verifier.Verify(signRS256(t, claims, key, ""))
+		assert.NoError(t, err)
+
+		_, err = verifier.Verify(signRS256(t, claims, rotated, "key-1"))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		_, err = verifier.Verify(signRS256(t, claims, rotated, "key-2"))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+
+		// Without a secret the HS256 tokens are rejected
+		token, _ := auth.SignHS256(claims, testJWTSecret)
+		_, err = verifier.Verify(token)
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+	})
+
+	t.Run("JWKS URL with rotated keys", func(t *testing.T) {
+		keys := map[string]*rsa.PrivateKey{"key-1": key}
+		downloads := 0
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			downloads++
+			w.Write(jwks(keys))
+		}))
+		defer server.Close()
+
+		verifier, err := auth.NewVerifier(auth.NewSettings(&config.Config{JWTJWKSURL: server.URL}))
+		assert.NoError(t, err)
+
+		_, err = verifier.Verify(signRS256(t, claims, key, "key-1"))
+		assert.NoError(t, err)
+		_, err = verifier.Verify(signRS256(t, claims, key, "key-1"))
+		assert.NoError(t, err)
+		assert.Equal(t, 1, downloads)
+
+		// The keys are downloaded again at most once a minute, so a token with an unknown key can't flood the issuer
+		keys["key-2"] = rotated
+		_, err = verifier.Verify(signRS256(t, claims, rotated, "key-2"))
+		assert.ErrorIs(t, err, auth.ErrInvalidToken)
+		assert.Equal(t, 1, downloads)
+	})
+}
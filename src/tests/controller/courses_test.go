@@ -1,15 +1,19 @@
 package controller_test
 
 import (
+	"context"
+	"courses-service/src/ai"
 	"courses-service/src/controller"
 	"courses-service/src/model"
 	"courses-service/src/router"
 	"courses-service/src/schemas"
+	"courses-service/src/service"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -22,15 +26,15 @@ import (
 var (
 	mockService      = &MockCourseService{}
 	mockErrorService = &MockCourseServiceWithError{}
-	normalController = controller.NewCourseController(mockService, nil, mockActivityService, mockNotificationsQueue)
-	errorController  = controller.NewCourseController(mockErrorService, nil, mockActivityService, mockNotificationsQueue)
+	normalController = controller.NewCourseController(mockService, nil, nil, mockNotificationsQueue)
+	errorController  = controller.NewCourseController(mockErrorService, nil, nil, mockNotificationsQueue)
 	normalRouter     = gin.Default()
 	errorRouter      = gin.Default()
 )
 
 func init() {
-	router.InitializeCoursesRoutes(normalRouter, normalController)
-	router.InitializeCoursesRoutes(errorRouter, errorController)
+	router.InitializeCoursesRoutes(normalRouter, normalController, auditor)
+	router.InitializeCoursesRoutes(errorRouter, errorController, auditor)
 }
 
 type MockCourseService struct{}
@@ -45,8 +49,18 @@ func (m *MockCourseService) RemoveAuxTeacherFromCourse(id string, titularTeacher
 	return &model.Course{}, nil
 }
 
+// UpdateAuxTeacherPermissions implements service.CourseServiceInterface.
+func (m *MockCourseService) UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	for _, permission := range permissions {
+		if !slices.Contains(model.AllAuxTeacherPermissions, permission) {
+			return nil, fmt.Errorf("%w: %s", service.ErrInvalidPermission, permission)
+		}
+	}
+	return &model.Course{AuxTeacherPermissions: map[string][]model.AuxTeacherPermission{auxTeacherId: permissions}}, nil
+}
+
 // AddAuxTeacherToCourse implements controller.CourseService.
-func (m *MockCourseService) AddAuxTeacherToCourse(id string, teacherId string, auxTeacherId string) (*model.Course, error) {
+func (m *MockCourseService) AddAuxTeacherToCourse(id string, teacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
 	return &model.Course{}, nil
 }
 
@@ -192,8 +206,13 @@ func (m *MockCourseServiceWithError) RemoveAuxTeacherFromCourse(id string, titul
 	return nil, errors.New("Error removing aux teacher from course")
 }
 
+// UpdateAuxTeacherPermissions implements service.CourseServiceInterface.
+func (m *MockCourseServiceWithError) UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return nil, errors.New("Error updating aux teacher permissions")
+}
+
 // AddAuxTeacherToCourse implements controller.CourseService.
-func (m *MockCourseServiceWithError) AddAuxTeacherToCourse(id string, teacherId string, auxTeacherId string) (*model.Course, error) {
+func (m *MockCourseServiceWithError) AddAuxTeacherToCourse(id string, teacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
 	return nil, errors.New("Error adding aux teacher to course")
 }
 
@@ -271,9 +290,10 @@ func TestCreateCourse(t *testing.T) {
 	w := httptest.NewRecorder()
 	startTime := time.Now()
 	endTime := startTime.Add(time.Second * 10)
-	body := `{"title": "Test Course", "description": "Test Description", "teacher_id": "123", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
+	body := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
 
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -284,6 +304,7 @@ func TestCreateCourseWithInvalidBody(t *testing.T) {
 	body := `{"invalid": "body"}`
 
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -294,9 +315,10 @@ func TestCreateCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	startTime := time.Now()
 	endTime := startTime.Add(time.Second * 10)
-	body := `{"title": "Test Course", "description": "Test Description", "teacher_id": "123", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
+	body := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
 
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -322,7 +344,8 @@ func TestGetCourseByIdWithError(t *testing.T) {
 
 func TestDeleteCourse(t *testing.T) {
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/courses/123?teacherId=teacher123", nil)
+	req, _ := http.NewRequest("DELETE", "/courses/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -330,19 +353,30 @@ func TestDeleteCourse(t *testing.T) {
 
 func TestDeleteCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/courses/123?teacherId=teacher123", nil)
+	req, _ := http.NewRequest("DELETE", "/courses/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
-func TestDeleteCourseWithoutTeacherId(t *testing.T) {
+func TestDeleteCourseWithoutToken(t *testing.T) {
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/courses/123", nil)
+	req, _ := http.NewRequest("DELETE", "/courses/123?teacherId=teacher123", nil)
 	normalRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Teacher ID is required")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestCreateCourseAsStudent(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "2025-01-01T00:00:00Z", "end_date": "2025-06-01T00:00:00Z"}`
+
+	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student123"))
+	normalRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
 }
 
 func TestGetCourseByTeacherId(t *testing.T) {
@@ -406,9 +440,10 @@ func TestUpdateCourse(t *testing.T) {
 	w := httptest.NewRecorder()
 	startTime := time.Now()
 	endTime := startTime.Add(time.Second * 10)
-	body := `{"title": "Test Course", "description": "Test Description", "teacher_id": "123", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
+	body := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -419,6 +454,7 @@ func TestUpdateCourseWithInvalidBody(t *testing.T) {
 	body := `invalid body`
 
 	req, _ := http.NewRequest("PUT", "/courses/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -428,9 +464,10 @@ func TestUpdateCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	startTime := time.Now()
 	endTime := startTime.Add(time.Second * 10)
-	body := `{"title": "Test Course", "description": "Test Description", "teacher_id": "123", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
+	body := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -438,9 +475,10 @@ func TestUpdateCourseWithError(t *testing.T) {
 
 func TestAddAuxTeacherToCourse(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"teacher_id": "123", "aux_teacher_id": "456"}`
+	body := `{"aux_teacher_id": "456"}`
 
 	req, _ := http.NewRequest("POST", "/courses/123/aux-teacher/add", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -451,6 +489,7 @@ func TestAddAuxTeacherToCourseWithInvalidBody(t *testing.T) {
 	body := `{"invalid": "body"}`
 
 	req, _ := http.NewRequest("POST", "/courses/123/aux-teacher/add", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -459,9 +498,10 @@ func TestAddAuxTeacherToCourseWithInvalidBody(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"teacher_id": "123", "aux_teacher_id": "456"}`
+	body := `{"aux_teacher_id": "456"}`
 
 	req, _ := http.NewRequest("POST", "/courses/123/aux-teacher/add", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -470,9 +510,10 @@ func TestAddAuxTeacherToCourseWithError(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"teacher_id": "123", "aux_teacher_id": "456"}`
+	body := `{"aux_teacher_id": "456"}`
 
 	req, _ := http.NewRequest("POST", "/courses//aux-teacher/add", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -480,10 +521,10 @@ func TestAddAuxTeacherToCourseWithEmptyCourseId(t *testing.T) {
 
 func TestRemoveAuxTeacherFromCourse(t *testing.T) {
 	w := httptest.NewRecorder()
-	teacherId := "123"
 	auxTeacherId := "456"
 
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?teacherId=%s&auxTeacherId=%s", teacherId, auxTeacherId), nil)
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?auxTeacherId=%s", auxTeacherId), nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -491,22 +532,22 @@ func TestRemoveAuxTeacherFromCourse(t *testing.T) {
 
 func TestRemoveAuxTeacherFromCourseWithInvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
-	teacherId := ""
 	auxTeacherId := ""
 
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?teacherId=%s&auxTeacherId=%s", teacherId, auxTeacherId), nil)
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?auxTeacherId=%s", auxTeacherId), nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Teacher ID and aux teacher ID are required")
+	assert.Contains(t, w.Body.String(), "Aux teacher ID is required")
 }
 
 func TestRemoveAuxTeacherFromCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	teacherId := "123"
 	auxTeacherId := "456"
 
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?teacherId=%s&auxTeacherId=%s", teacherId, auxTeacherId), nil)
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/123/aux-teacher/remove?auxTeacherId=%s", auxTeacherId), nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -515,15 +556,61 @@ func TestRemoveAuxTeacherFromCourseWithError(t *testing.T) {
 
 func TestRemoveAuxTeacherFromCourseWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	teacherId := "123"
 	auxTeacherId := "456"
 
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses//aux-teacher/remove?teacherId=%s&auxTeacherId=%s", teacherId, auxTeacherId), nil)
+	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses//aux-teacher/remove?auxTeacherId=%s", auxTeacherId), nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
 }
 
+func TestUpdateAuxTeacherPermissions(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := `{"permissions": ["grade_submissions", "moderate_forum"]}`
+
+	req, _ := http.NewRequest("PUT", "/courses/123/aux-teacher/456/permissions", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	normalRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"456":["grade_submissions","moderate_forum"]`)
+}
+
+func TestUpdateAuxTeacherPermissionsWithInvalidPermission(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := `{"permissions": ["delete_course"]}`
+
+	req, _ := http.NewRequest("PUT", "/courses/123/aux-teacher/456/permissions", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	normalRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid aux teacher permission")
+}
+
+func TestUpdateAuxTeacherPermissionsWithoutPermissions(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	req, _ := http.NewRequest("PUT", "/courses/123/aux-teacher/456/permissions", strings.NewReader(`{}`))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	normalRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestUpdateAuxTeacherPermissionsWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+	body := `{"permissions": []}`
+
+	req, _ := http.NewRequest("PUT", "/courses/123/aux-teacher/456/permissions", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
+	errorRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Equal(t, "{\"error\":\"Error updating aux teacher permissions\"}", w.Body.String())
+}
+
 func TestGetFavouriteCourses(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/courses/student/123/favourite", nil)
@@ -554,13 +641,13 @@ func TestGetFavouriteCoursesWithEmptyStudentId(t *testing.T) {
 func TestCreateCourseFeedback(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
-		"student_uuid": "student-123",
 		"score": 5,
 		"feedback_type": "POSITIVO",
 		"feedback": "Excellent course! Very informative and well structured."
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student-123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -574,13 +661,13 @@ func TestCreateCourseFeedback(t *testing.T) {
 func TestCreateCourseFeedbackWithEmptyCourseID(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
-		"student_uuid": "student-123",
 		"score": 4,
 		"feedback_type": "POSITIVO",
 		"feedback": "Good course"
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses//feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student-123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -591,11 +678,11 @@ func TestCreateCourseFeedbackWithEmptyCourseID(t *testing.T) {
 func TestCreateCourseFeedbackWithInvalidJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
-		"student_uuid": "student-123",
 		"invalid_field": "invalid"
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student-123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -608,18 +695,9 @@ func TestCreateCourseFeedbackWithMissingRequiredFields(t *testing.T) {
 		name string
 		body string
 	}{
-		{
-			name: "Missing student_uuid",
-			body: `{
-				"score": 5,
-				"feedback_type": "POSITIVO",
-				"feedback": "Great course"
-			}`,
-		},
 		{
 			name: "Missing score",
 			body: `{
-				"student_uuid": "student-123",
 				"feedback_type": "POSITIVO",
 				"feedback": "Great course"
 			}`,
@@ -627,7 +705,6 @@ func TestCreateCourseFeedbackWithMissingRequiredFields(t *testing.T) {
 		{
 			name: "Missing feedback_type",
 			body: `{
-				"student_uuid": "student-123",
 				"score": 5,
 				"feedback": "Great course"
 			}`,
@@ -635,7 +712,6 @@ func TestCreateCourseFeedbackWithMissingRequiredFields(t *testing.T) {
 		{
 			name: "Missing feedback",
 			body: `{
-				"student_uuid": "student-123",
 				"score": 5,
 				"feedback_type": "POSITIVO"
 			}`,
@@ -646,6 +722,7 @@ func TestCreateCourseFeedbackWithMissingRequiredFields(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(tc.body))
+			req.Header.Set("Authorization", studentToken("student-123"))
 			req.Header.Set("Content-Type", "application/json")
 			normalRouter.ServeHTTP(w, req)
 
@@ -670,13 +747,13 @@ func TestCreateCourseFeedbackWithDifferentFeedbackTypes(t *testing.T) {
 		t.Run("FeedbackType_"+tc.feedbackType, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			body := `{
-				"student_uuid": "student-123",
 				"score": ` + string(rune(tc.score+'0')) + `,
 				"feedback_type": "` + tc.feedbackType + `",
 				"feedback": "` + tc.feedback + `"
 			}`
 
 			req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+			req.Header.Set("Authorization", studentToken("student-123"))
 			req.Header.Set("Content-Type", "application/json")
 			normalRouter.ServeHTTP(w, req)
 
@@ -691,13 +768,13 @@ func TestCreateCourseFeedbackWithDifferentFeedbackTypes(t *testing.T) {
 func TestCreateCourseFeedbackWithServiceError(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
-		"student_uuid": "student-123",
 		"score": 4,
 		"feedback_type": "POSITIVO",
 		"feedback": "Good course"
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student-123"))
 	req.Header.Set("Content-Type", "application/json")
 	errorRouter.ServeHTTP(w, req)
 
@@ -721,13 +798,13 @@ func TestCreateCourseFeedbackWithScoreBoundaries(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			w := httptest.NewRecorder()
 			body := `{
-				"student_uuid": "student-123",
 				"score": ` + string(rune(tc.score+'0')) + `,
 				"feedback_type": "POSITIVO",
 				"feedback": "Course feedback"
 			}`
 
 			req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+			req.Header.Set("Authorization", studentToken("student-123"))
 			req.Header.Set("Content-Type", "application/json")
 			normalRouter.ServeHTTP(w, req)
 
@@ -740,9 +817,10 @@ func TestCreateCourseFeedbackWithScoreBoundaries(t *testing.T) {
 
 func TestCreateCourseFeedbackWithInvalidFeedbackType(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_uuid": "student-123", "score": 4, "feedback_type": "INVALID_TYPE", "feedback": "Good course"}`
+	body := `{"score": 4, "feedback_type": "INVALID_TYPE", "feedback": "Good course"}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", studentToken("student-123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -755,6 +833,7 @@ func TestGetCourseFeedback(t *testing.T) {
 	body := `{}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -772,6 +851,7 @@ func TestGetCourseFeedbackWithEmptyCourseID(t *testing.T) {
 	body := `{}`
 
 	req, _ := http.NewRequest("PUT", "/courses//feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -784,6 +864,7 @@ func TestGetCourseFeedbackWithNoFeedback(t *testing.T) {
 	body := `{}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-no-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -797,6 +878,7 @@ func TestGetCourseFeedbackWithFeedbackTypeFilter(t *testing.T) {
 	body := `{"feedback_type": "POSITIVO"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -813,6 +895,7 @@ func TestGetCourseFeedbackWithScoreRangeFilter(t *testing.T) {
 	body := `{"start_score": 4, "end_score": 5}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -829,6 +912,7 @@ func TestGetCourseFeedbackWithCombinedFilters(t *testing.T) {
 	body := `{"feedback_type": "POSITIVO", "start_score": 4, "end_score": 5}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -843,6 +927,7 @@ func TestGetCourseFeedbackWithInvalidJSON(t *testing.T) {
 	body := `{"feedback_type": "POSITIVO", "start_score": invalid}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -855,6 +940,7 @@ func TestGetCourseFeedbackWithServiceError(t *testing.T) {
 	body := `{}`
 
 	req, _ := http.NewRequest("PUT", "/courses/error-course/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	errorRouter.ServeHTTP(w, req)
 
@@ -867,6 +953,7 @@ func TestGetCourseFeedbackWithDateRangeFilter(t *testing.T) {
 	body := `{"start_date": "2024-01-01T00:00:00Z", "end_date": "2024-12-31T23:59:59Z"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-with-feedback/feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalRouter.ServeHTTP(w, req)
 
@@ -923,3 +1010,38 @@ func TestGetCourseMembersWithError(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "Error getting course members", response.Error)
 }
+
+// UnavailableAiProvider behaves like a provider whose circuit breaker is open
+type UnavailableAiProvider struct {
+	ai.FakeProvider
+}
+
+func (p *UnavailableAiProvider) SummarizeCourseFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (string, error) {
+	return "", fmt.Errorf("%w: circuit breaker is open", ai.ErrUnavailable)
+}
+
+func TestGetCourseFeedbackSummary(t *testing.T) {
+	tests := []struct {
+		name         string
+		aiClient     ai.Provider
+		expectedCode int
+	}{
+		{name: "summary generated", aiClient: ai.NewFakeProvider(), expectedCode: http.StatusOK},
+		{name: "AI unavailable", aiClient: &UnavailableAiProvider{}, expectedCode: http.StatusServiceUnavailable},
+		{name: "AI not configured", aiClient: nil, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := gin.Default()
+			router.InitializeCoursesRoutes(r, controller.NewCourseController(mockService, tt.aiClient, nil, mockNotificationsQueue), auditor)
+
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/courses/course-with-feedback/feedback/summary", nil)
+			req.Header.Set("Authorization", teacherToken("teacher123"))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
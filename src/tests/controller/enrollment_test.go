@@ -1,6 +1,7 @@
 package controller_test
 
 import (
+	"courses-service/src/auth"
 	"courses-service/src/controller"
 	"courses-service/src/model"
 	"courses-service/src/router"
@@ -19,15 +20,15 @@ import (
 var (
 	mockEnrollmentService      = &MockEnrollmentService{}
 	mockErrorEnrollmentService = &MockEnrollmentServiceWithError{}
-	normalEnrollmentController = controller.NewEnrollmentController(mockEnrollmentService, nil, mockActivityService, mockNotificationsQueue)
-	errorEnrollmentController  = controller.NewEnrollmentController(mockErrorEnrollmentService, nil, mockActivityService, mockNotificationsQueue)
+	normalEnrollmentController = controller.NewEnrollmentController(mockEnrollmentService, nil, nil, mockNotificationsQueue)
+	errorEnrollmentController  = controller.NewEnrollmentController(mockErrorEnrollmentService, nil, nil, mockNotificationsQueue)
 	normalEnrollmentRouter     = gin.Default()
 	errorEnrollmentRouter      = gin.Default()
 )
 
 func init() {
-	router.InitializeEnrollmentsRoutes(normalEnrollmentRouter, normalEnrollmentController)
-	router.InitializeEnrollmentsRoutes(errorEnrollmentRouter, errorEnrollmentController)
+	router.InitializeEnrollmentsRoutes(normalEnrollmentRouter, normalEnrollmentController, auditor)
+	router.InitializeEnrollmentsRoutes(errorEnrollmentRouter, errorEnrollmentController, auditor)
 }
 
 type MockEnrollmentService struct{}
@@ -84,7 +85,7 @@ func (m *MockEnrollmentService) UnsetFavouriteCourse(studentID, courseID string)
 }
 
 // ApproveStudent implements service.EnrollmentServiceInterface.
-func (m *MockEnrollmentService) ApproveStudent(studentID, courseID string) error {
+func (m *MockEnrollmentService) ApproveStudent(studentID, courseID, teacherUUID string) error {
 	if studentID == "error-student" || courseID == "error-course" {
 		return errors.New("error approving student")
 	}
@@ -92,7 +93,7 @@ func (m *MockEnrollmentService) ApproveStudent(studentID, courseID string) error
 }
 
 // DisapproveStudent implements service.EnrollmentServiceInterface.
-func (m *MockEnrollmentService) DisapproveStudent(studentID, courseID, reason string) error {
+func (m *MockEnrollmentService) DisapproveStudent(studentID, courseID, teacherUUID, reason string) error {
 	if studentID == "error-student" || courseID == "error-course" {
 		return errors.New("error disapproving student")
 	}
@@ -133,42 +134,37 @@ func (m *MockEnrollmentServiceWithError) UnsetFavouriteCourse(studentID, courseI
 }
 
 // ApproveStudent implements service.EnrollmentServiceInterface.
-func (m *MockEnrollmentServiceWithError) ApproveStudent(studentID, courseID string) error {
+func (m *MockEnrollmentServiceWithError) ApproveStudent(studentID, courseID, teacherUUID string) error {
 	return errors.New("Error approving student")
 }
 
 // DisapproveStudent implements service.EnrollmentServiceInterface.
-func (m *MockEnrollmentServiceWithError) DisapproveStudent(studentID, courseID, reason string) error {
+func (m *MockEnrollmentServiceWithError) DisapproveStudent(studentID, courseID, teacherUUID, reason string) error {
 	return errors.New("Error disapproving student")
 }
 
 func TestEnrollStudent(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
 	assert.Contains(t, w.Body.String(), "Student successfully enrolled in course")
 }
 
-func TestEnrollStudentWithInvalidBody(t *testing.T) {
+func TestEnrollStudentWithoutToken(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"invalid": "body"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", nil)
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Error:Field validation")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestEnrollStudentWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses//enroll", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses//enroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -176,9 +172,8 @@ func TestEnrollStudentWithEmptyCourseId(t *testing.T) {
 
 func TestEnrollStudentWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/enroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -187,30 +182,27 @@ func TestEnrollStudentWithError(t *testing.T) {
 
 func TestUnenrollStudent(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/course-123/unenroll?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/unenroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Student successfully unenrolled from course")
 }
 
-func TestUnenrollStudentWithInvalidBody(t *testing.T) {
+func TestUnenrollStudentWithoutToken(t *testing.T) {
 	w := httptest.NewRecorder()
 
-	req, _ := http.NewRequest("DELETE", "/courses/course-123/unenroll?studentId=", nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/unenroll?studentId=123e4567-e89b-12d3-a456-426614174000", nil)
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Student ID is required")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestUnenrollStudentWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses//unenroll?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses//unenroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -218,9 +210,8 @@ func TestUnenrollStudentWithEmptyCourseId(t *testing.T) {
 
 func TestUnenrollStudentWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/course-123/unenroll?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/unenroll", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -231,6 +222,7 @@ func TestGetEnrollmentsByCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("GET", "/courses/course-123/enrollments", nil)
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -240,6 +232,7 @@ func TestGetEnrollmentsByCourseIdWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("GET", "/courses/course-123/enrollments", nil)
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -248,42 +241,36 @@ func TestGetEnrollmentsByCourseIdWithError(t *testing.T) {
 
 func TestSetFavouriteCourse(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Favourite course set")
 }
 
-func TestSetFavouriteCourseWithInvalidBody(t *testing.T) {
+func TestSetFavouriteCourseWithoutToken(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"invalid": "body"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", nil)
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Error:Field validation")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestSetFavouriteCourseWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses//favourite", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses//favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Invalid course ID")
+	assert.Contains(t, w.Body.String(), "Course ID is required")
 }
 
 func TestSetFavouriteCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"student_id": "123e4567-e89b-12d3-a456-426614174000"}`
-
-	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", strings.NewReader(body))
+	req, _ := http.NewRequest("POST", "/courses/course-123/favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -292,41 +279,37 @@ func TestSetFavouriteCourseWithError(t *testing.T) {
 
 func TestUnsetFavouriteCourse(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/course-123/favourite?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
 	assert.Contains(t, w.Body.String(), "Favourite course unset")
 }
 
-func TestUnsetFavouriteCourseWithInvalidBody(t *testing.T) {
+func TestUnsetFavouriteCourseWithoutToken(t *testing.T) {
 	w := httptest.NewRecorder()
 
-	req, _ := http.NewRequest("DELETE", "/courses/course-123/favourite?studentId=", nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/favourite?studentId=123e4567-e89b-12d3-a456-426614174000", nil)
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Student ID is required")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestUnsetFavouriteCourseWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses//favourite?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses//favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
-	assert.Contains(t, w.Body.String(), "Invalid course ID")
+	assert.Contains(t, w.Body.String(), "Course ID is required")
 }
 
 func TestUnsetFavouriteCourseWithError(t *testing.T) {
 	w := httptest.NewRecorder()
-	studentId := "123e4567-e89b-12d3-a456-426614174000"
-
-	req, _ := http.NewRequest("DELETE", fmt.Sprintf("/courses/course-123/favourite?studentId=%s", studentId), nil)
+	req, _ := http.NewRequest("DELETE", "/courses/course-123/favourite", nil)
+	req.Header.Set("Authorization", studentToken("123e4567-e89b-12d3-a456-426614174000"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -337,7 +320,6 @@ func TestCreateFeedback(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "student-123",
-		"teacher_uuid": "teacher-456",
 		"course_id": "course-123",
 		"feedback_type": "POSITIVO",
 		"score": 4,
@@ -345,6 +327,7 @@ func TestCreateFeedback(t *testing.T) {
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -356,7 +339,6 @@ func TestCreateFeedbackWithEmptyCourseID(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "student-123",
-		"teacher_uuid": "teacher-456",
 		"course_id": "course-123",
 		"feedback_type": "POSITIVO",
 		"score": 4,
@@ -364,6 +346,7 @@ func TestCreateFeedbackWithEmptyCourseID(t *testing.T) {
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses//student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -374,11 +357,11 @@ func TestCreateFeedbackWithInvalidJSON(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "student-123",
-		"teacher_uuid": "teacher-456",
 		"invalid_field": "invalid"
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -390,7 +373,6 @@ func TestCreateFeedbackWithMissingRequiredFields(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "",
-		"teacher_uuid": "teacher-456",
 		"course_id": "course-123",
 		"feedback_type": "POSITIVO",
 		"score": 4,
@@ -398,6 +380,7 @@ func TestCreateFeedbackWithMissingRequiredFields(t *testing.T) {
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -409,7 +392,6 @@ func TestCreateFeedbackWithServiceError(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "student-123",
-		"teacher_uuid": "teacher-456",
 		"course_id": "course-123",
 		"feedback_type": "POSITIVO",
 		"score": 4,
@@ -417,6 +399,7 @@ func TestCreateFeedbackWithServiceError(t *testing.T) {
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
@@ -439,7 +422,6 @@ func TestCreateFeedbackWithDifferentFeedbackTypes(t *testing.T) {
 		w := httptest.NewRecorder()
 		body := fmt.Sprintf(`{
 			"student_uuid": "student-123",
-			"teacher_uuid": "teacher-456",
 			"course_id": "course-123",
 			"feedback_type": "%s",
 			"score": %d,
@@ -447,6 +429,7 @@ func TestCreateFeedbackWithDifferentFeedbackTypes(t *testing.T) {
 		}`, tc.feedbackType, tc.score)
 
 		req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+		req.Header.Set("Authorization", teacherToken("teacher-456"))
 		req.Header.Set("Content-Type", "application/json")
 		normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -459,7 +442,6 @@ func TestCreateFeedbackWithInvalidFeedbackType(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{
 		"student_uuid": "student-123",
-		"teacher_uuid": "teacher-456",
 		"course_id": "course-123",
 		"feedback_type": "INVALID_TYPE",
 		"score": 3,
@@ -467,6 +449,7 @@ func TestCreateFeedbackWithInvalidFeedbackType(t *testing.T) {
 	}`
 
 	req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher-456"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -487,7 +470,6 @@ func TestCreateFeedbackWithValidScoreBoundaries(t *testing.T) {
 		w := httptest.NewRecorder()
 		body := fmt.Sprintf(`{
 			"student_uuid": "student-123",
-			"teacher_uuid": "teacher-456",
 			"course_id": "course-123",
 			"feedback_type": "POSITIVO",
 			"score": %d,
@@ -495,6 +477,7 @@ func TestCreateFeedbackWithValidScoreBoundaries(t *testing.T) {
 		}`, tc.score)
 
 		req, _ := http.NewRequest("POST", "/courses/course-123/student-feedback", strings.NewReader(body))
+		req.Header.Set("Authorization", teacherToken("teacher-456"))
 		req.Header.Set("Content-Type", "application/json")
 		normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -510,6 +493,7 @@ func TestGetFeedbackByStudentId(t *testing.T) {
 	body := `{"course_id": "course-123", "feedback_type": "POSITIVO"}`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/student-with-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -526,6 +510,7 @@ func TestGetFeedbackByStudentIdWithEmptyStudentId(t *testing.T) {
 	body := `{"course_id": "course-123"}`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -537,6 +522,7 @@ func TestGetFeedbackByStudentIdWithInvalidBody(t *testing.T) {
 	body := `{"invalid": "body"}`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/student-123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -549,6 +535,7 @@ func TestGetFeedbackByStudentIdWithMalformedJSON(t *testing.T) {
 	body := `{"course_id": "course-123", "invalid_json"`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/student-123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -561,6 +548,7 @@ func TestGetFeedbackByStudentIdWithServiceError(t *testing.T) {
 	body := `{"course_id": "course-123"}`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/student-123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
@@ -573,6 +561,7 @@ func TestGetFeedbackByStudentIdWithNoFeedback(t *testing.T) {
 	body := `{"course_id": "course-123"}`
 
 	req, _ := http.NewRequest("PUT", "/feedback/student/student-without-feedback", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	req.Header.Set("Content-Type", "application/json")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -630,6 +619,7 @@ func TestGetFeedbackByStudentIdWithDifferentFilters(t *testing.T) {
 			w := httptest.NewRecorder()
 
 			req, _ := http.NewRequest("PUT", fmt.Sprintf("/feedback/student/%s", tc.studentID), strings.NewReader(tc.body))
+			req.Header.Set("Authorization", teacherToken("teacher123"))
 			req.Header.Set("Content-Type", "application/json")
 			normalEnrollmentRouter.ServeHTTP(w, req)
 
@@ -650,8 +640,7 @@ func TestApproveStudent(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -667,8 +656,7 @@ func TestApproveStudentWithEmptyCourseId(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses//students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -679,8 +667,7 @@ func TestApproveStudentWithEmptyStudentId(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students//approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -691,8 +678,7 @@ func TestApproveStudentWithBothEmptyIds(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses//students//approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -703,8 +689,7 @@ func TestApproveStudentWithServiceError(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -716,8 +701,7 @@ func TestApproveStudentWithErrorStudentId(t *testing.T) {
 
 	// Using "error-student" which triggers an error in our mock
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/error-student/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -729,8 +713,7 @@ func TestApproveStudentWithErrorCourseId(t *testing.T) {
 
 	// Using "error-course" which triggers an error in our mock
 	req, _ := http.NewRequest("PUT", "/courses/error-course/students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -740,32 +723,34 @@ func TestApproveStudentWithErrorCourseId(t *testing.T) {
 func TestApproveStudentWithoutTeacherHeaders(t *testing.T) {
 	w := httptest.NewRecorder()
 
-	// No teacher headers - should fail authorization
+	// No token - should fail authorization even with the old identity headers
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-456/approve", nil)
+	req.Header.Set("X-Teacher-UUID", "teacher-123")
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusUnauthorized, w.Code)
-	assert.Contains(t, w.Body.String(), "X-Teacher-UUID header is required")
+	assert.Contains(t, w.Body.String(), "Authorization header with a bearer token is required")
 }
 
-func TestApproveStudentWithEmptyTeacherUUID(t *testing.T) {
+func TestApproveStudentWithStudentToken(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "") // Empty UUID
-	req.Header.Set("X-Teacher-Name", "Test Teacher")
+	req.Header.Set("Authorization", studentToken("student-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusUnauthorized, w.Code)
-	assert.Contains(t, w.Body.String(), "X-Teacher-UUID header is required")
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.Contains(t, w.Body.String(), "the teacher role is required")
 }
 
-func TestApproveStudentWithOnlyTeacherUUID(t *testing.T) {
+func TestApproveStudentWithoutTeacherName(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-456/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	// No X-Teacher-Name header - should still work as it's optional
+	// No name claim - should still work as it's optional
+	claims := tokenClaims("teacher-123", auth.RoleTeacher)
+	delete(claims, "name")
+	req.Header.Set("Authorization", "Bearer "+signedToken(claims))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -815,8 +800,7 @@ func TestApproveStudentWithValidUUIDs(t *testing.T) {
 
 			url := fmt.Sprintf("/courses/%s/students/%s/approve", tc.courseId, tc.studentId)
 			req, _ := http.NewRequest("PUT", url, nil)
-			req.Header.Set("X-Teacher-UUID", "teacher-123")
-			req.Header.Set("X-Teacher-Name", "Test Teacher")
+			req.Header.Set("Authorization", teacherToken("teacher-123"))
 			normalEnrollmentRouter.ServeHTTP(w, req)
 
 			assert.Equal(t, tc.expected, w.Code)
@@ -834,8 +818,7 @@ func TestApproveStudentResponseStructure(t *testing.T) {
 	w := httptest.NewRecorder()
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/approve", nil)
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -852,8 +835,7 @@ func TestDisapproveStudent(t *testing.T) {
 	body := `{"reason": "Student did not meet the requirements"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -866,8 +848,7 @@ func TestDisapproveStudentWithEmptyCourseId(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses//students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -879,8 +860,7 @@ func TestDisapproveStudentWithEmptyStudentId(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students//disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -892,8 +872,7 @@ func TestDisapproveStudentWithBothEmptyIds(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses//students//disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -905,8 +884,7 @@ func TestDisapproveStudentWithInvalidJSON(t *testing.T) {
 	body := `{"invalid": json}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -918,8 +896,7 @@ func TestDisapproveStudentWithMissingReason(t *testing.T) {
 	body := `{}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -931,8 +908,7 @@ func TestDisapproveStudentWithEmptyReason(t *testing.T) {
 	body := `{"reason": ""}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -944,8 +920,7 @@ func TestDisapproveStudentWithServiceError(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	errorEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -957,8 +932,7 @@ func TestDisapproveStudentWithErrorStudentId(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/error-student/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -970,8 +944,7 @@ func TestDisapproveStudentWithErrorCourseId(t *testing.T) {
 	body := `{"reason": "Some reason"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/error-course/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -984,8 +957,7 @@ func TestDisapproveStudentWithLongReason(t *testing.T) {
 	body := fmt.Sprintf(`{"reason": "%s"}`, longReason)
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -998,8 +970,7 @@ func TestDisapproveStudentWithSpecialCharactersInReason(t *testing.T) {
 	body := `{"reason": "Student failed due to: 1) Poor attendance, 2) Low grades, 3) Missed deadlines & assignments"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -1012,8 +983,7 @@ func TestDisapproveStudentResponseStructure(t *testing.T) {
 	body := `{"reason": "Academic performance below standards"}`
 
 	req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-	req.Header.Set("X-Teacher-UUID", "teacher-123")
-	req.Header.Set("X-Teacher-Name", "Teacher Name")
+	req.Header.Set("Authorization", teacherToken("teacher-123"))
 	normalEnrollmentRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -1067,8 +1037,7 @@ func TestDisapproveStudentWithDifferentReasonTypes(t *testing.T) {
 			body := fmt.Sprintf(`{"reason": "%s"}`, tc.reason)
 
 			req, _ := http.NewRequest("PUT", "/courses/course-123/students/student-123/disapprove", strings.NewReader(body))
-			req.Header.Set("X-Teacher-UUID", "teacher-123")
-			req.Header.Set("X-Teacher-Name", "Teacher Name")
+			req.Header.Set("Authorization", teacherToken("teacher-123"))
 			normalEnrollmentRouter.ServeHTTP(w, req)
 
 			assert.Equal(t, http.StatusOK, w.Code)
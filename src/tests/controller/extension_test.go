@@ -0,0 +1,181 @@
+package controller_test
+
+import (
+	"bytes"
+	"context"
+	"courses-service/src/controller"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mock Extension Service
+type MockExtensionService struct{}
+
+func (m *MockExtensionService) CreateExtension(ctx context.Context, assignmentID, teacherUUID string, request schemas.CreateExtensionRequest) (*model.DeadlineExtension, error) {
+	if assignmentID == "non-existent" {
+		return nil, service.ErrAssignmentNotFound
+	}
+	if teacherUUID == "other-teacher" {
+		return nil, service.ErrUnauthorized
+	}
+	if request.DueDate.Before(time.Now()) {
+		return nil, fmt.Errorf("%w: due date must be after the assignment due date", service.ErrInvalidExtension)
+	}
+	return &model.DeadlineExtension{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: assignmentID,
+		CourseID:     "course-123",
+		StudentUUIDs: request.StudentUUIDs,
+		DueDate:      request.DueDate,
+		GrantedBy:    teacherUUID,
+	}, nil
+}
+
+func (m *MockExtensionService) GetExtensionsByAssignment(ctx context.Context, assignmentID, teacherUUID string) ([]model.DeadlineExtension, error) {
+	return []model.DeadlineExtension{{ID: primitive.NewObjectID(), AssignmentID: assignmentID, StudentUUIDs: []string{"student-123"}}}, nil
+}
+
+func (m *MockExtensionService) UpdateExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string, request schemas.UpdateExtensionRequest) (*model.DeadlineExtension, error) {
+	if extensionID == "non-existent" {
+		return nil, service.ErrExtensionNotFound
+	}
+	return &model.DeadlineExtension{ID: primitive.NewObjectID(), AssignmentID: assignmentID, DueDate: request.DueDate}, nil
+}
+
+func (m *MockExtensionService) DeleteExtension(ctx context.Context, assignmentID, extensionID, teacherUUID string) error {
+	if extensionID == "non-existent" {
+		return service.ErrExtensionNotFound
+	}
+	return nil
+}
+
+// Setup
+var (
+	extensionController = controller.NewExtensionController(&MockExtensionService{})
+	extensionRouter     = gin.Default()
+)
+
+func init() {
+	router.InitializeExtensionRoutes(extensionRouter, extensionController, auditor)
+}
+
+func newTeacherRequest(method, path, teacherUUID string, body any) *http.Request {
+	jsonBody, _ := json.Marshal(body)
+	req, _ := http.NewRequest(method, path, bytes.NewBuffer(jsonBody))
+	req.Header.Set("Content-Type", "application/json")
+	if teacherUUID != "" {
+		req.Header.Set("Authorization", teacherToken(teacherUUID))
+	}
+	return req
+}
+
+func TestCreateExtension(t *testing.T) {
+	req := newTeacherRequest("POST", "/assignments/assignment-123/extensions", "teacher-123", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student-123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response model.DeadlineExtension
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "assignment-123", response.AssignmentID)
+	assert.Equal(t, "teacher-123", response.GrantedBy)
+}
+
+func TestCreateExtensionWithoutTeacherHeader(t *testing.T) {
+	req := newTeacherRequest("POST", "/assignments/assignment-123/extensions", "", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student-123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestCreateExtensionWithMissingStudents(t *testing.T) {
+	req := newTeacherRequest("POST", "/assignments/assignment-123/extensions", "teacher-123", map[string]any{
+		"due_date": time.Now().Add(24 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateExtensionWithPastDueDate(t *testing.T) {
+	req := newTeacherRequest("POST", "/assignments/assignment-123/extensions", "teacher-123", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student-123"},
+		DueDate:      time.Now().Add(-24 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateExtensionByOtherTeacher(t *testing.T) {
+	req := newTeacherRequest("POST", "/assignments/assignment-123/extensions", "other-teacher", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student-123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetExtensionsByAssignment(t *testing.T) {
+	req := newTeacherRequest("GET", "/assignments/assignment-123/extensions", "teacher-123", nil)
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []model.DeadlineExtension
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+}
+
+func TestUpdateNonExistentExtension(t *testing.T) {
+	req := newTeacherRequest("PUT", "/assignments/assignment-123/extensions/non-existent", "teacher-123", schemas.UpdateExtensionRequest{
+		DueDate: time.Now().Add(48 * time.Hour),
+	})
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteExtension(t *testing.T) {
+	req := newTeacherRequest("DELETE", "/assignments/assignment-123/extensions/extension-123", "teacher-123", nil)
+
+	w := httptest.NewRecorder()
+	extensionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
@@ -114,10 +114,13 @@ func (m *MockForumService) GetQuestionsByCourseId(courseID string) ([]model.Foru
 	}, nil
 }
 
-func (m *MockForumService) UpdateQuestion(id, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error) {
+func (m *MockForumService) UpdateQuestion(id, userID, title, description string, tags []model.QuestionTag) (*model.ForumQuestion, error) {
 	if id == "non-existent" {
 		return nil, errors.New("question not found")
 	}
+	if userID == "wrong-author" {
+		return nil, errors.New("you can only update your own questions")
+	}
 
 	return &model.ForumQuestion{
 		ID:          mustParseForumObjectID("123456789012345678901234"),
@@ -131,11 +134,11 @@ func (m *MockForumService) UpdateQuestion(id, title, description string, tags []
 	}, nil
 }
 
-func (m *MockForumService) DeleteQuestion(id, authorID string) error {
+func (m *MockForumService) DeleteQuestion(id, userID string) error {
 	if id == "non-existent" {
 		return errors.New("question not found")
 	}
-	if authorID == "wrong-author" {
+	if userID == "wrong-author" {
 		return errors.New("you can only delete your own questions")
 	}
 	return nil
@@ -172,11 +175,11 @@ func (m *MockForumService) UpdateAnswer(questionID, answerID, authorID, content
 	}, nil
 }
 
-func (m *MockForumService) DeleteAnswer(questionID, answerID, authorID string) error {
+func (m *MockForumService) DeleteAnswer(questionID, answerID, userID string) error {
 	if questionID == "non-existent" || answerID == "non-existent" {
 		return errors.New("question or answer not found")
 	}
-	if authorID == "wrong-author" {
+	if userID == "wrong-author" {
 		return errors.New("you can only delete your own answers")
 	}
 	return nil
@@ -280,12 +283,12 @@ func mustParseForumObjectID(id string) primitive.ObjectID {
 // Setup
 var (
 	mockForumService  = &MockForumService{}
-	forumController   = controller.NewForumController(mockForumService, mockActivityService, mockNotificationsQueue)
+	forumController   = controller.NewForumController(mockForumService, mockNotificationsQueue)
 	normalForumRouter = gin.Default()
 )
 
 func init() {
-	router.InitializeForumRoutes(normalForumRouter, forumController)
+	router.InitializeForumRoutes(normalForumRouter, forumController, auditor)
 }
 
 // Test functions
@@ -294,7 +297,6 @@ func TestCreateQuestion(t *testing.T) {
 
 	requestBody := schemas.CreateQuestionRequest{
 		CourseID:    "course-123",
-		AuthorID:    "author-123",
 		Title:       "Test Question",
 		Description: "Test Description",
 		Tags:        []model.QuestionTag{model.QuestionTagGeneral},
@@ -302,6 +304,7 @@ func TestCreateQuestion(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -321,6 +324,7 @@ func TestCreateQuestion(t *testing.T) {
 func TestCreateQuestionWithInvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "/forum/questions", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -338,7 +342,6 @@ func TestCreateQuestionWithServiceError(t *testing.T) {
 
 	requestBody := schemas.CreateQuestionRequest{
 		CourseID:    "error-course",
-		AuthorID:    "author-123",
 		Title:       "Test Question",
 		Description: "Test Description",
 		Tags:        []model.QuestionTag{model.QuestionTagGeneral},
@@ -346,6 +349,7 @@ func TestCreateQuestionWithServiceError(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -362,6 +366,7 @@ func TestCreateQuestionWithServiceError(t *testing.T) {
 func TestGetQuestionById(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/questions/123456789012345678901234", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -379,6 +384,7 @@ func TestGetQuestionById(t *testing.T) {
 func TestGetQuestionByIdNotFound(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/questions/non-existent", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -393,6 +399,7 @@ func TestGetQuestionByIdNotFound(t *testing.T) {
 func TestGetQuestionsByCourseId(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/questions", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -412,6 +419,7 @@ func TestGetQuestionsByCourseId(t *testing.T) {
 func TestGetQuestionsByCourseIdWithError(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/error-course/questions", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -433,6 +441,7 @@ func TestUpdateQuestion(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -450,6 +459,7 @@ func TestUpdateQuestion(t *testing.T) {
 func TestUpdateQuestionWithInvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -466,17 +476,33 @@ func TestUpdateQuestionNotFound(t *testing.T) {
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("PUT", "/forum/questions/non-existent", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
+	req.Header.Set("Content-Type", "application/json")
+
+	w := httptest.NewRecorder()
+	normalForumRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+}
+
+func TestUpdateQuestionWithWrongAuthor(t *testing.T) {
+
+	jsonBody, _ := json.Marshal(schemas.UpdateQuestionRequest{Title: "Updated Title"})
+	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("wrong-author"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "you can only update your own questions")
 }
 
 func TestDeleteQuestion(t *testing.T) {
 
-	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234?authorId=author-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234", nil)
+	req.Header.Set("Authorization", studentToken("author-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -488,23 +514,19 @@ func TestDeleteQuestion(t *testing.T) {
 	assert.Equal(t, "Question deleted successfully", response.Message)
 }
 
-func TestDeleteQuestionWithoutAuthorId(t *testing.T) {
+func TestDeleteQuestionWithoutToken(t *testing.T) {
 
 	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234", nil)
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-
-	var response schemas.ErrorResponse
-	err := json.Unmarshal(w.Body.Bytes(), &response)
-	assert.NoError(t, err)
-	assert.Equal(t, "authorId query parameter is required", response.Error)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestDeleteQuestionWithWrongAuthor(t *testing.T) {
 
-	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234?authorId=wrong-author", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234", nil)
+	req.Header.Set("Authorization", studentToken("wrong-author"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -514,12 +536,12 @@ func TestDeleteQuestionWithWrongAuthor(t *testing.T) {
 func TestAddAnswer(t *testing.T) {
 
 	requestBody := schemas.CreateAnswerRequest{
-		AuthorID: "author-123",
-		Content:  "Test answer content",
+		Content: "Test answer content",
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -538,6 +560,7 @@ func TestAddAnswer(t *testing.T) {
 func TestAddAnswerWithInvalidJSON(t *testing.T) {
 
 	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -549,12 +572,12 @@ func TestAddAnswerWithInvalidJSON(t *testing.T) {
 func TestAddAnswerToNonExistentQuestion(t *testing.T) {
 
 	requestBody := schemas.CreateAnswerRequest{
-		AuthorID: "author-123",
-		Content:  "Test answer content",
+		Content: "Test answer content",
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions/non-existent/answers", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -570,7 +593,8 @@ func TestUpdateAnswer(t *testing.T) {
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
-	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234/answers/answer-123?authorId=author-123", bytes.NewBuffer(jsonBody))
+	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234/answers/answer-123", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -591,7 +615,8 @@ func TestUpdateAnswerWithWrongAuthor(t *testing.T) {
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
-	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234/answers/answer-123?authorId=wrong-author", bytes.NewBuffer(jsonBody))
+	req, _ := http.NewRequest("PUT", "/forum/questions/123456789012345678901234/answers/answer-123", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("wrong-author"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -602,7 +627,8 @@ func TestUpdateAnswerWithWrongAuthor(t *testing.T) {
 
 func TestDeleteAnswer(t *testing.T) {
 
-	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/answers/answer-123?authorId=author-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/answers/answer-123", nil)
+	req.Header.Set("Authorization", studentToken("author-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -614,18 +640,19 @@ func TestDeleteAnswer(t *testing.T) {
 	assert.Equal(t, "Answer deleted successfully", response.Message)
 }
 
-func TestDeleteAnswerWithoutAuthorId(t *testing.T) {
+func TestDeleteAnswerWithoutToken(t *testing.T) {
 
 	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/answers/answer-123", nil)
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestAcceptAnswer(t *testing.T) {
 
-	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers/answer-123/accept?authorId=author-123", nil)
+	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers/answer-123/accept", nil)
+	req.Header.Set("Authorization", studentToken("author-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -639,7 +666,8 @@ func TestAcceptAnswer(t *testing.T) {
 
 func TestAcceptAnswerWithWrongAuthor(t *testing.T) {
 
-	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers/answer-123/accept?authorId=wrong-author", nil)
+	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers/answer-123/accept", nil)
+	req.Header.Set("Authorization", studentToken("wrong-author"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -649,12 +677,12 @@ func TestAcceptAnswerWithWrongAuthor(t *testing.T) {
 func TestVoteQuestion(t *testing.T) {
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "voter-123",
 		VoteType: model.VoteTypeUp,
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("voter-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -671,12 +699,12 @@ func TestVoteQuestion(t *testing.T) {
 func TestVoteQuestionSelfVote(t *testing.T) {
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "author-123",
 		VoteType: model.VoteTypeUp,
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -688,12 +716,12 @@ func TestVoteQuestionSelfVote(t *testing.T) {
 func TestVoteAnswer(t *testing.T) {
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "voter-123",
 		VoteType: model.VoteTypeUp,
 	}
 
 	jsonBody, _ := json.Marshal(requestBody)
 	req, _ := http.NewRequest("POST", "/forum/questions/123456789012345678901234/answers/answer-123/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("voter-123"))
 	req.Header.Set("Content-Type", "application/json")
 
 	w := httptest.NewRecorder()
@@ -709,7 +737,8 @@ func TestVoteAnswer(t *testing.T) {
 
 func TestRemoveVoteFromQuestion(t *testing.T) {
 
-	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/vote?userId=voter-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/vote", nil)
+	req.Header.Set("Authorization", studentToken("voter-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -721,18 +750,19 @@ func TestRemoveVoteFromQuestion(t *testing.T) {
 	assert.Equal(t, "Vote removed successfully", response.Message)
 }
 
-func TestRemoveVoteFromQuestionWithoutUserId(t *testing.T) {
+func TestRemoveVoteFromQuestionWithoutToken(t *testing.T) {
 
 	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/vote", nil)
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestRemoveVoteFromAnswer(t *testing.T) {
 
-	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/answers/answer-123/vote?userId=voter-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/123456789012345678901234/answers/answer-123/vote", nil)
+	req.Header.Set("Authorization", studentToken("voter-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -747,6 +777,7 @@ func TestRemoveVoteFromAnswer(t *testing.T) {
 func TestSearchQuestions(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/search", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -763,6 +794,7 @@ func TestSearchQuestions(t *testing.T) {
 func TestSearchQuestionsWithQuery(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/search?query=architecture", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -777,6 +809,7 @@ func TestSearchQuestionsWithQuery(t *testing.T) {
 func TestSearchQuestionsWithTags(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/search?tags=teoria", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -791,6 +824,7 @@ func TestSearchQuestionsWithTags(t *testing.T) {
 func TestSearchQuestionsWithStatus(t *testing.T) {
 
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/search?status=open", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	w := httptest.NewRecorder()
 	normalForumRouter.ServeHTTP(w, req)
 
@@ -805,11 +839,12 @@ func TestSearchQuestionsWithStatus(t *testing.T) {
 func TestSearchQuestionsWithError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/forum/courses/error-course/search", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -818,11 +853,11 @@ func TestSearchQuestionsWithError(t *testing.T) {
 // Additional tests for missing coverage
 
 // Tests for UpdateAnswer missing cases
-func TestUpdateAnswerWithoutAuthorId(t *testing.T) {
+func TestUpdateAnswerWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.UpdateAnswerRequest{
 		Content: "Updated content",
@@ -834,20 +869,18 @@ func TestUpdateAnswerWithoutAuthorId(t *testing.T) {
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var response schemas.ErrorResponse
-	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "authorId query parameter is required", response.Error)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestUpdateAnswerWithInvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("PUT", "/forum/questions/question-123/answers/answer-123?authorId=author-123", bytes.NewBuffer([]byte("invalid json")))
+	req, _ := http.NewRequest("PUT", "/forum/questions/question-123/answers/answer-123", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -857,8 +890,8 @@ func TestUpdateAnswerWithInvalidJSON(t *testing.T) {
 func TestUpdateAnswerWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.UpdateAnswerRequest{
 		Content: "Updated content",
@@ -866,7 +899,8 @@ func TestUpdateAnswerWithServiceError(t *testing.T) {
 	jsonBody, _ := json.Marshal(requestBody)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("PUT", "/forum/questions/non-existent/answers/answer-123?authorId=author-123", bytes.NewBuffer(jsonBody))
+	req, _ := http.NewRequest("PUT", "/forum/questions/non-existent/answers/answer-123", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -877,41 +911,40 @@ func TestUpdateAnswerWithServiceError(t *testing.T) {
 func TestDeleteAnswerWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/answers/answer-123?authorId=author-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/answers/answer-123", nil)
+	req.Header.Set("Authorization", studentToken("author-123"))
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
 // Tests for AcceptAnswer missing cases
-func TestAcceptAnswerWithoutAuthorId(t *testing.T) {
+func TestAcceptAnswerWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/question-123/answers/answer-123/accept", nil)
 	r.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var response schemas.ErrorResponse
-	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "authorId query parameter is required", response.Error)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestAcceptAnswerWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("POST", "/forum/questions/non-existent/answers/answer-123/accept?authorId=author-123", nil)
+	req, _ := http.NewRequest("POST", "/forum/questions/non-existent/answers/answer-123/accept", nil)
+	req.Header.Set("Authorization", studentToken("author-123"))
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -921,11 +954,12 @@ func TestAcceptAnswerWithServiceError(t *testing.T) {
 func TestVoteQuestionWithInvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/question-123/vote", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -935,17 +969,17 @@ func TestVoteQuestionWithInvalidJSON(t *testing.T) {
 func TestVoteQuestionWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "user-123",
 		VoteType: model.VoteTypeUp,
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/non-existent/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("user-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -955,17 +989,17 @@ func TestVoteQuestionWithServiceError(t *testing.T) {
 func TestVoteQuestionWithDownVote(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "user-123",
 		VoteType: model.VoteTypeDown,
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/question-123/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("user-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -979,11 +1013,12 @@ func TestVoteQuestionWithDownVote(t *testing.T) {
 func TestVoteAnswerWithInvalidJSON(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/question-123/answers/answer-123/vote", bytes.NewBuffer([]byte("invalid json")))
+	req.Header.Set("Authorization", studentToken("author-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -993,17 +1028,17 @@ func TestVoteAnswerWithInvalidJSON(t *testing.T) {
 func TestVoteAnswerWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "user-123",
 		VoteType: model.VoteTypeUp,
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/non-existent/answers/answer-123/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("user-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -1013,17 +1048,17 @@ func TestVoteAnswerWithServiceError(t *testing.T) {
 func TestVoteAnswerWithDownVote(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	requestBody := schemas.VoteRequest{
-		UserID:   "user-123",
 		VoteType: model.VoteTypeDown,
 	}
 	jsonBody, _ := json.Marshal(requestBody)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/forum/questions/question-123/answers/answer-123/vote", bytes.NewBuffer(jsonBody))
+	req.Header.Set("Authorization", studentToken("user-123"))
 	req.Header.Set("Content-Type", "application/json")
 	r.ServeHTTP(w, req)
 
@@ -1037,41 +1072,40 @@ func TestVoteAnswerWithDownVote(t *testing.T) {
 func TestRemoveVoteFromQuestionWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/vote?userId=user-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/vote", nil)
+	req.Header.Set("Authorization", studentToken("user-123"))
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 }
 
 // Tests for RemoveVoteFromAnswer missing cases
-func TestRemoveVoteFromAnswerWithoutUserId(t *testing.T) {
+func TestRemoveVoteFromAnswerWithoutToken(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/forum/questions/question-123/answers/answer-123/vote", nil)
 	r.ServeHTTP(w, req)
 
-	assert.Equal(t, http.StatusBadRequest, w.Code)
-	var response schemas.ErrorResponse
-	json.Unmarshal(w.Body.Bytes(), &response)
-	assert.Equal(t, "userId query parameter is required", response.Error)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
 }
 
 func TestRemoveVoteFromAnswerWithServiceError(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
-	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/answers/answer-123/vote?userId=user-123", nil)
+	req, _ := http.NewRequest("DELETE", "/forum/questions/non-existent/answers/answer-123/vote", nil)
+	req.Header.Set("Authorization", studentToken("user-123"))
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -1081,12 +1115,13 @@ func TestRemoveVoteFromAnswerWithServiceError(t *testing.T) {
 func TestSearchQuestionsWithInvalidQuery(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 	r := gin.New()
-	forumController := controller.NewForumController(&MockForumService{}, &MockTeacherActivityService{}, &MockNotificationsQueue{})
-	router.InitializeForumRoutes(r, forumController)
+	forumController := controller.NewForumController(&MockForumService{}, &MockNotificationsQueue{})
+	router.InitializeForumRoutes(r, forumController, auditor)
 
 	w := httptest.NewRecorder()
 	// Test with malformed query parameters that could cause binding errors
 	req, _ := http.NewRequest("GET", "/forum/courses/course-123/search?tags=invalid[", nil)
+	req.Header.Set("Authorization", studentToken("student-123"))
 	r.ServeHTTP(w, req)
 
 	// The response could be 200 or 400 depending on how gin handles the malformed query
@@ -0,0 +1,186 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/controller"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockForumSuggestionService struct {
+	olderThan       time.Duration
+	approvedContent string
+}
+
+func (m *MockForumSuggestionService) SuggestAnswer(ctx context.Context, questionID, teacherUUID string) (*schemas.ForumSuggestionResponse, error) {
+	switch questionID {
+	case "disabled":
+		return nil, service.ErrForumSuggestionsDisabled
+	case "resolved":
+		return nil, service.ErrForumQuestionNotOpen
+	case "pending":
+		return nil, service.ErrSuggestionAlreadyPending
+	case "nonexistent":
+		return nil, fmt.Errorf("%w: question with id nonexistent not found", service.ErrForumQuestionNotFound)
+	case "unavailable":
+		return nil, fmt.Errorf("%w: circuit breaker is open", ai.ErrUnavailable)
+	}
+	if teacherUUID != "teacher123" {
+		return nil, service.ErrUnauthorized
+	}
+	return suggestionResponse(questionID, model.SuggestionStatusPending, "Respuesta sugerida"), nil
+}
+
+func (m *MockForumSuggestionService) SuggestAnswersForUnanswered(ctx context.Context, courseID, teacherUUID string, olderThan time.Duration) ([]schemas.ForumSuggestionResponse, error) {
+	m.olderThan = olderThan
+	return []schemas.ForumSuggestionResponse{*suggestionResponse("question123", model.SuggestionStatusPending, "Respuesta sugerida")}, nil
+}
+
+func (m *MockForumSuggestionService) GetPendingSuggestions(courseID, teacherUUID string) ([]model.ForumQuestion, error) {
+	return []model.ForumQuestion{}, nil
+}
+
+func (m *MockForumSuggestionService) ApproveSuggestion(questionID, answerID, teacherUUID, content string) (*schemas.ForumSuggestionResponse, error) {
+	if answerID == "approved" {
+		return nil, service.ErrSuggestionNotFound
+	}
+	m.approvedContent = content
+	return suggestionResponse(questionID, model.SuggestionStatusApproved, content), nil
+}
+
+func (m *MockForumSuggestionService) DiscardSuggestion(questionID, answerID, teacherUUID string) (*schemas.ForumSuggestionResponse, error) {
+	if answerID == "approved" {
+		return nil, service.ErrSuggestionNotFound
+	}
+	return suggestionResponse(questionID, model.SuggestionStatusPending, "Respuesta sugerida"), nil
+}
+
+func suggestionResponse(questionID string, status model.SuggestionStatus, content string) *schemas.ForumSuggestionResponse {
+	return &schemas.ForumSuggestionResponse{
+		QuestionID: questionID,
+		CourseID:   "course123",
+		Answer: model.ForumAnswer{
+			ID:         "answer123",
+			Content:    content,
+			Type:       model.AnswerTypeAiSuggestion,
+			Suggestion: &model.AnswerSuggestion{Status: status, PromptVersion: ai.FakePromptVersion},
+		},
+	}
+}
+
+func TestSuggestForumAnswer(t *testing.T) {
+	r := gin.Default()
+	router.InitializeForumSuggestionRoutes(r, controller.NewForumSuggestionController(&MockForumSuggestionService{}), auditor)
+
+	tests := []struct {
+		name         string
+		questionID   string
+		teacherUUID  string
+		expectedCode int
+	}{
+		{name: "valid", questionID: "question123", teacherUUID: "teacher123", expectedCode: http.StatusCreated},
+		{name: "without teacher", questionID: "question123", expectedCode: http.StatusUnauthorized},
+		{name: "other teacher", questionID: "question123", teacherUUID: "teacher456", expectedCode: http.StatusForbidden},
+		{name: "course did not opt in", questionID: "disabled", teacherUUID: "teacher123", expectedCode: http.StatusForbidden},
+		{name: "resolved question", questionID: "resolved", teacherUUID: "teacher123", expectedCode: http.StatusConflict},
+		{name: "suggestion pending", questionID: "pending", teacherUUID: "teacher123", expectedCode: http.StatusConflict},
+		{name: "nonexistent question", questionID: "nonexistent", teacherUUID: "teacher123", expectedCode: http.StatusNotFound},
+		{name: "AI unavailable", questionID: "unavailable", teacherUUID: "teacher123", expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/forum/questions/"+tt.questionID+"/suggestions", nil)
+			if tt.teacherUUID != "" {
+				req.Header.Set("Authorization", teacherToken(tt.teacherUUID))
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusCreated {
+				assert.Contains(t, w.Body.String(), `"type":"ai_suggestion"`)
+				assert.Contains(t, w.Body.String(), `"status":"pending_review"`)
+			}
+		})
+	}
+}
+
+func TestSuggestAnswersForUnansweredForumQuestions(t *testing.T) {
+	suggestionService := &MockForumSuggestionService{}
+	r := gin.Default()
+	router.InitializeForumSuggestionRoutes(r, controller.NewForumSuggestionController(suggestionService), auditor)
+
+	tests := []struct {
+		name              string
+		query             string
+		expectedCode      int
+		expectedOlderThan time.Duration
+	}{
+		{name: "default age", expectedCode: http.StatusCreated, expectedOlderThan: service.DefaultUnansweredAge},
+		{name: "custom age", query: "?older_than_hours=72", expectedCode: http.StatusCreated, expectedOlderThan: 72 * time.Hour},
+		{name: "negative age", query: "?older_than_hours=-1", expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestionService.olderThan = 0
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/forum/courses/course123/suggestions"+tt.query, nil)
+			req.Header.Set("Authorization", teacherToken("teacher123"))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedOlderThan, suggestionService.olderThan)
+		})
+	}
+}
+
+func TestReviewForumSuggestion(t *testing.T) {
+	suggestionService := &MockForumSuggestionService{}
+	r := gin.Default()
+	router.InitializeForumSuggestionRoutes(r, controller.NewForumSuggestionController(suggestionService), auditor)
+
+	tests := []struct {
+		name            string
+		method          string
+		path            string
+		body            string
+		expectedCode    int
+		expectedContent string
+	}{
+		{name: "approve as drafted", method: "POST", path: "/forum/questions/question123/suggestions/answer123/approve", expectedCode: http.StatusOK},
+		{name: "approve edited", method: "POST", path: "/forum/questions/question123/suggestions/answer123/approve", body: `{"content": "Respuesta editada"}`, expectedCode: http.StatusOK, expectedContent: "Respuesta editada"},
+		{name: "approve invalid body", method: "POST", path: "/forum/questions/question123/suggestions/answer123/approve", body: `{"content": 1}`, expectedCode: http.StatusBadRequest},
+		{name: "approve already approved", method: "POST", path: "/forum/questions/question123/suggestions/approved/approve", expectedCode: http.StatusNotFound},
+		{name: "discard", method: "DELETE", path: "/forum/questions/question123/suggestions/answer123", expectedCode: http.StatusOK},
+		{name: "discard already approved", method: "DELETE", path: "/forum/questions/question123/suggestions/approved", expectedCode: http.StatusNotFound},
+		{name: "pending suggestions", method: "GET", path: "/forum/courses/course123/suggestions", expectedCode: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestionService.approvedContent = ""
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Authorization", teacherToken("teacher123"))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			assert.Equal(t, tt.expectedContent, suggestionService.approvedContent)
+		})
+	}
+}
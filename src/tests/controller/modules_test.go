@@ -20,22 +20,22 @@ import (
 var (
 	mockModuleService      = &MockModuleService{}
 	mockModuleErrorService = &MockModuleServiceWithError{}
-	normalModuleController = controller.NewModuleController(mockModuleService, mockActivityService)
-	errorModuleController  = controller.NewModuleController(mockModuleErrorService, mockActivityService)
+	normalModuleController = controller.NewModuleController(mockModuleService)
+	errorModuleController  = controller.NewModuleController(mockModuleErrorService)
 	normalModuleRouter     = gin.Default()
 	errorModuleRouter      = gin.Default()
 )
 
 func init() {
 	gin.SetMode(gin.TestMode)
-	router.InitializeModulesRoutes(normalModuleRouter, normalModuleController)
-	router.InitializeModulesRoutes(errorModuleRouter, errorModuleController)
+	router.InitializeModulesRoutes(normalModuleRouter, normalModuleController, auditor)
+	router.InitializeModulesRoutes(errorModuleRouter, errorModuleController, auditor)
 }
 
 type MockModuleService struct{}
 
 // DeleteModule implements controller.ModuleService.
-func (m *MockModuleService) DeleteModule(id string) error {
+func (m *MockModuleService) DeleteModule(id string, teacherUUID string) error {
 	return nil
 }
 
@@ -53,7 +53,7 @@ func (m *MockModuleService) GetModulesByCourseId(courseId string) ([]model.Modul
 }
 
 // UpdateModule implements controller.ModuleService.
-func (m *MockModuleService) UpdateModule(id string, module model.Module) (*model.Module, error) {
+func (m *MockModuleService) UpdateModule(id string, teacherUUID string, module model.Module) (*model.Module, error) {
 	return &model.Module{
 		ID:          primitive.NewObjectID(),
 		Title:       "Test Module",
@@ -63,7 +63,7 @@ func (m *MockModuleService) UpdateModule(id string, module model.Module) (*model
 	}, nil
 }
 
-func (m *MockModuleService) CreateModule(module schemas.CreateModuleRequest) (*model.Module, error) {
+func (m *MockModuleService) CreateModule(teacherUUID string, module schemas.CreateModuleRequest) (*model.Module, error) {
 	return &model.Module{
 		ID:          primitive.NewObjectID(),
 		Title:       module.Title,
@@ -90,7 +90,7 @@ func (m *MockModuleServiceWithError) GetModuleById(id string) (*model.Module, er
 	return nil, errors.New("Error getting module by id")
 }
 
-func (m *MockModuleServiceWithError) DeleteModule(id string) error {
+func (m *MockModuleServiceWithError) DeleteModule(id string, teacherUUID string) error {
 	return errors.New("Error deleting module")
 }
 
@@ -98,11 +98,11 @@ func (m *MockModuleServiceWithError) GetModulesByCourseId(courseId string) ([]mo
 	return nil, errors.New("Error getting modules by course id")
 }
 
-func (m *MockModuleServiceWithError) UpdateModule(id string, module model.Module) (*model.Module, error) {
+func (m *MockModuleServiceWithError) UpdateModule(id string, teacherUUID string, module model.Module) (*model.Module, error) {
 	return nil, errors.New("Error updating module")
 }
 
-func (m *MockModuleServiceWithError) CreateModule(module schemas.CreateModuleRequest) (*model.Module, error) {
+func (m *MockModuleServiceWithError) CreateModule(teacherUUID string, module schemas.CreateModuleRequest) (*model.Module, error) {
 	return nil, errors.New("Error creating module")
 }
 
@@ -111,6 +111,7 @@ func TestCreateModule(t *testing.T) {
 
 	body := `{"title": "Test Module", "description": "Test Description", "order": 1, "course_id": "123"}`
 	req, _ := http.NewRequest("POST", "/modules", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -118,8 +119,9 @@ func TestCreateModule(t *testing.T) {
 
 func TestCreateModuleWithInvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
-	body := `{"invalid": "body"}`
+	body := `{"invalid": "body", "course_id": "123"}`
 	req, _ := http.NewRequest("POST", "/modules", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -130,6 +132,7 @@ func TestCreateModuleWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{"title": "Test Module", "description": "Test Description", "course_id": "123"}`
 	req, _ := http.NewRequest("POST", "/modules", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -171,6 +174,7 @@ func TestUpdateModule(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{"title": "Test Module", "description": "Test Description", "order": 1, "course_id": "123"}`
 	req, _ := http.NewRequest("PUT", "/modules/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusOK, w.Code)
@@ -180,6 +184,7 @@ func TestUpdateModuleWithInvalidModule(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `invalid body`
 	req, _ := http.NewRequest("PUT", "/modules/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusBadRequest, w.Code)
@@ -189,6 +194,7 @@ func TestUpdateModuleWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `{"title": "Test Module", "description": "Test Description", "order": 1, "course_id": "123"}`
 	req, _ := http.NewRequest("PUT", "/modules/123", strings.NewReader(body))
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
@@ -197,6 +203,7 @@ func TestUpdateModuleWithError(t *testing.T) {
 func TestDeleteModule(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/modules/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	normalModuleRouter.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusNoContent, w.Code)
@@ -205,5 +212,6 @@ func TestDeleteModule(t *testing.T) {
 func TestDeleteModuleWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("DELETE", "/modules/123", nil)
+	req.Header.Set("Authorization", teacherToken("teacher123"))
 	errorModuleRouter.ServeHTTP(w, req)
 }
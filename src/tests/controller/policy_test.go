@@ -0,0 +1,273 @@
+package controller_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"courses-service/src/auth"
+	"courses-service/src/middleware"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// membershipService is the membership service of the routes of every controller test
+var membershipService = &MockMembershipService{}
+
+// MockMembershipService makes every user a titular teacher, aux teacher and enrolled student of every
+// course, so the controller tests only check the handlers. The courses of the policy tests are the exception.
+type MockMembershipService struct {
+	calls int
+}
+
+func (m *MockMembershipService) GetMembership(courseID, userID string) (*auth.Membership, error) {
+	m.calls++
+	membership := &auth.Membership{CourseID: courseID, UserID: userID, Roles: []string{}}
+	switch courseID {
+	case "missing-course":
+		return nil, service.ErrCourseNotFound
+	case "suspended-course", "archived-course":
+		membership.Roles = append(membership.Roles, auth.RoleTitularTeacher)
+		membership.CourseStatus = strings.TrimSuffix(courseID, "-course")
+	case "policy-course":
+		switch userID {
+		case "titular-1":
+			membership.Roles = append(membership.Roles, auth.RoleTitularTeacher)
+		case "aux-1":
+			membership.Roles = append(membership.Roles, auth.RoleAuxTeacher)
+		case "student-1":
+			membership.Roles = append(membership.Roles, auth.RoleEnrolledStudent)
+		}
+	default:
+		membership.Roles = append(membership.Roles, auth.RoleTitularTeacher, auth.RoleAuxTeacher, auth.RoleEnrolledStudent)
+	}
+	return membership, nil
+}
+
+func (m *MockMembershipService) GetModuleCourseID(moduleID string) (string, error) {
+	return m.courseOf(moduleID, service.ErrModuleNotFound)
+}
+
+func (m *MockMembershipService) GetAssignmentCourseID(assignmentID string) (string, error) {
+	return m.courseOf(assignmentID, service.ErrAssignmentNotFound)
+}
+
+func (m *MockMembershipService) GetQuestionCourseID(questionID string) (string, error) {
+	return m.courseOf(questionID, service.ErrForumQuestionNotFound)
+}
+
+// IsStudentTeacher makes every teacher a teacher of every student but "policy-student", who only has titular-1
+func (m *MockMembershipService) IsStudentTeacher(teacherID, studentID string) (bool, error) {
+	if studentID == "policy-student" {
+		return teacherID == "titular-1", nil
+	}
+	return true, nil
+}
+
+func (m *MockMembershipService) courseOf(id string, notFound error) (string, error) {
+	switch id {
+	case "policy-missing":
+		return "", notFound
+	case "policy-item":
+		return "policy-course", nil
+	}
+	return "course123", nil
+}
+
+func policyRouter() *gin.Engine {
+	r := gin.Default()
+	ok := func(c *gin.Context) {
+		membership, _ := middleware.GetMembership(c)
+		c.JSON(http.StatusOK, gin.H{"roles": membership.Roles})
+	}
+	teachers := middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleTitularTeacher, auth.RoleAuxTeacher)
+	r.PUT("/courses/:id", middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleTitularTeacher), ok)
+	r.GET("/courses/:id/report", middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleTitularTeacher, auth.RoleAdmin), ok)
+	r.POST("/courses/:id/grade", middleware.TeacherAuth(), teachers, teachers, ok)
+	r.POST("/courses/:id/feedback", middleware.RequireCourseRole(middleware.CourseParam("id"), auth.RoleEnrolledStudent), ok)
+	r.PUT("/modules/:moduleId", middleware.RequireCourseRole(middleware.ModuleParam("moduleId"), auth.RoleAuxTeacher), ok)
+	r.POST("/assignments", middleware.RequireCourseRole(middleware.CourseBody("course_id"), auth.RoleAuxTeacher), func(c *gin.Context) {
+		var body map[string]string
+		if err := c.ShouldBindJSON(&body); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, body)
+	})
+	return r
+}
+
+func TestCourseRolePolicy(t *testing.T) {
+	r := policyRouter()
+
+	tests := []struct {
+		name          string
+		method        string
+		path          string
+		body          string
+		authorization string
+		expectedCode  int
+		expectedBody  string
+	}{
+		{name: "titular teacher", method: "PUT", path: "/courses/policy-course", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK},
+		{name: "aux teacher is not titular", method: "PUT", path: "/courses/policy-course", authorization: teacherToken("aux-1"), expectedCode: http.StatusForbidden},
+		{name: "teacher of another course", method: "PUT", path: "/courses/policy-course", authorization: teacherToken("teacher-2"), expectedCode: http.StatusForbidden},
+		{name: "without token", method: "PUT", path: "/courses/policy-course", expectedCode: http.StatusUnauthorized},
+		{name: "missing course", method: "PUT", path: "/courses/missing-course", authorization: teacherToken("titular-1"), expectedCode: http.StatusNotFound},
+		{name: "admin when allowed", method: "GET", path: "/courses/policy-course/report", authorization: adminToken("admin-1"), expectedCode: http.StatusOK, expectedBody: `"roles":["admin"]`},
+		{name: "admin when not allowed", method: "PUT", path: "/courses/policy-course", authorization: adminToken("admin-1"), expectedCode: http.StatusForbidden},
+		{name: "enrolled student", method: "POST", path: "/courses/policy-course/feedback", authorization: studentToken("student-1"), expectedCode: http.StatusOK},
+		{name: "student not enrolled", method: "POST", path: "/courses/policy-course/feedback", authorization: studentToken("student-2"), expectedCode: http.StatusForbidden},
+		{name: "course of the module", method: "PUT", path: "/modules/policy-item", authorization: teacherToken("aux-1"), expectedCode: http.StatusOK},
+		{name: "missing module", method: "PUT", path: "/modules/policy-missing", authorization: teacherToken("aux-1"), expectedCode: http.StatusNotFound},
+		{name: "course of the body", method: "POST", path: "/assignments", body: `{"course_id": "policy-course", "title": "TP 1"}`, authorization: teacherToken("aux-1"), expectedCode: http.StatusOK, expectedBody: `"title":"TP 1"`},
+		{name: "body without course", method: "POST", path: "/assignments", body: `{"title": "TP 1"}`, authorization: teacherToken("aux-1"), expectedCode: http.StatusBadRequest},
+		{name: "read an archived course", method: "GET", path: "/courses/archived-course/report", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK},
+		{name: "change an archived course", method: "PUT", path: "/courses/archived-course", authorization: teacherToken("titular-1"), expectedCode: http.StatusForbidden, expectedBody: "the course is archived"},
+		{name: "read a suspended course", method: "GET", path: "/courses/suspended-course/report", authorization: teacherToken("titular-1"), expectedCode: http.StatusForbidden, expectedBody: "the course is suspended"},
+		{name: "admin reads a suspended course", method: "GET", path: "/courses/suspended-course/report", authorization: adminToken("admin-1"), expectedCode: http.StatusOK},
+		{name: "body of a foreign course", method: "POST", path: "/assignments", body: `{"course_id": "policy-course"}`, authorization: teacherToken("titular-1"), expectedCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
+
+func TestCourseMembershipIsResolvedOncePerRequest(t *testing.T) {
+	r := policyRouter()
+	calls := membershipService.calls
+
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/courses/policy-course/grade", nil)
+	req.Header.Set("Authorization", teacherToken("aux-1"))
+	r.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"roles":["aux_teacher"]`)
+	assert.Equal(t, calls+1, membershipService.calls)
+}
+
+func TestStudentAccessPolicy(t *testing.T) {
+	r := gin.Default()
+	r.GET("/feedback/student/:id", middleware.RequireStudentAccess("id"), func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"student": c.Param("id")})
+	})
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "the student", authorization: studentToken("policy-student"), expectedCode: http.StatusOK},
+		{name: "teacher of the student", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK},
+		{name: "admin", authorization: adminToken("admin-1"), expectedCode: http.StatusOK},
+		{name: "another student", authorization: studentToken("student-1"), expectedCode: http.StatusForbidden},
+		{name: "teacher of other students", authorization: teacherToken("teacher-2"), expectedCode: http.StatusForbidden},
+		{name: "without token", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", "/feedback/student/policy-student", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestStatisticsPolicies(t *testing.T) {
+	r := gin.Default()
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	r.GET("/statistics/students/:studentId", middleware.RequireCourseRole(middleware.CourseQuery("course_id"), auth.RoleTitularTeacher, auth.RoleAuxTeacher, auth.RoleAdmin), ok)
+	r.GET("/statistics/teachers/:teacherId/courses", middleware.RequireSelf("teacherId", auth.RoleTeacher), ok)
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expectedCode  int
+	}{
+		{name: "teacher of the course", path: "/statistics/students/student-1?course_id=policy-course", authorization: teacherToken("aux-1"), expectedCode: http.StatusOK},
+		{name: "teacher of another course", path: "/statistics/students/student-1?course_id=policy-course", authorization: teacherToken("teacher-2"), expectedCode: http.StatusForbidden},
+		{name: "student of the course", path: "/statistics/students/student-1?course_id=policy-course", authorization: studentToken("student-1"), expectedCode: http.StatusForbidden},
+		{name: "admin for a student", path: "/statistics/students/student-1?course_id=policy-course", authorization: adminToken("admin-1"), expectedCode: http.StatusOK},
+		{name: "without course", path: "/statistics/students/student-1", authorization: teacherToken("titular-1"), expectedCode: http.StatusBadRequest},
+		{name: "suspended course", path: "/statistics/students/student-1?course_id=suspended-course", authorization: teacherToken("titular-1"), expectedCode: http.StatusForbidden},
+		{name: "the teacher", path: "/statistics/teachers/titular-1/courses", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK},
+		{name: "another teacher", path: "/statistics/teachers/titular-1/courses", authorization: teacherToken("aux-1"), expectedCode: http.StatusForbidden},
+		{name: "student with the same ID", path: "/statistics/teachers/student-1/courses", authorization: studentToken("student-1"), expectedCode: http.StatusForbidden},
+		{name: "admin for a teacher", path: "/statistics/teachers/titular-1/courses", authorization: adminToken("admin-1"), expectedCode: http.StatusOK},
+		{name: "without token", path: "/statistics/teachers/titular-1/courses", expectedCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
+
+func TestVisibleCoursePolicy(t *testing.T) {
+	r := gin.Default()
+	ok := func(c *gin.Context) { c.JSON(http.StatusOK, gin.H{}) }
+	r.GET("/courses/:id", middleware.RequireVisibleCourse(middleware.CourseParam("id")), ok)
+	r.GET("/modules/:id", middleware.RequireVisibleCourse(middleware.ModuleParam("id")), ok)
+	r.POST("/courses/:id/enroll", middleware.RequireVisibleCourse(middleware.CourseParam("id")), ok)
+
+	tests := []struct {
+		name         string
+		method       string
+		path         string
+		expectedCode int
+		expectedBody string
+	}{
+		{name: "active course", method: "GET", path: "/courses/policy-course", expectedCode: http.StatusOK},
+		{name: "read an archived course", method: "GET", path: "/courses/archived-course", expectedCode: http.StatusOK},
+		{name: "change an archived course", method: "POST", path: "/courses/archived-course/enroll", expectedCode: http.StatusForbidden, expectedBody: "the course is archived"},
+		{name: "suspended course", method: "GET", path: "/courses/suspended-course", expectedCode: http.StatusForbidden, expectedBody: "the course is suspended"},
+		{name: "missing course", method: "GET", path: "/courses/missing-course", expectedCode: http.StatusNotFound},
+		{name: "course of the module", method: "GET", path: "/modules/policy-item", expectedCode: http.StatusOK},
+		{name: "missing module", method: "GET", path: "/modules/policy-missing", expectedCode: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest(tt.method, tt.path, nil)
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedBody != "" {
+				assert.Contains(t, w.Body.String(), tt.expectedBody)
+			}
+		})
+	}
+}
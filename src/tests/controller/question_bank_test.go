@@ -0,0 +1,161 @@
+package controller_test
+
+import (
+	"context"
+	"courses-service/src/controller"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Mock Question Bank Service
+type MockQuestionBankService struct{}
+
+func (m *MockQuestionBankService) CreateQuestion(ctx context.Context, courseID, teacherUUID string, request schemas.CreateBankQuestionRequest) (*model.BankQuestion, error) {
+	if request.Type == "invalid" {
+		return nil, fmt.Errorf("%w: invalid question type %s", service.ErrInvalidBankQuestion, request.Type)
+	}
+	return &model.BankQuestion{
+		ID:        primitive.NewObjectID(),
+		CourseID:  courseID,
+		Question:  model.Question{Text: request.Text, Type: request.Type, Points: request.Points},
+		Tags:      request.Tags,
+		CreatedBy: teacherUUID,
+	}, nil
+}
+
+func (m *MockQuestionBankService) GetQuestions(ctx context.Context, courseID, teacherUUID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error) {
+	if teacherUUID == "other-teacher" {
+		return nil, service.ErrUnauthorized
+	}
+	return []model.BankQuestion{{ID: primitive.NewObjectID(), CourseID: courseID, Tags: []string{tag}, Difficulty: difficulty}}, nil
+}
+
+func (m *MockQuestionBankService) GetQuestion(ctx context.Context, courseID, questionID, teacherUUID string) (*model.BankQuestion, error) {
+	if questionID == "non-existent" {
+		return nil, service.ErrBankQuestionNotFound
+	}
+	return &model.BankQuestion{ID: primitive.NewObjectID(), CourseID: courseID}, nil
+}
+
+func (m *MockQuestionBankService) UpdateQuestion(ctx context.Context, courseID, questionID, teacherUUID string, request schemas.UpdateBankQuestionRequest) (*model.BankQuestion, error) {
+	return &model.BankQuestion{ID: primitive.NewObjectID(), CourseID: courseID, Question: model.Question{Text: request.Text}}, nil
+}
+
+func (m *MockQuestionBankService) DeleteQuestion(ctx context.Context, courseID, questionID, teacherUUID string) error {
+	if questionID == "non-existent" {
+		return service.ErrBankQuestionNotFound
+	}
+	return nil
+}
+
+// Setup
+var (
+	questionBankController = controller.NewQuestionBankController(&MockQuestionBankService{})
+	questionBankRouter     = gin.Default()
+)
+
+func init() {
+	router.InitializeQuestionBankRoutes(questionBankRouter, questionBankController, auditor)
+}
+
+func TestCreateBankQuestion(t *testing.T) {
+	req := newTeacherRequest("POST", "/courses/course-123/question-bank", "teacher-123", schemas.CreateBankQuestionRequest{
+		Text:   "Explain recursion",
+		Type:   model.QuestionTypeText,
+		Points: 5,
+		Tags:   []string{"recursion"},
+	})
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+
+	var response model.BankQuestion
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "course-123", response.CourseID)
+	assert.Equal(t, "Explain recursion", response.Question.Text)
+}
+
+func TestCreateBankQuestionWithInvalidType(t *testing.T) {
+	req := newTeacherRequest("POST", "/courses/course-123/question-bank", "teacher-123", schemas.CreateBankQuestionRequest{
+		Text:   "Explain recursion",
+		Type:   "invalid",
+		Points: 5,
+		Tags:   []string{"recursion"},
+	})
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestCreateBankQuestionWithoutTeacherHeader(t *testing.T) {
+	req := newTeacherRequest("POST", "/courses/course-123/question-bank", "", schemas.CreateBankQuestionRequest{
+		Text:   "Explain recursion",
+		Type:   model.QuestionTypeText,
+		Points: 5,
+		Tags:   []string{"recursion"},
+	})
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
+
+func TestGetBankQuestionsWithFilters(t *testing.T) {
+	req := newTeacherRequest("GET", "/courses/course-123/question-bank?tag=recursion&difficulty=hard", "teacher-123", nil)
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response []model.BankQuestion
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response, 1)
+	assert.Equal(t, []string{"recursion"}, response[0].Tags)
+	assert.Equal(t, model.QuestionDifficultyHard, response[0].Difficulty)
+}
+
+func TestGetBankQuestionsByOtherTeacher(t *testing.T) {
+	req := newTeacherRequest("GET", "/courses/course-123/question-bank", "other-teacher", nil)
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
+func TestGetNonExistentBankQuestion(t *testing.T) {
+	req := newTeacherRequest("GET", "/courses/course-123/question-bank/non-existent", "teacher-123", nil)
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNotFound, w.Code)
+}
+
+func TestDeleteBankQuestion(t *testing.T) {
+	req := newTeacherRequest("DELETE", "/courses/course-123/question-bank/question-123", "teacher-123", nil)
+
+	w := httptest.NewRecorder()
+	questionBankRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+}
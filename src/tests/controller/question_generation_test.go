@@ -0,0 +1,117 @@
+package controller_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"courses-service/src/ai"
+	"courses-service/src/controller"
+	"courses-service/src/model"
+	"courses-service/src/router"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+type MockQuestionGenerationService struct{}
+
+func (m *MockQuestionGenerationService) GenerateQuestions(ctx context.Context, moduleID, teacherUUID string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	switch moduleID {
+	case "invalid-model-answer":
+		return nil, fmt.Errorf("%w: got 0 questions", ai.ErrInvalidGeneratedQuestions)
+	case "unavailable":
+		return nil, fmt.Errorf("%w: circuit breaker is open", ai.ErrUnavailable)
+	}
+	if teacherUUID != "teacher123" {
+		return nil, service.ErrUnauthorized
+	}
+	if request.MultipleChoiceCount+request.TextCount == 0 {
+		return nil, service.ErrInvalidQuestionGeneration
+	}
+	return &schemas.GeneratedQuestionsResponse{
+		Questions:     []model.Question{{ID: "q1", Text: "¿Qué es un channel?", Type: model.QuestionTypeText, Points: 1}},
+		PromptVersion: ai.FakePromptVersion,
+	}, nil
+}
+
+func (m *MockQuestionGenerationService) AddQuestions(ctx context.Context, assignmentID, teacherUUID string, questions []model.Question) (*model.Assignment, error) {
+	switch assignmentID {
+	case "published":
+		return nil, service.ErrAssignmentNotDraft
+	case "nonexistent":
+		return nil, service.ErrAssignmentNotFound
+	}
+	return &model.Assignment{CourseID: "course123", Status: model.AssignmentStatusDraft, Questions: questions}, nil
+}
+
+func TestGenerateQuestions(t *testing.T) {
+	r := gin.Default()
+	router.InitializeQuestionGenerationRoutes(r, controller.NewQuestionGenerationController(&MockQuestionGenerationService{}), auditor)
+
+	tests := []struct {
+		name         string
+		moduleID     string
+		teacherUUID  string
+		body         string
+		expectedCode int
+	}{
+		{name: "valid", moduleID: "module123", teacherUUID: "teacher123", body: `{"multiple_choice_count": 2, "text_count": 1}`, expectedCode: http.StatusOK},
+		{name: "without teacher", moduleID: "module123", body: `{"text_count": 1}`, expectedCode: http.StatusUnauthorized},
+		{name: "other teacher", moduleID: "module123", teacherUUID: "teacher456", body: `{"text_count": 1}`, expectedCode: http.StatusForbidden},
+		{name: "negative count", moduleID: "module123", teacherUUID: "teacher123", body: `{"text_count": -1}`, expectedCode: http.StatusBadRequest},
+		{name: "no questions", moduleID: "module123", teacherUUID: "teacher123", body: `{}`, expectedCode: http.StatusBadRequest},
+		{name: "invalid model answer", moduleID: "invalid-model-answer", teacherUUID: "teacher123", body: `{"text_count": 1}`, expectedCode: http.StatusBadGateway},
+		{name: "AI unavailable", moduleID: "unavailable", teacherUUID: "teacher123", body: `{"text_count": 1}`, expectedCode: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/modules/"+tt.moduleID+"/generate-questions", strings.NewReader(tt.body))
+			if tt.teacherUUID != "" {
+				req.Header.Set("Authorization", teacherToken(tt.teacherUUID))
+			}
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Contains(t, w.Body.String(), `"prompt_version":"fake"`)
+			}
+		})
+	}
+}
+
+func TestAddQuestionsToAssignment(t *testing.T) {
+	r := gin.Default()
+	router.InitializeQuestionGenerationRoutes(r, controller.NewQuestionGenerationController(&MockQuestionGenerationService{}), auditor)
+	questions := `{"questions": [{"text": "¿Qué es un channel?", "type": "text", "points": 1}]}`
+
+	tests := []struct {
+		name         string
+		assignmentID string
+		body         string
+		expectedCode int
+	}{
+		{name: "draft assignment", assignmentID: "assignment123", body: questions, expectedCode: http.StatusOK},
+		{name: "published assignment", assignmentID: "published", body: questions, expectedCode: http.StatusConflict},
+		{name: "nonexistent assignment", assignmentID: "nonexistent", body: questions, expectedCode: http.StatusNotFound},
+		{name: "without questions", assignmentID: "assignment123", body: `{"questions": []}`, expectedCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("POST", "/assignments/"+tt.assignmentID+"/questions", strings.NewReader(tt.body))
+			req.Header.Set("Authorization", teacherToken("teacher123"))
+			r.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+		})
+	}
+}
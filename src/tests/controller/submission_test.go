@@ -14,6 +14,7 @@ import (
 	"courses-service/src/model"
 	"courses-service/src/queues"
 	"courses-service/src/schemas"
+	"courses-service/src/service"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -21,26 +22,41 @@ import (
 )
 
 var (
-	mockSubmissionService                     = &MockSubmissionService{}
-	mockSubmissionErrorService                = &MockSubmissionServiceWithError{}
-	submissionMockNotificationsQueue          = &MockSubmissionNotificationsQueue{}
-	submissionMockSubmissionAssignmentService = &MockSubmissionAssignmentService{}
-	normalSubmissionController                = controller.NewSubmissionController(mockSubmissionService, submissionMockNotificationsQueue, mockActivityService, submissionMockSubmissionAssignmentService)
-	errorSubmissionController                 = controller.NewSubmissionController(mockSubmissionErrorService, submissionMockNotificationsQueue, mockActivityService, submissionMockSubmissionAssignmentService)
-	normalSubmissionRouter                    = gin.Default()
-	errorSubmissionRouter                     = gin.Default()
+	mockSubmissionService            = &MockSubmissionService{}
+	mockSubmissionErrorService       = &MockSubmissionServiceWithError{}
+	submissionMockNotificationsQueue = &MockSubmissionNotificationsQueue{}
+	normalSubmissionController       = controller.NewSubmissionController(mockSubmissionService, submissionMockNotificationsQueue)
+	errorSubmissionController        = controller.NewSubmissionController(mockSubmissionErrorService, submissionMockNotificationsQueue)
+	normalSubmissionRouter           = gin.Default()
+	errorSubmissionRouter            = gin.Default()
 )
 
 // InitializeSubmissionRoutesForTest initializes submission routes without authentication middleware for testing
 func InitializeSubmissionRoutesForTest(r *gin.Engine, controller *controller.SubmissionController) {
 	// Add routes without authentication middleware
 	r.POST("/assignments/:assignmentId/submissions", mockStudentAuthMiddleware(), controller.CreateSubmission)
-	r.GET("/assignments/:assignmentId/submissions/:id", controller.GetSubmission)
+	r.GET("/assignments/:assignmentId/submissions/:id", mockStudentAuthMiddleware(), controller.GetSubmission)
 	r.PUT("/assignments/:assignmentId/submissions/:id", mockStudentAuthMiddleware(), controller.UpdateSubmission)
-	r.POST("/assignments/:assignmentId/submissions/:id/submit", controller.SubmitSubmission)
+	r.POST("/assignments/:assignmentId/submissions/:id/submit", mockStudentAuthMiddleware(), controller.SubmitSubmission)
 	r.GET("/students/:studentUUID/submissions", mockStudentAuthMiddleware(), controller.GetSubmissionsByStudent)
 	r.PUT("/assignments/:assignmentId/submissions/:id/grade", mockTeacherAuthMiddleware(), controller.GradeSubmission)
 	r.GET("/assignments/:assignmentId/submissions", controller.GetSubmissionsByAssignment)
+	r.POST("/assignments/:assignmentId/submissions/attempts", mockStudentAuthMiddleware(), controller.StartNewAttempt)
+	r.GET("/assignments/:assignmentId/students/:studentUUID/attempts", mockTeacherAuthMiddleware(), controller.GetAttemptHistory)
+	r.GET("/assignments/:assignmentId/submissions/:id/correction", mockUserAuthMiddleware(), controller.GetCorrectionStatus)
+}
+
+// mockUserAuthMiddleware simulates the authentication middleware shared by students and teachers
+func mockUserAuthMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userUUID := c.GetHeader("User-UUID")
+		if userUUID == "" {
+			userUUID = "student123" // default for tests
+		}
+
+		c.Set("user_uuid", userUUID)
+		c.Next()
+	}
 }
 
 // mockStudentAuthMiddleware simulates student authentication middleware for testing
@@ -132,7 +148,7 @@ func (m *MockSubmissionService) GetSubmission(ctx context.Context, id string) (*
 			UpdatedAt: time.Now(),
 		}, nil
 	}
-	return &model.Submission{
+	submission := &model.Submission{
 		ID:           mustParseSubmissionObjectID(id),
 		AssignmentID: "assignment123",
 		StudentUUID:  "student123",
@@ -147,7 +163,18 @@ func (m *MockSubmissionService) GetSubmission(ctx context.Context, id string) (*
 		},
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
-	}, nil
+	}
+	if id == "randomized" {
+		expected := 42.0
+		submission.Questions = []model.Question{
+			{ID: "q1", Text: "Pick the prime", Type: model.QuestionTypeMultipleChoice, Options: []string{"4", "7"}, CorrectAnswers: []string{"7"}, Points: 1},
+			{ID: "q2", Text: "The answer", Type: model.QuestionTypeNumeric, NumericAnswer: &expected, Points: 1},
+			{ID: "q3", Text: "Match the capitals", Type: model.QuestionTypeMatching, MatchingPairs: []model.MatchingPair{{Left: "France", Right: "Paris"}}, Points: 1},
+			{ID: "q4", Text: "Name the language", Type: model.QuestionTypeShortAnswer, AcceptedPatterns: []string{"go(lang)?"}, Points: 1},
+			{ID: "q5", Text: "Explain", Type: model.QuestionTypeText, Rubric: &model.Rubric{}, Points: 1},
+		}
+	}
+	return submission, nil
 }
 
 func (m *MockSubmissionService) GetSubmissionsByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
@@ -237,7 +264,43 @@ func (m *MockSubmissionService) GetOrCreateSubmission(ctx context.Context, assig
 	}, nil
 }
 
-func (m *MockSubmissionService) GradeSubmission(ctx context.Context, submissionID string, score *float64, feedback string) (*model.Submission, error) {
+func (m *MockSubmissionService) StartNewAttempt(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error) {
+	if assignmentID == "max-attempts-assignment" {
+		return nil, service.ErrMaxAttemptsReached
+	}
+	return &model.Submission{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: assignmentID,
+		StudentUUID:  studentUUID,
+		StudentName:  studentName,
+		Attempt:      2,
+		Status:       model.SubmissionStatusDraft,
+		Answers:      []model.Answer{},
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}, nil
+}
+
+func (m *MockSubmissionService) GetAttemptHistory(ctx context.Context, assignmentID, studentUUID string) (*schemas.AttemptHistoryResponse, error) {
+	score := 9.0
+	return &schemas.AttemptHistoryResponse{
+		AssignmentID:  assignmentID,
+		StudentUUID:   studentUUID,
+		MaxAttempts:   2,
+		ScoringPolicy: model.AttemptScoringHighest,
+		FinalScore:    &score,
+		Attempts: []model.Submission{
+			{AssignmentID: assignmentID, StudentUUID: studentUUID, Attempt: 1, Status: model.SubmissionStatusSubmitted, Score: &score},
+		},
+	}, nil
+}
+
+func (m *MockSubmissionService) GradeSubmission(ctx context.Context, submissionID, teacherUUID string, gradeRequest schemas.GradeSubmissionRequest) (*model.Submission, error) {
+	for _, answerGrade := range gradeRequest.AnswerGrades {
+		if answerGrade.QuestionID == "invalid-question" {
+			return nil, fmt.Errorf("%w: question invalid-question not found in assignment", service.ErrInvalidAnswerGrade)
+		}
+	}
 	return &model.Submission{
 		ID:           mustParseSubmissionObjectID(submissionID),
 		AssignmentID: "assignment123",
@@ -251,8 +314,8 @@ func (m *MockSubmissionService) GradeSubmission(ctx context.Context, submissionI
 				Type:       "text",
 			},
 		},
-		Score:     score,
-		Feedback:  feedback,
+		Score:     gradeRequest.Score,
+		Feedback:  gradeRequest.Feedback,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}, nil
@@ -265,8 +328,33 @@ func (m *MockSubmissionService) ValidateTeacherPermissions(ctx context.Context,
 	return nil
 }
 
+// GetCorrectionStatus implements service.SubmissionServiceInterface.
+func (m *MockSubmissionService) GetCorrectionStatus(ctx context.Context, submissionID, userUUID string) (*model.CorrectionJob, error) {
+	switch {
+	case submissionID == "nonexistent":
+		return nil, service.ErrSubmissionNotFound
+	case userUUID != "student123":
+		return nil, service.ErrUnauthorized
+	}
+	return &model.CorrectionJob{
+		ID:           primitive.NewObjectID(),
+		SubmissionID: submissionID,
+		AssignmentID: "assignment123",
+		StudentUUID:  "student123",
+		Status:       model.CorrectionJobPending,
+		Attempts:     1,
+		MaxAttempts:  5,
+		LastError:    "automatic correction failed: timeout",
+	}, nil
+}
+
 type MockSubmissionServiceWithError struct{}
 
+// GetCorrectionStatus implements service.SubmissionServiceInterface.
+func (m *MockSubmissionServiceWithError) GetCorrectionStatus(ctx context.Context, submissionID, userUUID string) (*model.CorrectionJob, error) {
+	return nil, errors.New("service error")
+}
+
 // AutoCorrectSubmission implements service.SubmissionServiceInterface.
 func (m *MockSubmissionServiceWithError) AutoCorrectSubmission(ctx context.Context, submissionID string) error {
 	panic("unimplemented")
@@ -305,7 +393,15 @@ func (m *MockSubmissionServiceWithError) GetOrCreateSubmission(ctx context.Conte
 	return nil, errors.New("error getting or creating submission")
 }
 
-func (m *MockSubmissionServiceWithError) GradeSubmission(ctx context.Context, submissionID string, score *float64, feedback string) (*model.Submission, error) {
+func (m *MockSubmissionServiceWithError) StartNewAttempt(ctx context.Context, assignmentID, studentUUID, studentName string) (*model.Submission, error) {
+	return nil, errors.New("error starting new attempt")
+}
+
+func (m *MockSubmissionServiceWithError) GetAttemptHistory(ctx context.Context, assignmentID, studentUUID string) (*schemas.AttemptHistoryResponse, error) {
+	return nil, errors.New("error getting attempt history")
+}
+
+func (m *MockSubmissionServiceWithError) GradeSubmission(ctx context.Context, submissionID, teacherUUID string, gradeRequest schemas.GradeSubmissionRequest) (*model.Submission, error) {
 	return nil, errors.New("error grading submission")
 }
 
@@ -333,36 +429,6 @@ func (m *MockSubmissionNotificationsQueue) Publish(message queues.QueueMessage)
 	return nil
 }
 
-type MockSubmissionAssignmentService struct{}
-
-func (m *MockSubmissionAssignmentService) GetAssignmentById(id string) (*model.Assignment, error) {
-	return &model.Assignment{
-		ID:       primitive.NewObjectID(),
-		CourseID: "course123",
-		Title:    "Test Assignment",
-	}, nil
-}
-
-func (m *MockSubmissionAssignmentService) CreateAssignment(c schemas.CreateAssignmentRequest) (*model.Assignment, error) {
-	return &model.Assignment{}, nil
-}
-
-func (m *MockSubmissionAssignmentService) GetAssignments() ([]*model.Assignment, error) {
-	return []*model.Assignment{}, nil
-}
-
-func (m *MockSubmissionAssignmentService) GetAssignmentsByCourseId(courseId string) ([]*model.Assignment, error) {
-	return []*model.Assignment{}, nil
-}
-
-func (m *MockSubmissionAssignmentService) UpdateAssignment(id string, updateAssignmentRequest schemas.UpdateAssignmentRequest) (*model.Assignment, error) {
-	return &model.Assignment{}, nil
-}
-
-func (m *MockSubmissionAssignmentService) DeleteAssignment(id string) error {
-	return nil
-}
-
 // Tests for CreateSubmission
 func TestCreateSubmission(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -435,6 +501,82 @@ func TestGetSubmission(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "assignment123")
 }
 
+func TestGetSubmissionHidesTheAnswerKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments/assignment123/submissions/randomized", nil)
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "Pick the prime")
+	assert.Contains(t, w.Body.String(), `"options":["4","7"]`)
+	assert.Contains(t, w.Body.String(), `"matching_left":["France"],"matching_right":["Paris"]`)
+	for _, field := range []string{"correct_answers", "numeric_answer", "matching_pairs", "accepted_patterns", "rubric"} {
+		assert.NotContains(t, w.Body.String(), field)
+	}
+}
+
+func TestGetAssignmentsHideTheAnswerKey(t *testing.T) {
+	answerKeyFields := []string{"correct_answers", "numeric_answer", "tolerance", "matching_pairs", "accepted_patterns", "rubric"}
+
+	tests := []struct {
+		name          string
+		path          string
+		authorization string
+		expectedCode  int
+		showsKey      bool
+	}{
+		{name: "assignment without token", path: "/assignments/policy-item", expectedCode: http.StatusOK},
+		{name: "assignment to an enrolled student", path: "/assignments/policy-item", authorization: studentToken("student-1"), expectedCode: http.StatusOK},
+		{name: "assignment to a teacher of another course", path: "/assignments/policy-item", authorization: teacherToken("teacher-2"), expectedCode: http.StatusOK},
+		{name: "assignment to the titular teacher", path: "/assignments/policy-item", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK, showsKey: true},
+		{name: "assignment to an aux teacher", path: "/assignments/policy-item", authorization: teacherToken("aux-1"), expectedCode: http.StatusOK, showsKey: true},
+		{name: "assignment to an admin", path: "/assignments/policy-item", authorization: adminToken("admin-1"), expectedCode: http.StatusOK, showsKey: true},
+		{name: "assignment with an invalid token", path: "/assignments/policy-item", authorization: "Bearer invalid", expectedCode: http.StatusUnauthorized},
+		{name: "course assignments without token", path: "/assignments/course/policy-course", expectedCode: http.StatusOK},
+		{name: "course assignments to an enrolled student", path: "/assignments/course/policy-course", authorization: studentToken("student-1"), expectedCode: http.StatusOK},
+		{name: "course assignments to the titular teacher", path: "/assignments/course/policy-course", authorization: teacherToken("titular-1"), expectedCode: http.StatusOK, showsKey: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			req, _ := http.NewRequest("GET", tt.path, nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			normalAssignmentRouter.ServeHTTP(w, req)
+
+			assert.Equal(t, tt.expectedCode, w.Code)
+			if tt.expectedCode != http.StatusOK {
+				return
+			}
+			assert.Contains(t, w.Body.String(), "Pick the prime")
+			for _, field := range answerKeyFields {
+				if tt.showsKey {
+					assert.Contains(t, w.Body.String(), field)
+				} else {
+					assert.NotContains(t, w.Body.String(), field)
+				}
+			}
+			if !tt.showsKey {
+				// The students still get the items to match, the right ones sorted
+				assert.Contains(t, w.Body.String(), `"matching_left":["Italy","France"],"matching_right":["Paris","Rome"]`)
+			}
+		})
+	}
+}
+
+func TestGetAllAssignmentsHidesTheAnswerKey(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments", nil)
+	req.Header.Set("Authorization", teacherToken("titular-1"))
+	normalAssignmentRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "What is 2+2?")
+	assert.NotContains(t, w.Body.String(), "correct_answers")
+}
+
 func TestGetSubmissionNotFound(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/assignments/assignment123/submissions/nonexistent", nil)
@@ -453,6 +595,16 @@ func TestGetSubmissionDifferentAssignment(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "submission not found")
 }
 
+func TestGetSubmissionOfAnotherStudent(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments/assignment123/submissions/valid-submission-id", nil)
+	req.Header.Set("Student-UUID", "student456")
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+	assert.NotContains(t, w.Body.String(), "assignment123")
+}
+
 func TestGetSubmissionWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("GET", "/assignments/assignment123/submissions/valid-submission-id", nil)
@@ -604,6 +756,15 @@ func TestSubmitSubmissionDifferentAssignment(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "submission not found")
 }
 
+func TestSubmitSubmissionOfAnotherStudent(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/assignments/assignment123/submissions/valid-submission-id/submit", nil)
+	req.Header.Set("Student-UUID", "student456")
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 func TestSubmitSubmissionWithError(t *testing.T) {
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/assignments/assignment123/submissions/valid-submission-id/submit", nil)
@@ -668,6 +829,60 @@ func TestGetSubmissionsByStudentWithError(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "error getting submissions by student")
 }
 
+// Tests for attempts
+func TestStartNewAttempt(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/assignments/assignment123/submissions/attempts", nil)
+	req.Header.Set("Student-UUID", "student123")
+
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Contains(t, w.Body.String(), `"attempt":2`)
+}
+
+func TestStartNewAttemptWithMaxAttemptsReached(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/assignments/max-attempts-assignment/submissions/attempts", nil)
+
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusConflict, w.Code)
+	assert.Contains(t, w.Body.String(), "maximum number of attempts reached")
+}
+
+func TestStartNewAttemptWithError(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("POST", "/assignments/assignment123/submissions/attempts", nil)
+
+	errorSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	assert.Contains(t, w.Body.String(), "error starting new attempt")
+}
+
+func TestGetAttemptHistory(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments/assignment123/students/student123/attempts", nil)
+	req.Header.Set("Teacher-UUID", "teacher123")
+
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"final_score":9`)
+	assert.Contains(t, w.Body.String(), `"scoring_policy":"highest"`)
+}
+
+func TestGetAttemptHistoryUnauthorizedTeacher(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments/assignment123/students/student123/attempts", nil)
+	req.Header.Set("Teacher-UUID", "unauthorized-teacher")
+
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusForbidden, w.Code)
+}
+
 // Tests for GradeSubmission
 func TestGradeSubmission(t *testing.T) {
 	w := httptest.NewRecorder()
@@ -688,6 +903,24 @@ func TestGradeSubmission(t *testing.T) {
 	assert.Contains(t, w.Body.String(), "Great work!")
 }
 
+func TestGradeSubmissionWithInvalidAnswerGrade(t *testing.T) {
+	w := httptest.NewRecorder()
+
+	body := `{
+		"feedback": "Great work!",
+		"answer_grades": [{"question_id": "invalid-question", "points": 2}]
+	}`
+
+	req, _ := http.NewRequest("PUT", "/assignments/assignment123/submissions/valid-submission-id/grade", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Teacher-UUID", "teacher123")
+
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+	assert.Contains(t, w.Body.String(), "invalid answer grade")
+}
+
 func TestGradeSubmissionWithInvalidBody(t *testing.T) {
 	w := httptest.NewRecorder()
 	body := `invalid json`
@@ -789,3 +1022,36 @@ func TestGradeSubmissionWithError(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, w.Code)
 	assert.Contains(t, w.Body.String(), "error getting submission")
 }
+
+func TestGetCorrectionStatus(t *testing.T) {
+	w := httptest.NewRecorder()
+	req, _ := http.NewRequest("GET", "/assignments/assignment123/submissions/submission123/correction", nil)
+	normalSubmissionRouter.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"status":"pending"`)
+	assert.Contains(t, w.Body.String(), `"attempts":1`)
+}
+
+func TestGetCorrectionStatusErrors(t *testing.T) {
+	tests := []struct {
+		path     string
+		userUUID string
+		router   *gin.Engine
+		expected int
+	}{
+		{"/assignments/assignment123/submissions/nonexistent/correction", "student123", normalSubmissionRouter, http.StatusNotFound},
+		{"/assignments/other-assignment/submissions/submission123/correction", "student123", normalSubmissionRouter, http.StatusNotFound},
+		{"/assignments/assignment123/submissions/submission123/correction", "student456", normalSubmissionRouter, http.StatusForbidden},
+		{"/assignments/assignment123/submissions/submission123/correction", "student123", errorSubmissionRouter, http.StatusInternalServerError},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		req, _ := http.NewRequest("GET", test.path, nil)
+		req.Header.Set("User-UUID", test.userUUID)
+		test.router.ServeHTTP(w, req)
+
+		assert.Equal(t, test.expected, w.Code, test.path)
+	}
+}
@@ -1,6 +1,7 @@
 package e2e_test
 
 import (
+	"courses-service/src/auth"
 	"courses-service/src/config"
 	"courses-service/src/router"
 	"courses-service/src/schemas"
@@ -18,7 +19,8 @@ import (
 )
 
 var (
-	r       = router.NewRouter(config.NewConfig())
+	cfg     = config.NewConfig()
+	r       = router.NewRouter(cfg)
 	dbSetup *testutil.DBSetup
 )
 
@@ -48,9 +50,10 @@ func TestCreateCourse(t *testing.T) {
 
 	startTime := time.Now()
 	endTime := startTime.Add(time.Second * 10)
-	course := `{"title": "Test Course", "description": "Test Description", "teacher_id": "123", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
+	course := `{"title": "Test Course", "description": "Test Description", "capacity": 10, "start_date": "` + startTime.Format(time.RFC3339) + `", "end_date": "` + endTime.Format(time.RFC3339) + `"}`
 
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(course))
+	authorize(req, auth.RoleTeacher, "123", "")
 	r.ServeHTTP(w, req)
 
 	assert.Equal(t, http.StatusCreated, w.Code)
@@ -68,8 +71,9 @@ func TestGetCoursesForAUserThatIsTeacherAndStudent(t *testing.T) {
 
 	// Create first course
 	w := httptest.NewRecorder()
-	course1JSON := `{"title": "Test Course 1", "description": "Test Description 1", "teacher_id": "` + teacherId1 + `", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
+	course1JSON := `{"title": "Test Course 1", "description": "Test Description 1", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(course1JSON))
+	authorize(req, auth.RoleTeacher, teacherId1, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -81,8 +85,9 @@ func TestGetCoursesForAUserThatIsTeacherAndStudent(t *testing.T) {
 
 	// Create second course
 	w = httptest.NewRecorder()
-	course2JSON := `{"title": "Test Course 2", "description": "Test Description 2", "teacher_id": "` + teacherId2 + `", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
+	course2JSON := `{"title": "Test Course 2", "description": "Test Description 2", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
 	req, _ = http.NewRequest("POST", "/courses", strings.NewReader(course2JSON))
+	authorize(req, auth.RoleTeacher, teacherId2, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -94,8 +99,9 @@ func TestGetCoursesForAUserThatIsTeacherAndStudent(t *testing.T) {
 
 	// Create third course (where student is teacher)
 	w = httptest.NewRecorder()
-	course3JSON := `{"title": "Test Course 3", "description": "Test Description 3", "teacher_id": "` + studentId + `", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
+	course3JSON := `{"title": "Test Course 3", "description": "Test Description 3", "capacity": 10, "start_date": "` + time.Now().Format(time.RFC3339) + `", "end_date": "` + time.Now().Add(time.Second*10).Format(time.RFC3339) + `"}`
 	req, _ = http.NewRequest("POST", "/courses", strings.NewReader(course3JSON))
+	authorize(req, auth.RoleTeacher, studentId, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -108,15 +114,15 @@ func TestGetCoursesForAUserThatIsTeacherAndStudent(t *testing.T) {
 
 	// Enroll student in course1
 	w = httptest.NewRecorder()
-	enrollment1JSON := `{"student_id": "` + studentId + `"}`
-	req, _ = http.NewRequest("POST", "/courses/"+course1ID+"/enroll", strings.NewReader(enrollment1JSON))
+	req, _ = http.NewRequest("POST", "/courses/"+course1ID+"/enroll", nil)
+	authorize(req, auth.RoleStudent, studentId, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
 	// Enroll student in course2
 	w = httptest.NewRecorder()
-	enrollment2JSON := `{"student_id": "` + studentId + `"}`
-	req, _ = http.NewRequest("POST", "/courses/"+course2ID+"/enroll", strings.NewReader(enrollment2JSON))
+	req, _ = http.NewRequest("POST", "/courses/"+course2ID+"/enroll", nil)
+	authorize(req, auth.RoleStudent, studentId, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -179,14 +185,14 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	courseJSON := fmt.Sprintf(`{
 		"title": "Algoritmos y Estructuras de Datos",
 		"description": "Curso completo de algoritmos y estructuras de datos con proyectos prácticos",
-		"teacher_id": "%s",
 		"capacity": 50,
 		"start_date": "%s",
 		"end_date": "%s"
-	}`, teacherID, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	}`, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(courseJSON))
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -218,6 +224,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/modules", strings.NewReader(moduleJSON))
+		authorize(req, auth.RoleTeacher, teacherID, teacherName)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -299,6 +306,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/assignments", strings.NewReader(assignmentJSON))
+		authorize(req, auth.RoleTeacher, teacherID, teacherName)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -321,9 +329,9 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	}
 
 	for _, student := range students {
-		enrollmentJSON := fmt.Sprintf(`{"student_id": "%s"}`, student.id)
 		w = httptest.NewRecorder()
-		req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", strings.NewReader(enrollmentJSON))
+		req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", nil)
+		authorize(req, auth.RoleStudent, student.id, student.name)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		fmt.Printf("Enrolled student: %s (ID: %s)\n", student.name, student.id)
@@ -362,8 +370,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 			// Set student auth headers
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[i]+"/submissions", strings.NewReader(submissionJSON))
-			req.Header.Set("X-Student-UUID", studentID)
-			req.Header.Set("X-Student-Name", getStudentName(studentID, students))
+			authorize(req, auth.RoleStudent, studentID, getStudentName(studentID, students))
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -375,8 +382,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 			// Submit the submission
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID+"/submit", nil)
-			req.Header.Set("X-Student-UUID", studentID)
-			req.Header.Set("X-Student-Name", getStudentName(studentID, students))
+			authorize(req, auth.RoleStudent, studentID, getStudentName(studentID, students))
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -388,8 +394,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("PUT", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID+"/grade", strings.NewReader(gradeJSON))
-			req.Header.Set("X-Teacher-UUID", teacherID)
-			req.Header.Set("X-Teacher-Name", teacherName)
+			authorize(req, auth.RoleTeacher, teacherID, teacherName)
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -416,14 +421,14 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	for i, q := range questionData {
 		questionJSON := fmt.Sprintf(`{
 			"course_id": "%s",
-			"author_id": "%s",
 			"title": "%s",
 			"description": "%s",
 			"tags": ["general", "teoria"]
-		}`, courseID, q.authorID, q.title, q.desc)
+		}`, courseID, q.title, q.desc)
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions", strings.NewReader(questionJSON))
+		authorize(req, auth.RoleStudent, q.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -449,13 +454,13 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 
 	for _, answer := range answerData {
 		answerJSON := fmt.Sprintf(`{
-			"author_id": "%s",
 			"content": "%s"
-		}`, answer.authorID, answer.content)
+		}`, answer.content)
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions/"+questionIDs[answer.questionIdx]+"/answers",
 			strings.NewReader(answerJSON))
+		authorize(req, auth.RoleStudent, answer.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		fmt.Printf("✓ Created forum answer by %s\n", answer.authorID)
@@ -474,9 +479,10 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	}
 
 	for _, vote := range voteData {
-		voteJSON := fmt.Sprintf(`{"vote_type": %d, "user_id": "%s"}`, vote.voteType, vote.voterID)
+		voteJSON := fmt.Sprintf(`{"vote_type": %d}`, vote.voteType)
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions/"+questionIDs[vote.questionIdx]+"/vote", strings.NewReader(voteJSON))
+		authorize(req, auth.RoleStudent, vote.voterID, "")
 		r.ServeHTTP(w, req)
 		// Note: Vote endpoints might return different status codes, so we'll be more lenient
 		fmt.Printf("Added vote to question %d by student %s\n", vote.questionIdx+1, vote.voterID)
@@ -492,8 +498,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	fmt.Println("Testing course statistics...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/statistics/courses/"+courseID, nil)
-	req.Header.Set("X-Teacher-UUID", teacherID)
-	req.Header.Set("X-Teacher-Name", teacherName)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -604,8 +609,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	for _, student := range students {
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("GET", "/statistics/students/"+student.id+"?course_id="+courseID, nil)
-		req.Header.Set("X-Teacher-UUID", teacherID)
-		req.Header.Set("X-Teacher-Name", teacherName)
+		authorize(req, auth.RoleTeacher, teacherID, teacherName)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -707,8 +711,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	fmt.Println("Testing teacher courses statistics...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/statistics/teachers/"+teacherID+"/courses", nil)
-	req.Header.Set("X-Teacher-UUID", teacherID)
-	req.Header.Set("X-Teacher-Name", teacherName)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -731,8 +734,7 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 	// Re-test course statistics with more detailed verification
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/statistics/courses/"+courseID, nil)
-	req.Header.Set("X-Teacher-UUID", teacherID)
-	req.Header.Set("X-Teacher-Name", teacherName)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -789,6 +791,22 @@ func TestCompleteStatisticsE2E(t *testing.T) {
 }
 
 // Helper function to get student name by ID
+// authorize signs a token for the user with the secret of the test environment
+func authorize(req *http.Request, role, userID, name string) {
+	settings := auth.NewSettings(cfg)
+	token, err := auth.SignHS256(map[string]any{
+		"sub":   userID,
+		"name":  name,
+		"aud":   settings.Audience,
+		"roles": []string{role},
+		"exp":   time.Now().Add(time.Hour).Unix(),
+	}, settings.Secret)
+	if err != nil {
+		panic(err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+}
+
 func getStudentName(studentID string, students []struct {
 	id   string
 	name string
@@ -853,14 +871,14 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	courseJSON := fmt.Sprintf(`{
 		"title": "Programación Avanzada",
 		"description": "Curso de programación con proyectos prácticos",
-		"teacher_id": "%s",
 		"capacity": 30,
 		"start_date": "%s",
 		"end_date": "%s"
-	}`, teacherID, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
+	}`, startDate.Format(time.RFC3339), endDate.Format(time.RFC3339))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(courseJSON))
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -915,6 +933,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/assignments", strings.NewReader(assignmentJSON))
+		authorize(req, auth.RoleTeacher, teacherID, teacherName)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -936,9 +955,9 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	}
 
 	for _, student := range students {
-		enrollmentJSON := fmt.Sprintf(`{"student_id": "%s"}`, student.id)
 		w = httptest.NewRecorder()
-		req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", strings.NewReader(enrollmentJSON))
+		req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", nil)
+		authorize(req, auth.RoleStudent, student.id, student.name)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		fmt.Printf("✓ Enrolled student: %s (ID: %s)\n", student.name, student.id)
@@ -976,8 +995,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[i]+"/submissions", strings.NewReader(submissionJSON))
-			req.Header.Set("X-Student-UUID", studentID)
-			req.Header.Set("X-Student-Name", studentName)
+			authorize(req, auth.RoleStudent, studentID, studentName)
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -990,8 +1008,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 			// Submit the submission
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID+"/submit", nil)
-			req.Header.Set("X-Student-UUID", studentID)
-			req.Header.Set("X-Student-Name", studentName)
+			authorize(req, auth.RoleStudent, studentID, studentName)
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1003,8 +1020,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("PUT", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID+"/grade", strings.NewReader(gradeJSON))
-			req.Header.Set("X-Teacher-UUID", teacherID)
-			req.Header.Set("X-Teacher-Name", teacherName)
+			authorize(req, auth.RoleTeacher, teacherID, teacherName)
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1018,8 +1034,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 		for i, submissionID := range studentSubmissions {
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("GET", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID, nil)
-			req.Header.Set("X-Student-UUID", studentID)
-			req.Header.Set("X-Student-Name", getStudentName(studentID, students))
+			authorize(req, auth.RoleStudent, studentID, getStudentName(studentID, students))
 			r.ServeHTTP(w, req)
 			assert.Equal(t, http.StatusOK, w.Code)
 		}
@@ -1032,8 +1047,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	// Approve Maria (good student)
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("PUT", "/courses/"+courseID+"/students/"+student1ID+"/approve", nil)
-	req.Header.Set("X-Teacher-UUID", teacherID)
-	req.Header.Set("X-Teacher-Name", teacherName)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 	fmt.Printf("✓ Approved student: %s\n", student1Name)
@@ -1044,8 +1058,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	}`
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("PUT", "/courses/"+courseID+"/students/"+student2ID+"/disapprove", strings.NewReader(disapprovalJSON))
-	req.Header.Set("X-Teacher-UUID", teacherID)
-	req.Header.Set("X-Teacher-Name", teacherName)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 	fmt.Printf("✓ Disapproved student: %s with reason: Academic performance insufficient\n", student2Name)
@@ -1054,6 +1067,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	fmt.Println("Step 7: Verifying enrollment statuses...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/courses/"+courseID+"/enrollments", nil)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1091,8 +1105,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	for i, submissionID := range submissionIDs[student2ID] {
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("GET", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID, nil)
-		req.Header.Set("X-Student-UUID", student2ID)
-		req.Header.Set("X-Student-Name", student2Name)
+		authorize(req, auth.RoleStudent, student2ID, student2Name)
 		r.ServeHTTP(w, req)
 		if w.Code == http.StatusOK {
 			juanSubmissionCount++
@@ -1103,9 +1116,9 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 
 	// Step 9: Juan re-enrolls in the course
 	fmt.Println("Step 9: Juan re-enrolling in the course...")
-	enrollmentJSON := fmt.Sprintf(`{"student_id": "%s"}`, student2ID)
 	w = httptest.NewRecorder()
-	req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", strings.NewReader(enrollmentJSON))
+	req, _ = http.NewRequest("POST", "/courses/"+courseID+"/enroll", nil)
+	authorize(req, auth.RoleStudent, student2ID, student2Name)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 	fmt.Printf("✓ %s successfully re-enrolled in the course\n", student2Name)
@@ -1114,6 +1127,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	fmt.Println("Step 10: Verifying Juan's enrollment is reactivated...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/courses/"+courseID+"/enrollments", nil)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1164,8 +1178,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	for i, submissionID := range submissionIDs[student2ID] {
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("GET", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID, nil)
-		req.Header.Set("X-Student-UUID", student2ID)
-		req.Header.Set("X-Student-Name", student2Name)
+		authorize(req, auth.RoleStudent, student2ID, student2Name)
 		r.ServeHTTP(w, req)
 		if w.Code == http.StatusNotFound || w.Code == http.StatusBadRequest {
 			deletedSubmissionCount++
@@ -1182,8 +1195,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	for i, submissionID := range submissionIDs[student1ID] {
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("GET", "/assignments/"+assignmentIDs[i]+"/submissions/"+submissionID, nil)
-		req.Header.Set("X-Student-UUID", student1ID)
-		req.Header.Set("X-Student-Name", student1Name)
+		authorize(req, auth.RoleStudent, student1ID, student1Name)
 		r.ServeHTTP(w, req)
 		if w.Code == http.StatusOK {
 			mariaSubmissionCount++
@@ -1209,8 +1221,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[0]+"/submissions", strings.NewReader(newSubmissionJSON))
-	req.Header.Set("X-Student-UUID", student2ID)
-	req.Header.Set("X-Student-Name", student2Name)
+	authorize(req, auth.RoleStudent, student2ID, student2Name)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1224,6 +1235,7 @@ func TestStudentDisapprovalAndReEnrollmentE2E(t *testing.T) {
 	fmt.Println("Step 14: Final verification...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/courses/"+courseID+"/enrollments", nil)
+	authorize(req, auth.RoleTeacher, teacherID, teacherName)
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1303,14 +1315,14 @@ func TestForumParticipantsE2E(t *testing.T) {
 	courseJSON := fmt.Sprintf(`{
 		"title": "Test Forum Course",
 		"description": "Course for testing forum participants",
-		"teacher_id": "%s",
 		"capacity": 30,
 		"start_date": "%s",
 		"end_date": "%s"
-	}`, teacherID, time.Now().Format(time.RFC3339), time.Now().AddDate(0, 1, 0).Format(time.RFC3339))
+	}`, time.Now().Format(time.RFC3339), time.Now().AddDate(0, 1, 0).Format(time.RFC3339))
 
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/courses", strings.NewReader(courseJSON))
+	authorize(req, auth.RoleTeacher, teacherID, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -1335,14 +1347,14 @@ func TestForumParticipantsE2E(t *testing.T) {
 	for i, q := range questions {
 		questionJSON := fmt.Sprintf(`{
 			"course_id": "%s",
-			"author_id": "%s",
 			"title": "%s",
 			"description": "%s",
 			"tags": ["general"]
-		}`, courseID, q.authorID, q.title, q.description)
+		}`, courseID, q.title, q.description)
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions", strings.NewReader(questionJSON))
+		authorize(req, auth.RoleStudent, q.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -1366,13 +1378,13 @@ func TestForumParticipantsE2E(t *testing.T) {
 
 	for _, answer := range answers {
 		answerJSON := fmt.Sprintf(`{
-			"author_id": "%s",
 			"content": "%s"
-		}`, answer.authorID, answer.content)
+		}`, answer.content)
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions/"+questionIDs[answer.questionIdx]+"/answers",
 			strings.NewReader(answerJSON))
+		authorize(req, auth.RoleStudent, answer.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		fmt.Printf("✓ Created forum answer by %s\n", answer.authorID)
@@ -1390,9 +1402,10 @@ func TestForumParticipantsE2E(t *testing.T) {
 	}
 
 	for _, vote := range votes {
-		voteJSON := fmt.Sprintf(`{"vote_type": %d, "user_id": "%s"}`, vote.voteType, vote.voterID)
+		voteJSON := fmt.Sprintf(`{"vote_type": %d}`, vote.voteType)
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions/"+questionIDs[vote.questionIdx]+"/vote", strings.NewReader(voteJSON))
+		authorize(req, auth.RoleTeacher, vote.voterID, "")
 		r.ServeHTTP(w, req)
 		fmt.Printf("✓ Added vote to question %d by student %s\n", vote.questionIdx+1, vote.voterID)
 	}
@@ -1404,6 +1417,7 @@ func TestForumParticipantsE2E(t *testing.T) {
 	fmt.Println("Step 5: Testing forum participants endpoint...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/forum/courses/"+courseID+"/participants", nil)
+	authorize(req, auth.RoleTeacher, teacherID, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1436,6 +1450,7 @@ func TestForumParticipantsE2E(t *testing.T) {
 	fmt.Println("Step 7: Testing error case...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/forum/courses/non-existent-course/participants", nil)
+	authorize(req, auth.RoleTeacher, teacherID, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusNotFound, w.Code)
 
@@ -1526,15 +1541,15 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 		courseJSON := fmt.Sprintf(`{
 			"title": "%s",
 			"description": "Curso de %s con enfoque práctico",
-			"teacher_id": "%s",
 			"capacity": %d,
 			"start_date": "%s",
 			"end_date": "%s"
-		}`, course.title, course.title, course.teacherID, course.capacity,
+		}`, course.title, course.title, course.capacity,
 			course.startDate.Format(time.RFC3339), course.endDate.Format(time.RFC3339))
 
 		w := httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", "/courses", strings.NewReader(courseJSON))
+		authorize(req, auth.RoleTeacher, course.teacherID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -1547,9 +1562,10 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 
 	// Step 2: Add auxiliary teacher to first course
 	fmt.Println("Step 2: Adding auxiliary teacher...")
-	auxTeacherJSON := fmt.Sprintf(`{"teacher_id": "%s", "aux_teacher_id": "%s"}`, teacher1ID, auxTeacherID)
+	auxTeacherJSON := fmt.Sprintf(`{"aux_teacher_id": "%s"}`, auxTeacherID)
 	w := httptest.NewRecorder()
 	req, _ := http.NewRequest("POST", "/courses/"+courseIDs[0]+"/aux-teacher/add", strings.NewReader(auxTeacherJSON))
+	authorize(req, auth.RoleTeacher, teacher1ID, "")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 	fmt.Printf("✓ Added auxiliary teacher %s to course %s\n", auxTeacherID, courseIDs[0])
@@ -1572,17 +1588,17 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 	}
 
 	for _, enrollment := range enrollments {
-		enrollmentJSON := fmt.Sprintf(`{"student_id": "%s"}`, enrollment.studentID)
 		w = httptest.NewRecorder()
-		req, _ = http.NewRequest("POST", "/courses/"+courseIDs[enrollment.courseIdx]+"/enroll", strings.NewReader(enrollmentJSON))
+		req, _ = http.NewRequest("POST", "/courses/"+courseIDs[enrollment.courseIdx]+"/enroll", nil)
+		authorize(req, auth.RoleStudent, enrollment.studentID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
 		// Simulate different enrollment statuses by dropping/completing some
 		if enrollment.status == "dropped" {
-			unenrollJSON := fmt.Sprintf(`{"student_id": "%s"}`, enrollment.studentID)
 			w = httptest.NewRecorder()
-			req, _ = http.NewRequest("DELETE", "/courses/"+courseIDs[enrollment.courseIdx]+"/unenroll", strings.NewReader(unenrollJSON))
+			req, _ = http.NewRequest("DELETE", "/courses/"+courseIDs[enrollment.courseIdx]+"/unenroll", nil)
+			authorize(req, auth.RoleStudent, enrollment.studentID, "")
 			r.ServeHTTP(w, req)
 		}
 
@@ -1636,6 +1652,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/assignments", strings.NewReader(assignmentJSON))
+		authorize(req, auth.RoleTeacher, courses[assignment.courseIdx].teacherID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -1686,8 +1703,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 		w = httptest.NewRecorder()
 		req, _ := http.NewRequest("POST", "/assignments/"+assignmentIDs[submission.assignmentIdx]+"/submissions",
 			strings.NewReader(submissionJSON))
-		req.Header.Set("X-Student-UUID", submission.studentID)
-		req.Header.Set("X-Student-Name", submission.studentID)
+		authorize(req, auth.RoleStudent, submission.studentID, submission.studentID)
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1702,8 +1718,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("POST", "/assignments/"+assignmentIDs[submission.assignmentIdx]+
 				"/submissions/"+submissionID+"/submit", nil)
-			req.Header.Set("X-Student-UUID", submission.studentID)
-			req.Header.Set("X-Student-Name", submission.studentID)
+			authorize(req, auth.RoleStudent, submission.studentID, submission.studentID)
 			r.ServeHTTP(w, req)
 		}
 
@@ -1724,8 +1739,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 			w = httptest.NewRecorder()
 			req, _ = http.NewRequest("PUT", "/assignments/"+assignmentIDs[submission.assignmentIdx]+
 				"/submissions/"+submissionID+"/grade", strings.NewReader(gradeJSON))
-			req.Header.Set("X-Teacher-UUID", teacher1ID)
-			req.Header.Set("X-Teacher-Name", "Prof. Teacher")
+			authorize(req, auth.RoleTeacher, teacher1ID, "Prof. Teacher")
 			r.ServeHTTP(w, req)
 		}
 
@@ -1755,14 +1769,14 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 
 		questionJSON := fmt.Sprintf(`{
 			"course_id": "%s",
-			"author_id": "%s",
 			"title": "%s",
 			"description": "Descripción detallada de la pregunta",
 			"tags": %s
-		}`, courseIDs[question.courseIdx], question.authorID, question.title, string(tagsJSON))
+		}`, courseIDs[question.courseIdx], question.title, string(tagsJSON))
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions", strings.NewReader(questionJSON))
+		authorize(req, auth.RoleStudent, question.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 
@@ -1788,13 +1802,17 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 
 	for _, answer := range forumAnswers {
 		answerJSON := fmt.Sprintf(`{
-			"author_id": "%s",
 			"content": "%s"
-		}`, answer.authorID, answer.content)
+		}`, answer.content)
 
 		w = httptest.NewRecorder()
 		req, _ = http.NewRequest("POST", "/forum/questions/"+questionIDs[answer.questionIdx]+"/answers",
 			strings.NewReader(answerJSON))
+		role := auth.RoleStudent
+		if answer.authorID == teacher1ID || answer.authorID == teacher2ID {
+			role = auth.RoleTeacher
+		}
+		authorize(req, role, answer.authorID, "")
 		r.ServeHTTP(w, req)
 		assert.Equal(t, http.StatusCreated, w.Code)
 		fmt.Printf("✓ Created forum answer by %s\n", answer.authorID)
@@ -1810,6 +1828,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 	fmt.Println("Testing /backoffice/statistics/general...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/backoffice/statistics/general", nil)
+	authorize(req, auth.RoleAdmin, "admin-001", "Admin")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1846,6 +1865,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 	fmt.Println("Testing /backoffice/statistics/courses...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/backoffice/statistics/courses", nil)
+	authorize(req, auth.RoleAdmin, "admin-001", "Admin")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -1873,6 +1893,7 @@ func TestBackofficeStatisticsE2E(t *testing.T) {
 	fmt.Println("Testing /backoffice/statistics/assignments...")
 	w = httptest.NewRecorder()
 	req, _ = http.NewRequest("GET", "/backoffice/statistics/assignments", nil)
+	authorize(req, auth.RoleAdmin, "admin-001", "Admin")
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusOK, w.Code)
 
@@ -0,0 +1,125 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlockAndUnblockUser(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("blocked_users")
+	})
+
+	blockedUserRepository := repository.NewBlockedUserRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	missing, err := blockedUserRepository.GetByUser(ctx, "student123")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	err = blockedUserRepository.Block(ctx, &model.BlockedUser{UserUUID: "student123", Reason: "Spam", BlockedBy: "admin123", BlockedAt: time.Now()})
+	assert.NoError(t, err)
+
+	// Blocking the user again replaces the block
+	err = blockedUserRepository.Block(ctx, &model.BlockedUser{UserUUID: "student123", Reason: "Offensive answers", BlockedBy: "admin456", BlockedAt: time.Now()})
+	assert.NoError(t, err)
+
+	blocked, err := blockedUserRepository.GetByUser(ctx, "student123")
+	assert.NoError(t, err)
+	assert.NotNil(t, blocked)
+	assert.Equal(t, "Offensive answers", blocked.Reason)
+	assert.Equal(t, "admin456", blocked.BlockedBy)
+
+	unblocked, err := blockedUserRepository.Unblock(ctx, "student123")
+	assert.NoError(t, err)
+	assert.True(t, unblocked)
+
+	blocked, err = blockedUserRepository.GetByUser(ctx, "student123")
+	assert.NoError(t, err)
+	assert.Nil(t, blocked)
+
+	// Unblocking a user that is not blocked tells there was no block
+	unblocked, err = blockedUserRepository.Unblock(ctx, "student123")
+	assert.NoError(t, err)
+	assert.False(t, unblocked)
+}
+
+func TestGetAllBlockedUsers(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("blocked_users")
+	})
+
+	blockedUserRepository := repository.NewBlockedUserRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	users, err := blockedUserRepository.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.NotNil(t, users)
+	assert.Empty(t, users)
+
+	now := time.Now()
+	err = blockedUserRepository.Block(ctx, &model.BlockedUser{UserUUID: "student-older", Reason: "Spam", BlockedBy: "admin123", BlockedAt: now.Add(-time.Hour)})
+	assert.NoError(t, err)
+	err = blockedUserRepository.Block(ctx, &model.BlockedUser{UserUUID: "student-newer", Reason: "Spam", BlockedBy: "admin123", BlockedAt: now})
+	assert.NoError(t, err)
+
+	// The latest block comes first
+	users, err = blockedUserRepository.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, users, 2)
+	assert.Equal(t, "student-newer", users[0].UserUUID)
+	assert.Equal(t, "student-older", users[1].UserUUID)
+}
+
+func TestGetAdminActionLogs(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("admin_action_logs")
+	})
+
+	logRepository := repository.NewAdminActionLogRepository(dbSetup.Client, dbSetup.DBName)
+	now := time.Now()
+
+	for i, log := range []model.AdminActionLog{
+		{AdminUUID: "admin123", Action: model.AdminActionSuspendCourse, CourseID: "course123", TargetID: "course123", Reason: "Reported content"},
+		{AdminUUID: "admin123", Action: model.AdminActionRemoveForumQuestion, CourseID: "course123", TargetID: "question123", Reason: "Offensive question"},
+		{AdminUUID: "admin456", Action: model.AdminActionBlockUser, TargetID: "student123", Reason: "Spam"},
+		{AdminUUID: "admin456", Action: model.AdminActionReactivateCourse, CourseID: "course123", TargetID: "course123", Reason: "Content reviewed"},
+	} {
+		log.Timestamp = now.Add(time.Duration(i) * time.Minute)
+		err := logRepository.Create(context.TODO(), &log)
+		assert.NoError(t, err)
+		assert.False(t, log.ID.IsZero())
+	}
+
+	tests := []struct {
+		name            string
+		filter          schemas.AdminActionLogFilter
+		expectedTargets []string
+	}{
+		{name: "all the logs, the latest first", expectedTargets: []string{"course123", "student123", "question123", "course123"}},
+		{name: "by admin", filter: schemas.AdminActionLogFilter{AdminUUID: "admin456"}, expectedTargets: []string{"course123", "student123"}},
+		{name: "by course", filter: schemas.AdminActionLogFilter{CourseID: "course123"}, expectedTargets: []string{"course123", "question123", "course123"}},
+		{name: "by target", filter: schemas.AdminActionLogFilter{TargetID: "student123"}, expectedTargets: []string{"student123"}},
+		{name: "by action", filter: schemas.AdminActionLogFilter{Action: model.AdminActionSuspendCourse}, expectedTargets: []string{"course123"}},
+		{name: "by admin and action", filter: schemas.AdminActionLogFilter{AdminUUID: "admin123", Action: model.AdminActionBlockUser}, expectedTargets: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, err := logRepository.GetLogs(context.TODO(), tt.filter)
+			assert.NoError(t, err)
+			targets := []string{}
+			for _, log := range logs {
+				targets = append(targets, log.TargetID)
+			}
+			assert.Equal(t, tt.expectedTargets, targets)
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAndGetAiCacheEntry(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("ai_cache")
+	})
+
+	cacheRepository := repository.NewAiCacheRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	missing, err := cacheRepository.Get(ctx, "prompt-hash")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	entry := model.AiCacheEntry{Key: "prompt-hash", Operation: "summarize_feedback", CourseID: "course123", Response: "First summary", CreatedAt: time.Now()}
+	err = cacheRepository.Set(ctx, &entry)
+	assert.NoError(t, err)
+
+	found, err := cacheRepository.Get(ctx, "prompt-hash")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "summarize_feedback", found.Operation)
+	assert.Equal(t, "course123", found.CourseID)
+	assert.Equal(t, "First summary", found.Response)
+
+	// Setting the same key replaces the answer
+	entry.Response = "Second summary"
+	err = cacheRepository.Set(ctx, &entry)
+	assert.NoError(t, err)
+
+	found, err = cacheRepository.Get(ctx, "prompt-hash")
+	assert.NoError(t, err)
+	assert.Equal(t, "Second summary", found.Response)
+}
+
+func TestDeleteAiCacheEntries(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("ai_cache")
+	})
+
+	cacheRepository := repository.NewAiCacheRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	for _, entry := range []model.AiCacheEntry{
+		{Key: "course123-summary", CourseID: "course123"},
+		{Key: "course123-student1-summary", CourseID: "course123", StudentUUID: "student1"},
+		{Key: "course456-summary", CourseID: "course456"},
+		{Key: "student1-summary", StudentUUID: "student1"},
+		{Key: "student2-summary", StudentUUID: "student2"},
+	} {
+		entry.Response = "Summary"
+		entry.CreatedAt = time.Now()
+		err := cacheRepository.Set(ctx, &entry)
+		assert.NoError(t, err)
+	}
+
+	err := cacheRepository.DeleteByCourse(ctx, "course123")
+	assert.NoError(t, err)
+	err = cacheRepository.DeleteByStudent(ctx, "student1")
+	assert.NoError(t, err)
+
+	for key, kept := range map[string]bool{
+		"course123-summary":          false,
+		"course123-student1-summary": false,
+		"course456-summary":          true,
+		"student1-summary":           false,
+		"student2-summary":           true,
+	} {
+		found, err := cacheRepository.Get(ctx, key)
+		assert.NoError(t, err)
+		assert.Equal(t, kept, found != nil, key)
+	}
+}
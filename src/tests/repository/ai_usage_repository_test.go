@@ -0,0 +1,104 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestAiUsage records the calls of teacher1 in course123, one of them two days ago, and of teacher2 in course456
+func createTestAiUsage(t *testing.T, usageRepository *repository.AiUsageRepository, now time.Time) {
+	recent := now.Add(-time.Hour)
+	for _, usage := range []model.AiUsage{
+		{CourseID: "course123", TeacherUUID: "teacher1", PromptTokens: 100, CompletionTokens: 50, LatencyMs: 200, Success: true, CreatedAt: recent},
+		{CourseID: "course123", TeacherUUID: "teacher1", PromptTokens: 10, CompletionTokens: 5, LatencyMs: 2, Success: true, Cached: true, CreatedAt: recent},
+		{CourseID: "course123", TeacherUUID: "teacher1", PromptTokens: 100, LatencyMs: 400, CreatedAt: recent},
+		{CourseID: "course456", TeacherUUID: "teacher2", PromptTokens: 20, CompletionTokens: 10, LatencyMs: 100, Success: true, CreatedAt: recent},
+		{CourseID: "course123", TeacherUUID: "teacher1", PromptTokens: 1000, CompletionTokens: 1000, LatencyMs: 50, Success: true, CreatedAt: now.Add(-48 * time.Hour)},
+	} {
+		usage.Operation = "correct_submission"
+		usage.Provider = "fake"
+		if err := usageRepository.Create(context.TODO(), &usage); err != nil {
+			t.Fatalf("Failed to create AI usage: %v", err)
+		}
+	}
+}
+
+func TestCreateAiUsage(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("ai_usage")
+	})
+
+	usageRepository := repository.NewAiUsageRepository(dbSetup.Client, dbSetup.DBName)
+
+	usage := model.AiUsage{Operation: "summarize_feedback", Provider: "fake", CourseID: "course123", PromptTokens: 10, Success: true, CreatedAt: time.Now()}
+	err := usageRepository.Create(context.TODO(), &usage)
+	assert.NoError(t, err)
+	assert.False(t, usage.ID.IsZero())
+}
+
+func TestGetAiUsageByCourse(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("ai_usage")
+	})
+
+	usageRepository := repository.NewAiUsageRepository(dbSetup.Client, dbSetup.DBName)
+	now := time.Now()
+	createTestAiUsage(t, usageRepository, now)
+	ctx := context.TODO()
+	dayAgo := now.Add(-24 * time.Hour)
+
+	// The courses with more tokens come first, the cached calls don't count for the average latency
+	usage, err := usageRepository.GetUsageByCourse(ctx, &dayAgo, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []schemas.AiUsageSummary{
+		{CourseID: "course123", Requests: 3, CachedRequests: 1, FailedRequests: 1, PromptTokens: 210, CompletionTokens: 55, TotalTokens: 265, AvgLatencyMs: 300, MaxLatencyMs: 400},
+		{CourseID: "course456", Requests: 1, PromptTokens: 20, CompletionTokens: 10, TotalTokens: 30, AvgLatencyMs: 100, MaxLatencyMs: 100},
+	}, usage)
+
+	// The upper bound is exclusive
+	usage, err = usageRepository.GetUsageByCourse(ctx, nil, &dayAgo)
+	assert.NoError(t, err)
+	assert.Len(t, usage, 1)
+	assert.Equal(t, "course123", usage[0].CourseID)
+	assert.Equal(t, 1, usage[0].Requests)
+	assert.Equal(t, 2000, usage[0].TotalTokens)
+
+	usage, err = usageRepository.GetUsageByCourse(ctx, nil, nil)
+	assert.NoError(t, err)
+	assert.Len(t, usage, 2)
+	assert.Equal(t, 4, usage[0].Requests)
+	assert.Equal(t, 2265, usage[0].TotalTokens)
+
+	future := now.Add(time.Hour)
+	usage, err = usageRepository.GetUsageByCourse(ctx, &future, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, usage)
+	assert.Empty(t, usage)
+}
+
+func TestGetAiUsageByTeacher(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("ai_usage")
+	})
+
+	usageRepository := repository.NewAiUsageRepository(dbSetup.Client, dbSetup.DBName)
+	now := time.Now()
+	createTestAiUsage(t, usageRepository, now)
+	dayAgo := now.Add(-24 * time.Hour)
+
+	usage, err := usageRepository.GetUsageByTeacher(context.TODO(), &dayAgo, &now)
+	assert.NoError(t, err)
+	assert.Len(t, usage, 2)
+	assert.Equal(t, "teacher1", usage[0].TeacherUUID)
+	assert.Empty(t, usage[0].CourseID)
+	assert.Equal(t, 265, usage[0].TotalTokens)
+	assert.Equal(t, "teacher2", usage[1].TeacherUUID)
+	assert.Equal(t, 30, usage[1].TotalTokens)
+}
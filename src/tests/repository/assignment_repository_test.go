@@ -372,6 +372,43 @@ func TestUpdateAssignmentPartial(t *testing.T) {
 	assert.Equal(t, assignment.GracePeriod, updatedAssignment.GracePeriod)
 }
 
+func TestUpdateAssignmentRubric(t *testing.T) {
+	t.Cleanup(func() {
+		assignmentDBSetup.CleanupCollection("assignments")
+	})
+
+	assignmentRepository := repository.NewAssignmentRepository(assignmentDBSetup.Client, assignmentDBSetup.DBName)
+
+	createdAssignment, err := assignmentRepository.CreateAssignment(createTestAssignment())
+	assert.NoError(t, err)
+	assert.Nil(t, createdAssignment.Rubric)
+
+	rubric := &model.Rubric{
+		Criteria: []model.RubricCriterion{
+			{
+				ID:    "clarity",
+				Title: "Clarity",
+				Levels: []model.RubricLevel{
+					{ID: "excellent", Title: "Excellent", Points: 10},
+					{ID: "insufficient", Title: "Insufficient", Points: 0},
+				},
+			},
+		},
+	}
+	updatedAssignment, err := assignmentRepository.UpdateAssignment(createdAssignment.ID.Hex(), model.Assignment{Rubric: rubric})
+	assert.NoError(t, err)
+	assert.Equal(t, rubric, updatedAssignment.Rubric)
+
+	// Updating other fields keeps the rubric
+	_, err = assignmentRepository.UpdateAssignment(createdAssignment.ID.Hex(), model.Assignment{Title: "Updated Assignment"})
+	assert.NoError(t, err)
+
+	foundAssignment, err := assignmentRepository.GetByID(context.TODO(), createdAssignment.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, "Updated Assignment", foundAssignment.Title)
+	assert.Equal(t, rubric, foundAssignment.Rubric)
+}
+
 func TestUpdateAssignmentWithInvalidID(t *testing.T) {
 	t.Cleanup(func() {
 		assignmentDBSetup.CleanupCollection("assignments")
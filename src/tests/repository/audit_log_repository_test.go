@@ -0,0 +1,155 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/auth"
+	"courses-service/src/model"
+	"courses-service/src/repository"
+	"courses-service/src/schemas"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// createTestAuditLogs records the changes of the teachers in course123 and of a student, an admin and the
+// server in course456, around base
+func createTestAuditLogs(t *testing.T, logRepository *repository.AuditLogRepository, base time.Time) {
+	for _, log := range []model.AuditLog{
+		{RequestID: "request-1", ActorUUID: "teacher123", ActorRoles: []string{auth.RoleTitularTeacher}, Action: model.AuditActionCreate, Entity: model.AuditEntityCourse, EntityID: "course123", CourseID: "course123", Timestamp: base.Add(-48 * time.Hour)},
+		{RequestID: "request-2", ActorUUID: "teacher123", ActorRoles: []string{auth.RoleTitularTeacher}, Action: model.AuditActionCreate, Entity: model.AuditEntityModule, EntityID: "module123", CourseID: "course123", Timestamp: base.Add(-24 * time.Hour)},
+		// A request that changed two entities at the same time, the last recorded comes first
+		{RequestID: "request-3", ActorUUID: "aux123", ActorRoles: []string{auth.RoleAuxTeacher}, Action: model.AuditActionUpdate, Entity: model.AuditEntityAssignment, EntityID: "assignment123", CourseID: "course123", Timestamp: base.Add(-time.Hour)},
+		{RequestID: "request-3", ActorUUID: "aux123", ActorRoles: []string{auth.RoleAuxTeacher}, Action: model.AuditActionUpdate, Entity: model.AuditEntityAssignment, EntityID: "assignment456", CourseID: "course123", Timestamp: base.Add(-time.Hour)},
+		{RequestID: "request-4", ActorUUID: "student123", ActorRoles: []string{auth.RoleEnrolledStudent}, Action: model.AuditActionCreate, Entity: model.AuditEntitySubmission, EntityID: "submission123", CourseID: "course456", Timestamp: base},
+		{RequestID: "request-5", ActorUUID: "admin123", ActorRoles: []string{auth.RoleAdmin}, Action: model.AuditActionDelete, Entity: model.AuditEntityCourse, EntityID: "course456", CourseID: "course456", Timestamp: base.Add(time.Hour)},
+		{RequestID: "request-6", ActorUUID: model.AuditActorSystem, ActorRoles: []string{model.AuditActorSystem}, Action: model.AuditActionUpdate, Entity: model.AuditEntitySubmission, EntityID: "submission123", CourseID: "course456", Timestamp: base.Add(2 * time.Hour)},
+	} {
+		log.Method = "POST"
+		log.Path = "/courses"
+		if err := logRepository.Create(context.TODO(), &log); err != nil {
+			t.Fatalf("Failed to create audit log: %v", err)
+		}
+	}
+}
+
+func auditLogEntityIDs(logs []model.AuditLog) []string {
+	ids := []string{}
+	for _, log := range logs {
+		ids = append(ids, log.EntityID)
+	}
+	return ids
+}
+
+func TestCreateAuditLog(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("audit_logs")
+	})
+
+	logRepository := repository.NewAuditLogRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	log := model.AuditLog{
+		RequestID:  "request123",
+		ActorUUID:  "teacher123",
+		ActorRoles: []string{auth.RoleTitularTeacher},
+		Action:     model.AuditActionUpdate,
+		Entity:     model.AuditEntityCourse,
+		EntityID:   "course123",
+		CourseID:   "course123",
+		Method:     "PUT",
+		Path:       "/courses/course123",
+		Changes:    []model.AuditChange{{Field: "title", Before: "Old title", After: "New title"}},
+		Timestamp:  time.Now(),
+	}
+	err := logRepository.Create(ctx, &log)
+	assert.NoError(t, err)
+	assert.False(t, log.ID.IsZero())
+
+	logs, total, err := logRepository.GetLogs(ctx, schemas.AuditLogFilter{Page: 1, PageSize: 10})
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), total)
+	assert.Len(t, logs, 1)
+	assert.Equal(t, log.ID, logs[0].ID)
+	assert.Equal(t, "request123", logs[0].RequestID)
+	assert.Equal(t, []string{auth.RoleTitularTeacher}, logs[0].ActorRoles)
+	assert.Equal(t, "PUT", logs[0].Method)
+	assert.Equal(t, "/courses/course123", logs[0].Path)
+	assert.Equal(t, []model.AuditChange{{Field: "title", Before: "Old title", After: "New title"}}, logs[0].Changes)
+	assert.WithinDuration(t, log.Timestamp, logs[0].Timestamp, time.Millisecond)
+}
+
+func TestGetAuditLogsByFilter(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("audit_logs")
+	})
+
+	logRepository := repository.NewAuditLogRepository(dbSetup.Client, dbSetup.DBName)
+	base := time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC)
+	createTestAuditLogs(t, logRepository, base)
+	dayAgo := base.Add(-24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		filter      schemas.AuditLogFilter
+		expectedIDs []string
+	}{
+		{name: "all the logs, the latest first", expectedIDs: []string{"submission123", "course456", "submission123", "assignment456", "assignment123", "module123", "course123"}},
+		{name: "by actor", filter: schemas.AuditLogFilter{ActorUUID: "teacher123"}, expectedIDs: []string{"module123", "course123"}},
+		{name: "by role", filter: schemas.AuditLogFilter{Role: auth.RoleAuxTeacher}, expectedIDs: []string{"assignment456", "assignment123"}},
+		{name: "by system role", filter: schemas.AuditLogFilter{Role: model.AuditActorSystem}, expectedIDs: []string{"submission123"}},
+		{name: "by entity", filter: schemas.AuditLogFilter{Entity: model.AuditEntitySubmission}, expectedIDs: []string{"submission123", "submission123"}},
+		{name: "by entity id", filter: schemas.AuditLogFilter{EntityID: "course456"}, expectedIDs: []string{"course456"}},
+		{name: "by course", filter: schemas.AuditLogFilter{CourseID: "course456"}, expectedIDs: []string{"submission123", "course456", "submission123"}},
+		{name: "by action", filter: schemas.AuditLogFilter{Action: model.AuditActionDelete}, expectedIDs: []string{"course456"}},
+		{name: "by request", filter: schemas.AuditLogFilter{RequestID: "request-3"}, expectedIDs: []string{"assignment456", "assignment123"}},
+		{name: "from is inclusive", filter: schemas.AuditLogFilter{From: &base}, expectedIDs: []string{"submission123", "course456", "submission123"}},
+		{name: "to is exclusive", filter: schemas.AuditLogFilter{To: &base}, expectedIDs: []string{"assignment456", "assignment123", "module123", "course123"}},
+		{name: "between from and to", filter: schemas.AuditLogFilter{From: &dayAgo, To: &base}, expectedIDs: []string{"assignment456", "assignment123", "module123"}},
+		{name: "by entity and course", filter: schemas.AuditLogFilter{Entity: model.AuditEntityCourse, CourseID: "course123"}, expectedIDs: []string{"course123"}},
+		{name: "no match", filter: schemas.AuditLogFilter{ActorUUID: "aux123", Action: model.AuditActionCreate}, expectedIDs: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.filter.Page = 1
+			tt.filter.PageSize = 10
+			logs, total, err := logRepository.GetLogs(context.TODO(), tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, int64(len(tt.expectedIDs)), total)
+			assert.Equal(t, tt.expectedIDs, auditLogEntityIDs(logs))
+		})
+	}
+}
+
+func TestGetAuditLogsByPage(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("audit_logs")
+	})
+
+	logRepository := repository.NewAuditLogRepository(dbSetup.Client, dbSetup.DBName)
+	createTestAuditLogs(t, logRepository, time.Date(2025, 6, 10, 12, 0, 0, 0, time.UTC))
+
+	tests := []struct {
+		name          string
+		filter        schemas.AuditLogFilter
+		expectedIDs   []string
+		expectedTotal int64
+	}{
+		{name: "first page", filter: schemas.AuditLogFilter{Page: 1, PageSize: 3}, expectedIDs: []string{"submission123", "course456", "submission123"}, expectedTotal: 7},
+		{name: "second page", filter: schemas.AuditLogFilter{Page: 2, PageSize: 3}, expectedIDs: []string{"assignment456", "assignment123", "module123"}, expectedTotal: 7},
+		{name: "last page", filter: schemas.AuditLogFilter{Page: 3, PageSize: 3}, expectedIDs: []string{"course123"}, expectedTotal: 7},
+		{name: "past the last page", filter: schemas.AuditLogFilter{Page: 4, PageSize: 3}, expectedIDs: []string{}, expectedTotal: 7},
+		{name: "page of a filter", filter: schemas.AuditLogFilter{CourseID: "course123", Page: 2, PageSize: 2}, expectedIDs: []string{"module123", "course123"}, expectedTotal: 4},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			logs, total, err := logRepository.GetLogs(context.TODO(), tt.filter)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedTotal, total)
+			assert.Equal(t, tt.expectedIDs, auditLogEntityIDs(logs))
+		})
+	}
+}
@@ -0,0 +1,301 @@
+package repository_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createTestCorrectionJob(t *testing.T, jobRepository *repository.CorrectionJobRepository, submissionID string, status model.CorrectionJobStatus, nextRunAt time.Time) *model.CorrectionJob {
+	job := &model.CorrectionJob{
+		SubmissionID: submissionID,
+		AssignmentID: "assignment123",
+		StudentUUID:  "student123",
+		Status:       status,
+		MaxAttempts:  3,
+		NextRunAt:    nextRunAt,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+	if err := jobRepository.Create(context.TODO(), job); err != nil {
+		t.Fatalf("Failed to create correction job: %v", err)
+	}
+	return job
+}
+
+func TestGetLatestCorrectionJobBySubmission(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	first := &model.CorrectionJob{SubmissionID: "submission123", Status: model.CorrectionJobFailed, CreatedAt: time.Now().Add(-time.Hour)}
+	err := jobRepository.Create(ctx, first)
+	assert.NoError(t, err)
+	latest := createTestCorrectionJob(t, jobRepository, "submission123", model.CorrectionJobPending, time.Now())
+	createTestCorrectionJob(t, jobRepository, "submission456", model.CorrectionJobPending, time.Now())
+
+	found, err := jobRepository.GetLatestBySubmission(ctx, "submission123")
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, latest.ID, found.ID)
+	assert.Equal(t, model.CorrectionJobPending, found.Status)
+
+	missing, err := jobRepository.GetLatestBySubmission(ctx, "submission789")
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+}
+
+func TestClaimNextCorrectionJob(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+	lockedUntil := now.Add(5 * time.Minute)
+
+	createTestCorrectionJob(t, jobRepository, "submission-done", model.CorrectionJobDone, now.Add(-3*time.Hour))
+	createTestCorrectionJob(t, jobRepository, "submission-failed", model.CorrectionJobFailed, now.Add(-3*time.Hour))
+	createTestCorrectionJob(t, jobRepository, "submission-retry-later", model.CorrectionJobPending, now.Add(time.Minute))
+	newer := createTestCorrectionJob(t, jobRepository, "submission-newer", model.CorrectionJobPending, now.Add(-time.Minute))
+	older := createTestCorrectionJob(t, jobRepository, "submission-older", model.CorrectionJobPending, now.Add(-time.Hour))
+
+	// The oldest ready job is taken first
+	claimed, err := jobRepository.ClaimNext(ctx, now, lockedUntil)
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+	assert.Equal(t, older.ID, claimed.ID)
+	assert.Equal(t, model.CorrectionJobRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+	assert.NotNil(t, claimed.LockedUntil)
+	assert.WithinDuration(t, lockedUntil, *claimed.LockedUntil, time.Millisecond)
+
+	claimed, err = jobRepository.ClaimNext(ctx, now, lockedUntil)
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+	assert.Equal(t, newer.ID, claimed.ID)
+
+	// The job waiting for its retry, the running, done and failed ones are not ready
+	claimed, err = jobRepository.ClaimNext(ctx, now, lockedUntil)
+	assert.NoError(t, err)
+	assert.Nil(t, claimed)
+
+	// The retry is ready once its time comes
+	claimed, err = jobRepository.ClaimNext(ctx, now.Add(2*time.Minute), now.Add(7*time.Minute))
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+	assert.Equal(t, "submission-retry-later", claimed.SubmissionID)
+}
+
+func TestClaimNextCorrectionJobAfterTheLockExpires(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+
+	job := createTestCorrectionJob(t, jobRepository, "submission123", model.CorrectionJobPending, now.Add(-time.Minute))
+	claimed, err := jobRepository.ClaimNext(ctx, now, now.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, job.ID, claimed.ID)
+
+	// The worker holds the job while its lock lasts
+	claimed, err = jobRepository.ClaimNext(ctx, now.Add(4*time.Minute), now.Add(9*time.Minute))
+	assert.NoError(t, err)
+	assert.Nil(t, claimed)
+
+	// The worker crashed, the job is taken again when the lock expires
+	later := now.Add(6 * time.Minute)
+	claimed, err = jobRepository.ClaimNext(ctx, later, later.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+	assert.Equal(t, job.ID, claimed.ID)
+	assert.Equal(t, model.CorrectionJobRunning, claimed.Status)
+	assert.Equal(t, 2, claimed.Attempts)
+	assert.WithinDuration(t, later.Add(5*time.Minute), *claimed.LockedUntil, time.Millisecond)
+}
+
+func TestUpdateCorrectionJobAfterLosingTheLock(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+
+	createTestCorrectionJob(t, jobRepository, "submission123", model.CorrectionJobPending, now.Add(-time.Minute))
+	first, err := jobRepository.ClaimNext(ctx, now, now.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, first.ClaimToken)
+
+	holds, err := jobRepository.HoldsClaim(ctx, first)
+	assert.NoError(t, err)
+	assert.True(t, holds)
+
+	// The lock of the first worker expires and a second worker claims the job
+	later := now.Add(6 * time.Minute)
+	second, err := jobRepository.ClaimNext(ctx, later, later.Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Equal(t, first.ID, second.ID)
+	assert.NotEqual(t, first.ClaimToken, second.ClaimToken)
+
+	holds, err = jobRepository.HoldsClaim(ctx, first)
+	assert.NoError(t, err)
+	assert.False(t, holds)
+
+	// The late result of the first worker doesn't overwrite the job of the second one
+	first.Status = model.CorrectionJobFailed
+	first.LastError = "automatic correction failed"
+	updated, err := jobRepository.Update(ctx, first)
+	assert.NoError(t, err)
+	assert.False(t, updated)
+
+	found, err := jobRepository.GetLatestBySubmission(ctx, "submission123")
+	assert.NoError(t, err)
+	assert.Equal(t, model.CorrectionJobRunning, found.Status)
+	assert.Empty(t, found.LastError)
+	assert.Equal(t, 2, found.Attempts)
+
+	second.Status = model.CorrectionJobDone
+	updated, err = jobRepository.Update(ctx, second)
+	assert.NoError(t, err)
+	assert.True(t, updated)
+
+	found, err = jobRepository.GetLatestBySubmission(ctx, "submission123")
+	assert.NoError(t, err)
+	assert.Equal(t, model.CorrectionJobDone, found.Status)
+}
+
+func TestClaimNextCorrectionJobIsAtomic(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	now := time.Now()
+
+	const jobs = 5
+	const workers = 20
+	for i := range jobs {
+		createTestCorrectionJob(t, jobRepository, primitive.NewObjectID().Hex(), model.CorrectionJobPending, now.Add(-time.Duration(i+1)*time.Minute))
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	claims := make(map[primitive.ObjectID]int)
+	empty := 0
+	for range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := jobRepository.ClaimNext(context.TODO(), now, now.Add(5*time.Minute))
+			assert.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if claimed == nil {
+				empty++
+				return
+			}
+			claims[claimed.ID]++
+		}()
+	}
+	wg.Wait()
+
+	// Every job is taken by a single worker, the rest of the workers find no job
+	assert.Len(t, claims, jobs)
+	for id, count := range claims {
+		assert.Equal(t, 1, count, "job %s was claimed more than once", id.Hex())
+	}
+	assert.Equal(t, workers-jobs, empty)
+}
+
+func TestClaimCorrectionJobByID(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+	lockedUntil := now.Add(5 * time.Minute)
+
+	createTestCorrectionJob(t, jobRepository, "submission-older", model.CorrectionJobPending, now.Add(-time.Hour))
+	job := createTestCorrectionJob(t, jobRepository, "submission123", model.CorrectionJobPending, now.Add(-time.Minute))
+	later := createTestCorrectionJob(t, jobRepository, "submission-later", model.CorrectionJobPending, now.Add(time.Minute))
+	done := createTestCorrectionJob(t, jobRepository, "submission-done", model.CorrectionJobDone, now.Add(-time.Minute))
+
+	// Only the given job is taken, even with older jobs ready
+	claimed, err := jobRepository.ClaimByID(ctx, job.ID.Hex(), now, lockedUntil)
+	assert.NoError(t, err)
+	assert.NotNil(t, claimed)
+	assert.Equal(t, job.ID, claimed.ID)
+	assert.Equal(t, model.CorrectionJobRunning, claimed.Status)
+	assert.Equal(t, 1, claimed.Attempts)
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{name: "already running", id: job.ID.Hex()},
+		{name: "waiting for its retry", id: later.ID.Hex()},
+		{name: "done", id: done.ID.Hex()},
+		{name: "missing", id: primitive.NewObjectID().Hex()},
+		{name: "invalid id", id: "invalid-id"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claimed, err := jobRepository.ClaimByID(ctx, tt.id, now, lockedUntil)
+			assert.NoError(t, err)
+			assert.Nil(t, claimed)
+		})
+	}
+}
+
+func TestClaimCorrectionJobByIDIsAtomic(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("correction_jobs")
+	})
+
+	jobRepository := repository.NewCorrectionJobRepository(dbSetup.Client, dbSetup.DBName)
+	now := time.Now()
+	job := createTestCorrectionJob(t, jobRepository, "submission123", model.CorrectionJobPending, now.Add(-time.Minute))
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	claims := 0
+	for range 10 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			claimed, err := jobRepository.ClaimByID(context.TODO(), job.ID.Hex(), now, now.Add(5*time.Minute))
+			assert.NoError(t, err)
+			if claimed != nil {
+				mu.Lock()
+				claims++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1, claims)
+	found, err := jobRepository.GetLatestBySubmission(context.TODO(), "submission123")
+	assert.NoError(t, err)
+	assert.Equal(t, 1, found.Attempts)
+}
@@ -0,0 +1,148 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createTestExtension(assignmentID, courseID string, studentUUIDs ...string) model.DeadlineExtension {
+	return model.DeadlineExtension{
+		AssignmentID: assignmentID,
+		CourseID:     courseID,
+		StudentUUIDs: studentUUIDs,
+		DueDate:      time.Now().Add(48 * time.Hour),
+		Reason:       "Medical certificate",
+		GrantedBy:    "teacher-123",
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+func TestCreateAndGetExtension(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("deadline_extensions")
+	})
+
+	extensionRepository := repository.NewExtensionRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	extension := createTestExtension("assignment123", "course123", "student1", "student2")
+	extension.GroupName = "Group A"
+	err := extensionRepository.Create(ctx, &extension)
+	assert.NoError(t, err)
+	assert.False(t, extension.ID.IsZero())
+
+	found, err := extensionRepository.GetByID(ctx, extension.ID.Hex())
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "assignment123", found.AssignmentID)
+	assert.Equal(t, "course123", found.CourseID)
+	assert.Equal(t, []string{"student1", "student2"}, found.StudentUUIDs)
+	assert.Equal(t, "Group A", found.GroupName)
+	assert.Equal(t, "teacher-123", found.GrantedBy)
+	assert.WithinDuration(t, extension.DueDate, found.DueDate, time.Millisecond)
+}
+
+func TestGetExtensionByIDNotFound(t *testing.T) {
+	extensionRepository := repository.NewExtensionRepository(dbSetup.Client, dbSetup.DBName)
+
+	found, err := extensionRepository.GetByID(context.TODO(), primitive.NewObjectID().Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	_, err = extensionRepository.GetByID(context.TODO(), "invalid-id")
+	assert.Error(t, err)
+}
+
+func TestUpdateExtension(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("deadline_extensions")
+	})
+
+	extensionRepository := repository.NewExtensionRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	extension := createTestExtension("assignment123", "course123", "student1")
+	err := extensionRepository.Create(ctx, &extension)
+	assert.NoError(t, err)
+
+	extension.StudentUUIDs = []string{"student1", "student3"}
+	extension.DueDate = extension.DueDate.Add(24 * time.Hour)
+	extension.Reason = "Extended again"
+	err = extensionRepository.Update(ctx, &extension)
+	assert.NoError(t, err)
+
+	found, err := extensionRepository.GetByID(ctx, extension.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"student1", "student3"}, found.StudentUUIDs)
+	assert.Equal(t, "Extended again", found.Reason)
+	assert.WithinDuration(t, extension.DueDate, found.DueDate, time.Millisecond)
+}
+
+func TestGetExtensionsByAssignmentStudentAndCourse(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("deadline_extensions")
+	})
+
+	extensionRepository := repository.NewExtensionRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	for _, extension := range []model.DeadlineExtension{
+		createTestExtension("assignment123", "course123", "student1", "student2"),
+		createTestExtension("assignment123", "course123", "student3"),
+		createTestExtension("assignment456", "course123", "student1"),
+		createTestExtension("assignment789", "course456", "student1"),
+	} {
+		err := extensionRepository.Create(ctx, &extension)
+		assert.NoError(t, err)
+	}
+
+	byAssignment, err := extensionRepository.GetByAssignment(ctx, "assignment123")
+	assert.NoError(t, err)
+	assert.Len(t, byAssignment, 2)
+
+	// The student matches any of the students of the extension
+	byStudent, err := extensionRepository.GetByAssignmentAndStudent(ctx, "assignment123", "student2")
+	assert.NoError(t, err)
+	assert.Len(t, byStudent, 1)
+	assert.Equal(t, []string{"student1", "student2"}, byStudent[0].StudentUUIDs)
+
+	byCourse, err := extensionRepository.GetByCourse(ctx, "course123")
+	assert.NoError(t, err)
+	assert.Len(t, byCourse, 3)
+
+	none, err := extensionRepository.GetByAssignmentAndStudent(ctx, "assignment456", "student2")
+	assert.NoError(t, err)
+	assert.NotNil(t, none)
+	assert.Empty(t, none)
+}
+
+func TestDeleteExtension(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("deadline_extensions")
+	})
+
+	extensionRepository := repository.NewExtensionRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	extension := createTestExtension("assignment123", "course123", "student1")
+	err := extensionRepository.Create(ctx, &extension)
+	assert.NoError(t, err)
+
+	err = extensionRepository.Delete(ctx, extension.ID.Hex())
+	assert.NoError(t, err)
+
+	found, err := extensionRepository.GetByID(ctx, extension.ID.Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	err = extensionRepository.Delete(ctx, "invalid-id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,96 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestFeedbackAnalysis(feedbackID, courseID string, sentiment model.FeedbackSentiment, feedbackCreatedAt time.Time, topics ...model.FeedbackTopic) model.FeedbackAnalysis {
+	return model.FeedbackAnalysis{
+		FeedbackID:        feedbackID,
+		CourseID:          courseID,
+		Sentiment:         sentiment,
+		Topics:            topics,
+		Method:            model.FeedbackAnalysisMethodKeywords,
+		FeedbackCreatedAt: feedbackCreatedAt,
+		AnalyzedAt:        time.Now(),
+	}
+}
+
+func TestSaveAndGetFeedbackAnalysesByCourse(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("course_feedback_analysis")
+	})
+
+	analysisRepository := repository.NewFeedbackAnalysisRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+
+	err := analysisRepository.SaveMany(ctx, []model.FeedbackAnalysis{
+		createTestFeedbackAnalysis("feedback-newer", "course123", model.FeedbackSentimentNegative, now, model.FeedbackTopicWorkload),
+		createTestFeedbackAnalysis("feedback-older", "course123", model.FeedbackSentimentPositive, now.Add(-time.Hour), model.FeedbackTopicTeaching, model.FeedbackTopicMaterials),
+		createTestFeedbackAnalysis("feedback-other-course", "course456", model.FeedbackSentimentNeutral, now),
+	})
+	assert.NoError(t, err)
+
+	// The oldest feedback comes first
+	analyses, err := analysisRepository.GetByCourse(ctx, "course123")
+	assert.NoError(t, err)
+	assert.Len(t, analyses, 2)
+	assert.Equal(t, "feedback-older", analyses[0].FeedbackID)
+	assert.Equal(t, model.FeedbackSentimentPositive, analyses[0].Sentiment)
+	assert.Equal(t, []model.FeedbackTopic{model.FeedbackTopicTeaching, model.FeedbackTopicMaterials}, analyses[0].Topics)
+	assert.Equal(t, "feedback-newer", analyses[1].FeedbackID)
+
+	analyses, err = analysisRepository.GetByCourse(ctx, "course789")
+	assert.NoError(t, err)
+	assert.NotNil(t, analyses)
+	assert.Empty(t, analyses)
+
+	// Saving nothing is not an error
+	err = analysisRepository.SaveMany(ctx, nil)
+	assert.NoError(t, err)
+}
+
+func TestSaveFeedbackAnalysesReplacesThePreviousAnalysis(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("course_feedback_analysis")
+	})
+
+	analysisRepository := repository.NewFeedbackAnalysisRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+	now := time.Now()
+
+	err := analysisRepository.SaveMany(ctx, []model.FeedbackAnalysis{
+		createTestFeedbackAnalysis("feedback123", "course123", model.FeedbackSentimentNeutral, now),
+	})
+	assert.NoError(t, err)
+
+	// The AI analysis replaces the keyword one of the same feedback
+	analysis := createTestFeedbackAnalysis("feedback123", "course123", model.FeedbackSentimentNegative, now, model.FeedbackTopicExams)
+	analysis.Method = model.FeedbackAnalysisMethodAI
+	analysis.PromptVersion = "v1"
+	analysis.SentimentScore = -0.8
+	err = analysisRepository.SaveMany(ctx, []model.FeedbackAnalysis{
+		analysis,
+		createTestFeedbackAnalysis("feedback456", "course123", model.FeedbackSentimentPositive, now.Add(time.Minute)),
+	})
+	assert.NoError(t, err)
+
+	analyses, err := analysisRepository.GetByCourse(ctx, "course123")
+	assert.NoError(t, err)
+	assert.Len(t, analyses, 2)
+	assert.Equal(t, "feedback123", analyses[0].FeedbackID)
+	assert.Equal(t, model.FeedbackSentimentNegative, analyses[0].Sentiment)
+	assert.Equal(t, -0.8, analyses[0].SentimentScore)
+	assert.Equal(t, []model.FeedbackTopic{model.FeedbackTopicExams}, analyses[0].Topics)
+	assert.Equal(t, model.FeedbackAnalysisMethodAI, analyses[0].Method)
+	assert.Equal(t, "v1", analyses[0].PromptVersion)
+	assert.Equal(t, "feedback456", analyses[1].FeedbackID)
+}
@@ -0,0 +1,85 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestCreateAndGetFile(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("files")
+	})
+
+	fileRepository := repository.NewFileRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	file := model.StoredFile{
+		Name:        "tp1.pdf",
+		ContentType: "application/pdf",
+		Size:        2048,
+		Checksum:    "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08",
+		StorageKey:  "course123/submission/tp1.pdf",
+		Purpose:     model.FilePurposeSubmission,
+		CourseID:    "course123",
+		OwnerUUID:   "student123",
+		CreatedAt:   time.Now(),
+	}
+	err := fileRepository.Create(ctx, &file)
+	assert.NoError(t, err)
+	assert.False(t, file.ID.IsZero())
+
+	found, err := fileRepository.GetByID(ctx, file.ID.Hex())
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "tp1.pdf", found.Name)
+	assert.Equal(t, "application/pdf", found.ContentType)
+	assert.Equal(t, int64(2048), found.Size)
+	assert.Equal(t, file.Checksum, found.Checksum)
+	// The storage key is not serialized to JSON but it is kept in the database
+	assert.Equal(t, "course123/submission/tp1.pdf", found.StorageKey)
+	assert.Equal(t, model.FilePurposeSubmission, found.Purpose)
+	assert.Equal(t, "student123", found.OwnerUUID)
+}
+
+func TestGetFileNotFound(t *testing.T) {
+	fileRepository := repository.NewFileRepository(dbSetup.Client, dbSetup.DBName)
+
+	found, err := fileRepository.GetByID(context.TODO(), primitive.NewObjectID().Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	// An id that is not a file id is a missing file, not an error
+	found, err = fileRepository.GetByID(context.TODO(), "invalid-id")
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+}
+
+func TestDeleteFile(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("files")
+	})
+
+	fileRepository := repository.NewFileRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	file := model.StoredFile{Name: "notes.txt", StorageKey: "course123/module_resource/notes.txt", Purpose: model.FilePurposeModuleResource, CreatedAt: time.Now()}
+	err := fileRepository.Create(ctx, &file)
+	assert.NoError(t, err)
+
+	err = fileRepository.Delete(ctx, file.ID.Hex())
+	assert.NoError(t, err)
+
+	found, err := fileRepository.GetByID(ctx, file.ID.Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	err = fileRepository.Delete(ctx, "invalid-id")
+	assert.Error(t, err)
+}
@@ -0,0 +1,195 @@
+package repository_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/repository"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func createTestBankQuestion(courseID, text string, difficulty model.QuestionDifficulty, tags ...string) model.BankQuestion {
+	return model.BankQuestion{
+		CourseID: courseID,
+		Question: model.Question{
+			ID:             primitive.NewObjectID().Hex(),
+			Text:           text,
+			Type:           model.QuestionTypeMultipleChoice,
+			Options:        []string{"3", "4", "5"},
+			CorrectAnswers: []string{"4"},
+			Points:         10,
+		},
+		Tags:       tags,
+		Difficulty: difficulty,
+		CreatedBy:  "teacher-123",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+}
+
+// createTestBank creates the questions of the bank of course123 and one of another course
+func createTestBank(t *testing.T, questionBankRepository *repository.QuestionBankRepository) {
+	for _, question := range []model.BankQuestion{
+		createTestBankQuestion("course123", "Easy algebra", model.QuestionDifficultyEasy, "algebra"),
+		createTestBankQuestion("course123", "Hard algebra", model.QuestionDifficultyHard, "algebra"),
+		createTestBankQuestion("course123", "Easy algebra and geometry", model.QuestionDifficultyEasy, "algebra", "geometry"),
+		createTestBankQuestion("course123", "Geometry", model.QuestionDifficultyMedium, "geometry"),
+		createTestBankQuestion("course456", "Algebra of another course", model.QuestionDifficultyEasy, "algebra"),
+	} {
+		if err := questionBankRepository.Create(context.TODO(), &question); err != nil {
+			t.Fatalf("Failed to create bank question: %v", err)
+		}
+	}
+}
+
+func TestCreateAndGetBankQuestion(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("question_bank")
+	})
+
+	questionBankRepository := repository.NewQuestionBankRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	question := createTestBankQuestion("course123", "What is 2+2?", model.QuestionDifficultyEasy, "arithmetic")
+	err := questionBankRepository.Create(ctx, &question)
+	assert.NoError(t, err)
+	assert.False(t, question.ID.IsZero())
+
+	found, err := questionBankRepository.GetByID(ctx, question.ID.Hex())
+	assert.NoError(t, err)
+	assert.NotNil(t, found)
+	assert.Equal(t, "course123", found.CourseID)
+	assert.Equal(t, "What is 2+2?", found.Question.Text)
+	assert.Equal(t, []string{"4"}, found.Question.CorrectAnswers)
+	assert.Equal(t, []string{"arithmetic"}, found.Tags)
+	assert.Equal(t, model.QuestionDifficultyEasy, found.Difficulty)
+
+	missing, err := questionBankRepository.GetByID(ctx, primitive.NewObjectID().Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, missing)
+
+	_, err = questionBankRepository.GetByID(ctx, "invalid-id")
+	assert.Error(t, err)
+}
+
+func TestUpdateBankQuestion(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("question_bank")
+	})
+
+	questionBankRepository := repository.NewQuestionBankRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	question := createTestBankQuestion("course123", "What is 2+2?", model.QuestionDifficultyEasy, "arithmetic")
+	err := questionBankRepository.Create(ctx, &question)
+	assert.NoError(t, err)
+
+	question.Question.Text = "What is 2+3?"
+	question.Question.CorrectAnswers = []string{"5"}
+	question.Tags = []string{"arithmetic", "sums"}
+	question.Difficulty = model.QuestionDifficultyMedium
+	err = questionBankRepository.Update(ctx, &question)
+	assert.NoError(t, err)
+
+	found, err := questionBankRepository.GetByID(ctx, question.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, "What is 2+3?", found.Question.Text)
+	assert.Equal(t, []string{"5"}, found.Question.CorrectAnswers)
+	assert.Equal(t, []string{"arithmetic", "sums"}, found.Tags)
+	assert.Equal(t, model.QuestionDifficultyMedium, found.Difficulty)
+}
+
+func TestGetBankQuestionsByCourse(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("question_bank")
+	})
+
+	questionBankRepository := repository.NewQuestionBankRepository(dbSetup.Client, dbSetup.DBName)
+	createTestBank(t, questionBankRepository)
+
+	tests := []struct {
+		name          string
+		courseID      string
+		tag           string
+		difficulty    model.QuestionDifficulty
+		expectedTexts []string
+	}{
+		{name: "whole bank", courseID: "course123", expectedTexts: []string{"Easy algebra", "Hard algebra", "Easy algebra and geometry", "Geometry"}},
+		{name: "by tag", courseID: "course123", tag: "geometry", expectedTexts: []string{"Easy algebra and geometry", "Geometry"}},
+		{name: "by difficulty", courseID: "course123", difficulty: model.QuestionDifficultyEasy, expectedTexts: []string{"Easy algebra", "Easy algebra and geometry"}},
+		{name: "by tag and difficulty", courseID: "course123", tag: "algebra", difficulty: model.QuestionDifficultyHard, expectedTexts: []string{"Hard algebra"}},
+		{name: "course without bank", courseID: "course789", expectedTexts: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			questions, err := questionBankRepository.GetByCourse(context.TODO(), tt.courseID, tt.tag, tt.difficulty)
+			assert.NoError(t, err)
+			texts := []string{}
+			for _, question := range questions {
+				texts = append(texts, question.Question.Text)
+			}
+			assert.ElementsMatch(t, tt.expectedTexts, texts)
+		})
+	}
+}
+
+func TestGetRandomBankQuestionsByTag(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("question_bank")
+	})
+
+	questionBankRepository := repository.NewQuestionBankRepository(dbSetup.Client, dbSetup.DBName)
+	createTestBank(t, questionBankRepository)
+	ctx := context.TODO()
+
+	// The draw never repeats a question nor takes one of another course or tag
+	drawn, err := questionBankRepository.GetRandomByTag(ctx, "course123", "algebra", "", 2)
+	assert.NoError(t, err)
+	assert.Len(t, drawn, 2)
+	assert.NotEqual(t, drawn[0].ID, drawn[1].ID)
+	for _, question := range drawn {
+		assert.Equal(t, "course123", question.CourseID)
+		assert.Contains(t, question.Tags, "algebra")
+	}
+
+	// Asking for more questions than the bank has gives all of them
+	all, err := questionBankRepository.GetRandomByTag(ctx, "course123", "algebra", "", 10)
+	assert.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	easy, err := questionBankRepository.GetRandomByTag(ctx, "course123", "algebra", model.QuestionDifficultyEasy, 10)
+	assert.NoError(t, err)
+	assert.Len(t, easy, 2)
+
+	none, err := questionBankRepository.GetRandomByTag(ctx, "course123", "calculus", "", 2)
+	assert.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestDeleteBankQuestion(t *testing.T) {
+	t.Cleanup(func() {
+		dbSetup.CleanupCollection("question_bank")
+	})
+
+	questionBankRepository := repository.NewQuestionBankRepository(dbSetup.Client, dbSetup.DBName)
+	ctx := context.TODO()
+
+	question := createTestBankQuestion("course123", "What is 2+2?", model.QuestionDifficultyEasy, "arithmetic")
+	err := questionBankRepository.Create(ctx, &question)
+	assert.NoError(t, err)
+
+	err = questionBankRepository.Delete(ctx, question.ID.Hex())
+	assert.NoError(t, err)
+
+	found, err := questionBankRepository.GetByID(ctx, question.ID.Hex())
+	assert.NoError(t, err)
+	assert.Nil(t, found)
+
+	err = questionBankRepository.Delete(ctx, "invalid-id")
+	assert.Error(t, err)
+}
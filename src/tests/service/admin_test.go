@@ -0,0 +1,288 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// AdminMockCourseRepository returns the configured course for any id but "missing-course"
+type AdminMockCourseRepository struct {
+	MockCourseRepository
+	course *model.Course
+}
+
+func (m *AdminMockCourseRepository) GetCourseById(id string) (*model.Course, error) {
+	if id == "missing-course" {
+		return nil, nil
+	}
+	return m.course, nil
+}
+
+// AdminMockEnrollmentRepository has "enrolled-student" actively enrolled and records the drops
+type AdminMockEnrollmentRepository struct {
+	MockEnrollmentRepository
+	dropped []string
+}
+
+func (m *AdminMockEnrollmentRepository) GetEnrollmentByStudentIdAndCourseId(studentID, courseID string) (*model.Enrollment, error) {
+	if studentID != "enrolled-student" {
+		return nil, nil
+	}
+	return &model.Enrollment{StudentID: studentID, CourseID: courseID, Status: model.EnrollmentStatusActive}, nil
+}
+
+func (m *AdminMockEnrollmentRepository) DisapproveStudent(studentID, courseID, reason string) error {
+	m.dropped = append(m.dropped, studentID)
+	return nil
+}
+
+// MockBlockedUserRepository keeps the blocked users in memory
+type MockBlockedUserRepository struct {
+	blocked map[string]model.BlockedUser
+}
+
+func (m *MockBlockedUserRepository) Block(ctx context.Context, blockedUser *model.BlockedUser) error {
+	if m.blocked == nil {
+		m.blocked = make(map[string]model.BlockedUser)
+	}
+	m.blocked[blockedUser.UserUUID] = *blockedUser
+	return nil
+}
+
+func (m *MockBlockedUserRepository) Unblock(ctx context.Context, userUUID string) (bool, error) {
+	if _, ok := m.blocked[userUUID]; !ok {
+		return false, nil
+	}
+	delete(m.blocked, userUUID)
+	return true, nil
+}
+
+func (m *MockBlockedUserRepository) GetByUser(ctx context.Context, userUUID string) (*model.BlockedUser, error) {
+	blocked, ok := m.blocked[userUUID]
+	if !ok {
+		return nil, nil
+	}
+	return &blocked, nil
+}
+
+func (m *MockBlockedUserRepository) GetAll(ctx context.Context) ([]model.BlockedUser, error) {
+	blocked := []model.BlockedUser{}
+	for _, user := range m.blocked {
+		blocked = append(blocked, user)
+	}
+	return blocked, nil
+}
+
+// MockAdminActionLogRepository keeps the actions in memory, or fails to store them
+type MockAdminActionLogRepository struct {
+	logs []model.AdminActionLog
+	err  error
+}
+
+func (m *MockAdminActionLogRepository) Create(ctx context.Context, log *model.AdminActionLog) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.logs = append(m.logs, *log)
+	return nil
+}
+
+func (m *MockAdminActionLogRepository) GetLogs(ctx context.Context, filter schemas.AdminActionLogFilter) ([]model.AdminActionLog, error) {
+	return m.logs, nil
+}
+
+type adminTestSetup struct {
+	service     *service.AdminService
+	course      *model.Course
+	enrollments *AdminMockEnrollmentRepository
+	logs        *MockAdminActionLogRepository
+}
+
+func newAdminTestSetup() adminTestSetup {
+	course := &model.Course{
+		Title:       "Go",
+		TeacherUUID: "teacher123",
+		TeacherName: "Teacher",
+		AuxTeachers: []string{"aux-teacher1"},
+	}
+	enrollments := &AdminMockEnrollmentRepository{}
+	logs := &MockAdminActionLogRepository{}
+	adminService := service.NewAdminService(&AdminMockCourseRepository{course: course}, enrollments, &MockForumRepository{}, &MockBlockedUserRepository{}, logs)
+	return adminTestSetup{service: adminService, course: course, enrollments: enrollments, logs: logs}
+}
+
+func TestSuspendCourseIsRecorded(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	course, err := setup.service.SuspendCourse(context.Background(), "course123", "admin1", "Spam")
+
+	assert.NoError(t, err)
+	assert.Equal(t, model.CourseStatusSuspended, course.Status)
+	assert.Len(t, setup.logs.logs, 1)
+	assert.Equal(t, model.AdminActionSuspendCourse, setup.logs.logs[0].Action)
+	assert.Equal(t, "admin1", setup.logs.logs[0].AdminUUID)
+	assert.Equal(t, "course123", setup.logs.logs[0].CourseID)
+	assert.Equal(t, "Spam", setup.logs.logs[0].Reason)
+	assert.Equal(t, "Status changed from active to suspended", setup.logs.logs[0].Details)
+}
+
+func TestChangeCourseStatusToTheSameStatus(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	_, err := setup.service.ReactivateCourse(context.Background(), "course123", "admin1", "Mistake")
+
+	assert.ErrorIs(t, err, service.ErrInvalidCourseStatus)
+	assert.Empty(t, setup.logs.logs)
+}
+
+func TestArchiveCourseWithoutReason(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	_, err := setup.service.ArchiveCourse(context.Background(), "course123", "admin1", "  ")
+
+	assert.ErrorIs(t, err, service.ErrReasonRequired)
+	assert.Equal(t, model.CourseStatus(""), setup.course.Status)
+	assert.Empty(t, setup.logs.logs)
+}
+
+func TestArchiveMissingCourse(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	_, err := setup.service.ArchiveCourse(context.Background(), "missing-course", "admin1", "Old")
+
+	assert.ErrorIs(t, err, service.ErrCourseNotFound)
+}
+
+func TestAdminActionNotRecorded(t *testing.T) {
+	setup := newAdminTestSetup()
+	setup.logs.err = errors.New("database down")
+
+	_, err := setup.service.ArchiveCourse(context.Background(), "course123", "admin1", "Old")
+
+	assert.ErrorContains(t, err, "the archive_course action was done but couldn't be recorded")
+}
+
+func TestReassignTeacher(t *testing.T) {
+	setup := newAdminTestSetup()
+	request := schemas.ReassignTeacherRequest{TeacherUUID: "teacher456", TeacherName: "New Teacher", Reason: "The teacher left"}
+
+	course, err := setup.service.ReassignTeacher(context.Background(), "course123", "admin1", request)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "teacher456", course.TeacherUUID)
+	assert.Equal(t, "New Teacher", course.TeacherName)
+	assert.Len(t, setup.logs.logs, 1)
+	assert.Equal(t, "Titular teacher changed from teacher123 to teacher456", setup.logs.logs[0].Details)
+}
+
+func TestReassignTeacherInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		request schemas.ReassignTeacherRequest
+		err     error
+	}{
+		{name: "same teacher", request: schemas.ReassignTeacherRequest{TeacherUUID: "teacher123", TeacherName: "Teacher", Reason: "Reason"}, err: service.ErrInvalidTeacherReassignment},
+		{name: "enrolled as student", request: schemas.ReassignTeacherRequest{TeacherUUID: "enrolled-teacher", TeacherName: "Teacher", Reason: "Reason"}, err: service.ErrInvalidTeacherReassignment},
+		{name: "without name", request: schemas.ReassignTeacherRequest{TeacherUUID: "teacher456", TeacherName: " ", Reason: "Reason"}, err: service.ErrInvalidTeacherReassignment},
+		{name: "without reason", request: schemas.ReassignTeacherRequest{TeacherUUID: "teacher456", TeacherName: "Teacher"}, err: service.ErrReasonRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setup := newAdminTestSetup()
+
+			_, err := setup.service.ReassignTeacher(context.Background(), "course123", "admin1", tt.request)
+
+			assert.ErrorIs(t, err, tt.err)
+			assert.Equal(t, "teacher123", setup.course.TeacherUUID)
+		})
+	}
+}
+
+func TestAdminUnenrollStudent(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	err := setup.service.UnenrollStudent(context.Background(), "course123", "enrolled-student", "admin1", "Abusive messages")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"enrolled-student"}, setup.enrollments.dropped)
+	assert.Equal(t, "enrolled-student", setup.logs.logs[0].TargetID)
+}
+
+func TestAdminUnenrollStudentNotEnrolled(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	err := setup.service.UnenrollStudent(context.Background(), "course123", "other-student", "admin1", "Abusive messages")
+
+	assert.ErrorIs(t, err, service.ErrStudentNotEnrolled)
+	assert.Empty(t, setup.enrollments.dropped)
+}
+
+func TestAdminRemoveForumContent(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	err := setup.service.RemoveForumQuestion(context.Background(), "question-with-answers", "admin1", "Offensive")
+	assert.NoError(t, err)
+
+	err = setup.service.RemoveForumAnswer(context.Background(), "question-with-answers", "answer-123", "admin1", "Offensive")
+	assert.NoError(t, err)
+
+	assert.Len(t, setup.logs.logs, 2)
+	assert.Equal(t, "course-123", setup.logs.logs[0].CourseID)
+	assert.Equal(t, "Question by author-123: Test Question", setup.logs.logs[0].Details)
+	assert.Equal(t, "answer-123", setup.logs.logs[1].TargetID)
+}
+
+func TestAdminRemoveMissingForumContent(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	err := setup.service.RemoveForumQuestion(context.Background(), "non-existent-question", "admin1", "Offensive")
+	assert.ErrorIs(t, err, service.ErrForumQuestionNotFound)
+
+	err = setup.service.RemoveForumAnswer(context.Background(), "question-with-answers", "non-existent-answer", "admin1", "Offensive")
+	assert.ErrorIs(t, err, service.ErrForumAnswerNotFound)
+
+	assert.Empty(t, setup.logs.logs)
+}
+
+func TestBlockAndUnblockUser(t *testing.T) {
+	setup := newAdminTestSetup()
+	ctx := context.Background()
+
+	blocked, err := setup.service.BlockUser(ctx, "student123", "admin1", "Harassment")
+	assert.NoError(t, err)
+	assert.Equal(t, "admin1", blocked.BlockedBy)
+
+	isBlocked, err := setup.service.IsBlocked("student123")
+	assert.NoError(t, err)
+	assert.True(t, isBlocked)
+
+	err = setup.service.UnblockUser(ctx, "student123", "admin1", "Appeal accepted")
+	assert.NoError(t, err)
+
+	isBlocked, err = setup.service.IsBlocked("student123")
+	assert.NoError(t, err)
+	assert.False(t, isBlocked)
+
+	err = setup.service.UnblockUser(ctx, "student123", "admin1", "Again")
+	assert.ErrorIs(t, err, service.ErrUserNotBlocked)
+
+	assert.Len(t, setup.logs.logs, 2)
+	assert.Equal(t, model.AdminActionBlockUser, setup.logs.logs[0].Action)
+	assert.Equal(t, model.AdminActionUnblockUser, setup.logs.logs[1].Action)
+}
+
+func TestAdminCannotBlockThemselves(t *testing.T) {
+	setup := newAdminTestSetup()
+
+	_, err := setup.service.BlockUser(context.Background(), "admin1", "admin1", "Testing")
+
+	assert.ErrorIs(t, err, service.ErrInvalidUserBlock)
+}
@@ -0,0 +1,183 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// promptCapturingServer answers every chat completion with content and keeps the last prompt it received
+func promptCapturingServer(t *testing.T, content string, prompt *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			Messages []struct {
+				Content string `json:"content"`
+			} `json:"messages"`
+		}
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		*prompt = request.Messages[0].Content
+		_, _ = w.Write(chatCompletion(content))
+	}))
+}
+
+func TestPromptsAreWrittenInTheLanguageOfTheCourse(t *testing.T) {
+	tests := []struct {
+		language string
+		expected string
+	}{
+		{language: "", expected: "El texto debe ser en español."},
+		{language: model.CourseLanguageSpanish, expected: "El texto debe ser en español."},
+		{language: model.CourseLanguageEnglish, expected: "The text must be in English."},
+		{language: model.CourseLanguagePortuguese, expected: "O texto deve ser em português."},
+		{language: "fr", expected: "El texto debe ser en español."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.language, func(t *testing.T) {
+			var prompt string
+			server := promptCapturingServer(t, "Resumen", &prompt)
+			defer server.Close()
+
+			provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+			ctx := ai.WithScope(context.Background(), ai.Scope{Language: test.language})
+			feedbacks := []*model.CourseFeedback{{Score: 4, FeedbackType: model.FeedbackTypePositive, Feedback: "Muy claro"}}
+
+			_, err := provider.SummarizeCourseFeedbacks(ctx, feedbacks)
+			assert.NoError(t, err)
+			assert.Contains(t, prompt, test.expected)
+			assert.Contains(t, prompt, "Feedback: Muy claro\n")
+		})
+	}
+}
+
+func TestCorrectionPromptListsQuestionsAndRubric(t *testing.T) {
+	var prompt string
+	server := promptCapturingServer(t, `{"ai_score": 7, "ai_feedback": "Good", "needs_manual_review": false, "questions": [
+		{"question_id": "q1", "score": 5, "feedback": "Right"}, {"question_id": "q2", "score": 2, "feedback": "Partial"}]}`, &prompt)
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+	assignment, submission := correctionTestData()
+	assignment.Questions[1].Rubric = &model.Rubric{Criteria: []model.RubricCriterion{{
+		ID: "c1", Title: "Precisión", Levels: []model.RubricLevel{
+			{ID: "l1", Title: "Excelente", Points: 2},
+			{ID: "l2", Title: "Insuficiente", Description: "Definición vaga", Points: 1},
+		},
+	}}}
+	ctx := ai.WithScope(context.Background(), ai.Scope{Language: model.CourseLanguageEnglish})
+
+	correction, err := provider.CorrectSubmission(ctx, assignment, submission)
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "Maximum Score: 10.00\n")
+	assert.Contains(t, prompt, "ID: q1\nQuestion: ¿Qué es Go?\nType: text\nPoints: 6.00\nStudent Answer: Un lenguaje\n")
+	// The levels are scaled to the points of the question
+	assert.Contains(t, prompt, "Rubric:\n- Criterion c1: Precisión\n  - Level l1: Excelente, 4.00 points\n  - Level l2: Insuficiente, 2.00 points (Definición vaga)\n")
+	assert.Equal(t, ai.PromptVersion(ai.OperationSubmissionCorrection, model.CourseLanguageEnglish), correction.PromptVersion)
+}
+
+func TestForumSuggestionPromptUsesModulesAndAcceptedAnswers(t *testing.T) {
+	var prompt string
+	server := promptCapturingServer(t, "  Usá close(ch) desde el emisor.\n", &prompt)
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+	accepted, pending := "a1", "a2"
+	resolved := []model.ForumQuestion{
+		{Title: "¿Qué es un channel?", Description: "No entiendo", AcceptedAnswerID: &accepted, Answers: []model.ForumAnswer{{ID: accepted, Content: "Un tubo entre goroutines"}}},
+		{Title: "Sin aprobar", AcceptedAnswerID: &pending, Answers: []model.ForumAnswer{{ID: pending, Content: "Borrador", Type: model.AnswerTypeAiSuggestion}}},
+	}
+	question := &model.ForumQuestion{Title: "¿Cómo cierro un channel?", Description: "Me da panic"}
+	modules := []model.Module{{Title: "Concurrencia", Description: "Goroutines y channels"}}
+
+	suggestion, err := provider.SuggestForumAnswer(context.Background(), question, modules, resolved)
+	assert.NoError(t, err)
+	assert.Equal(t, "Usá close(ch) desde el emisor.", suggestion.Content)
+	assert.Equal(t, ai.PromptVersion(ai.OperationForumAnswerSuggestion, model.CourseLanguageSpanish), suggestion.PromptVersion)
+	assert.Contains(t, prompt, "Módulo: Concurrencia\nDescripción: Goroutines y channels\n")
+	assert.Contains(t, prompt, "Pregunta: ¿Qué es un channel?\nNo entiendo\nRespuesta aceptada: Un tubo entre goroutines\n")
+	assert.NotContains(t, prompt, "Borrador")
+	assert.Contains(t, prompt, "Pregunta: ¿Cómo cierro un channel?\nMe da panic\n")
+}
+
+func TestFeedbackAnalysisPromptNumbersTheFeedbacks(t *testing.T) {
+	feedbacks := []*model.CourseFeedback{
+		{ID: primitive.NewObjectID(), Score: 5, FeedbackType: model.FeedbackTypePositive, Feedback: "Great teacher"},
+		{ID: primitive.NewObjectID(), Score: 2, FeedbackType: model.FeedbackTypeNegative, Feedback: "Too many assignments"},
+	}
+	ctx := ai.WithScope(context.Background(), ai.Scope{Language: model.CourseLanguageEnglish})
+
+	var prompt string
+	server := promptCapturingServer(t, `{"feedbacks": [
+		{"index": 2, "sentiment": "negative", "sentiment_score": -0.6, "topics": ["assignments", "workload", "workload"]},
+		{"index": 1, "sentiment": "positive", "sentiment_score": 0.9, "topics": ["teaching"]}]}`, &prompt)
+	defer server.Close()
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+
+	analysis, err := provider.AnalyzeFeedbacks(ctx, feedbacks)
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "Feedback 2\nScore: 2\nType: NEGATIVO\nFeedback: Too many assignments\n")
+	assert.Contains(t, prompt, "- organization\n")
+	assert.Equal(t, ai.PromptVersion(ai.OperationFeedbackAnalysis, model.CourseLanguageEnglish), analysis.PromptVersion)
+	assert.Equal(t, feedbacks[0].ID.Hex(), analysis.Analyses[0].FeedbackID)
+	assert.Equal(t, model.FeedbackSentimentPositive, analysis.Analyses[0].Sentiment)
+	assert.Equal(t, []model.FeedbackTopic{model.FeedbackTopicAssignments, model.FeedbackTopicWorkload}, analysis.Analyses[1].Topics)
+	assert.Equal(t, model.FeedbackAnalysisMethodAI, analysis.Analyses[1].Method)
+
+	for _, answer := range []string{
+		`{"feedbacks": [{"index": 1, "sentiment": "positive", "sentiment_score": 0.9, "topics": []}]}`,
+		`{"feedbacks": [{"index": 1, "sentiment": "positive", "sentiment_score": 0.9, "topics": []}, {"index": 1, "sentiment": "positive", "sentiment_score": 0.9, "topics": []}]}`,
+		`{"feedbacks": [{"index": 1, "sentiment": "happy", "sentiment_score": 0.9, "topics": []}, {"index": 2, "sentiment": "negative", "sentiment_score": -0.6, "topics": []}]}`,
+		`{"feedbacks": [{"index": 1, "sentiment": "positive", "sentiment_score": 3, "topics": []}, {"index": 2, "sentiment": "negative", "sentiment_score": -0.6, "topics": []}]}`,
+		`{"feedbacks": [{"index": 1, "sentiment": "positive", "sentiment_score": 0.9, "topics": ["food"]}, {"index": 2, "sentiment": "negative", "sentiment_score": -0.6, "topics": []}]}`,
+	} {
+		invalidServer := promptCapturingServer(t, answer, &prompt)
+		provider := ai.NewOpenAIProvider(invalidServer.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+		_, err := provider.AnalyzeFeedbacks(ctx, feedbacks)
+		assert.ErrorIs(t, err, ai.ErrInvalidFeedbackAnalysis, answer)
+		invalidServer.Close()
+	}
+}
+
+func TestPromptVersions(t *testing.T) {
+	spanish := ai.PromptVersion(ai.OperationSubmissionCorrection, model.CourseLanguageSpanish)
+	english := ai.PromptVersion(ai.OperationSubmissionCorrection, model.CourseLanguageEnglish)
+
+	assert.Regexp(t, `^es/submission_correction@[0-9a-f]{8}$`, spanish)
+	assert.Regexp(t, `^en/submission_correction@[0-9a-f]{8}$`, english)
+	// Languages without templates use the Spanish ones
+	assert.Equal(t, spanish, ai.PromptVersion(ai.OperationSubmissionCorrection, "fr"))
+	assert.Equal(t, spanish, ai.PromptVersion(ai.OperationSubmissionCorrection, ""))
+
+	for _, operation := range []string{ai.OperationCourseFeedbackSummary, ai.OperationStudentFeedbackSummary, ai.OperationSubmissionFeedbackSummary, ai.OperationForumAnswerSuggestion, ai.OperationFeedbackAnalysis} {
+		for _, language := range []string{model.CourseLanguageSpanish, model.CourseLanguageEnglish, model.CourseLanguagePortuguese} {
+			assert.Regexp(t, "^"+language+"/"+operation+"@", ai.PromptVersion(operation, language))
+		}
+	}
+}
+
+func TestPromptVersionIsRecordedInTheUsage(t *testing.T) {
+	var prompt string
+	server := promptCapturingServer(t, "Resumen", &prompt)
+	defer server.Close()
+
+	usageRepo := &AiUsageMockRepository{}
+	usageService := service.NewAiUsageService(usageRepo, &AiCacheMockRepository{}, service.AiUsageSettings{})
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{Usage: usageService})
+	ctx := ai.WithScope(context.Background(), ai.Scope{Language: model.CourseLanguagePortuguese})
+
+	_, err := provider.SummarizeSubmissionFeedback(ctx, nil, "Falta la conclusión")
+	assert.NoError(t, err)
+	assert.Contains(t, prompt, "Pontuação: Não atribuída\nFeedback: Falta la conclusión\n")
+	assert.Len(t, usageRepo.usages, 1)
+	assert.Equal(t, ai.PromptVersion(ai.OperationSubmissionFeedbackSummary, model.CourseLanguagePortuguese), usageRepo.usages[0].PromptVersion)
+}
@@ -0,0 +1,289 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/config"
+	"courses-service/src/model"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewProviderSelectsBackend(t *testing.T) {
+	provider, err := ai.NewProvider(&config.Config{AiProvider: ai.ProviderFake}, ai.Hooks{})
+	assert.NoError(t, err)
+	assert.IsType(t, &ai.FakeProvider{}, provider)
+
+	// The environment doesn't choose the provider, only the config does
+	provider, err = ai.NewProvider(&config.Config{Environment: "test", AiProvider: ai.ProviderOpenAI}, ai.Hooks{})
+	assert.NoError(t, err)
+	assert.IsType(t, &ai.OpenAIProvider{}, provider)
+
+	_, err = ai.NewProvider(&config.Config{AiProvider: "unknown"}, ai.Hooks{})
+	assert.Error(t, err)
+}
+
+func TestNewSettingsReadsConfig(t *testing.T) {
+	settings := ai.NewSettings(&config.Config{}, ai.ProviderGemini)
+	assert.Equal(t, "gemini-2.0-flash", settings.Model)
+	assert.Nil(t, settings.Temperature)
+	assert.Equal(t, 60*time.Second, settings.Timeout)
+
+	settings = ai.NewSettings(&config.Config{AiModel: "llama3", AiTemperature: "0.2", AiTimeoutSeconds: "5"}, ai.ProviderOpenAI)
+	assert.Equal(t, "llama3", settings.Model)
+	assert.Equal(t, 0.2, *settings.Temperature)
+	assert.Equal(t, 5*time.Second, settings.Timeout)
+}
+
+// chatCompletion builds an OpenAI chat completion answering with content
+func chatCompletion(content string) []byte {
+	response, _ := json.Marshal(map[string]any{
+		"choices": []map[string]any{{"message": map[string]string{"role": "assistant", "content": content}}},
+	})
+	return response
+}
+
+func correctionTestData() (*model.Assignment, *model.Submission) {
+	assignment := &model.Assignment{TotalPoints: 10, Questions: []model.Question{
+		{ID: "q1", Text: "¿Qué es Go?", Type: model.QuestionTypeText, Points: 6},
+		{ID: "q2", Text: "¿Qué es una goroutine?", Type: model.QuestionTypeText, Points: 4},
+	}}
+	submission := &model.Submission{Answers: []model.Answer{
+		{QuestionID: "q1", Content: "Un lenguaje"},
+		{QuestionID: "q2", Content: "Un hilo liviano"},
+	}}
+	return assignment, submission
+}
+
+func TestOpenAIProviderCorrectsSubmission(t *testing.T) {
+	var request struct {
+		Model          string   `json:"model"`
+		Temperature    *float64 `json:"temperature"`
+		ResponseFormat struct {
+			Type       string `json:"type"`
+			JSONSchema struct {
+				Strict bool `json:"strict"`
+				Schema struct {
+					Required []string `json:"required"`
+				} `json:"schema"`
+			} `json:"json_schema"`
+		} `json:"response_format"`
+		Messages []struct {
+			Content string `json:"content"`
+		} `json:"messages"`
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v1/chat/completions", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		_, _ = w.Write(chatCompletion("```json\n" + `{"ai_score": 7, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 5, "feedback": "Correcto"},
+			{"question_id": "q2", "score": 2, "feedback": "Incompleto"}]}` + "\n```"))
+	}))
+	defer server.Close()
+
+	temperature := 0.1
+	provider := ai.NewOpenAIProvider(server.URL+"/v1/", "secret", ai.Settings{Model: "llama3", Temperature: &temperature, Timeout: time.Second}, ai.Hooks{})
+	assignment, submission := correctionTestData()
+
+	correction, err := provider.CorrectSubmission(context.Background(), assignment, submission)
+	assert.NoError(t, err)
+	assert.Equal(t, 7.0, correction.AIScore)
+	assert.Equal(t, "Bien", correction.AIFeedback)
+	assert.Len(t, correction.Questions, 2)
+	assert.Equal(t, 2.0, correction.Questions[1].Score)
+	assert.Equal(t, "llama3", request.Model)
+	assert.Equal(t, 0.1, *request.Temperature)
+	assert.Equal(t, "json_schema", request.ResponseFormat.Type)
+	assert.True(t, request.ResponseFormat.JSONSchema.Strict)
+	assert.Contains(t, request.ResponseFormat.JSONSchema.Schema.Required, "questions")
+	assert.Contains(t, request.Messages[0].Content, "¿Qué es Go?")
+}
+
+func TestOpenAIProviderRejectsInvalidCorrections(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "not JSON", content: "La entrega está muy bien, 7 puntos"},
+		{name: "text around the JSON", content: `Corrección: {"ai_score": 7, "ai_feedback": "Bien", "needs_manual_review": false, "questions": []}`},
+		{name: "missing fields", content: `{"ai_score": 7, "ai_feedback": "Bien"}`},
+		{name: "unknown fields", content: `{"ai_score": 7, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [], "grade": "A"}`},
+		{name: "score above the total", content: `{"ai_score": 12, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 6, "feedback": "Bien"}, {"question_id": "q2", "score": 6, "feedback": "Bien"}]}`},
+		{name: "question above its points", content: `{"ai_score": 7, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 2, "feedback": "Bien"}, {"question_id": "q2", "score": 5, "feedback": "Bien"}]}`},
+		{name: "negative score", content: `{"ai_score": 4, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 5, "feedback": "Bien"}, {"question_id": "q2", "score": -1, "feedback": "Mal"}]}`},
+		{name: "missing question", content: `{"ai_score": 5, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 5, "feedback": "Bien"}]}`},
+		{name: "unknown question", content: `{"ai_score": 7, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 5, "feedback": "Bien"}, {"question_id": "q2", "score": 1, "feedback": "Bien"}, {"question_id": "q3", "score": 1, "feedback": "Bien"}]}`},
+		{name: "breakdown doesn't add up", content: `{"ai_score": 9, "ai_feedback": "Bien", "needs_manual_review": false, "questions": [
+			{"question_id": "q1", "score": 5, "feedback": "Bien"}, {"question_id": "q2", "score": 2, "feedback": "Bien"}]}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write(chatCompletion(tt.content))
+			}))
+			defer server.Close()
+
+			provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+			assignment, submission := correctionTestData()
+
+			correction, err := provider.CorrectSubmission(context.Background(), assignment, submission)
+			assert.ErrorIs(t, err, ai.ErrInvalidCorrection)
+			assert.Nil(t, correction)
+		})
+	}
+}
+
+func TestOpenAIProviderReturnsErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "overloaded", http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+	_, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.ErrorContains(t, err, "status 503")
+
+	release := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer slow.Close()
+	defer close(release)
+
+	provider = ai.NewOpenAIProvider(slow.URL, "", ai.Settings{Model: "llama3", Timeout: 50 * time.Millisecond}, ai.Hooks{})
+	_, err = provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.ErrorContains(t, err, "deadline exceeded")
+}
+
+func TestFakeProviderIsDeterministic(t *testing.T) {
+	provider := ai.NewFakeProvider()
+	assignment := &model.Assignment{TotalPoints: 20, Questions: []model.Question{
+		{ID: "q1", Type: model.QuestionTypeText, Points: 10},
+		{ID: "q2", Type: model.QuestionTypeText, Points: 10},
+	}}
+
+	first, err := provider.CorrectSubmission(context.Background(), assignment, &model.Submission{})
+	assert.NoError(t, err)
+	second, _ := provider.CorrectSubmission(context.Background(), assignment, &model.Submission{})
+	assert.Equal(t, first, second)
+	assert.Equal(t, 16.0, first.AIScore)
+	assert.Len(t, first.Questions, 2)
+	assert.Equal(t, 8.0, first.Questions[0].Score)
+}
+
+func TestOpenAIProviderRetriesTransientErrors(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Resumen"}}]}`))
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second, MaxRetries: 2, RetryBaseDelay: time.Millisecond, BreakerThreshold: 3}, ai.Hooks{})
+	summary, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Resumen", summary)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestOpenAIProviderDoesNotRetryRejectedRequests(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, "invalid model", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second, MaxRetries: 2, RetryBaseDelay: time.Millisecond, BreakerThreshold: 1}, ai.Hooks{})
+	for range 2 {
+		_, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+		assert.ErrorContains(t, err, "status 400")
+		assert.NotErrorIs(t, err, ai.ErrUnavailable)
+	}
+	// Rejected requests don't open the circuit breaker
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestCircuitBreakerOpensAfterFailures(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Resumen"}}]}`))
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second, BreakerThreshold: 2, BreakerCooldown: 100 * time.Millisecond}, ai.Hooks{})
+	for range 2 {
+		_, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+		assert.ErrorIs(t, err, ai.ErrUnavailable)
+	}
+	assert.Equal(t, int32(2), calls.Load())
+
+	// While open the model is not called
+	_, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// After the cooldown a trial request closes the breaker again
+	time.Sleep(150 * time.Millisecond)
+	healthy.Store(true)
+	summary, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Resumen", summary)
+	_, err = provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestCircuitBreakerCancelledTrialEndsHalfOpen(t *testing.T) {
+	var calls atomic.Int32
+	var healthy atomic.Bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !healthy.Load() {
+			http.Error(w, "overloaded", http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Resumen"}}]}`))
+	}))
+	defer server.Close()
+
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second, BreakerThreshold: 1, BreakerCooldown: 50 * time.Millisecond}, ai.Hooks{})
+	_, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+
+	// The trial request after the cooldown is cancelled by the caller
+	time.Sleep(80 * time.Millisecond)
+	healthy.Store(true)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = provider.SummarizeSubmissionFeedback(ctx, nil, "Buen trabajo")
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The next request is the new trial instead of finding the breaker half-open forever
+	summary, err := provider.SummarizeSubmissionFeedback(context.Background(), nil, "Buen trabajo")
+	assert.NoError(t, err)
+	assert.Equal(t, "Resumen", summary)
+}
@@ -0,0 +1,165 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AiUsageMockRepository struct {
+	usages   []*model.AiUsage
+	courses  []schemas.AiUsageSummary
+	teachers []schemas.AiUsageSummary
+}
+
+func (m *AiUsageMockRepository) Create(ctx context.Context, usage *model.AiUsage) error {
+	m.usages = append(m.usages, usage)
+	return nil
+}
+
+func (m *AiUsageMockRepository) GetUsageByCourse(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error) {
+	return m.courses, nil
+}
+
+func (m *AiUsageMockRepository) GetUsageByTeacher(ctx context.Context, from, to *time.Time) ([]schemas.AiUsageSummary, error) {
+	return m.teachers, nil
+}
+
+type AiCacheMockRepository struct {
+	entries map[string]*model.AiCacheEntry
+}
+
+func (m *AiCacheMockRepository) Get(ctx context.Context, key string) (*model.AiCacheEntry, error) {
+	return m.entries[key], nil
+}
+
+func (m *AiCacheMockRepository) Set(ctx context.Context, entry *model.AiCacheEntry) error {
+	if m.entries == nil {
+		m.entries = make(map[string]*model.AiCacheEntry)
+	}
+	m.entries[entry.Key] = entry
+	return nil
+}
+
+func (m *AiCacheMockRepository) DeleteByCourse(ctx context.Context, courseID string) error {
+	for key, entry := range m.entries {
+		if entry.CourseID == courseID {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+func (m *AiCacheMockRepository) DeleteByStudent(ctx context.Context, studentUUID string) error {
+	for key, entry := range m.entries {
+		if entry.StudentUUID == studentUUID {
+			delete(m.entries, key)
+		}
+	}
+	return nil
+}
+
+func TestFeedbackSummariesAreCachedAndAccounted(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		_, _ = w.Write([]byte(`{"choices":[{"message":{"role":"assistant","content":"Resumen"}}],"usage":{"prompt_tokens":120,"completion_tokens":30}}`))
+	}))
+	defer server.Close()
+
+	usageRepo := &AiUsageMockRepository{}
+	cacheRepo := &AiCacheMockRepository{}
+	usageService := service.NewAiUsageService(usageRepo, cacheRepo, service.AiUsageSettings{})
+	provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{Cache: usageService, Usage: usageService})
+
+	ctx := ai.WithScope(context.Background(), ai.Scope{CourseID: "course123", TeacherUUID: "teacher123"})
+	feedbacks := []*model.CourseFeedback{{StudentUUID: "student123", Score: 4, Feedback: "Muy buen curso"}}
+
+	for range 2 {
+		summary, err := provider.SummarizeCourseFeedbacks(ctx, feedbacks)
+		assert.NoError(t, err)
+		assert.Equal(t, "Resumen", summary)
+	}
+	assert.Equal(t, int32(1), calls.Load())
+
+	// Both calls are accounted to the course, only the first one used tokens
+	assert.Len(t, usageRepo.usages, 2)
+	assert.Equal(t, "course123", usageRepo.usages[0].CourseID)
+	assert.Equal(t, "teacher123", usageRepo.usages[0].TeacherUUID)
+	assert.Equal(t, ai.OperationCourseFeedbackSummary, usageRepo.usages[0].Operation)
+	assert.Equal(t, ai.ProviderOpenAI, usageRepo.usages[0].Provider)
+	assert.Equal(t, 120, usageRepo.usages[0].PromptTokens)
+	assert.Equal(t, 30, usageRepo.usages[0].CompletionTokens)
+	assert.False(t, usageRepo.usages[0].Cached)
+	assert.True(t, usageRepo.usages[1].Cached)
+	assert.Zero(t, usageRepo.usages[1].PromptTokens)
+
+	// New feedback changes the prompt, so it's never answered from the cache
+	feedbacks = append(feedbacks, &model.CourseFeedback{StudentUUID: "student456", Score: 2, Feedback: "Muy difícil"})
+	_, err := provider.SummarizeCourseFeedbacks(ctx, feedbacks)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Writing feedback drops the cached summaries of the course
+	assert.NoError(t, usageService.InvalidateCourse(context.Background(), "course123"))
+	assert.Empty(t, cacheRepo.entries)
+	_, err = provider.SummarizeCourseFeedbacks(ctx, feedbacks)
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestStudentSummaryCacheIsInvalidatedByStudent(t *testing.T) {
+	cacheRepo := &AiCacheMockRepository{}
+	usageService := service.NewAiUsageService(&AiUsageMockRepository{}, cacheRepo, service.AiUsageSettings{})
+
+	usageService.Set(ai.WithScope(context.Background(), ai.Scope{StudentUUID: "student123"}), "key1", ai.OperationStudentFeedbackSummary, "Resumen 1")
+	usageService.Set(ai.WithScope(context.Background(), ai.Scope{StudentUUID: "student456"}), "key2", ai.OperationStudentFeedbackSummary, "Resumen 2")
+
+	assert.NoError(t, usageService.InvalidateStudent(context.Background(), "student123"))
+	_, found := usageService.Get(context.Background(), "key1")
+	assert.False(t, found)
+	summary, found := usageService.Get(context.Background(), "key2")
+	assert.True(t, found)
+	assert.Equal(t, "Resumen 2", summary)
+}
+
+func TestGetAiUsageReport(t *testing.T) {
+	usageRepo := &AiUsageMockRepository{
+		courses: []schemas.AiUsageSummary{
+			{CourseID: "course123", Requests: 4, CachedRequests: 2, PromptTokens: 1000000, CompletionTokens: 200000, TotalTokens: 1200000, AvgLatencyMs: 300, MaxLatencyMs: 500},
+			{CourseID: "course456", Requests: 2, FailedRequests: 1, PromptTokens: 500000, TotalTokens: 500000, AvgLatencyMs: 600, MaxLatencyMs: 900},
+		},
+		teachers: []schemas.AiUsageSummary{
+			{TeacherUUID: "teacher123", Requests: 6, PromptTokens: 1500000, CompletionTokens: 200000, TotalTokens: 1700000},
+		},
+	}
+	usageService := service.NewAiUsageService(usageRepo, &AiCacheMockRepository{}, service.AiUsageSettings{PromptTokenPrice: 0.1, CompletionTokenPrice: 0.4})
+
+	report, err := usageService.GetUsageReport(context.Background(), schemas.AiUsageReportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, report.Totals.Requests)
+	assert.Equal(t, 2, report.Totals.CachedRequests)
+	assert.Equal(t, 1, report.Totals.FailedRequests)
+	assert.Equal(t, 1700000, report.Totals.TotalTokens)
+	assert.Equal(t, int64(900), report.Totals.MaxLatencyMs)
+	// Cached requests don't count for the latency
+	assert.InDelta(t, 450.0, report.Totals.AvgLatencyMs, 0.001)
+	assert.InDelta(t, 0.18, report.Courses[0].EstimatedCost, 1e-9)
+	assert.InDelta(t, 0.23, report.Teachers[0].EstimatedCost, 1e-9)
+	assert.InDelta(t, 0.23, report.Totals.EstimatedCost, 1e-9)
+
+	from := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, -1, 0)
+	_, err = usageService.GetUsageReport(context.Background(), schemas.AiUsageReportRequest{From: &from, To: &to})
+	assert.ErrorIs(t, err, service.ErrInvalidDateRange)
+}
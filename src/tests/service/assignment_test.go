@@ -117,6 +117,9 @@ func (m *MockAssignmentRepository) GetByID(ctx context.Context, id string) (*mod
 	if id == "error-assignment-id" {
 		return nil, errors.New("Error getting assignment by ID")
 	}
+	if id == "error-deleting-assignment" {
+		return &model.Assignment{ID: primitive.NewObjectID(), Title: "Test Assignment", CourseID: "course123"}, nil
+	}
 	return nil, nil // Assignment not found
 }
 
@@ -275,18 +278,25 @@ func (m *MockCourseService) CreateCourseFeedback(courseId string, feedbackReques
 }
 
 func (m *MockCourseService) GetCourseById(id string) (*model.Course, error) {
-	if id == "valid-course-id" {
+	if id == "valid-course-id" || id == "course123" {
 		return &model.Course{
 			ID:          primitive.NewObjectID(),
 			Title:       "Test Course",
 			Description: "Test Course Description",
 			TeacherUUID: "teacher-123",
 			Capacity:    30,
+			AuxTeachers: []string{"aux-teacher-123", "grading-aux-teacher"},
+			AuxTeacherPermissions: map[string][]model.AuxTeacherPermission{
+				"grading-aux-teacher": {model.PermissionGradeSubmissions},
+			},
 		}, nil
 	}
 	if id == "error-course-id" {
 		return nil, errors.New("Error getting course")
 	}
+	if id == "suspended-course" {
+		return &model.Course{ID: primitive.NewObjectID(), Title: "Suspended Course", Status: model.CourseStatusSuspended}, nil
+	}
 	return nil, nil // Course not found
 }
 
@@ -309,12 +319,15 @@ func (m *MockCourseService) GetCourseByTitle(title string) ([]*model.Course, err
 func (m *MockCourseService) UpdateCourse(id string, updateCourseRequest schemas.UpdateCourseRequest) (*model.Course, error) {
 	return nil, nil
 }
-func (m *MockCourseService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error) {
+func (m *MockCourseService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
 	return nil, nil
 }
 func (m *MockCourseService) RemoveAuxTeacherFromCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error) {
 	return nil, nil
 }
+func (m *MockCourseService) UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return nil, nil
+}
 func (m *MockCourseService) GetFavouriteCourses(studentId string) ([]*model.Course, error) {
 	return nil, nil
 }
@@ -342,6 +355,32 @@ func TestGetAssignments(t *testing.T) {
 	assert.Equal(t, "Test Assignment 2", assignments[1].Title)
 }
 
+// MockAssignmentRepositoryWithSuspendedCourse adds the assignments of a suspended course to the mock ones
+type MockAssignmentRepositoryWithSuspendedCourse struct {
+	MockAssignmentRepository
+}
+
+func (m *MockAssignmentRepositoryWithSuspendedCourse) GetAssignments() ([]*model.Assignment, error) {
+	assignments, err := m.MockAssignmentRepository.GetAssignments()
+	suspended := []*model.Assignment{
+		{ID: primitive.NewObjectID(), Title: "Suspended Assignment 1", CourseID: "suspended-course"},
+		{ID: primitive.NewObjectID(), Title: "Suspended Assignment 2", CourseID: "suspended-course"},
+	}
+	return append(assignments, suspended...), err
+}
+
+func TestGetAssignmentsHidesTheSuspendedCourses(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepositoryWithSuspendedCourse{}, &MockCourseService{})
+
+	assignments, err := assignmentService.GetAssignments()
+	assert.NoError(t, err)
+	titles := []string{}
+	for _, assignment := range assignments {
+		titles = append(titles, assignment.Title)
+	}
+	assert.Equal(t, []string{"Test Assignment 1", "Test Assignment 2"}, titles)
+}
+
 // Tests for GetAssignmentById
 func TestGetAssignmentById(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
@@ -407,7 +446,7 @@ func TestCreateAssignment(t *testing.T) {
 		PassingScore: 6.0,
 	}
 
-	assignment, err := assignmentService.CreateAssignment(request)
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
 	assert.NoError(t, err)
 	assert.NotNil(t, assignment)
 	assert.Equal(t, request.Title, assignment.Title)
@@ -449,7 +488,7 @@ func TestCreateAssignmentWithCourseNotFound(t *testing.T) {
 		PassingScore: 6.0,
 	}
 
-	assignment, err := assignmentService.CreateAssignment(request)
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "course not found")
@@ -482,7 +521,7 @@ func TestCreateAssignmentWithErrorGettingCourse(t *testing.T) {
 		PassingScore: 6.0,
 	}
 
-	assignment, err := assignmentService.CreateAssignment(request)
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "Error getting course")
@@ -515,7 +554,7 @@ func TestCreateAssignmentWithErrorCreating(t *testing.T) {
 		PassingScore: 6.0,
 	}
 
-	assignment, err := assignmentService.CreateAssignment(request)
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "course not found")
@@ -548,7 +587,7 @@ func TestUpdateAssignment(t *testing.T) {
 		PassingScore: 9.0,
 	}
 
-	assignment, err := assignmentService.UpdateAssignment("valid-assignment-id", updateRequest)
+	assignment, err := assignmentService.UpdateAssignment("valid-assignment-id", "teacher-123", updateRequest)
 	assert.NoError(t, err)
 	assert.NotNil(t, assignment)
 	assert.Equal(t, updateRequest.Title, assignment.Title)
@@ -577,7 +616,7 @@ func TestUpdateAssignmentWithEmptyId(t *testing.T) {
 		PassingScore: 9.0,
 	}
 
-	assignment, err := assignmentService.UpdateAssignment("", updateRequest)
+	assignment, err := assignmentService.UpdateAssignment("", "teacher-123", updateRequest)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "id is required")
@@ -599,7 +638,7 @@ func TestUpdateAssignmentWithAssignmentNotFound(t *testing.T) {
 		PassingScore: 9.0,
 	}
 
-	assignment, err := assignmentService.UpdateAssignment("nonexistent-assignment-id", updateRequest)
+	assignment, err := assignmentService.UpdateAssignment("nonexistent-assignment-id", "teacher-123", updateRequest)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "assignment not found")
@@ -621,7 +660,7 @@ func TestUpdateAssignmentWithErrorGettingAssignment(t *testing.T) {
 		PassingScore: 9.0,
 	}
 
-	assignment, err := assignmentService.UpdateAssignment("error-assignment-id", updateRequest)
+	assignment, err := assignmentService.UpdateAssignment("error-assignment-id", "teacher-123", updateRequest)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "Error getting assignment by ID")
@@ -643,7 +682,7 @@ func TestUpdateAssignmentWithErrorUpdating(t *testing.T) {
 		PassingScore: 9.0,
 	}
 
-	assignment, err := assignmentService.UpdateAssignment("error-updating-assignment", updateRequest)
+	assignment, err := assignmentService.UpdateAssignment("error-updating-assignment", "teacher-123", updateRequest)
 	assert.Error(t, err)
 	assert.Nil(t, assignment)
 	assert.Contains(t, err.Error(), "assignment not found")
@@ -653,14 +692,14 @@ func TestUpdateAssignmentWithErrorUpdating(t *testing.T) {
 func TestDeleteAssignment(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
 
-	err := assignmentService.DeleteAssignment("valid-assignment-id")
+	err := assignmentService.DeleteAssignment("valid-assignment-id", "teacher-123")
 	assert.NoError(t, err)
 }
 
 func TestDeleteAssignmentWithEmptyId(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
 
-	err := assignmentService.DeleteAssignment("")
+	err := assignmentService.DeleteAssignment("", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "id is required")
 }
@@ -668,7 +707,7 @@ func TestDeleteAssignmentWithEmptyId(t *testing.T) {
 func TestDeleteAssignmentWithError(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
 
-	err := assignmentService.DeleteAssignment("error-deleting-assignment")
+	err := assignmentService.DeleteAssignment("error-deleting-assignment", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Error deleting assignment")
 }
@@ -676,11 +715,25 @@ func TestDeleteAssignmentWithError(t *testing.T) {
 func TestDeleteAssignmentNotFound(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
 
-	err := assignmentService.DeleteAssignment("nonexistent-assignment-id")
+	err := assignmentService.DeleteAssignment("nonexistent-assignment-id", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "assignment not found")
 }
 
+func TestDeleteAssignmentWithAuxTeacherPermissions(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
+
+	// The aux teacher without a permission set keeps all the permissions
+	err := assignmentService.DeleteAssignment("valid-assignment-id", "aux-teacher-123")
+	assert.NoError(t, err)
+
+	err = assignmentService.DeleteAssignment("valid-assignment-id", "grading-aux-teacher")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+	err = assignmentService.DeleteAssignment("valid-assignment-id", "other-teacher")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
 // Tests for GetAssignmentsByCourseId
 func TestGetAssignmentsByCourseId(t *testing.T) {
 	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
@@ -719,3 +772,107 @@ func TestGetAssignmentsByCourseIdWithError(t *testing.T) {
 	assert.Nil(t, assignments)
 	assert.Contains(t, err.Error(), "Error getting assignments by course ID")
 }
+
+func TestCreateAssignmentWithTimeLimitOnHomework(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
+
+	request := schemas.CreateAssignmentRequest{
+		Title:            "Homework",
+		Description:      "Homework Description",
+		Instructions:     "Homework Instructions",
+		Type:             "homework",
+		CourseID:         "valid-course-id",
+		DueDate:          time.Now().Add(24 * time.Hour),
+		Status:           "published",
+		TimeLimitMinutes: 60,
+	}
+
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
+	assert.Error(t, err)
+	assert.Nil(t, assignment)
+}
+
+func TestCreateAssignmentWithInvalidAvailabilityWindow(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
+
+	availableFrom := time.Now().Add(2 * time.Hour)
+	availableUntil := time.Now().Add(time.Hour)
+	request := schemas.CreateAssignmentRequest{
+		Title:          "Exam",
+		Description:    "Exam Description",
+		Instructions:   "Exam Instructions",
+		Type:           "exam",
+		CourseID:       "valid-course-id",
+		DueDate:        time.Now().Add(24 * time.Hour),
+		Status:         "published",
+		AvailableFrom:  &availableFrom,
+		AvailableUntil: &availableUntil,
+	}
+
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
+	assert.Error(t, err)
+	assert.Nil(t, assignment)
+}
+
+func TestCreateAssignmentWithInconsistentQuestions(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
+
+	questions := map[string]model.Question{
+		"true/false without a boolean answer": {ID: "q1", Type: model.QuestionTypeTrueFalse, CorrectAnswers: []string{"maybe"}, Points: 1},
+		"numeric without an expected answer":  {ID: "q1", Type: model.QuestionTypeNumeric, Points: 1},
+		"ordering missing options":            {ID: "q1", Type: model.QuestionTypeOrdering, Options: []string{"a", "b", "c"}, CorrectAnswers: []string{"a", "b"}, Points: 1},
+		"matching with a single pair":         {ID: "q1", Type: model.QuestionTypeMatching, MatchingPairs: []model.MatchingPair{{Left: "a", Right: "1"}}, Points: 1},
+		"matching with its own items":         {ID: "q1", Type: model.QuestionTypeMatching, MatchingPairs: []model.MatchingPair{{Left: "a", Right: "1"}, {Left: "b", Right: "2"}}, MatchingRight: []string{"1", "2"}, Points: 1},
+		"short answer with an invalid regex":  {ID: "q1", Type: model.QuestionTypeShortAnswer, AcceptedPatterns: []string{"("}, Points: 1},
+		"unknown type":                        {ID: "q1", Type: "essay", Points: 1},
+	}
+
+	for name, question := range questions {
+		t.Run(name, func(t *testing.T) {
+			request := schemas.CreateAssignmentRequest{
+				Title:     "Quiz",
+				Type:      "quiz",
+				CourseID:  "valid-course-id",
+				DueDate:   time.Now().Add(24 * time.Hour),
+				Status:    "published",
+				Questions: []model.Question{question},
+			}
+
+			assignment, err := assignmentService.CreateAssignment("teacher-123", request)
+			assert.ErrorIs(t, err, service.ErrInvalidQuestion)
+			assert.Nil(t, assignment)
+		})
+	}
+}
+
+func TestCreateAssignmentWithInvalidRubric(t *testing.T) {
+	assignmentService := service.NewAssignmentService(&MockAssignmentRepository{}, &MockCourseService{})
+
+	request := schemas.CreateAssignmentRequest{
+		Title:    "Essay",
+		Type:     "homework",
+		CourseID: "valid-course-id",
+		DueDate:  time.Now().Add(24 * time.Hour),
+		Status:   "published",
+		Rubric: &model.Rubric{Criteria: []model.RubricCriterion{
+			{ID: "clarity", Levels: []model.RubricLevel{{ID: "low", Points: 0}, {ID: "low", Points: 2}}},
+		}},
+	}
+
+	assignment, err := assignmentService.CreateAssignment("teacher-123", request)
+	assert.ErrorIs(t, err, service.ErrInvalidRubric)
+	assert.Nil(t, assignment)
+
+	// Rubrics are only for questions graded by hand or by the AI
+	request.Rubric = nil
+	request.Questions = []model.Question{{
+		ID:             "q1",
+		Type:           model.QuestionTypeTrueFalse,
+		CorrectAnswers: []string{"true"},
+		Points:         1,
+		Rubric:         &model.Rubric{Criteria: []model.RubricCriterion{{ID: "c", Levels: []model.RubricLevel{{ID: "l", Points: 1}}}}},
+	}}
+	assignment, err = assignmentService.CreateAssignment("teacher-123", request)
+	assert.ErrorIs(t, err, service.ErrInvalidQuestion)
+	assert.Nil(t, assignment)
+}
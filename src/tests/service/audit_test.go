@@ -0,0 +1,195 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// MockAuditLogRepository keeps the logs in memory and the filter of the last query
+type MockAuditLogRepository struct {
+	logs   []model.AuditLog
+	filter schemas.AuditLogFilter
+}
+
+func (m *MockAuditLogRepository) Create(ctx context.Context, log *model.AuditLog) error {
+	m.logs = append(m.logs, *log)
+	return nil
+}
+
+func (m *MockAuditLogRepository) GetLogs(ctx context.Context, filter schemas.AuditLogFilter) ([]model.AuditLog, int64, error) {
+	m.filter = filter
+	return m.logs, int64(len(m.logs)), nil
+}
+
+// MockAuditRecorder returns a new version of the entity on every snapshot and keeps the recorded changes
+type MockAuditRecorder struct {
+	snapshots int
+	records   []schemas.AuditRecord
+}
+
+func (m *MockAuditRecorder) Snapshot(ctx context.Context, entity model.AuditEntity, id string) map[string]any {
+	m.snapshots++
+	return map[string]any{"id": id, "version": m.snapshots}
+}
+
+func (m *MockAuditRecorder) Record(ctx context.Context, record schemas.AuditRecord) error {
+	m.records = append(m.records, record)
+	return nil
+}
+
+func (m *MockAuditRecorder) LatestSubmissionID(ctx context.Context, assignmentID, studentUUID string) string {
+	return ""
+}
+
+func (m *MockAuditRecorder) GetLogs(ctx context.Context, filter schemas.AuditLogFilter) (*schemas.AuditLogPage, error) {
+	return &schemas.AuditLogPage{}, nil
+}
+
+// assertSystemChanges checks that every change was recorded with the system as the actor
+func assertSystemChanges(t *testing.T, recorder *MockAuditRecorder, entity model.AuditEntity, operation string, ids ...string) {
+	t.Helper()
+	recorded := []string{}
+	for _, record := range recorder.records {
+		assert.Equal(t, model.AuditActorSystem, record.ActorUUID)
+		assert.Equal(t, []string{model.AuditActorSystem}, record.ActorRoles)
+		assert.Equal(t, entity, record.Entity)
+		assert.Equal(t, operation, record.Path)
+		assert.NotEqual(t, record.Before, record.After)
+		recorded = append(recorded, record.EntityID)
+	}
+	assert.ElementsMatch(t, ids, recorded)
+}
+
+func newAuditTestService(course *model.Course) (*service.AuditService, *MockAuditLogRepository) {
+	logs := &MockAuditLogRepository{}
+	auditService := service.NewAuditService(&AdminMockCourseRepository{course: course}, &MockModuleRepository{}, &MockAssignmentRepository{},
+		nil, &AdminMockEnrollmentRepository{}, &MockForumRepository{}, nil, nil, logs)
+	return auditService, logs
+}
+
+func TestAuditSnapshot(t *testing.T) {
+	auditService, _ := newAuditTestService(&model.Course{Title: "Go", TeacherUUID: "teacher123"})
+	ctx := context.Background()
+
+	course := auditService.Snapshot(ctx, model.AuditEntityCourse, "course123")
+	assert.Equal(t, "Go", course["title"])
+	assert.Equal(t, "teacher123", course["teacher_uuid"])
+
+	enrollment := auditService.Snapshot(ctx, model.AuditEntityEnrollment, "course123:enrolled-student")
+	assert.Equal(t, "course123", enrollment["course_id"])
+	assert.Equal(t, "active", enrollment["status"])
+
+	assert.Nil(t, auditService.Snapshot(ctx, model.AuditEntityCourse, "missing-course"))
+	assert.Nil(t, auditService.Snapshot(ctx, model.AuditEntityEnrollment, "course123:other-student"))
+	assert.Nil(t, auditService.Snapshot(ctx, model.AuditEntityEnrollment, "course123"))
+	assert.Nil(t, auditService.Snapshot(ctx, model.AuditEntityCourse, ""))
+}
+
+func TestAuditRecordUpdate(t *testing.T) {
+	auditService, logs := newAuditTestService(nil)
+
+	err := auditService.Record(context.Background(), schemas.AuditRecord{
+		RequestID:  "request123",
+		ActorUUID:  "aux-teacher1",
+		ActorRoles: []string{"aux_teacher"},
+		Entity:     model.AuditEntityModule,
+		EntityID:   "module123",
+		Method:     "PUT",
+		Path:       "/modules/module123",
+		Before:     map[string]any{"course_id": "course123", "title": "Intro", "order": 1.0, "updated_at": "2026-01-01T00:00:00Z"},
+		After:      map[string]any{"course_id": "course123", "title": "Introduction", "order": 1.0, "updated_at": "2026-02-01T00:00:00Z", "description": "First steps"},
+	})
+
+	assert.NoError(t, err)
+	assert.Len(t, logs.logs, 1)
+	log := logs.logs[0]
+	assert.Equal(t, model.AuditActionUpdate, log.Action)
+	assert.Equal(t, "request123", log.RequestID)
+	assert.Equal(t, "aux-teacher1", log.ActorUUID)
+	assert.Equal(t, []string{"aux_teacher"}, log.ActorRoles)
+	assert.Equal(t, "course123", log.CourseID)
+	assert.Equal(t, []model.AuditChange{
+		{Field: "description", After: "First steps"},
+		{Field: "title", Before: "Intro", After: "Introduction"},
+	}, log.Changes)
+}
+
+func TestAuditRecordCreateAndDelete(t *testing.T) {
+	auditService, logs := newAuditTestService(nil)
+	ctx := context.Background()
+	course := map[string]any{"title": "Go", "teacher_uuid": "teacher123"}
+
+	err := auditService.Record(ctx, schemas.AuditRecord{Entity: model.AuditEntityCourse, EntityID: "course123", After: course})
+	assert.NoError(t, err)
+	err = auditService.Record(ctx, schemas.AuditRecord{Entity: model.AuditEntityCourse, EntityID: "course123", Before: course})
+	assert.NoError(t, err)
+
+	assert.Len(t, logs.logs, 2)
+	assert.Equal(t, model.AuditActionCreate, logs.logs[0].Action)
+	assert.Equal(t, model.AuditActionDelete, logs.logs[1].Action)
+	assert.Equal(t, "course123", logs.logs[0].CourseID)
+	assert.Equal(t, model.AuditChange{Field: "teacher_uuid", After: "teacher123"}, logs.logs[0].Changes[0])
+	assert.Equal(t, model.AuditChange{Field: "teacher_uuid", Before: "teacher123"}, logs.logs[1].Changes[0])
+}
+
+func TestAuditRecordWithoutChanges(t *testing.T) {
+	auditService, logs := newAuditTestService(nil)
+	ctx := context.Background()
+
+	err := auditService.Record(ctx, schemas.AuditRecord{
+		Entity:   model.AuditEntityModule,
+		EntityID: "module123",
+		Before:   map[string]any{"title": "Intro", "updated_at": "2026-01-01T00:00:00Z"},
+		After:    map[string]any{"title": "Intro", "updated_at": "2026-02-01T00:00:00Z"},
+	})
+	assert.NoError(t, err)
+
+	err = auditService.Record(ctx, schemas.AuditRecord{Entity: model.AuditEntityModule, EntityID: "missing-module"})
+	assert.NoError(t, err)
+
+	assert.Empty(t, logs.logs)
+}
+
+func TestGetAuditLogsPagination(t *testing.T) {
+	tests := []struct {
+		name             string
+		filter           schemas.AuditLogFilter
+		expectedPage     int
+		expectedPageSize int
+	}{
+		{name: "defaults", filter: schemas.AuditLogFilter{}, expectedPage: 1, expectedPageSize: schemas.DefaultAuditPageSize},
+		{name: "requested page", filter: schemas.AuditLogFilter{Page: 3, PageSize: 50}, expectedPage: 3, expectedPageSize: 50},
+		{name: "page size over the max", filter: schemas.AuditLogFilter{PageSize: 1000}, expectedPage: 1, expectedPageSize: schemas.MaxAuditPageSize},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditService, logs := newAuditTestService(nil)
+
+			page, err := auditService.GetLogs(context.Background(), tt.filter)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedPage, page.Page)
+			assert.Equal(t, tt.expectedPageSize, page.PageSize)
+			assert.Equal(t, tt.expectedPage, logs.filter.Page)
+			assert.Equal(t, tt.expectedPageSize, logs.filter.PageSize)
+		})
+	}
+}
+
+func TestGetAuditLogsInvalidDateRange(t *testing.T) {
+	auditService, _ := newAuditTestService(nil)
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	_, err := auditService.GetLogs(context.Background(), schemas.AuditLogFilter{From: &from, To: &to})
+
+	assert.ErrorIs(t, err, service.ErrInvalidDateRange)
+}
@@ -0,0 +1,113 @@
+package service_test
+
+import (
+	"testing"
+
+	"courses-service/src/model"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestIsAutoGradable(t *testing.T) {
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeMultipleChoice, CorrectAnswers: []string{"a"}}))
+	assert.False(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeMultipleChoice}))
+	assert.False(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeText, CorrectAnswers: []string{"a"}}))
+}
+
+func TestAutoGradeMultipleChoiceExactMatch(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeMultipleChoice, CorrectAnswers: []string{"Paris"}, Points: 2}
+
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: "paris "}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: "Rome"}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: nil}))
+}
+
+func TestAutoGradeMultipleChoiceWithoutPartialCreditRequiresAllOptions(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeMultipleChoice, CorrectAnswers: []string{"a", "b"}, Points: 4}
+
+	assert.Equal(t, 4.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"b", "a"}}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"a"}}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"a", "b", "c"}}))
+}
+
+func TestAutoGradeMultipleChoiceWithPartialCredit(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeMultipleChoice, CorrectAnswers: []string{"a", "b", "c", "d"}, Points: 4, PartialCredit: true}
+
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: primitive.A{"a", "b"}}))
+	assert.Equal(t, 1.0, service.AutoGradeAnswer(question, model.Answer{Content: []interface{}{"a", "b", "x"}}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"a", "x", "y"}}))
+}
+
+func TestAutoGradeMultipleChoiceWithNegativeMarking(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeMultipleChoice, CorrectAnswers: []string{"a"}, Points: 4, NegativeMarking: 0.25}
+
+	assert.Equal(t, 4.0, service.AutoGradeAnswer(question, model.Answer{Content: "a"}))
+	assert.Equal(t, -1.0, service.AutoGradeAnswer(question, model.Answer{Content: "b"}))
+	// Unanswered questions are not penalized
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: ""}))
+}
+
+func TestIsAutoGradableRicherQuestionTypes(t *testing.T) {
+	expected := 3.0
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeNumeric, NumericAnswer: &expected}))
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeTrueFalse, CorrectAnswers: []string{"true"}}))
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeMatching, MatchingPairs: []model.MatchingPair{{Left: "a", Right: "1"}}}))
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeOrdering, CorrectAnswers: []string{"a", "b"}}))
+	assert.True(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeShortAnswer, AcceptedPatterns: []string{"paris"}}))
+	assert.False(t, service.IsAutoGradable(model.Question{Type: model.QuestionTypeNumeric}))
+}
+
+func TestAutoGradeNumericWithTolerance(t *testing.T) {
+	expected := 3.14
+	question := model.Question{Type: model.QuestionTypeNumeric, NumericAnswer: &expected, Tolerance: 0.01, Points: 2}
+
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: 3.145}))
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: "3.14"}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: int32(3)}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: "pi"}))
+}
+
+func TestAutoGradeTrueFalse(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeTrueFalse, CorrectAnswers: []string{"false"}, Points: 2, NegativeMarking: 0.5}
+
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: false}))
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: "False"}))
+	assert.Equal(t, -1.0, service.AutoGradeAnswer(question, model.Answer{Content: true}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: nil}))
+}
+
+func TestAutoGradeMatching(t *testing.T) {
+	question := model.Question{
+		Type:          model.QuestionTypeMatching,
+		MatchingPairs: []model.MatchingPair{{Left: "Argentina", Right: "Buenos Aires"}, {Left: "Chile", Right: "Santiago"}},
+		Points:        4,
+	}
+
+	assert.Equal(t, 4.0, service.AutoGradeAnswer(question, model.Answer{Content: map[string]interface{}{"argentina": "Buenos Aires", "Chile": "santiago"}}))
+	assert.Equal(t, 4.0, service.AutoGradeAnswer(question, model.Answer{Content: primitive.D{{Key: "Argentina", Value: "Buenos Aires"}, {Key: "Chile", Value: "Santiago"}}}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: map[string]interface{}{"Argentina": "Buenos Aires", "Chile": "Lima"}}))
+
+	question.PartialCredit = true
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: map[string]interface{}{"Argentina": "Buenos Aires", "Chile": "Lima"}}))
+}
+
+func TestAutoGradeOrdering(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeOrdering, CorrectAnswers: []string{"a", "b", "c", "d"}, Points: 4}
+
+	assert.Equal(t, 4.0, service.AutoGradeAnswer(question, model.Answer{Content: []interface{}{"a", "b", "c", "d"}}))
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"a", "b", "d", "c"}}))
+
+	question.PartialCredit = true
+	assert.Equal(t, 2.0, service.AutoGradeAnswer(question, model.Answer{Content: []string{"a", "b", "d", "c"}}))
+}
+
+func TestAutoGradeShortAnswer(t *testing.T) {
+	question := model.Question{Type: model.QuestionTypeShortAnswer, AcceptedPatterns: []string{`buenos\s+aires`, "caba"}, Points: 3}
+
+	assert.Equal(t, 3.0, service.AutoGradeAnswer(question, model.Answer{Content: " Buenos  Aires "}))
+	assert.Equal(t, 3.0, service.AutoGradeAnswer(question, model.Answer{Content: "CABA"}))
+	// Patterns must match the whole answer
+	assert.Equal(t, 0.0, service.AutoGradeAnswer(question, model.Answer{Content: "not caba"}))
+}
@@ -0,0 +1,332 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type CorrectionJobMockRepository struct {
+	mu   sync.Mutex
+	jobs []*model.CorrectionJob
+}
+
+func (m *CorrectionJobMockRepository) Create(ctx context.Context, job *model.CorrectionJob) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	job.ID = primitive.NewObjectID()
+	stored := *job
+	m.jobs = append(m.jobs, &stored)
+	return nil
+}
+
+func (m *CorrectionJobMockRepository) Update(ctx context.Context, job *model.CorrectionJob) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i, stored := range m.jobs {
+		if stored.ID == job.ID && stored.ClaimToken == job.ClaimToken {
+			updated := *job
+			m.jobs[i] = &updated
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *CorrectionJobMockRepository) HoldsClaim(ctx context.Context, job *model.CorrectionJob) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for _, stored := range m.jobs {
+		if stored.ID == job.ID {
+			return stored.ClaimToken == job.ClaimToken, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *CorrectionJobMockRepository) GetLatestBySubmission(ctx context.Context, submissionID string) (*model.CorrectionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.jobs) - 1; i >= 0; i-- {
+		if m.jobs[i].SubmissionID == submissionID {
+			job := *m.jobs[i]
+			return &job, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *CorrectionJobMockRepository) ClaimNext(ctx context.Context, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	return m.claim(func(job *model.CorrectionJob) bool { return true }, now, lockedUntil)
+}
+
+func (m *CorrectionJobMockRepository) ClaimByID(ctx context.Context, id string, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	return m.claim(func(job *model.CorrectionJob) bool { return job.ID.Hex() == id }, now, lockedUntil)
+}
+
+func (m *CorrectionJobMockRepository) claim(match func(job *model.CorrectionJob) bool, now, lockedUntil time.Time) (*model.CorrectionJob, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var ready []*model.CorrectionJob
+	for _, job := range m.jobs {
+		pending := job.Status == model.CorrectionJobPending && !job.NextRunAt.After(now)
+		lost := job.Status == model.CorrectionJobRunning && job.LockedUntil != nil && !job.LockedUntil.After(now)
+		if match(job) && (pending || lost) {
+			ready = append(ready, job)
+		}
+	}
+	if len(ready) == 0 {
+		return nil, nil
+	}
+	sort.Slice(ready, func(i, j int) bool { return ready[i].NextRunAt.Before(ready[j].NextRunAt) })
+
+	ready[0].Status = model.CorrectionJobRunning
+	ready[0].LockedUntil = &lockedUntil
+	ready[0].ClaimToken = primitive.NewObjectID().Hex()
+	ready[0].Attempts++
+	job := *ready[0]
+	return &job, nil
+}
+
+func (m *CorrectionJobMockRepository) job(submissionID string) model.CorrectionJob {
+	job, _ := m.GetLatestBySubmission(context.TODO(), submissionID)
+	return *job
+}
+
+type MockSubmissionCorrector struct {
+	mu              sync.Mutex
+	err             error
+	delay           time.Duration
+	whileCorrecting func()
+	calls           int
+	running         int
+	maxRunning      int
+	saved           []string
+	markedAsFailed  []string
+}
+
+func (m *MockSubmissionCorrector) AutoCorrectSubmission(ctx context.Context, submissionID string) error {
+	m.mu.Lock()
+	m.calls++
+	m.running++
+	m.maxRunning = max(m.maxRunning, m.running)
+	m.mu.Unlock()
+
+	time.Sleep(m.delay)
+	if m.whileCorrecting != nil {
+		m.whileCorrecting()
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.running--
+	if m.err != nil {
+		return m.err
+	}
+	// As the submission service, the correction is only saved by the worker holding the job
+	if err := service.CheckCorrectionClaim(ctx); err != nil {
+		return err
+	}
+	m.saved = append(m.saved, submissionID)
+	return nil
+}
+
+func (m *MockSubmissionCorrector) MarkCorrectionFailed(ctx context.Context, submissionID string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.markedAsFailed = append(m.markedAsFailed, submissionID)
+	return nil
+}
+
+func testCorrectionSettings() service.CorrectionSettings {
+	return service.CorrectionSettings{
+		MaxAttempts:  3,
+		Concurrency:  2,
+		BaseBackoff:  10 * time.Millisecond,
+		MaxBackoff:   40 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+		LockDuration: time.Minute,
+	}
+}
+
+// runCorrectionWorker runs a worker until the jobs of the given submissions are finished
+func runCorrectionWorker(t *testing.T, jobRepo *CorrectionJobMockRepository, queue *service.CorrectionQueue, corrector *MockSubmissionCorrector, submissionIDs ...string) {
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		service.NewCorrectionWorker(queue, corrector).Run(ctx)
+		close(done)
+	}()
+
+	assert.Eventually(t, func() bool {
+		for _, submissionID := range submissionIDs {
+			status := jobRepo.job(submissionID).Status
+			if status != model.CorrectionJobDone && status != model.CorrectionJobFailed {
+				return false
+			}
+		}
+		return true
+	}, 2*time.Second, 5*time.Millisecond)
+
+	cancel()
+	<-done
+}
+
+func enqueueCorrection(t *testing.T, queue *service.CorrectionQueue) string {
+	submission := &model.Submission{ID: primitive.NewObjectID(), AssignmentID: "assignment123", StudentUUID: "student123"}
+	job, err := queue.Enqueue(context.TODO(), submission)
+	assert.NoError(t, err)
+	assert.Equal(t, model.CorrectionJobPending, job.Status)
+	return submission.ID.Hex()
+}
+
+func TestCorrectionWorkerCompletesJobs(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	queue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	corrector := &MockSubmissionCorrector{}
+
+	submissionID := enqueueCorrection(t, queue)
+	runCorrectionWorker(t, jobRepo, queue, corrector, submissionID)
+
+	job := jobRepo.job(submissionID)
+	assert.Equal(t, model.CorrectionJobDone, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.NotNil(t, job.CompletedAt)
+	assert.Nil(t, job.LockedUntil)
+	assert.Equal(t, []string{submissionID}, corrector.saved)
+}
+
+func TestCorrectionWorkerDropsTheResultAfterLosingTheLock(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	queue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	submissionID := enqueueCorrection(t, queue)
+	corrector := &MockSubmissionCorrector{whileCorrecting: func() {
+		// The correction takes longer than the lock and another worker claims the job again
+		later := time.Now().Add(2 * time.Minute)
+		claimed, err := jobRepo.ClaimNext(context.TODO(), later, later.Add(time.Minute))
+		assert.NoError(t, err)
+		assert.NotNil(t, claimed)
+	}}
+	worker := service.NewCorrectionWorker(queue, corrector)
+
+	err := worker.ProcessJob(context.TODO(), jobRepo.job(submissionID).ID.Hex())
+	assert.NoError(t, err)
+
+	// Neither the correction nor the state of the job of the first worker are saved
+	assert.Equal(t, 1, corrector.calls)
+	assert.Empty(t, corrector.saved)
+	job := jobRepo.job(submissionID)
+	assert.Equal(t, model.CorrectionJobRunning, job.Status)
+	assert.Equal(t, 2, job.Attempts)
+	assert.Nil(t, job.CompletedAt)
+}
+
+func TestCorrectionWorkerDoesNotFinishAJobClaimedByAnotherWorker(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	settings := testCorrectionSettings()
+	settings.MaxAttempts = 1
+	queue := service.NewCorrectionQueue(jobRepo, nil, settings)
+	submissionID := enqueueCorrection(t, queue)
+	corrector := &MockSubmissionCorrector{err: service.ErrAICorrectionFailed, whileCorrecting: func() {
+		later := time.Now().Add(2 * time.Minute)
+		_, err := jobRepo.ClaimNext(context.TODO(), later, later.Add(time.Minute))
+		assert.NoError(t, err)
+	}}
+	worker := service.NewCorrectionWorker(queue, corrector)
+
+	err := worker.ProcessJob(context.TODO(), jobRepo.job(submissionID).ID.Hex())
+	assert.NoError(t, err)
+
+	// The failure of the first worker neither fails the job of the second one nor flags the submission
+	job := jobRepo.job(submissionID)
+	assert.Equal(t, model.CorrectionJobRunning, job.Status)
+	assert.Empty(t, job.LastError)
+	assert.Empty(t, corrector.markedAsFailed)
+}
+
+func TestCorrectionWorkerRetriesWithBackoff(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	queue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	corrector := &MockSubmissionCorrector{err: service.ErrAICorrectionFailed}
+
+	submissionID := enqueueCorrection(t, queue)
+	start := time.Now()
+	runCorrectionWorker(t, jobRepo, queue, corrector, submissionID)
+
+	job := jobRepo.job(submissionID)
+	assert.Equal(t, model.CorrectionJobFailed, job.Status)
+	assert.Equal(t, 3, job.Attempts)
+	assert.Equal(t, 3, corrector.calls)
+	assert.Contains(t, job.LastError, "automatic correction failed")
+	// Waited 10ms after the first attempt and 20ms after the second one
+	assert.GreaterOrEqual(t, time.Since(start), 30*time.Millisecond)
+	// The submission is left for the teachers once every attempt failed
+	assert.Equal(t, []string{submissionID}, corrector.markedAsFailed)
+}
+
+func TestCorrectionWorkerDoesNotRetryMissingSubmissions(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	queue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	corrector := &MockSubmissionCorrector{err: service.ErrSubmissionNotFound}
+
+	submissionID := enqueueCorrection(t, queue)
+	runCorrectionWorker(t, jobRepo, queue, corrector, submissionID)
+
+	job := jobRepo.job(submissionID)
+	assert.Equal(t, model.CorrectionJobFailed, job.Status)
+	assert.Equal(t, 1, job.Attempts)
+	assert.Empty(t, corrector.markedAsFailed)
+}
+
+func TestCorrectionWorkerLimitsConcurrency(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	queue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	corrector := &MockSubmissionCorrector{delay: 20 * time.Millisecond}
+
+	var submissionIDs []string
+	for range 6 {
+		submissionIDs = append(submissionIDs, enqueueCorrection(t, queue))
+	}
+	runCorrectionWorker(t, jobRepo, queue, corrector, submissionIDs...)
+
+	assert.Equal(t, 6, corrector.calls)
+	assert.Equal(t, 2, corrector.maxRunning)
+}
+
+type MockCorrectionNotifier struct {
+	jobIDs []string
+}
+
+func (m *MockCorrectionNotifier) NotifyCorrectionJob(jobID string) error {
+	m.jobIDs = append(m.jobIDs, jobID)
+	return errors.New("broker unavailable")
+}
+
+func TestCorrectionWorkerProcessesNotifiedJobs(t *testing.T) {
+	jobRepo := &CorrectionJobMockRepository{}
+	notifier := &MockCorrectionNotifier{}
+	queue := service.NewCorrectionQueue(jobRepo, notifier, testCorrectionSettings())
+	corrector := &MockSubmissionCorrector{}
+	worker := service.NewCorrectionWorker(queue, corrector)
+
+	// A failed notification doesn't lose the job, it is stored before announcing it
+	submissionID := enqueueCorrection(t, queue)
+	assert.Len(t, notifier.jobIDs, 1)
+
+	assert.NoError(t, worker.ProcessJob(context.TODO(), notifier.jobIDs[0]))
+	assert.Equal(t, model.CorrectionJobDone, jobRepo.job(submissionID).Status)
+
+	// Delivering the same message again does nothing
+	assert.NoError(t, worker.ProcessJob(context.TODO(), notifier.jobIDs[0]))
+	assert.Equal(t, 1, corrector.calls)
+}
@@ -179,9 +179,26 @@ func (m *MockCourseRepository) RemoveAuxTeacherFromCourse(course *model.Course,
 	return &model.Course{}, nil
 }
 
+func (m *MockCourseRepository) UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	course.AuxTeacherPermissions = map[string][]model.AuxTeacherPermission{auxTeacherId: permissions}
+	return course, nil
+}
+
+func (m *MockCourseRepository) UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error) {
+	course.Status = status
+	return course, nil
+}
+
+func (m *MockCourseRepository) ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error) {
+	course.TeacherUUID = teacherUUID
+	course.TeacherName = teacherName
+	return course, nil
+}
+
 // AddAuxTeacherToCourse implements service.CourseRepository.
 func (m *MockCourseRepository) AddAuxTeacherToCourse(course *model.Course, auxTeacherId string) (*model.Course, error) {
-	return &model.Course{}, nil
+	course.AuxTeachers = append(course.AuxTeachers, auxTeacherId)
+	return course, nil
 }
 
 // GetCoursesByStudentId implements service.CourseRepository.
@@ -365,6 +382,13 @@ func (m *MockCourseRepository) GetCourseByTeacherId(teacherId string) ([]*model.
 			},
 		}, nil
 	}
+	if teacherId == "teacher-with-moderated-courses" {
+		return []*model.Course{
+			{ID: primitive.NewObjectID(), Title: "Active Course", TeacherUUID: teacherId},
+			{ID: primitive.NewObjectID(), Title: "Suspended Course", TeacherUUID: teacherId, Status: model.CourseStatusSuspended},
+			{ID: primitive.NewObjectID(), Title: "Archived Course", TeacherUUID: teacherId, Status: model.CourseStatusArchived},
+		}, nil
+	}
 	return []*model.Course{}, nil
 }
 
@@ -601,6 +625,22 @@ func TestGetCourseByTeacherIdWithEmptyId(t *testing.T) {
 	assert.Equal(t, 0, len(courses))
 }
 
+func TestGetCoursesHidesTheSuspendedCourses(t *testing.T) {
+	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
+
+	courses, err := courseService.GetCourseByTeacherId("teacher-with-moderated-courses")
+	assert.NoError(t, err)
+	titles := []string{}
+	for _, course := range courses {
+		titles = append(titles, course.Title)
+	}
+	assert.Equal(t, []string{"Active Course", "Archived Course"}, titles)
+
+	userCourses, err := courseService.GetCoursesByUserId("teacher-with-moderated-courses")
+	assert.NoError(t, err)
+	assert.Len(t, userCourses.Teacher, 2)
+}
+
 func TestGetCourseByTitle(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
 	courses, err := courseService.GetCourseByTitle("Test Course")
@@ -719,14 +759,14 @@ func TestGetCoursesByUserIdWithEmptyId(t *testing.T) {
 
 func TestAddAuxTeacherToCourse(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "new-aux-teacher")
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "new-aux-teacher", nil)
 	assert.NoError(t, err)
 	assert.NotNil(t, course)
 }
 
 func TestAddAuxTeacherToCourseWithNonExistentCourse(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("non-existent-course", "owner-teacher", "new-aux-teacher")
+	course, err := courseService.AddAuxTeacherToCourse("non-existent-course", "owner-teacher", "new-aux-teacher", nil)
 	assert.Error(t, err)
 	assert.Nil(t, course)
 	assert.Contains(t, err.Error(), "course not found")
@@ -734,7 +774,7 @@ func TestAddAuxTeacherToCourseWithNonExistentCourse(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithNonOwnerTeacher(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "non-owner-teacher", "new-aux-teacher")
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "non-owner-teacher", "new-aux-teacher", nil)
 	assert.Error(t, err)
 	assert.Nil(t, course)
 	assert.Contains(t, err.Error(), "the teacher trying to add an aux teacher is not the owner of the course")
@@ -742,7 +782,7 @@ func TestAddAuxTeacherToCourseWithNonOwnerTeacher(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithTitularTeacherAsAux(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "owner-teacher")
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "owner-teacher", nil)
 	assert.Error(t, err)
 	assert.Nil(t, course)
 	assert.Contains(t, err.Error(), "the titular teacher cannot be an aux teacher for his own course")
@@ -750,7 +790,7 @@ func TestAddAuxTeacherToCourseWithTitularTeacherAsAux(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithExistingAuxTeacher(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "aux-teacher-1")
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "aux-teacher-1", nil)
 	assert.Error(t, err)
 	assert.Nil(t, course)
 	assert.Contains(t, err.Error(), "aux teacher already exists")
@@ -758,7 +798,7 @@ func TestAddAuxTeacherToCourseWithExistingAuxTeacher(t *testing.T) {
 
 func TestAddAuxTeacherToCourseWithEnrolledTeacher(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
-	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "enrolled-teacher")
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "enrolled-teacher", nil)
 	assert.Error(t, err)
 	assert.Nil(t, course)
 	assert.Contains(t, err.Error(), "aux teacher already exists")
@@ -811,6 +851,50 @@ func TestRemoveAuxTeacherFromCourseWithEnrolledTeacher(t *testing.T) {
 	assert.Contains(t, err.Error(), "the aux teacher is already enrolled in the course")
 }
 
+func TestAddAuxTeacherToCourseWithPermissions(t *testing.T) {
+	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
+
+	course, err := courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "new-aux-teacher", nil)
+	assert.NoError(t, err)
+	assert.Equal(t, model.AllAuxTeacherPermissions, course.AuxTeacherPermissions["new-aux-teacher"])
+
+	course, err = courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "new-aux-teacher", []model.AuxTeacherPermission{
+		model.PermissionGradeSubmissions, model.PermissionModerateForum, model.PermissionGradeSubmissions,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AuxTeacherPermission{model.PermissionGradeSubmissions, model.PermissionModerateForum}, course.AuxTeacherPermissions["new-aux-teacher"])
+	assert.True(t, course.TeacherCan("new-aux-teacher", model.PermissionGradeSubmissions))
+	assert.False(t, course.TeacherCan("new-aux-teacher", model.PermissionEditModules))
+
+	_, err = courseService.AddAuxTeacherToCourse("course-with-owner", "owner-teacher", "new-aux-teacher", []model.AuxTeacherPermission{"delete_course"})
+	assert.ErrorIs(t, err, service.ErrInvalidPermission)
+}
+
+func TestUpdateAuxTeacherPermissions(t *testing.T) {
+	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
+
+	course, err := courseService.UpdateAuxTeacherPermissions("course-with-owner", "owner-teacher", "aux-teacher-1", []model.AuxTeacherPermission{model.PermissionEditModules})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.AuxTeacherPermission{model.PermissionEditModules}, course.PermissionsOf("aux-teacher-1"))
+	// The aux teachers added before the permission sets keep all of them
+	assert.Equal(t, model.AllAuxTeacherPermissions, course.PermissionsOf("enrolled-teacher"))
+
+	course, err = courseService.UpdateAuxTeacherPermissions("course-with-owner", "owner-teacher", "aux-teacher-1", []model.AuxTeacherPermission{})
+	assert.NoError(t, err)
+	assert.Empty(t, course.PermissionsOf("aux-teacher-1"))
+	assert.False(t, course.TeacherCan("aux-teacher-1", model.PermissionEditModules))
+	assert.True(t, course.TeacherCan("owner-teacher", model.PermissionEditModules))
+
+	_, err = courseService.UpdateAuxTeacherPermissions("course-with-owner", "aux-teacher-1", "aux-teacher-1", model.AllAuxTeacherPermissions)
+	assert.EqualError(t, err, "the teacher trying to update the aux teacher permissions is not the owner of the course")
+
+	_, err = courseService.UpdateAuxTeacherPermissions("course-with-owner", "owner-teacher", "non-assigned-aux", model.AllAuxTeacherPermissions)
+	assert.EqualError(t, err, "aux teacher is not assigned to this course")
+
+	_, err = courseService.UpdateAuxTeacherPermissions("course-with-owner", "owner-teacher", "aux-teacher-1", []model.AuxTeacherPermission{"delete_course"})
+	assert.ErrorIs(t, err, service.ErrInvalidPermission)
+}
+
 func TestGetFavouriteCourses(t *testing.T) {
 	courseService := service.NewCourseService(&MockCourseRepository{}, &MockEnrollmentRepository{})
 	courses, err := courseService.GetFavouriteCourses("student-with-favourites")
@@ -1415,6 +1499,18 @@ func (m *MockCourseRepositoryWithError) RemoveAuxTeacherFromCourse(course *model
 	return nil, errors.New("error removing aux teacher")
 }
 
+func (m *MockCourseRepositoryWithError) UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return nil, errors.New("error updating aux teacher permissions")
+}
+
+func (m *MockCourseRepositoryWithError) UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error) {
+	return nil, errors.New("error updating course status")
+}
+
+func (m *MockCourseRepositoryWithError) ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error) {
+	return nil, errors.New("error reassigning teacher")
+}
+
 func (m *MockCourseRepositoryWithError) GetCoursesByAuxTeacherId(auxTeacherId string) ([]*model.Course, error) {
 	return nil, errors.New("error getting courses by aux teacher")
 }
@@ -7,6 +7,7 @@ import (
 	"courses-service/src/service"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -208,6 +209,13 @@ func (m *MockEnrollmentRepositoryForEnrollmentService) GetFeedbackByStudentId(st
 			},
 		}, nil
 	}
+	if studentID == "student-of-a-suspended-course" {
+		return []*model.StudentFeedback{
+			{StudentUUID: studentID, CourseID: "suspended-course", Feedback: "Suspended course feedback"},
+			{StudentUUID: studentID, CourseID: "course-123", Feedback: "Active course feedback"},
+			{StudentUUID: studentID, CourseID: "suspended-course", Feedback: "More suspended course feedback"},
+		}, nil
+	}
 	return []*model.StudentFeedback{}, nil
 }
 
@@ -298,6 +306,15 @@ func (m *MockCourseRepositoryForEnrollment) GetCourseById(id string) (*model.Cou
 			TeacherUUID:    "teacher-123",
 		}, nil
 	}
+	if id == "suspended-course" {
+		return &model.Course{
+			ID:          primitive.NewObjectID(),
+			Title:       "Suspended Course",
+			Capacity:    10,
+			TeacherUUID: "teacher-123",
+			Status:      model.CourseStatusSuspended,
+		}, nil
+	}
 	if id == "full-course" {
 		return &model.Course{
 			ID:             primitive.NewObjectID(),
@@ -372,6 +389,15 @@ func (m *MockCourseRepositoryForEnrollment) AddAuxTeacherToCourse(course *model.
 func (m *MockCourseRepositoryForEnrollment) RemoveAuxTeacherFromCourse(course *model.Course, auxTeacherId string) (*model.Course, error) {
 	return nil, nil
 }
+func (m *MockCourseRepositoryForEnrollment) UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return nil, nil
+}
+func (m *MockCourseRepositoryForEnrollment) UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error) {
+	return nil, nil
+}
+func (m *MockCourseRepositoryForEnrollment) ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error) {
+	return nil, nil
+}
 func (m *MockCourseRepositoryForEnrollment) UpdateStudentsAmount(courseID string, newStudentsAmount int) error {
 	return nil
 }
@@ -430,6 +456,14 @@ func (m *MockSubmissionRepositoryForEnrollmentService) GetByAssignmentAndStudent
 	return nil, nil
 }
 
+func (m *MockSubmissionRepositoryForEnrollmentService) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
+func (m *MockSubmissionRepositoryForEnrollmentService) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
 func (m *MockSubmissionRepositoryForEnrollmentService) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	return []model.Submission{}, nil
 }
@@ -495,6 +529,13 @@ func TestEnrollStudentWithFullCourse(t *testing.T) {
 	assert.Contains(t, err.Error(), "course full-course is full")
 }
 
+func TestEnrollStudentInSuspendedCourse(t *testing.T) {
+	enrollmentService := createEnrollmentServiceForTests()
+
+	err := enrollmentService.EnrollStudent("valid-student", "suspended-course")
+	assert.ErrorIs(t, err, service.ErrCourseNotActive)
+}
+
 func TestEnrollStudentAsTeacher(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
@@ -989,6 +1030,16 @@ func TestGetFeedbackByStudentId(t *testing.T) {
 	assert.Equal(t, "Excellent work!", feedback[0].Feedback)
 }
 
+func TestGetFeedbackByStudentIdHidesTheSuspendedCourses(t *testing.T) {
+	enrollmentService := createEnrollmentServiceForTests()
+
+	feedback, err := enrollmentService.GetFeedbackByStudentId("student-of-a-suspended-course", schemas.GetFeedbackByStudentIdRequest{})
+
+	assert.NoError(t, err)
+	assert.Len(t, feedback, 1)
+	assert.Equal(t, "Active course feedback", feedback[0].Feedback)
+}
+
 func TestGetFeedbackByStudentIdWithEmptyStudentID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
@@ -1079,14 +1130,14 @@ func TestGetFeedbackByStudentIdWithFilters(t *testing.T) {
 func TestApproveStudent(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "valid-course")
+	err := enrollmentService.ApproveStudent("valid-student", "valid-course", "teacher-123")
 	assert.NoError(t, err)
 }
 
 func TestApproveStudentWithEmptyStudentID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("", "valid-course")
+	err := enrollmentService.ApproveStudent("", "valid-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "student ID is required")
 }
@@ -1094,7 +1145,7 @@ func TestApproveStudentWithEmptyStudentID(t *testing.T) {
 func TestApproveStudentWithEmptyCourseID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "")
+	err := enrollmentService.ApproveStudent("valid-student", "", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course ID is required")
 }
@@ -1102,7 +1153,7 @@ func TestApproveStudentWithEmptyCourseID(t *testing.T) {
 func TestApproveStudentWithBothEmptyIDs(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("", "")
+	err := enrollmentService.ApproveStudent("", "", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "student ID is required")
 }
@@ -1110,7 +1161,7 @@ func TestApproveStudentWithBothEmptyIDs(t *testing.T) {
 func TestApproveStudentWithWhitespaceStudentID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("   ", "valid-course")
+	err := enrollmentService.ApproveStudent("   ", "valid-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "student ID is required")
 }
@@ -1118,7 +1169,7 @@ func TestApproveStudentWithWhitespaceStudentID(t *testing.T) {
 func TestApproveStudentWithWhitespaceCourseID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "   ")
+	err := enrollmentService.ApproveStudent("valid-student", "   ", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course ID is required")
 }
@@ -1126,7 +1177,7 @@ func TestApproveStudentWithWhitespaceCourseID(t *testing.T) {
 func TestApproveStudentWithNonExistentCourse(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "non-existent-course")
+	err := enrollmentService.ApproveStudent("valid-student", "non-existent-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course not found")
 }
@@ -1134,7 +1185,7 @@ func TestApproveStudentWithNonExistentCourse(t *testing.T) {
 func TestApproveStudentWithCourseRepositoryError(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "error-course")
+	err := enrollmentService.ApproveStudent("valid-student", "error-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course not found")
 }
@@ -1142,7 +1193,7 @@ func TestApproveStudentWithCourseRepositoryError(t *testing.T) {
 func TestApproveStudentWithRepositoryError(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("error-student", "valid-course")
+	err := enrollmentService.ApproveStudent("error-student", "valid-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error approving student")
 }
@@ -1150,7 +1201,7 @@ func TestApproveStudentWithRepositoryError(t *testing.T) {
 func TestApproveStudentWithRepositoryErrorFromCourseID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.ApproveStudent("valid-student", "error-course-repo")
+	err := enrollmentService.ApproveStudent("valid-student", "error-course-repo", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error approving student")
 }
@@ -1192,7 +1243,7 @@ func TestApproveStudentWithValidUUIDs(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := enrollmentService.ApproveStudent(tc.studentID, tc.courseID)
+			err := enrollmentService.ApproveStudent(tc.studentID, tc.courseID, "teacher-123")
 			if tc.shouldErr {
 				assert.Error(t, err)
 			} else {
@@ -1239,7 +1290,7 @@ func TestApproveStudentWithSpecialCharacters(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			err := enrollmentService.ApproveStudent(tc.studentID, tc.courseID)
+			err := enrollmentService.ApproveStudent(tc.studentID, tc.courseID, "teacher-123")
 			if tc.shouldErr {
 				assert.Error(t, err)
 			} else {
@@ -1253,7 +1304,7 @@ func TestApproveStudentCourseValidation(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
 	// Test that course validation happens before repository call
-	err := enrollmentService.ApproveStudent("valid-student", "non-existent-course")
+	err := enrollmentService.ApproveStudent("valid-student", "non-existent-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course not found")
 }
@@ -1262,7 +1313,7 @@ func TestApproveStudentErrorPropagation(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
 	// Test that repository errors are properly wrapped
-	err := enrollmentService.ApproveStudent("error-student", "valid-course")
+	err := enrollmentService.ApproveStudent("error-student", "valid-course", "teacher-123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "error approving student")
 }
@@ -1271,7 +1322,7 @@ func TestApproveStudentServiceLayerValidation(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
 	// Test input sanitization - trimming whitespace
-	err := enrollmentService.ApproveStudent("  valid-student  ", "  valid-course  ")
+	err := enrollmentService.ApproveStudent("  valid-student  ", "  valid-course  ", "teacher-123")
 	assert.NoError(t, err) // Should succeed since our implementation now trims whitespace
 }
 
@@ -1279,7 +1330,7 @@ func TestApproveStudentServiceLayerValidation(t *testing.T) {
 func TestDisapproveStudent(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "Did not meet course requirements")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "teacher-123", "Did not meet course requirements")
 
 	assert.NoError(t, err)
 }
@@ -1287,7 +1338,7 @@ func TestDisapproveStudent(t *testing.T) {
 func TestDisapproveStudentWithEmptyStudentID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("", "valid-course", "Valid reason")
+	err := enrollmentService.DisapproveStudent("", "valid-course", "teacher-123", "Valid reason")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "student ID is required")
@@ -1296,7 +1347,7 @@ func TestDisapproveStudentWithEmptyStudentID(t *testing.T) {
 func TestDisapproveStudentWithEmptyCourseID(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "", "Valid reason")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "", "teacher-123", "Valid reason")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course ID is required")
@@ -1305,7 +1356,7 @@ func TestDisapproveStudentWithEmptyCourseID(t *testing.T) {
 func TestDisapproveStudentWithEmptyReason(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "teacher-123", "")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "reason is required")
@@ -1314,7 +1365,7 @@ func TestDisapproveStudentWithEmptyReason(t *testing.T) {
 func TestDisapproveStudentWithWhitespaceReason(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "   ")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "teacher-123", "   ")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "reason is required")
@@ -1323,7 +1374,7 @@ func TestDisapproveStudentWithWhitespaceReason(t *testing.T) {
 func TestDisapproveStudentWithNonExistentCourse(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "non-existent-course", "Valid reason")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "non-existent-course", "teacher-123", "Valid reason")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course not found")
@@ -1332,7 +1383,7 @@ func TestDisapproveStudentWithNonExistentCourse(t *testing.T) {
 func TestDisapproveStudentWithCourseRepositoryError(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "error-course", "Valid reason")
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "error-course", "teacher-123", "Valid reason")
 
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "course not found")
@@ -1342,7 +1393,7 @@ func TestDisapproveStudentWithSpecialCharactersInReason(t *testing.T) {
 	enrollmentService := createEnrollmentServiceForTests()
 
 	specialReason := "Student failed to meet requirements: @#$%^&*()_+{}|:<>?[]\\;',./"
-	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", specialReason)
+	err := enrollmentService.DisapproveStudent("student-with-enrollment", "course-with-enrollment", "teacher-123", specialReason)
 
 	assert.NoError(t, err)
 }
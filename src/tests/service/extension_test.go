@@ -0,0 +1,220 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type ExtensionMockRepository struct {
+	extensions map[string]*model.DeadlineExtension
+	deleted    []string
+}
+
+func NewExtensionMockRepository(extensions ...model.DeadlineExtension) *ExtensionMockRepository {
+	repo := &ExtensionMockRepository{extensions: make(map[string]*model.DeadlineExtension)}
+	for i := range extensions {
+		repo.extensions[extensions[i].ID.Hex()] = &extensions[i]
+	}
+	return repo
+}
+
+func (m *ExtensionMockRepository) Create(ctx context.Context, extension *model.DeadlineExtension) error {
+	extension.ID = primitive.NewObjectID()
+	m.extensions[extension.ID.Hex()] = extension
+	return nil
+}
+
+func (m *ExtensionMockRepository) Update(ctx context.Context, extension *model.DeadlineExtension) error {
+	m.extensions[extension.ID.Hex()] = extension
+	return nil
+}
+
+func (m *ExtensionMockRepository) GetByID(ctx context.Context, id string) (*model.DeadlineExtension, error) {
+	extension, ok := m.extensions[id]
+	if !ok {
+		return nil, nil
+	}
+	copied := *extension
+	return &copied, nil
+}
+
+func (m *ExtensionMockRepository) GetByAssignment(ctx context.Context, assignmentID string) ([]model.DeadlineExtension, error) {
+	extensions := []model.DeadlineExtension{}
+	for _, extension := range m.extensions {
+		if extension.AssignmentID == assignmentID {
+			extensions = append(extensions, *extension)
+		}
+	}
+	return extensions, nil
+}
+
+func (m *ExtensionMockRepository) GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.DeadlineExtension, error) {
+	extensions := []model.DeadlineExtension{}
+	for _, extension := range m.extensions {
+		for _, student := range extension.StudentUUIDs {
+			if extension.AssignmentID == assignmentID && student == studentUUID {
+				extensions = append(extensions, *extension)
+			}
+		}
+	}
+	return extensions, nil
+}
+
+func (m *ExtensionMockRepository) GetByCourse(ctx context.Context, courseID string) ([]model.DeadlineExtension, error) {
+	extensions := []model.DeadlineExtension{}
+	for _, extension := range m.extensions {
+		if extension.CourseID == courseID {
+			extensions = append(extensions, *extension)
+		}
+	}
+	return extensions, nil
+}
+
+func (m *ExtensionMockRepository) Delete(ctx context.Context, id string) error {
+	delete(m.extensions, id)
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+
+const extensionAssignmentID = "123456789012345678901234"
+
+func extensionAssignment() *model.Assignment {
+	return &model.Assignment{
+		ID:       mustParseSubmissionObjectID("assignment123"),
+		CourseID: "course123",
+		DueDate:  time.Now().Add(-2 * time.Hour),
+	}
+}
+
+func TestCreateExtension(t *testing.T) {
+	extensionRepo := NewExtensionMockRepository()
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	extension, err := extensionService.CreateExtension(context.TODO(), extensionAssignmentID, "teacher123", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+		Reason:       "Medical certificate",
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "course123", extension.CourseID)
+	assert.Equal(t, "teacher123", extension.GrantedBy)
+	assert.Len(t, extensionRepo.extensions, 1)
+}
+
+func TestCreateExtensionByAuxTeacher(t *testing.T) {
+	extensionRepo := NewExtensionMockRepository()
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	_, err := extensionService.CreateExtension(context.TODO(), extensionAssignmentID, "aux-teacher1", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student123", "student456"},
+		GroupName:    "Group 3",
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+	assert.NoError(t, err)
+}
+
+func TestCreateExtensionByOtherTeacher(t *testing.T) {
+	extensionRepo := NewExtensionMockRepository()
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	_, err := extensionService.CreateExtension(context.TODO(), extensionAssignmentID, "other-teacher", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+	assert.Equal(t, service.ErrUnauthorized, err)
+	assert.Empty(t, extensionRepo.extensions)
+}
+
+func TestCreateExtensionBeforeAssignmentDueDate(t *testing.T) {
+	extensionRepo := NewExtensionMockRepository()
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	_, err := extensionService.CreateExtension(context.TODO(), extensionAssignmentID, "teacher123", schemas.CreateExtensionRequest{
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(-3 * time.Hour),
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidExtension)
+}
+
+func TestUpdateExtensionFromOtherAssignment(t *testing.T) {
+	extension := model.DeadlineExtension{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: "other-assignment",
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	}
+	extensionRepo := NewExtensionMockRepository(extension)
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	_, err := extensionService.UpdateExtension(context.TODO(), extensionAssignmentID, extension.ID.Hex(), "teacher123", schemas.UpdateExtensionRequest{
+		DueDate: time.Now().Add(48 * time.Hour),
+	})
+	assert.Equal(t, service.ErrExtensionNotFound, err)
+}
+
+func TestDeleteExtension(t *testing.T) {
+	extension := model.DeadlineExtension{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: extensionAssignmentID,
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	}
+	extensionRepo := NewExtensionMockRepository(extension)
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionService := service.NewExtensionService(extensionRepo, assignmentRepo, &CourseMockService{})
+
+	err := extensionService.DeleteExtension(context.TODO(), extensionAssignmentID, extension.ID.Hex(), "teacher123")
+	assert.NoError(t, err)
+	assert.Equal(t, []string{extension.ID.Hex()}, extensionRepo.deleted)
+}
+
+func TestSubmitSubmissionWithExtensionIsNotLate(t *testing.T) {
+	submission := draftSubmission()
+	submission.AssignmentID = extensionAssignmentID
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	assignment := extensionAssignment()
+	assignment.LatePolicy = model.LatePolicyReject
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+	extensionRepo := NewExtensionMockRepository(model.DeadlineExtension{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: extensionAssignmentID,
+		StudentUUIDs: []string{"student123"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, extensionRepo, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusSubmitted, submissionRepo.updated.Status)
+}
+
+func TestSubmitSubmissionWithExtensionForOtherStudentIsLate(t *testing.T) {
+	submission := draftSubmission()
+	submission.AssignmentID = extensionAssignmentID
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: extensionAssignment()}
+	extensionRepo := NewExtensionMockRepository(model.DeadlineExtension{
+		ID:           primitive.NewObjectID(),
+		AssignmentID: extensionAssignmentID,
+		StudentUUIDs: []string{"student456"},
+		DueDate:      time.Now().Add(24 * time.Hour),
+	})
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, extensionRepo, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusLate, submissionRepo.updated.Status)
+}
@@ -0,0 +1,200 @@
+package service_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/config"
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// FeedbackMockCourseRepository returns the configured course
+type FeedbackMockCourseRepository struct {
+	MockCourseRepository
+	course *model.Course
+}
+
+func (m *FeedbackMockCourseRepository) GetCourseById(id string) (*model.Course, error) {
+	return m.course, nil
+}
+
+func (m *FeedbackMockCourseRepository) GetCourses() ([]*model.Course, error) {
+	return []*model.Course{m.course}, nil
+}
+
+// MockFeedbackAnalysisRepository keeps the analyses in memory by feedback
+type MockFeedbackAnalysisRepository struct {
+	analyses map[string]model.FeedbackAnalysis
+}
+
+func (m *MockFeedbackAnalysisRepository) SaveMany(ctx context.Context, analyses []model.FeedbackAnalysis) error {
+	if m.analyses == nil {
+		m.analyses = make(map[string]model.FeedbackAnalysis)
+	}
+	for _, analysis := range analyses {
+		m.analyses[analysis.FeedbackID] = analysis
+	}
+	return nil
+}
+
+func (m *MockFeedbackAnalysisRepository) GetByCourse(ctx context.Context, courseID string) ([]model.FeedbackAnalysis, error) {
+	analyses := []model.FeedbackAnalysis{}
+	for _, analysis := range m.analyses {
+		if analysis.CourseID == courseID {
+			analyses = append(analyses, analysis)
+		}
+	}
+	return analyses, nil
+}
+
+// FeedbackAnalysisAiProvider answers like the fake provider, or with the given errors one batch at a time
+type FeedbackAnalysisAiProvider struct {
+	ai.FakeProvider
+	errors  []error
+	batches [][]*model.CourseFeedback
+	scope   ai.Scope
+}
+
+func (p *FeedbackAnalysisAiProvider) AnalyzeFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (*schemas.FeedbackAnalysisResponse, error) {
+	p.scope = ai.ScopeFrom(ctx)
+	p.batches = append(p.batches, feedbacks)
+	if len(p.errors) > 0 {
+		err := p.errors[0]
+		p.errors = p.errors[1:]
+		if err != nil {
+			return nil, err
+		}
+	}
+	return p.FakeProvider.AnalyzeFeedbacks(ctx, feedbacks)
+}
+
+func courseFeedback(score int, feedbackType model.FeedbackType, text string, createdAt time.Time) model.CourseFeedback {
+	return model.CourseFeedback{
+		ID:           primitive.NewObjectID(),
+		StudentUUID:  "student123",
+		FeedbackType: feedbackType,
+		Score:        score,
+		Feedback:     text,
+		CreatedAt:    createdAt,
+	}
+}
+
+func feedbackCourse(feedbacks ...model.CourseFeedback) *model.Course {
+	return &model.Course{ID: primitive.NewObjectID(), TeacherUUID: "teacher123", Language: "pt", Feedback: feedbacks}
+}
+
+func TestAnalyzePendingFeedbackInBatches(t *testing.T) {
+	date := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	var feedbacks []model.CourseFeedback
+	for i := range 5 {
+		feedbacks = append(feedbacks, courseFeedback(5, model.FeedbackTypePositive, fmt.Sprintf("Feedback %d", i), date))
+	}
+	course := feedbackCourse(feedbacks...)
+	analysisRepo := &MockFeedbackAnalysisRepository{}
+	provider := &FeedbackAnalysisAiProvider{errors: []error{nil, fmt.Errorf("%w: got 1 analyses for 2 feedbacks", ai.ErrInvalidFeedbackAnalysis), ai.ErrUnavailable}}
+	analyticsService := service.NewFeedbackAnalyticsService(&FeedbackMockCourseRepository{course: course}, analysisRepo, provider, service.FeedbackAnalysisSettings{BatchSize: 2})
+
+	// The invalid answer is analyzed by keywords, the unavailable AI leaves the last feedback pending
+	analyzed, err := analyticsService.AnalyzePendingFeedback(context.TODO(), course.ID.Hex())
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+	assert.Equal(t, 4, analyzed)
+	assert.Len(t, provider.batches, 3)
+	assert.Equal(t, ai.Scope{CourseID: course.ID.Hex(), TeacherUUID: "teacher123", Language: "pt"}, provider.scope)
+	assert.Equal(t, model.FeedbackAnalysisMethodAI, analysisRepo.analyses[feedbacks[0].ID.Hex()].Method)
+	assert.Equal(t, ai.FakePromptVersion, analysisRepo.analyses[feedbacks[1].ID.Hex()].PromptVersion)
+	assert.Equal(t, model.FeedbackAnalysisMethodKeywords, analysisRepo.analyses[feedbacks[2].ID.Hex()].Method)
+	assert.Equal(t, course.ID.Hex(), analysisRepo.analyses[feedbacks[3].ID.Hex()].CourseID)
+	assert.NotContains(t, analysisRepo.analyses, feedbacks[4].ID.Hex())
+
+	// Only the pending feedback is sent again
+	analyzed, err = analyticsService.AnalyzePendingFeedback(context.TODO(), course.ID.Hex())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, analyzed)
+	assert.Equal(t, []*model.CourseFeedback{&course.Feedback[4]}, provider.batches[3])
+
+	analyzed, err = analyticsService.AnalyzePendingFeedback(context.TODO(), course.ID.Hex())
+	assert.NoError(t, err)
+	assert.Zero(t, analyzed)
+	assert.Len(t, provider.batches, 4)
+}
+
+func TestFeedbackTrendsWithKeywordAnalysis(t *testing.T) {
+	march := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	april := time.Date(2026, 4, 15, 10, 0, 0, 0, time.UTC)
+	course := feedbackCourse(
+		courseFeedback(5, model.FeedbackTypePositive, "La profesora explica excelente y los videos son muy útiles", march),
+		courseFeedback(2, model.FeedbackTypeNegative, "No me gustó el examen parcial, fue muy confuso", march.AddDate(0, 0, 1)),
+		courseFeedback(1, model.FeedbackTypeNegative, "The platform is slow and the deadlines were not clear", april),
+		courseFeedback(3, model.FeedbackTypeNeutral, "Sin comentarios", april.AddDate(0, 0, 1)),
+	)
+	// Without the AI every feedback is analyzed by keywords when the trends are requested
+	analyticsService := service.NewFeedbackAnalyticsService(&FeedbackMockCourseRepository{course: course}, &MockFeedbackAnalysisRepository{}, nil, service.FeedbackAnalysisSettings{BatchSize: 20})
+
+	_, err := analyticsService.AnalyzePendingFeedback(context.TODO(), course.ID.Hex())
+	assert.ErrorIs(t, err, ai.ErrUnavailable)
+
+	trends, err := analyticsService.GetFeedbackTrends(context.TODO(), course.ID.Hex(), time.Time{}, time.Time{}, "")
+	assert.NoError(t, err)
+	assert.Equal(t, schemas.FeedbackTrendMonth, trends.Interval)
+	assert.Equal(t, 4, trends.TotalFeedbacks)
+	assert.Equal(t, 4, trends.AnalyzedWithKeywords)
+	assert.Zero(t, trends.AnalyzedWithAI)
+	assert.Equal(t, 1, trends.Sentiment.Positive)
+	assert.Equal(t, 1, trends.Sentiment.Neutral)
+	assert.Equal(t, 2, trends.Sentiment.Negative)
+	assert.Equal(t, 2.75, trends.Sentiment.AverageRating)
+
+	mentions := make(map[model.FeedbackTopic]schemas.TopicTrend)
+	for _, topic := range trends.Topics {
+		mentions[topic.Topic] = topic
+	}
+	assert.Equal(t, 1, mentions[model.FeedbackTopicTeaching].Positive)
+	assert.Equal(t, 1, mentions[model.FeedbackTopicMaterials].Positive)
+	assert.Equal(t, 1, mentions[model.FeedbackTopicExams].Negative)
+	assert.Equal(t, 1, mentions[model.FeedbackTopicPlatform].Negative)
+	assert.Equal(t, 1, mentions[model.FeedbackTopicOrganization].Negative)
+	assert.NotContains(t, mentions, model.FeedbackTopicForum)
+
+	assert.Len(t, trends.Periods, 2)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), trends.Periods[0].PeriodStart)
+	assert.Equal(t, 2, trends.Periods[0].Sentiment.Total)
+	assert.Equal(t, time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC), trends.Periods[1].PeriodStart)
+	assert.Equal(t, 1, trends.Periods[1].Sentiment.Neutral)
+
+	weekly, err := analyticsService.GetFeedbackTrends(context.TODO(), course.ID.Hex(), april, april, schemas.FeedbackTrendWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, weekly.TotalFeedbacks)
+	assert.Equal(t, time.Date(2026, 4, 13, 0, 0, 0, 0, time.UTC), weekly.Periods[0].PeriodStart)
+
+	_, err = analyticsService.GetFeedbackTrends(context.TODO(), course.ID.Hex(), time.Time{}, time.Time{}, "year")
+	assert.ErrorIs(t, err, service.ErrInvalidTrendInterval)
+	_, err = analyticsService.GetFeedbackTrends(context.TODO(), course.ID.Hex(), april, march, "")
+	assert.ErrorIs(t, err, service.ErrInvalidDateRange)
+}
+
+func TestFeedbackTrendsUseStoredAnalyses(t *testing.T) {
+	date := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	course := feedbackCourse(
+		courseFeedback(5, model.FeedbackTypePositive, "Muy bueno", date),
+		courseFeedback(1, model.FeedbackTypeNegative, "Muy malo", date),
+	)
+	analysisRepo := &MockFeedbackAnalysisRepository{}
+	analyticsService := service.NewFeedbackAnalyticsService(&FeedbackMockCourseRepository{course: course}, analysisRepo, ai.NewFakeProvider(), service.NewFeedbackAnalysisSettings(&config.Config{}))
+
+	assert.NoError(t, analyticsService.AnalyzeAllCourses(context.TODO()))
+	trends, err := analyticsService.GetFeedbackTrends(context.TODO(), course.ID.Hex(), time.Time{}, time.Time{}, schemas.FeedbackTrendWeek)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, trends.AnalyzedWithAI)
+	assert.Equal(t, 1, trends.Sentiment.Positive)
+	assert.Equal(t, 1, trends.Sentiment.Negative)
+	assert.Equal(t, 0.0, trends.Sentiment.AverageSentiment)
+	assert.Empty(t, trends.Topics)
+}
@@ -0,0 +1,151 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+	"courses-service/src/storage"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type FileMockRepository struct {
+	files map[string]*model.StoredFile
+}
+
+func (m *FileMockRepository) Create(ctx context.Context, file *model.StoredFile) error {
+	if m.files == nil {
+		m.files = make(map[string]*model.StoredFile)
+	}
+	m.files[file.ID.Hex()] = file
+	return nil
+}
+
+func (m *FileMockRepository) GetByID(ctx context.Context, id string) (*model.StoredFile, error) {
+	return m.files[id], nil
+}
+
+func (m *FileMockRepository) Delete(ctx context.Context, id string) error {
+	delete(m.files, id)
+	return nil
+}
+
+type FileCourseMockService struct {
+	CourseMockService
+}
+
+func (m *FileCourseMockService) GetCourseMembers(courseId string) (*schemas.CourseMembersResponse, error) {
+	return &schemas.CourseMembersResponse{
+		TeacherID:      "teacher123",
+		AuxTeachersIDs: []string{"aux-teacher1"},
+		StudentsIDs:    []string{"student123", "student456"},
+	}, nil
+}
+
+var pdfContent = []byte("%PDF-1.4\n1 0 obj\n<< /Type /Catalog >>\nendobj\n")
+
+func newTestFileService(t *testing.T) (*service.FileService, *FileMockRepository) {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	fileRepo := &FileMockRepository{}
+	settings := service.FileSettings{
+		MaxSize:      1 << 10,
+		AllowedTypes: []string{"application/pdf"},
+		SigningKey:   []byte("test-key"),
+		LinkTTL:      time.Minute,
+	}
+	return service.NewFileService(fileRepo, fileStorage, &FileCourseMockService{}, settings), fileRepo
+}
+
+func uploadRequest(purpose model.FilePurpose) schemas.UploadFileRequest {
+	return schemas.UploadFileRequest{CourseID: "course123", Purpose: purpose}
+}
+
+func TestUploadFileAndDownloadWithSignedLink(t *testing.T) {
+	fileService, _ := newTestFileService(t)
+	checksum := sha256.Sum256(pdfContent)
+
+	request := uploadRequest(model.FilePurposeSubmission)
+	request.Checksum = hex.EncodeToString(checksum[:])
+	uploaded, err := fileService.UploadFile(context.TODO(), "student123", request, "../tp1.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.NoError(t, err)
+	assert.Equal(t, "tp1.pdf", uploaded.File.Name)
+	assert.Equal(t, "application/pdf", uploaded.File.ContentType)
+	assert.Equal(t, request.Checksum, uploaded.File.Checksum)
+
+	link, err := url.Parse(uploaded.DownloadURL)
+	assert.NoError(t, err)
+	expires, _ := strconv.ParseInt(link.Query().Get("expires"), 10, 64)
+	fileID := uploaded.File.ID.Hex()
+
+	file, content, err := fileService.OpenFile(context.TODO(), fileID, expires, link.Query().Get("signature"))
+	assert.NoError(t, err)
+	defer content.Close()
+	data, _ := io.ReadAll(content)
+	assert.Equal(t, pdfContent, data)
+	assert.Equal(t, fileID, file.ID.Hex())
+
+	// Tampered or expired links are rejected
+	_, _, err = fileService.OpenFile(context.TODO(), fileID, expires+60, link.Query().Get("signature"))
+	assert.ErrorIs(t, err, service.ErrInvalidFileLink)
+	_, _, err = fileService.OpenFile(context.TODO(), fileID, time.Now().Add(-time.Minute).Unix(), link.Query().Get("signature"))
+	assert.ErrorIs(t, err, service.ErrInvalidFileLink)
+}
+
+func TestUploadFileRejectsInvalidFiles(t *testing.T) {
+	fileService, fileRepo := newTestFileService(t)
+
+	_, err := fileService.UploadFile(context.TODO(), "student123", uploadRequest(model.FilePurposeSubmission), "big.pdf", 2<<10, bytes.NewReader(pdfContent))
+	assert.ErrorIs(t, err, service.ErrFileTooLarge)
+
+	// The type is detected from the content, not from the name
+	script := []byte("#!/bin/sh\necho hi\n")
+	_, err = fileService.UploadFile(context.TODO(), "student123", uploadRequest(model.FilePurposeSubmission), "script.pdf", int64(len(script)), bytes.NewReader(script))
+	assert.ErrorIs(t, err, service.ErrFileTypeNotAllowed)
+
+	request := uploadRequest(model.FilePurposeSubmission)
+	request.Checksum = strings.Repeat("0", 64)
+	_, err = fileService.UploadFile(context.TODO(), "student123", request, "tp1.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.ErrorIs(t, err, service.ErrFileChecksumMismatch)
+
+	_, err = fileService.UploadFile(context.TODO(), "student123", uploadRequest("avatar"), "tp1.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.ErrorIs(t, err, service.ErrInvalidFile)
+
+	assert.Empty(t, fileRepo.files)
+}
+
+func TestFileAccessDependsOnCourseRole(t *testing.T) {
+	fileService, _ := newTestFileService(t)
+
+	// Only teachers upload module resources
+	_, err := fileService.UploadFile(context.TODO(), "student123", uploadRequest(model.FilePurposeModuleResource), "notes.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	_, err = fileService.UploadFile(context.TODO(), "outsider", uploadRequest(model.FilePurposeSubmission), "tp1.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+	submission, err := fileService.UploadFile(context.TODO(), "student123", uploadRequest(model.FilePurposeSubmission), "tp1.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.NoError(t, err)
+	resource, err := fileService.UploadFile(context.TODO(), "aux-teacher1", uploadRequest(model.FilePurposeModuleResource), "notes.pdf", int64(len(pdfContent)), bytes.NewReader(pdfContent))
+	assert.NoError(t, err)
+
+	// Submissions are private to their owner and the teachers, resources are visible to every student
+	_, err = fileService.GetFile(context.TODO(), submission.File.ID.Hex(), "teacher123")
+	assert.NoError(t, err)
+	_, err = fileService.GetFile(context.TODO(), submission.File.ID.Hex(), "student456")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	_, err = fileService.GetFile(context.TODO(), resource.File.ID.Hex(), "student456")
+	assert.NoError(t, err)
+	_, err = fileService.GetFile(context.TODO(), "64b7f0f0f0f0f0f0f0f0f0f0", "student123")
+	assert.ErrorIs(t, err, service.ErrFileNotFound)
+}
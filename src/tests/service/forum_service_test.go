@@ -200,6 +200,10 @@ func (m *MockForumRepository) AcceptAnswer(questionID, answerID string) error {
 	return nil
 }
 
+func (m *MockForumRepository) ReviewAnswerSuggestion(questionID, answerID, content string, suggestion model.AnswerSuggestion) (*model.ForumAnswer, error) {
+	return &model.ForumAnswer{ID: answerID, Content: content, Type: model.AnswerTypeAiSuggestion, Suggestion: &suggestion}, nil
+}
+
 func (m *MockForumRepository) AddVoteToQuestion(questionID, userID string, voteType int) error {
 	if questionID == "non-existent-question" {
 		return errors.New("question not found")
@@ -317,6 +321,10 @@ func (m *MockForumCourseRepository) GetCourseById(id string) (*model.Course, err
 		Description: "Test Description",
 		TeacherUUID: "teacher-123",
 		Capacity:    30,
+		AuxTeachers: []string{"aux-moderator", "aux-grader"},
+		AuxTeacherPermissions: map[string][]model.AuxTeacherPermission{
+			"aux-grader": {model.PermissionGradeSubmissions},
+		},
 	}, nil
 }
 
@@ -360,6 +368,18 @@ func (m *MockForumCourseRepository) RemoveAuxTeacherFromCourse(course *model.Cou
 	return &model.Course{}, nil
 }
 
+func (m *MockForumCourseRepository) UpdateAuxTeacherPermissions(course *model.Course, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return &model.Course{}, nil
+}
+
+func (m *MockForumCourseRepository) UpdateCourseStatus(course *model.Course, status model.CourseStatus) (*model.Course, error) {
+	return &model.Course{}, nil
+}
+
+func (m *MockForumCourseRepository) ReassignTeacher(course *model.Course, teacherUUID, teacherName string) (*model.Course, error) {
+	return &model.Course{}, nil
+}
+
 func (m *MockForumCourseRepository) GetCoursesByAuxTeacherId(auxTeacherId string) ([]*model.Course, error) {
 	return []*model.Course{}, nil
 }
@@ -577,7 +597,7 @@ func TestUpdateQuestion(t *testing.T) {
 	courseRepo := &MockForumCourseRepository{}
 	forumService := service.NewForumService(forumRepo, courseRepo)
 
-	question, err := forumService.UpdateQuestion("question-123", "Updated Title", "Updated Description", []model.QuestionTag{model.QuestionTagPractica})
+	question, err := forumService.UpdateQuestion("question-123", "author-123", "Updated Title", "Updated Description", []model.QuestionTag{model.QuestionTagPractica})
 
 	assert.NoError(t, err)
 	assert.NotNil(t, question)
@@ -591,7 +611,7 @@ func TestUpdateQuestionWithEmptyID(t *testing.T) {
 	courseRepo := &MockForumCourseRepository{}
 	forumService := service.NewForumService(forumRepo, courseRepo)
 
-	question, err := forumService.UpdateQuestion("", "Updated Title", "Updated Description", []model.QuestionTag{model.QuestionTagPractica})
+	question, err := forumService.UpdateQuestion("", "author-123", "Updated Title", "Updated Description", []model.QuestionTag{model.QuestionTagPractica})
 
 	assert.Error(t, err)
 	assert.Nil(t, question)
@@ -603,7 +623,7 @@ func TestUpdateQuestionWithNoFields(t *testing.T) {
 	courseRepo := &MockForumCourseRepository{}
 	forumService := service.NewForumService(forumRepo, courseRepo)
 
-	question, err := forumService.UpdateQuestion("question-123", "", "", []model.QuestionTag{})
+	question, err := forumService.UpdateQuestion("question-123", "author-123", "", "", []model.QuestionTag{})
 
 	assert.Error(t, err)
 	assert.Nil(t, question)
@@ -653,6 +673,56 @@ func TestDeleteQuestionWithWrongAuthor(t *testing.T) {
 	assert.Equal(t, "you can only delete your own questions", err.Error())
 }
 
+func TestUpdateQuestionWithWrongAuthor(t *testing.T) {
+	forumRepo := &MockForumRepository{}
+	courseRepo := &MockForumCourseRepository{}
+	forumService := service.NewForumService(forumRepo, courseRepo)
+
+	question, err := forumService.UpdateQuestion("question-author-123", "wrong-author", "Updated Title", "", nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, question)
+	assert.Equal(t, "you can only update your own questions", err.Error())
+}
+
+func TestForumModeration(t *testing.T) {
+	forumRepo := &MockForumRepository{}
+	courseRepo := &MockForumCourseRepository{}
+	forumService := service.NewForumService(forumRepo, courseRepo)
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedError bool
+	}{
+		{name: "titular teacher", userID: "teacher-123"},
+		{name: "aux teacher with the moderate forum permission", userID: "aux-moderator"},
+		{name: "aux teacher without the moderate forum permission", userID: "aux-grader", expectedError: true},
+		{name: "another student", userID: "student-456", expectedError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, updateErr := forumService.UpdateQuestion("question-author-123", tt.userID, "Moderated Title", "", nil)
+			deleteErr := forumService.DeleteQuestion("question-author-123", tt.userID)
+			deleteAnswerErr := forumService.DeleteAnswer("question-with-answers", "answer-123", tt.userID)
+			_, updateAnswerErr := forumService.UpdateAnswer("question-with-answers", "answer-123", tt.userID, "Moderated content")
+
+			if tt.expectedError {
+				assert.EqualError(t, updateErr, "you can only update your own questions")
+				assert.EqualError(t, deleteErr, "you can only delete your own questions")
+				assert.EqualError(t, deleteAnswerErr, "you can only delete your own answers")
+			} else {
+				assert.NoError(t, updateErr)
+				assert.NoError(t, deleteErr)
+				assert.NoError(t, deleteAnswerErr)
+			}
+			// The moderators remove the answers, they don't write them for their authors
+			assert.EqualError(t, updateAnswerErr, "you can only update your own answers")
+		})
+	}
+}
+
 func TestAddAnswer(t *testing.T) {
 	forumRepo := &MockForumRepository{}
 	courseRepo := &MockForumCourseRepository{}
@@ -1035,7 +1105,7 @@ func TestUpdateQuestionWithInvalidTags(t *testing.T) {
 	forumService := service.NewForumService(mockForumRepo, mockCourseRepo)
 
 	invalidTags := []model.QuestionTag{"invalid-tag"}
-	_, err := forumService.UpdateQuestion("valid-question", "New Title", "New Description", invalidTags)
+	_, err := forumService.UpdateQuestion("valid-question", "author-123", "New Title", "New Description", invalidTags)
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid tag")
 }
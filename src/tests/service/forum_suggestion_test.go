@@ -0,0 +1,232 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// SuggestionMockForumRepository keeps the questions in memory
+type SuggestionMockForumRepository struct {
+	MockForumRepository
+	questions []*model.ForumQuestion
+}
+
+func (m *SuggestionMockForumRepository) find(id string) *model.ForumQuestion {
+	for _, question := range m.questions {
+		if question.ID.Hex() == id {
+			return question
+		}
+	}
+	return nil
+}
+
+func (m *SuggestionMockForumRepository) GetQuestionById(id string) (*model.ForumQuestion, error) {
+	question := m.find(id)
+	if question == nil {
+		return nil, errors.New("question not found")
+	}
+	copied := *question
+	copied.Answers = append([]model.ForumAnswer{}, question.Answers...)
+	return &copied, nil
+}
+
+func (m *SuggestionMockForumRepository) GetQuestionsByCourseId(courseID string) ([]model.ForumQuestion, error) {
+	var questions []model.ForumQuestion
+	for _, question := range m.questions {
+		if question.CourseID == courseID {
+			copied := *question
+			copied.Answers = append([]model.ForumAnswer{}, question.Answers...)
+			questions = append(questions, copied)
+		}
+	}
+	return questions, nil
+}
+
+func (m *SuggestionMockForumRepository) AddAnswer(questionID string, answer model.ForumAnswer) (*model.ForumAnswer, error) {
+	answer.ID = primitive.NewObjectID().Hex()
+	question := m.find(questionID)
+	question.Answers = append(question.Answers, answer)
+	return &answer, nil
+}
+
+func (m *SuggestionMockForumRepository) DeleteAnswer(questionID, answerID string) error {
+	question := m.find(questionID)
+	for i, answer := range question.Answers {
+		if answer.ID == answerID {
+			question.Answers = append(question.Answers[:i], question.Answers[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("answer not found")
+}
+
+func (m *SuggestionMockForumRepository) ReviewAnswerSuggestion(questionID, answerID, content string, suggestion model.AnswerSuggestion) (*model.ForumAnswer, error) {
+	question := m.find(questionID)
+	for i := range question.Answers {
+		if question.Answers[i].ID == answerID {
+			if content != "" {
+				question.Answers[i].Content = content
+			}
+			question.Answers[i].Suggestion = &suggestion
+			return &question.Answers[i], nil
+		}
+	}
+	return nil, errors.New("answer not found")
+}
+
+// SuggestionMockCourseService returns the configured course
+type SuggestionMockCourseService struct {
+	CourseMockService
+	course *model.Course
+}
+
+func (m *SuggestionMockCourseService) GetCourseById(id string) (*model.Course, error) {
+	return m.course, nil
+}
+
+// ForumRecordingAiProvider answers like the fake provider and keeps the accepted answers it received
+type ForumRecordingAiProvider struct {
+	ai.FakeProvider
+	scope    ai.Scope
+	resolved []model.ForumQuestion
+}
+
+func (p *ForumRecordingAiProvider) SuggestForumAnswer(ctx context.Context, question *model.ForumQuestion, modules []model.Module, resolved []model.ForumQuestion) (*schemas.ForumAnswerSuggestion, error) {
+	p.scope = ai.ScopeFrom(ctx)
+	p.resolved = resolved
+	return p.FakeProvider.SuggestForumAnswer(ctx, question, modules, resolved)
+}
+
+func suggestionCourse(enabled bool) *model.Course {
+	return &model.Course{TeacherUUID: "teacher123", AuxTeachers: []string{"aux-teacher1"}, Language: "en", ForumAiSuggestions: &enabled}
+}
+
+func forumQuestion(status model.QuestionStatus, age time.Duration, answers ...model.ForumAnswer) *model.ForumQuestion {
+	return &model.ForumQuestion{
+		ID:        primitive.NewObjectID(),
+		CourseID:  "course123",
+		AuthorID:  "student123",
+		Title:     "¿Cómo cierro un channel?",
+		Status:    status,
+		Answers:   answers,
+		CreatedAt: time.Now().Add(-age),
+	}
+}
+
+func TestSuggestForumAnswerIsHiddenUntilApproved(t *testing.T) {
+	open := forumQuestion(model.QuestionStatusOpen, time.Hour)
+	acceptedID := "accepted-answer"
+	resolved := forumQuestion(model.QuestionStatusResolved, 72*time.Hour, model.ForumAnswer{ID: acceptedID, Content: "Con close(ch)", IsAccepted: true})
+	resolved.AcceptedAnswerID = &acceptedID
+	forumRepo := &SuggestionMockForumRepository{questions: []*model.ForumQuestion{open, resolved}}
+	provider := &ForumRecordingAiProvider{}
+	suggestionService := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: suggestionCourse(true)}, provider, nil)
+	forumService := service.NewForumService(forumRepo, &MockForumCourseRepository{})
+
+	suggestion, err := suggestionService.SuggestAnswer(context.TODO(), open.ID.Hex(), "aux-teacher1")
+	assert.NoError(t, err)
+	assert.Equal(t, model.AnswerTypeAiSuggestion, suggestion.Answer.Type)
+	assert.Equal(t, model.SuggestionStatusPending, suggestion.Answer.Suggestion.Status)
+	assert.Equal(t, ai.FakePromptVersion, suggestion.Answer.Suggestion.PromptVersion)
+	assert.Equal(t, "course123", suggestion.CourseID)
+	assert.Equal(t, []model.ForumQuestion{*resolved}, provider.resolved)
+	assert.Equal(t, ai.Scope{CourseID: "course123", TeacherUUID: "teacher123", Language: "en"}, provider.scope)
+
+	_, err = suggestionService.SuggestAnswer(context.TODO(), open.ID.Hex(), "teacher123")
+	assert.ErrorIs(t, err, service.ErrSuggestionAlreadyPending)
+
+	// The students don't see the suggestion nor can accept it
+	question, err := forumService.GetQuestionById(open.ID.Hex())
+	assert.NoError(t, err)
+	assert.Empty(t, question.Answers)
+	err = forumService.AcceptAnswer(open.ID.Hex(), suggestion.Answer.ID, "student123")
+	assert.EqualError(t, err, "answer not found")
+
+	pending, err := suggestionService.GetPendingSuggestions("course123", "teacher123")
+	assert.NoError(t, err)
+	assert.Len(t, pending, 1)
+
+	approved, err := suggestionService.ApproveSuggestion(open.ID.Hex(), suggestion.Answer.ID, "teacher123", " Con close(ch), solo desde el emisor ")
+	assert.NoError(t, err)
+	assert.Equal(t, "Con close(ch), solo desde el emisor", approved.Answer.Content)
+	assert.Equal(t, model.SuggestionStatusApproved, approved.Answer.Suggestion.Status)
+	assert.Equal(t, "teacher123", approved.Answer.Suggestion.ReviewedBy)
+	assert.Equal(t, ai.FakePromptVersion, approved.Answer.Suggestion.PromptVersion)
+
+	question, err = forumService.GetQuestionById(open.ID.Hex())
+	assert.NoError(t, err)
+	assert.Len(t, question.Answers, 1)
+	assert.Equal(t, model.AnswerTypeAiSuggestion, question.Answers[0].Type)
+
+	_, err = suggestionService.DiscardSuggestion(open.ID.Hex(), suggestion.Answer.ID, "teacher123")
+	assert.ErrorIs(t, err, service.ErrSuggestionNotFound)
+}
+
+func TestSuggestForumAnswerRequiresOptIn(t *testing.T) {
+	open := forumQuestion(model.QuestionStatusOpen, time.Hour)
+	closed := forumQuestion(model.QuestionStatusClosed, time.Hour)
+	forumRepo := &SuggestionMockForumRepository{questions: []*model.ForumQuestion{open, closed}}
+
+	disabled := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: suggestionCourse(false)}, ai.NewFakeProvider(), nil)
+	_, err := disabled.SuggestAnswer(context.TODO(), open.ID.Hex(), "teacher123")
+	assert.ErrorIs(t, err, service.ErrForumSuggestionsDisabled)
+
+	notSet := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: &model.Course{TeacherUUID: "teacher123"}}, ai.NewFakeProvider(), nil)
+	_, err = notSet.SuggestAnswersForUnanswered(context.TODO(), "course123", "teacher123", time.Minute)
+	assert.ErrorIs(t, err, service.ErrForumSuggestionsDisabled)
+
+	enabled := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: suggestionCourse(true)}, ai.NewFakeProvider(), nil)
+	_, err = enabled.SuggestAnswer(context.TODO(), open.ID.Hex(), "student123")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	_, err = enabled.SuggestAnswer(context.TODO(), closed.ID.Hex(), "teacher123")
+	assert.ErrorIs(t, err, service.ErrForumQuestionNotOpen)
+	_, err = enabled.SuggestAnswer(context.TODO(), primitive.NewObjectID().Hex(), "teacher123")
+	assert.ErrorIs(t, err, service.ErrForumQuestionNotFound)
+}
+
+func TestSuggestAnswersForUnansweredQuestions(t *testing.T) {
+	oldest := forumQuestion(model.QuestionStatusOpen, 96*time.Hour)
+	old := forumQuestion(model.QuestionStatusOpen, 72*time.Hour)
+	recent := forumQuestion(model.QuestionStatusOpen, time.Hour)
+	answered := forumQuestion(model.QuestionStatusOpen, 72*time.Hour, model.ForumAnswer{ID: "answer123", Content: "Con close(ch)"})
+	forumRepo := &SuggestionMockForumRepository{questions: []*model.ForumQuestion{recent, old, answered, oldest}}
+	suggestionService := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: suggestionCourse(true)}, ai.NewFakeProvider(), nil)
+
+	suggestions, err := suggestionService.SuggestAnswersForUnanswered(context.TODO(), "course123", "teacher123", service.DefaultUnansweredAge)
+	assert.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+	assert.Equal(t, oldest.ID.Hex(), suggestions[0].QuestionID)
+	assert.Equal(t, old.ID.Hex(), suggestions[1].QuestionID)
+
+	// The questions with a pending suggestion are not drafted again
+	suggestions, err = suggestionService.SuggestAnswersForUnanswered(context.TODO(), "course123", "teacher123", service.DefaultUnansweredAge)
+	assert.NoError(t, err)
+	assert.Empty(t, suggestions)
+
+	discarded, err := suggestionService.DiscardSuggestion(old.ID.Hex(), old.Answers[0].ID, "aux-teacher1")
+	assert.NoError(t, err)
+	assert.Equal(t, old.ID.Hex(), discarded.QuestionID)
+	assert.Empty(t, old.Answers)
+}
+
+func TestSuggestAnswersForUnansweredIsAudited(t *testing.T) {
+	oldest := forumQuestion(model.QuestionStatusOpen, 96*time.Hour)
+	old := forumQuestion(model.QuestionStatusOpen, 72*time.Hour)
+	forumRepo := &SuggestionMockForumRepository{questions: []*model.ForumQuestion{old, oldest}}
+	recorder := &MockAuditRecorder{}
+	suggestionService := service.NewForumSuggestionService(forumRepo, &MockModuleRepository{}, &SuggestionMockCourseService{course: suggestionCourse(true)}, ai.NewFakeProvider(), recorder)
+
+	_, err := suggestionService.SuggestAnswersForUnanswered(context.TODO(), "course123", "teacher123", service.DefaultUnansweredAge)
+	assert.NoError(t, err)
+	assertSystemChanges(t, recorder, model.AuditEntityForumQuestion, "forum_suggestions", oldest.ID.Hex(), old.ID.Hex())
+}
@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"testing"
+
+	"courses-service/src/auth"
+	"courses-service/src/model"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func newMembershipService(course *model.Course) *service.MembershipService {
+	return service.NewMembershipService(
+		&FeedbackMockCourseRepository{course: course},
+		&MockEnrollmentRepository{},
+		&MockModuleRepository{},
+		&MockAssignmentRepository{},
+		&MockForumRepository{},
+	)
+}
+
+func TestGetMembership(t *testing.T) {
+	course := &model.Course{TeacherUUID: "titular-teacher", AuxTeachers: []string{"aux-teacher", "enrolled-teacher"}}
+	membershipService := newMembershipService(course)
+
+	tests := []struct {
+		name          string
+		userID        string
+		expectedRoles []string
+	}{
+		{name: "titular teacher", userID: "titular-teacher", expectedRoles: []string{auth.RoleTitularTeacher}},
+		{name: "aux teacher", userID: "aux-teacher", expectedRoles: []string{auth.RoleAuxTeacher}},
+		{name: "enrolled student", userID: "enrolled-student", expectedRoles: []string{auth.RoleEnrolledStudent}},
+		{name: "aux teacher enrolled as student", userID: "enrolled-teacher", expectedRoles: []string{auth.RoleAuxTeacher, auth.RoleEnrolledStudent}},
+		{name: "not a member", userID: "non-enrolled-student", expectedRoles: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			membership, err := membershipService.GetMembership("course123", tt.userID)
+			assert.NoError(t, err)
+			assert.Equal(t, &auth.Membership{CourseID: "course123", UserID: tt.userID, Roles: tt.expectedRoles, CourseStatus: "active"}, membership)
+		})
+	}
+
+	course.Status = model.CourseStatusArchived
+	membership, err := membershipService.GetMembership("course123", "titular-teacher")
+	assert.NoError(t, err)
+	assert.Equal(t, "archived", membership.CourseStatus)
+
+	_, err = membershipService.GetMembership("course123", "error-checking-student")
+	assert.EqualError(t, err, "Error checking enrollment")
+
+	_, err = newMembershipService(nil).GetMembership("course123", "titular-teacher")
+	assert.ErrorIs(t, err, service.ErrCourseNotFound)
+}
+
+func TestGetCourseOfCourseItems(t *testing.T) {
+	membershipService := newMembershipService(nil)
+
+	courseID, err := membershipService.GetModuleCourseID("valid-module-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "valid-course-id", courseID)
+	_, err = membershipService.GetModuleCourseID("nonexistent-module")
+	assert.ErrorIs(t, err, service.ErrModuleNotFound)
+
+	courseID, err = membershipService.GetAssignmentCourseID("valid-assignment-id")
+	assert.NoError(t, err)
+	assert.Equal(t, "course123", courseID)
+	_, err = membershipService.GetAssignmentCourseID("nonexistent-assignment")
+	assert.ErrorIs(t, err, service.ErrAssignmentNotFound)
+
+	courseID, err = membershipService.GetQuestionCourseID("question-with-answers")
+	assert.NoError(t, err)
+	assert.Equal(t, "course-123", courseID)
+	_, err = membershipService.GetQuestionCourseID("non-existent-question")
+	assert.ErrorIs(t, err, service.ErrForumQuestionNotFound)
+}
+
+func TestIsStudentTeacher(t *testing.T) {
+	membershipService := newMembershipService(nil)
+	studentID := "123e4567-e89b-12d3-a456-426614174000"
+
+	tests := []struct {
+		name      string
+		teacherID string
+		studentID string
+		expected  bool
+	}{
+		{name: "titular teacher of a course of the student", teacherID: "teacher-123", studentID: studentID, expected: true},
+		{name: "aux teacher of a course of the student", teacherID: "aux-teacher-1", studentID: studentID, expected: true},
+		{name: "teacher of other courses", teacherID: "teacher-456", studentID: studentID, expected: false},
+		{name: "student without courses", teacherID: "teacher-123", studentID: "student-without-courses", expected: false},
+		{name: "without teacher", teacherID: "", studentID: studentID, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			isTeacher, err := membershipService.IsStudentTeacher(tt.teacherID, tt.studentID)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, isTeacher)
+		})
+	}
+}
@@ -14,6 +14,21 @@ import (
 
 type MockModuleRepository struct{}
 
+// ModuleMockCourseService returns the same course for every ID, with an aux teacher that can only grade
+type ModuleMockCourseService struct {
+	CourseMockService
+}
+
+func (m *ModuleMockCourseService) GetCourseById(id string) (*model.Course, error) {
+	return &model.Course{
+		TeacherUUID: "teacher123",
+		AuxTeachers: []string{"aux-teacher1", "grading-aux-teacher"},
+		AuxTeacherPermissions: map[string][]model.AuxTeacherPermission{
+			"grading-aux-teacher": {model.PermissionGradeSubmissions},
+		},
+	}, nil
+}
+
 // GetNextModuleOrder implements repository.ModuleRepositoryInterface.
 func (m *MockModuleRepository) GetNextModuleOrder(courseID string) (int, error) {
 	if courseID == "course-with-modules" {
@@ -91,6 +106,9 @@ func (m *MockModuleRepository) GetModuleById(id string) (*model.Module, error) {
 	if id == "invalid-module-id" {
 		return nil, errors.New("invalid module ID")
 	}
+	if id == "error-deleting-module" {
+		return &model.Module{ID: primitive.NewObjectID(), Title: "Test Module", CourseID: "valid-course-id"}, nil
+	}
 	return nil, errors.New("module not found")
 }
 
@@ -290,7 +308,7 @@ func mustParseModuleObjectID(id string) primitive.ObjectID {
 
 // Tests for CreateModule
 func TestCreateModule(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	request := schemas.CreateModuleRequest{
 		Title:       "New Module",
@@ -298,7 +316,7 @@ func TestCreateModule(t *testing.T) {
 		CourseID:    "empty-course",
 	}
 
-	module, err := moduleService.CreateModule(request)
+	module, err := moduleService.CreateModule("teacher123", request)
 	assert.NoError(t, err)
 	assert.NotNil(t, module)
 	assert.Equal(t, request.Title, module.Title)
@@ -312,7 +330,7 @@ func TestCreateModule(t *testing.T) {
 }
 
 func TestCreateModuleWithExistingTitle(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	request := schemas.CreateModuleRequest{
 		Title:       "Existing Module",
@@ -320,14 +338,14 @@ func TestCreateModuleWithExistingTitle(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.CreateModule(request)
+	module, err := moduleService.CreateModule("teacher123", request)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "module with title Existing Module already exists")
 }
 
 func TestCreateModuleWithErrorGettingOrder(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	request := schemas.CreateModuleRequest{
 		Title:       "New Module",
@@ -335,14 +353,14 @@ func TestCreateModuleWithErrorGettingOrder(t *testing.T) {
 		CourseID:    "error-course",
 	}
 
-	module, err := moduleService.CreateModule(request)
+	module, err := moduleService.CreateModule("teacher123", request)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "Error getting next module order")
 }
 
 func TestCreateModuleWithErrorCreating(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	request := schemas.CreateModuleRequest{
 		Title:       "New Module",
@@ -350,7 +368,7 @@ func TestCreateModuleWithErrorCreating(t *testing.T) {
 		CourseID:    "error-creating-course",
 	}
 
-	module, err := moduleService.CreateModule(request)
+	module, err := moduleService.CreateModule("teacher123", request)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "Error creating module")
@@ -358,7 +376,7 @@ func TestCreateModuleWithErrorCreating(t *testing.T) {
 
 // Tests for GetModulesByCourseId
 func TestGetModulesByCourseId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	modules, err := moduleService.GetModulesByCourseId("course-with-modules")
 	assert.NoError(t, err)
@@ -369,7 +387,7 @@ func TestGetModulesByCourseId(t *testing.T) {
 }
 
 func TestGetModulesByCourseIdWithEmptyCourse(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	modules, err := moduleService.GetModulesByCourseId("empty-course")
 	assert.NoError(t, err)
@@ -378,7 +396,7 @@ func TestGetModulesByCourseIdWithEmptyCourse(t *testing.T) {
 }
 
 func TestGetModulesByCourseIdWithEmptyId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	modules, err := moduleService.GetModulesByCourseId("")
 	assert.Error(t, err)
@@ -387,7 +405,7 @@ func TestGetModulesByCourseIdWithEmptyId(t *testing.T) {
 }
 
 func TestGetModulesByCourseIdWithError(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	modules, err := moduleService.GetModulesByCourseId("error-course")
 	assert.Error(t, err)
@@ -397,7 +415,7 @@ func TestGetModulesByCourseIdWithError(t *testing.T) {
 
 // Tests for GetModuleById
 func TestGetModuleById(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleById("valid-module-id")
 	assert.NoError(t, err)
@@ -408,7 +426,7 @@ func TestGetModuleById(t *testing.T) {
 }
 
 func TestGetModuleByIdWithEmptyId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleById("")
 	assert.Error(t, err)
@@ -417,7 +435,7 @@ func TestGetModuleByIdWithEmptyId(t *testing.T) {
 }
 
 func TestGetModuleByIdWithError(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleById("error-module-id")
 	assert.Error(t, err)
@@ -426,7 +444,7 @@ func TestGetModuleByIdWithError(t *testing.T) {
 }
 
 func TestGetModuleByIdWithInvalidId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleById("invalid-module-id")
 	assert.Error(t, err)
@@ -436,7 +454,7 @@ func TestGetModuleByIdWithInvalidId(t *testing.T) {
 
 // Tests for GetModuleByOrder
 func TestGetModuleByOrder(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleByOrder("valid-course-id", 1)
 	assert.NoError(t, err)
@@ -446,7 +464,7 @@ func TestGetModuleByOrder(t *testing.T) {
 }
 
 func TestGetModuleByOrderWithEmptyCourseId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleByOrder("", 1)
 	assert.Error(t, err)
@@ -455,7 +473,7 @@ func TestGetModuleByOrderWithEmptyCourseId(t *testing.T) {
 }
 
 func TestGetModuleByOrderWithError(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleByOrder("error-course", 1)
 	assert.Error(t, err)
@@ -463,9 +481,30 @@ func TestGetModuleByOrderWithError(t *testing.T) {
 	assert.Contains(t, err.Error(), "Error getting module by order")
 }
 
+func TestCreateModuleWithAuxTeacherPermissions(t *testing.T) {
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
+
+	request := schemas.CreateModuleRequest{
+		Title:    "New Module",
+		CourseID: "empty-course",
+	}
+
+	module, err := moduleService.CreateModule("aux-teacher1", request)
+	assert.NoError(t, err)
+	assert.NotNil(t, module)
+
+	module, err = moduleService.CreateModule("grading-aux-teacher", request)
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	assert.Nil(t, module)
+
+	module, err = moduleService.CreateModule("student123", request)
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	assert.Nil(t, module)
+}
+
 // Tests for UpdateModule
 func TestUpdateModule(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		ID:          mustParseModuleObjectID("valid-module-id"),
@@ -476,7 +515,7 @@ func TestUpdateModule(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("valid-module-id", updateModule)
+	module, err := moduleService.UpdateModule("valid-module-id", "teacher123", updateModule)
 	assert.NoError(t, err)
 	assert.NotNil(t, module)
 	assert.Equal(t, updateModule.Title, module.Title)
@@ -486,7 +525,7 @@ func TestUpdateModule(t *testing.T) {
 }
 
 func TestUpdateModuleWithEmptyId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		Title:       "Updated Module",
@@ -495,14 +534,14 @@ func TestUpdateModuleWithEmptyId(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("", updateModule)
+	module, err := moduleService.UpdateModule("", "teacher123", updateModule)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "module id is required")
 }
 
 func TestUpdateModuleWithExistingTitleConflict(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		ID:          mustParseModuleObjectID("valid-module-id"),
@@ -512,14 +551,14 @@ func TestUpdateModuleWithExistingTitleConflict(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("valid-module-id", updateModule)
+	module, err := moduleService.UpdateModule("valid-module-id", "teacher123", updateModule)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "module with title Different Module already exists")
 }
 
 func TestUpdateModuleWithErrorGettingByName(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		Title:       "Some Module",
@@ -530,14 +569,14 @@ func TestUpdateModuleWithErrorGettingByName(t *testing.T) {
 
 	// Este test debe fallar porque GetModuleByName falla cuando courseid=error-course
 	// pero ahora primero se llama GetModuleById, así que necesitamos que ese ID falle
-	module, err := moduleService.UpdateModule("error-module-id", updateModule)
+	module, err := moduleService.UpdateModule("error-module-id", "teacher123", updateModule)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "failed to get current module")
 }
 
 func TestUpdateModuleWithErrorGettingModuleByName(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		Title:       "Some Module", // Título diferente al actual
@@ -550,14 +589,14 @@ func TestUpdateModuleWithErrorGettingModuleByName(t *testing.T) {
 	// 1. GetModuleById("valid-module-id") funciona → obtiene módulo con título "Test Module"
 	// 2. Título "Some Module" != "Test Module" → necesita verificar duplicados
 	// 3. GetModuleByName("error-course", "Some Module") falla → retorna error
-	module, err := moduleService.UpdateModule("valid-module-id", updateModule)
+	module, err := moduleService.UpdateModule("valid-module-id", "teacher123", updateModule)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "Error getting module by name")
 }
 
 func TestUpdateModuleWithErrorUpdating(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		Title:       "Non-existing Module",
@@ -566,14 +605,14 @@ func TestUpdateModuleWithErrorUpdating(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("error-updating-module", updateModule)
+	module, err := moduleService.UpdateModule("error-updating-module", "teacher123", updateModule)
 	assert.Error(t, err)
 	assert.Nil(t, module)
 	assert.Contains(t, err.Error(), "module not found")
 }
 
 func TestUpdateModuleWithEmptyData(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	updateModule := model.Module{
 		ID:          mustParseModuleObjectID("valid-module-id"),
@@ -584,7 +623,7 @@ func TestUpdateModuleWithEmptyData(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("valid-module-id", updateModule)
+	module, err := moduleService.UpdateModule("valid-module-id", "teacher123", updateModule)
 	assert.NoError(t, err)
 	assert.NotNil(t, module)
 	assert.NotNil(t, module.Resources)
@@ -592,7 +631,7 @@ func TestUpdateModuleWithEmptyData(t *testing.T) {
 }
 
 func TestUpdateModuleWithData(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	testResources := []model.ModuleResource{
 		{
@@ -611,7 +650,7 @@ func TestUpdateModuleWithData(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("valid-module-id", updateModule)
+	module, err := moduleService.UpdateModule("valid-module-id", "teacher123", updateModule)
 	assert.NoError(t, err)
 	assert.NotNil(t, module)
 	assert.NotNil(t, module.Resources)
@@ -620,7 +659,7 @@ func TestUpdateModuleWithData(t *testing.T) {
 }
 
 func TestUpdateModulePreservingExistingData(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	// Update module without providing Resources field (should preserve existing resources)
 	updateModule := model.Module{
@@ -632,7 +671,7 @@ func TestUpdateModulePreservingExistingData(t *testing.T) {
 		CourseID:    "valid-course-id",
 	}
 
-	module, err := moduleService.UpdateModule("module-with-data", updateModule)
+	module, err := moduleService.UpdateModule("module-with-data", "teacher123", updateModule)
 	assert.NoError(t, err)
 	assert.NotNil(t, module)
 	assert.NotNil(t, module.Resources)
@@ -641,7 +680,7 @@ func TestUpdateModulePreservingExistingData(t *testing.T) {
 }
 
 func TestGetModuleByIdWithData(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	module, err := moduleService.GetModuleById("module-with-data")
 	assert.NoError(t, err)
@@ -654,7 +693,7 @@ func TestGetModuleByIdWithData(t *testing.T) {
 }
 
 func TestGetModulesByCourseIdWithMixedData(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
 	modules, err := moduleService.GetModulesByCourseId("course-with-modules")
 	assert.NoError(t, err)
@@ -673,32 +712,40 @@ func TestGetModulesByCourseIdWithMixedData(t *testing.T) {
 
 // Tests for DeleteModule
 func TestDeleteModule(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
-	err := moduleService.DeleteModule("valid-module-id")
+	err := moduleService.DeleteModule("valid-module-id", "teacher123")
 	assert.NoError(t, err)
 }
 
 func TestDeleteModuleWithEmptyId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
-	err := moduleService.DeleteModule("")
+	err := moduleService.DeleteModule("", "teacher123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "module id is required")
 }
 
 func TestDeleteModuleWithError(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
-	err := moduleService.DeleteModule("error-deleting-module")
+	err := moduleService.DeleteModule("error-deleting-module", "teacher123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "Error deleting module")
 }
 
 func TestDeleteModuleWithInvalidId(t *testing.T) {
-	moduleService := service.NewModuleService(&MockModuleRepository{})
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
 
-	err := moduleService.DeleteModule("invalid-module-id")
+	err := moduleService.DeleteModule("invalid-module-id", "teacher123")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "invalid module ID")
 }
+
+func TestDeleteModuleWithAuxTeacherWithoutPermission(t *testing.T) {
+	moduleService := service.NewModuleService(&MockModuleRepository{}, nil, &ModuleMockCourseService{})
+
+	err := moduleService.DeleteModule("valid-module-id", "grading-aux-teacher")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+	assert.Contains(t, err.Error(), "edit_modules")
+}
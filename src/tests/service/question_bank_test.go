@@ -0,0 +1,266 @@
+package service_test
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type QuestionBankMockRepository struct {
+	questions []model.BankQuestion
+	created   *model.BankQuestion
+	deleted   string
+}
+
+func (m *QuestionBankMockRepository) Create(ctx context.Context, question *model.BankQuestion) error {
+	question.ID = primitive.NewObjectID()
+	m.created = question
+	return nil
+}
+
+func (m *QuestionBankMockRepository) Update(ctx context.Context, question *model.BankQuestion) error {
+	return nil
+}
+
+func (m *QuestionBankMockRepository) GetByID(ctx context.Context, id string) (*model.BankQuestion, error) {
+	for _, question := range m.questions {
+		if question.ID.Hex() == id {
+			return &question, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *QuestionBankMockRepository) GetByCourse(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty) ([]model.BankQuestion, error) {
+	questions := []model.BankQuestion{}
+	for _, question := range m.questions {
+		if question.CourseID != courseID || (tag != "" && !slices.Contains(question.Tags, tag)) {
+			continue
+		}
+		if difficulty != "" && question.Difficulty != difficulty {
+			continue
+		}
+		questions = append(questions, question)
+	}
+	return questions, nil
+}
+
+func (m *QuestionBankMockRepository) GetRandomByTag(ctx context.Context, courseID, tag string, difficulty model.QuestionDifficulty, count int) ([]model.BankQuestion, error) {
+	questions, _ := m.GetByCourse(ctx, courseID, tag, difficulty)
+	if len(questions) > count {
+		questions = questions[:count]
+	}
+	return questions, nil
+}
+
+func (m *QuestionBankMockRepository) Delete(ctx context.Context, id string) error {
+	m.deleted = id
+	return nil
+}
+
+func bankQuestion(tag string, text string) model.BankQuestion {
+	return model.BankQuestion{
+		ID:       primitive.NewObjectID(),
+		CourseID: "course123",
+		Question: model.Question{
+			Text:           text,
+			Type:           model.QuestionTypeMultipleChoice,
+			Options:        []string{"a", "b", "c", "d"},
+			CorrectAnswers: []string{"a"},
+			Points:         5,
+		},
+		Tags:       []string{tag},
+		Difficulty: model.QuestionDifficultyEasy,
+	}
+}
+
+func TestCreateBankQuestion(t *testing.T) {
+	questionBankRepo := &QuestionBankMockRepository{}
+	questionBankService := service.NewQuestionBankService(questionBankRepo, &CourseMockService{})
+
+	question, err := questionBankService.CreateQuestion(context.TODO(), "course123", "teacher123", schemas.CreateBankQuestionRequest{
+		Text:           "What is 2+2?",
+		Type:           model.QuestionTypeMultipleChoice,
+		Options:        []string{"3", "4"},
+		CorrectAnswers: []string{"4"},
+		Points:         2,
+		Tags:           []string{"arithmetic"},
+		Difficulty:     model.QuestionDifficultyEasy,
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, "course123", question.CourseID)
+	assert.Equal(t, "teacher123", question.CreatedBy)
+	assert.NotNil(t, questionBankRepo.created)
+}
+
+func TestCreateBankQuestionWithCorrectAnswerNotInOptions(t *testing.T) {
+	questionBankRepo := &QuestionBankMockRepository{}
+	questionBankService := service.NewQuestionBankService(questionBankRepo, &CourseMockService{})
+
+	_, err := questionBankService.CreateQuestion(context.TODO(), "course123", "teacher123", schemas.CreateBankQuestionRequest{
+		Text:           "What is 2+2?",
+		Type:           model.QuestionTypeMultipleChoice,
+		Options:        []string{"3", "4"},
+		CorrectAnswers: []string{"5"},
+		Points:         2,
+		Tags:           []string{"arithmetic"},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidBankQuestion)
+	assert.Nil(t, questionBankRepo.created)
+}
+
+func TestCreateBankQuestionByOtherTeacher(t *testing.T) {
+	questionBankService := service.NewQuestionBankService(&QuestionBankMockRepository{}, &CourseMockService{})
+
+	_, err := questionBankService.CreateQuestion(context.TODO(), "course123", "other-teacher", schemas.CreateBankQuestionRequest{
+		Text:   "Explain recursion",
+		Type:   model.QuestionTypeText,
+		Points: 2,
+		Tags:   []string{"recursion"},
+	})
+	assert.Equal(t, service.ErrUnauthorized, err)
+}
+
+func TestGetBankQuestionsByTag(t *testing.T) {
+	questionBankRepo := &QuestionBankMockRepository{questions: []model.BankQuestion{
+		bankQuestion("arithmetic", "q1"),
+		bankQuestion("geometry", "q2"),
+	}}
+	questionBankService := service.NewQuestionBankService(questionBankRepo, &CourseMockService{})
+
+	questions, err := questionBankService.GetQuestions(context.TODO(), "course123", "aux-teacher1", "geometry", "")
+	assert.NoError(t, err)
+	assert.Len(t, questions, 1)
+	assert.Equal(t, "q2", questions[0].Question.Text)
+}
+
+func TestDeleteBankQuestionFromOtherCourse(t *testing.T) {
+	question := bankQuestion("arithmetic", "q1")
+	question.CourseID = "other-course"
+	questionBankRepo := &QuestionBankMockRepository{questions: []model.BankQuestion{question}}
+	questionBankService := service.NewQuestionBankService(questionBankRepo, &CourseMockService{})
+
+	err := questionBankService.DeleteQuestion(context.TODO(), "course123", question.ID.Hex(), "teacher123")
+	assert.Equal(t, service.ErrBankQuestionNotFound, err)
+	assert.Empty(t, questionBankRepo.deleted)
+}
+
+func randomizedAssignment(draws ...model.QuestionDraw) *model.Assignment {
+	return &model.Assignment{
+		ID:       mustParseSubmissionObjectID("assignment123"),
+		CourseID: "course123",
+		Type:     "homework",
+		Questions: []model.Question{
+			{ID: "fixed", Type: model.QuestionTypeText, Points: 10, Order: 1},
+		},
+		QuestionDraws:  draws,
+		ShuffleOptions: true,
+	}
+}
+
+func TestGetOrCreateSubmissionDrawsQuestionsFromBank(t *testing.T) {
+	questionBankRepo := &QuestionBankMockRepository{questions: []model.BankQuestion{
+		bankQuestion("arithmetic", "q1"),
+		bankQuestion("arithmetic", "q2"),
+		bankQuestion("geometry", "q3"),
+	}}
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: randomizedAssignment(
+		model.QuestionDraw{Tag: "arithmetic", Count: 2},
+		model.QuestionDraw{Tag: "geometry", Count: 1},
+	)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, questionBankRepo, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.Len(t, submission.Questions, 4)
+	assert.Equal(t, "fixed", submission.Questions[0].ID)
+	assert.Equal(t, questionBankRepo.questions[2].ID.Hex(), submission.Questions[3].ID)
+	assert.Equal(t, 4, submission.Questions[3].Order)
+	for _, question := range submission.Questions[1:] {
+		assert.ElementsMatch(t, []string{"a", "b", "c", "d"}, question.Options)
+	}
+	// The bank keeps its option order
+	assert.Equal(t, []string{"a", "b", "c", "d"}, questionBankRepo.questions[0].Question.Options)
+}
+
+func TestGetOrCreateSubmissionGivesTheMatchingItems(t *testing.T) {
+	assignment := randomizedAssignment()
+	assignment.Questions = append(assignment.Questions, model.Question{
+		ID:            "capitals",
+		Type:          model.QuestionTypeMatching,
+		MatchingPairs: []model.MatchingPair{{Left: "Argentina", Right: "Buenos Aires"}, {Left: "Chile", Right: "Santiago"}, {Left: "Peru", Right: "Lima"}},
+		Points:        3,
+		Order:         2,
+	})
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	matching := submission.Questions[1]
+	assert.Equal(t, []string{"Argentina", "Chile", "Peru"}, matching.MatchingLeft)
+	assert.ElementsMatch(t, []string{"Buenos Aires", "Santiago", "Lima"}, matching.MatchingRight)
+	// The pairs stay on the stored submission for grading but are not in the view of the student
+	assert.Len(t, matching.MatchingPairs, 3)
+	view := submission.StudentView().Questions[1]
+	assert.Nil(t, view.MatchingPairs)
+	assert.Equal(t, matching.MatchingRight, view.MatchingRight)
+	assert.Empty(t, assignment.Questions[1].MatchingRight)
+}
+
+func TestStudentViewSortsTheUnshuffledMatchingItems(t *testing.T) {
+	question := model.Question{
+		ID:            "capitals",
+		Type:          model.QuestionTypeMatching,
+		MatchingPairs: []model.MatchingPair{{Left: "Chile", Right: "Santiago"}, {Left: "Argentina", Right: "Buenos Aires"}},
+	}
+
+	view := question.WithoutAnswerKey()
+	assert.Nil(t, view.MatchingPairs)
+	assert.Equal(t, []string{"Chile", "Argentina"}, view.MatchingLeft)
+	assert.Equal(t, []string{"Buenos Aires", "Santiago"}, view.MatchingRight)
+}
+
+func TestGetOrCreateSubmissionWithNotEnoughBankQuestions(t *testing.T) {
+	questionBankRepo := &QuestionBankMockRepository{questions: []model.BankQuestion{bankQuestion("arithmetic", "q1")}}
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: randomizedAssignment(
+		model.QuestionDraw{Tag: "arithmetic", Count: 2},
+	)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, questionBankRepo, nil, &CourseMockService{}, nil, nil, nil)
+
+	_, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.ErrorIs(t, err, service.ErrNotEnoughBankQuestions)
+	assert.Nil(t, submissionRepo.created)
+}
+
+func TestGradeSubmissionUsesReceivedQuestions(t *testing.T) {
+	drawn := bankQuestion("arithmetic", "q1")
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusSubmitted
+	submission.Questions = []model.Question{{ID: drawn.ID.Hex(), Type: model.QuestionTypeText, Points: 5}}
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: randomizedAssignment(model.QuestionDraw{Tag: "arithmetic", Count: 1})}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{QuestionID: drawn.ID.Hex(), Points: 4}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 4.0, *gradedSubmission.Score)
+
+	// Questions of the assignment the student didn't receive can't be graded
+	_, err = submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{QuestionID: "fixed", Points: 4}},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidAnswerGrade)
+}
@@ -0,0 +1,220 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/ai"
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+	"courses-service/src/storage"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// GenerationMockModuleRepository always returns the configured module
+type GenerationMockModuleRepository struct {
+	MockModuleRepository
+	module *model.Module
+}
+
+func (m *GenerationMockModuleRepository) GetModuleById(id string) (*model.Module, error) {
+	return m.module, nil
+}
+
+// GenerationMockAssignmentRepository returns the configured assignment and keeps the last update
+type GenerationMockAssignmentRepository struct {
+	AssignmentMockRepository
+	assignment *model.Assignment
+	updated    *model.Assignment
+}
+
+func (m *GenerationMockAssignmentRepository) GetByID(ctx context.Context, id string) (*model.Assignment, error) {
+	return m.assignment, nil
+}
+
+func (m *GenerationMockAssignmentRepository) UpdateAssignment(id string, update model.Assignment) (*model.Assignment, error) {
+	m.updated = &update
+	return &update, nil
+}
+
+// RecordingAiProvider answers like the fake provider and keeps what it received to generate questions
+type RecordingAiProvider struct {
+	ai.FakeProvider
+	scope     ai.Scope
+	resources []string
+}
+
+func (p *RecordingAiProvider) GenerateQuestions(ctx context.Context, module *model.Module, resources []string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	p.scope = ai.ScopeFrom(ctx)
+	p.resources = resources
+	return p.FakeProvider.GenerateQuestions(ctx, module, resources, request)
+}
+
+func generationModule() *model.Module {
+	return &model.Module{ID: primitive.NewObjectID(), Title: "Concurrencia", Description: "Goroutines y channels", CourseID: "course123"}
+}
+
+func TestGenerateQuestionsFromModule(t *testing.T) {
+	fileStorage, err := storage.NewLocalStorage(t.TempDir())
+	assert.NoError(t, err)
+	fileRepo := &FileMockRepository{}
+	notes := &model.StoredFile{ID: primitive.NewObjectID(), ContentType: "text/plain", CourseID: "course123", StorageKey: "course123/notes"}
+	slides := &model.StoredFile{ID: primitive.NewObjectID(), ContentType: "application/pdf", CourseID: "course123", StorageKey: "course123/slides"}
+	otherCourse := &model.StoredFile{ID: primitive.NewObjectID(), ContentType: "text/plain", CourseID: "other-course", StorageKey: "other-course/notes"}
+	for _, file := range []*model.StoredFile{notes, slides, otherCourse} {
+		assert.NoError(t, fileRepo.Create(context.TODO(), file))
+		assert.NoError(t, fileStorage.Put(context.TODO(), file.StorageKey, bytes.NewReader([]byte("Contenido de "+file.StorageKey)), 0, file.ContentType))
+	}
+
+	module := generationModule()
+	module.Resources = []model.ModuleResource{
+		{Id: 1, Name: "Apunte", FileID: notes.ID.Hex()},
+		{Id: 2, Name: "Diapositivas", FileID: slides.ID.Hex()},
+		{Id: 3, Name: "Otro curso", FileID: otherCourse.ID.Hex()},
+		{Id: 4, Name: "Link", Url: "https://go.dev"},
+	}
+	provider := &RecordingAiProvider{}
+	generationService := service.NewQuestionGenerationService(&GenerationMockModuleRepository{module: module}, nil, fileRepo, fileStorage, &CourseMockService{}, provider)
+
+	generated, err := generationService.GenerateQuestions(context.TODO(), module.ID.Hex(), "aux-teacher1", schemas.GenerateQuestionsRequest{MultipleChoiceCount: 2, TextCount: 1})
+	assert.NoError(t, err)
+	assert.Len(t, generated.Questions, 3)
+	assert.Equal(t, model.QuestionTypeMultipleChoice, generated.Questions[0].Type)
+	assert.Equal(t, model.QuestionTypeText, generated.Questions[2].Type)
+	for i, question := range generated.Questions {
+		assert.NotEmpty(t, question.ID)
+		assert.Equal(t, 1.0, question.Points)
+		assert.Equal(t, i+1, question.Order)
+	}
+	// Only the text resources of the course are sent to the model
+	assert.Equal(t, []string{"Contenido de course123/notes"}, provider.resources)
+	assert.Equal(t, ai.Scope{CourseID: "course123", TeacherUUID: "teacher123"}, provider.scope)
+}
+
+func TestGenerateQuestionsValidatesRequest(t *testing.T) {
+	module := generationModule()
+	generationService := service.NewQuestionGenerationService(&GenerationMockModuleRepository{module: module}, nil, nil, nil, &CourseMockService{}, ai.NewFakeProvider())
+
+	_, err := generationService.GenerateQuestions(context.TODO(), module.ID.Hex(), "teacher123", schemas.GenerateQuestionsRequest{})
+	assert.ErrorIs(t, err, service.ErrInvalidQuestionGeneration)
+
+	_, err = generationService.GenerateQuestions(context.TODO(), module.ID.Hex(), "teacher123", schemas.GenerateQuestionsRequest{MultipleChoiceCount: 15, TextCount: 6})
+	assert.ErrorIs(t, err, service.ErrInvalidQuestionGeneration)
+
+	_, err = generationService.GenerateQuestions(context.TODO(), module.ID.Hex(), "student123", schemas.GenerateQuestionsRequest{TextCount: 1})
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+	generated, err := generationService.GenerateQuestions(context.TODO(), module.ID.Hex(), "teacher123", schemas.GenerateQuestionsRequest{TextCount: 2, Points: 5})
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, generated.Questions[1].Points)
+	assert.Equal(t, ai.FakePromptVersion, generated.PromptVersion)
+}
+
+func TestAddQuestionsToDraftAssignment(t *testing.T) {
+	assignmentRepo := &GenerationMockAssignmentRepository{assignment: &model.Assignment{
+		CourseID:    "course123",
+		Status:      model.AssignmentStatusDraft,
+		TotalPoints: 4,
+		Questions:   []model.Question{{ID: "q1", Text: "¿Qué es Go?", Type: model.QuestionTypeText, Points: 4, Order: 1}},
+	}}
+	generationService := service.NewQuestionGenerationService(nil, assignmentRepo, nil, nil, &CourseMockService{}, nil)
+
+	questions := []model.Question{
+		{Text: "¿Qué es un channel?", Type: model.QuestionTypeMultipleChoice, Options: []string{"Una cola", "Un hilo"}, CorrectAnswers: []string{"Una cola"}, Points: 2},
+		{ID: "q3", Text: "Explicá select", Type: model.QuestionTypeText, CorrectAnswers: []string{"Espera varios channels"}, Points: 3},
+	}
+	assignment, err := generationService.AddQuestions(context.TODO(), "assignment123", "teacher123", questions)
+	assert.NoError(t, err)
+	assert.Len(t, assignment.Questions, 3)
+	assert.NotEmpty(t, assignment.Questions[1].ID)
+	assert.Equal(t, 2, assignment.Questions[1].Order)
+	assert.Equal(t, "q3", assignment.Questions[2].ID)
+	assert.Equal(t, 3, assignment.Questions[2].Order)
+	assert.Equal(t, 9.0, assignmentRepo.updated.TotalPoints)
+}
+
+func TestAddQuestionsRejectsInvalidQuestions(t *testing.T) {
+	newService := func(status string) (*service.QuestionGenerationService, *GenerationMockAssignmentRepository) {
+		assignmentRepo := &GenerationMockAssignmentRepository{assignment: &model.Assignment{
+			CourseID:  "course123",
+			Status:    status,
+			Questions: []model.Question{{ID: "q1", Text: "¿Qué es Go?", Type: model.QuestionTypeText, Points: 4}},
+		}}
+		return service.NewQuestionGenerationService(nil, assignmentRepo, nil, nil, &CourseMockService{}, nil), assignmentRepo
+	}
+	valid := model.Question{Text: "Explicá select", Type: model.QuestionTypeText, Points: 3}
+
+	generationService, assignmentRepo := newService(model.AssignmentStatusPublished)
+	_, err := generationService.AddQuestions(context.TODO(), "assignment123", "teacher123", []model.Question{valid})
+	assert.ErrorIs(t, err, service.ErrAssignmentNotDraft)
+
+	generationService, assignmentRepo = newService(model.AssignmentStatusDraft)
+	_, err = generationService.AddQuestions(context.TODO(), "assignment123", "student123", []model.Question{valid})
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+	repeated := valid
+	repeated.ID = "q1"
+	_, err = generationService.AddQuestions(context.TODO(), "assignment123", "teacher123", []model.Question{repeated})
+	assert.ErrorIs(t, err, service.ErrInvalidQuestion)
+
+	withoutPoints := valid
+	withoutPoints.Points = 0
+	_, err = generationService.AddQuestions(context.TODO(), "assignment123", "teacher123", []model.Question{withoutPoints})
+	assert.ErrorIs(t, err, service.ErrInvalidQuestion)
+
+	wrongOption := model.Question{Text: "¿Qué es un channel?", Type: model.QuestionTypeMultipleChoice, Options: []string{"Una cola", "Un hilo"}, CorrectAnswers: []string{"Un mutex"}, Points: 2}
+	_, err = generationService.AddQuestions(context.TODO(), "assignment123", "teacher123", []model.Question{wrongOption})
+	assert.ErrorIs(t, err, service.ErrInvalidQuestion)
+	assert.Nil(t, assignmentRepo.updated)
+}
+
+func TestOpenAIProviderGeneratesQuestions(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		valid   bool
+	}{
+		{name: "valid", valid: true, content: `{"questions": [
+			{"type": "multiple_choice", "text": "¿Qué es un channel?", "options": ["Una cola", "Un hilo", "Un mutex"], "correct_answers": ["Una cola"]},
+			{"type": "text", "text": "Explicá select", "options": [], "correct_answers": ["Espera varios channels"]}]}`},
+		{name: "wrong amount", content: `{"questions": [
+			{"type": "text", "text": "Explicá select", "options": [], "correct_answers": ["Espera varios channels"]}]}`},
+		{name: "correct answer not an option", content: `{"questions": [
+			{"type": "multiple_choice", "text": "¿Qué es un channel?", "options": ["Una cola", "Un hilo"], "correct_answers": ["Un mutex"]},
+			{"type": "text", "text": "Explicá select", "options": [], "correct_answers": ["Espera varios channels"]}]}`},
+		{name: "text without reference answer", content: `{"questions": [
+			{"type": "multiple_choice", "text": "¿Qué es un channel?", "options": ["Una cola", "Un hilo"], "correct_answers": ["Una cola"]},
+			{"type": "text", "text": "Explicá select", "options": [], "correct_answers": []}]}`},
+		{name: "unexpected type", content: `{"questions": [
+			{"type": "multiple_choice", "text": "¿Qué es un channel?", "options": ["Una cola", "Un hilo"], "correct_answers": ["Una cola"]},
+			{"type": "numeric", "text": "¿Cuántos cores?", "options": [], "correct_answers": ["4"]}]}`},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var prompt string
+			server := promptCapturingServer(t, test.content, &prompt)
+			defer server.Close()
+
+			provider := ai.NewOpenAIProvider(server.URL, "", ai.Settings{Model: "llama3", Timeout: time.Second}, ai.Hooks{})
+			generated, err := provider.GenerateQuestions(context.Background(), generationModule(), []string{"Los channels comunican goroutines"},
+				schemas.GenerateQuestionsRequest{MultipleChoiceCount: 1, TextCount: 1})
+
+			assert.Contains(t, prompt, "Módulo: Concurrencia\nDescripción: Goroutines y channels\n\nRecurso:\nLos channels comunican goroutines\n")
+			if !test.valid {
+				assert.ErrorIs(t, err, ai.ErrInvalidGeneratedQuestions)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Len(t, generated.Questions, 2)
+			assert.Equal(t, []string{"Una cola"}, generated.Questions[0].CorrectAnswers)
+			assert.Nil(t, generated.Questions[1].Options)
+			assert.Equal(t, ai.PromptVersion(ai.OperationQuestionGeneration, model.CourseLanguageSpanish), generated.PromptVersion)
+		})
+	}
+}
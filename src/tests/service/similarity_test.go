@@ -0,0 +1,192 @@
+package service_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"courses-service/src/model"
+	"courses-service/src/schemas"
+	"courses-service/src/service"
+
+	"github.com/stretchr/testify/assert"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type SimilarityMockSubmissionRepository struct {
+	*SubmissionMockRepository
+	submissions map[string][]model.Submission
+	updated     []*model.Submission
+}
+
+func (m *SimilarityMockSubmissionRepository) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
+	return m.submissions[assignmentID], nil
+}
+
+func (m *SimilarityMockSubmissionRepository) GetByID(ctx context.Context, id string) (*model.Submission, error) {
+	for _, submissions := range m.submissions {
+		for _, submission := range submissions {
+			if submission.ID.Hex() == id {
+				return &submission, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+func (m *SimilarityMockSubmissionRepository) Update(ctx context.Context, submission *model.Submission) error {
+	m.updated = append(m.updated, submission)
+	return nil
+}
+
+type SimilarityMockAssignmentRepository struct {
+	*AssignmentMockRepository
+	assignments []*model.Assignment
+}
+
+func (m *SimilarityMockAssignmentRepository) GetByID(ctx context.Context, id string) (*model.Assignment, error) {
+	for _, assignment := range m.assignments {
+		if assignment.ID.Hex() == id {
+			return assignment, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *SimilarityMockAssignmentRepository) GetAssignmentsByCourseId(courseId string) ([]*model.Assignment, error) {
+	var assignments []*model.Assignment
+	for _, assignment := range m.assignments {
+		if assignment.CourseID == courseId {
+			assignments = append(assignments, assignment)
+		}
+	}
+	return assignments, nil
+}
+
+type SimilarityMockCourseService struct {
+	CourseMockService
+	courses []*model.Course
+}
+
+func (m *SimilarityMockCourseService) GetCourseById(id string) (*model.Course, error) {
+	for _, course := range m.courses {
+		if course.ID.Hex() == id {
+			return course, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *SimilarityMockCourseService) GetCourseByTeacherId(teacherId string) ([]*model.Course, error) {
+	return m.courses, nil
+}
+
+const (
+	originalEssay = "The industrial revolution began in Britain because of abundant coal deposits, a stable banking system and a growing colonial market for textiles."
+	copiedEssay   = "As we saw in class, the industrial revolution began in Britain because of abundant coal deposits, a stable banking system and a growing colonial market for textiles."
+	otherEssay    = "Steam engines allowed factories to move away from rivers, which changed where cities grew during the nineteenth century in Europe."
+)
+
+func newSimilarityFixture(auditService service.AuditServiceInterface) (*service.SimilarityService, *SimilarityMockSubmissionRepository, string) {
+	lastTerm := &model.Course{ID: primitive.NewObjectID(), Title: "History", TeacherUUID: "teacher123", StartDate: time.Now().AddDate(-1, 0, 0)}
+	course := &model.Course{ID: primitive.NewObjectID(), Title: "History", TeacherUUID: "teacher123", StartDate: time.Now()}
+	question := model.Question{ID: "q1", Text: "Why did the industrial revolution begin in Britain?", Type: model.QuestionTypeText, Points: 10}
+
+	assignment := &model.Assignment{ID: primitive.NewObjectID(), Title: "Essay", CourseID: course.ID.Hex(), Questions: []model.Question{question}}
+	question.ID = "old-q1"
+	priorAssignment := &model.Assignment{ID: primitive.NewObjectID(), Title: "Essay", CourseID: lastTerm.ID.Hex(), Questions: []model.Question{question}}
+
+	submission := func(assignment *model.Assignment, studentUUID, questionID, text string, status model.SubmissionStatus) model.Submission {
+		return model.Submission{
+			ID:           primitive.NewObjectID(),
+			AssignmentID: assignment.ID.Hex(),
+			StudentUUID:  studentUUID,
+			Status:       status,
+			Answers:      []model.Answer{{QuestionID: questionID, Content: text, Type: string(model.QuestionTypeText)}},
+		}
+	}
+
+	submissionRepo := &SimilarityMockSubmissionRepository{submissions: map[string][]model.Submission{
+		assignment.ID.Hex(): {
+			submission(assignment, "student1", "q1", originalEssay, model.SubmissionStatusSubmitted),
+			submission(assignment, "student2", "q1", copiedEssay, model.SubmissionStatusSubmitted),
+			submission(assignment, "student3", "q1", otherEssay, model.SubmissionStatusSubmitted),
+			submission(assignment, "student4", "q1", originalEssay, model.SubmissionStatusDraft),
+		},
+		priorAssignment.ID.Hex(): {
+			submission(priorAssignment, "former-student", "old-q1", otherEssay, model.SubmissionStatusSubmitted),
+		},
+	}}
+	assignmentRepo := &SimilarityMockAssignmentRepository{assignments: []*model.Assignment{assignment, priorAssignment}}
+	courseService := &SimilarityMockCourseService{courses: []*model.Course{course, lastTerm}}
+
+	return service.NewSimilarityService(submissionRepo, assignmentRepo, courseService, auditService), submissionRepo, assignment.ID.Hex()
+}
+
+func TestSimilarityReportFindsCopiedAnswers(t *testing.T) {
+	similarityService, _, assignmentID := newSimilarityFixture(nil)
+
+	report, err := similarityService.GetSimilarityReport(context.TODO(), assignmentID, "teacher123", schemas.SimilarityReportRequest{})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.ComparedSubmissions)
+	assert.Equal(t, 1, report.PriorTermSubmissions)
+	assert.Len(t, report.Pairs, 2)
+
+	// Student 3 copied an answer from the last edition of the course
+	assert.Equal(t, 1.0, report.Pairs[0].Similarity)
+	assert.Equal(t, "student3", report.Pairs[0].Submission.StudentUUID)
+	assert.True(t, report.Pairs[0].Other.PriorTerm)
+
+	pair := report.Pairs[1]
+	assert.Equal(t, "q1", pair.QuestionID)
+	assert.ElementsMatch(t, []string{"student1", "student2"}, []string{pair.Submission.StudentUUID, pair.Other.StudentUUID})
+	assert.GreaterOrEqual(t, pair.Similarity, 0.5)
+	assert.Contains(t, pair.Excerpts[0], "industrial revolution began in Britain because of abundant coal deposits")
+
+	disabled := false
+	report, err = similarityService.GetSimilarityReport(context.TODO(), assignmentID, "teacher123", schemas.SimilarityReportRequest{IncludePriorTerms: &disabled})
+	assert.NoError(t, err)
+	assert.Len(t, report.Pairs, 1)
+}
+
+func TestSimilarityReportValidatesRequest(t *testing.T) {
+	similarityService, _, assignmentID := newSimilarityFixture(nil)
+
+	_, err := similarityService.GetSimilarityReport(context.TODO(), assignmentID, "other-teacher", schemas.SimilarityReportRequest{})
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+
+	_, err = similarityService.GetSimilarityReport(context.TODO(), assignmentID, "teacher123", schemas.SimilarityReportRequest{Threshold: 1.5})
+	assert.ErrorIs(t, err, service.ErrInvalidSimilarityThreshold)
+
+	_, err = similarityService.GetSimilarityReport(context.TODO(), primitive.NewObjectID().Hex(), "teacher123", schemas.SimilarityReportRequest{})
+	assert.ErrorIs(t, err, service.ErrAssignmentNotFound)
+}
+
+func TestFlagSimilarSubmissions(t *testing.T) {
+	similarityService, submissionRepo, assignmentID := newSimilarityFixture(nil)
+
+	report, err := similarityService.FlagSimilarSubmissions(context.TODO(), assignmentID, "teacher123", schemas.SimilarityReportRequest{})
+	assert.NoError(t, err)
+
+	// Submissions of earlier editions are never modified
+	assert.Equal(t, 3, report.FlaggedSubmissions)
+	assert.Len(t, submissionRepo.updated, 3)
+	for _, submission := range submissionRepo.updated {
+		assert.Equal(t, assignmentID, submission.AssignmentID)
+		assert.True(t, *submission.NeedsManualReview)
+	}
+}
+
+func TestFlagSimilarSubmissionsIsAudited(t *testing.T) {
+	recorder := &MockAuditRecorder{}
+	similarityService, submissionRepo, assignmentID := newSimilarityFixture(recorder)
+
+	_, err := similarityService.FlagSimilarSubmissions(context.TODO(), assignmentID, "teacher123", schemas.SimilarityReportRequest{})
+	assert.NoError(t, err)
+
+	flagged := []string{}
+	for _, submission := range submissionRepo.updated {
+		flagged = append(flagged, submission.ID.Hex())
+	}
+	assertSystemChanges(t, recorder, model.AuditEntitySubmission, "similarity_flag", flagged...)
+}
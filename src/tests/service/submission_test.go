@@ -3,9 +3,11 @@ package service_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
+	"courses-service/src/ai"
 	"courses-service/src/model"
 	"courses-service/src/schemas"
 	"courses-service/src/service"
@@ -68,6 +70,18 @@ func (m *SubmissionMockRepository) GetByAssignmentAndStudent(ctx context.Context
 	return nil, errors.New("repository error")
 }
 
+func (m *SubmissionMockRepository) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	submission, err := m.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+	if err != nil || submission == nil {
+		return []model.Submission{}, err
+	}
+	return []model.Submission{*submission}, nil
+}
+
+func (m *SubmissionMockRepository) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
 func (m *SubmissionMockRepository) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	if assignmentID == "assignment123" {
 		return []model.Submission{
@@ -147,6 +161,14 @@ func (m *SubmissionMockRepositoryWithError) GetByAssignmentAndStudent(ctx contex
 	return nil, errors.New("repository get error")
 }
 
+func (m *SubmissionMockRepositoryWithError) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return nil, errors.New("repository get error")
+}
+
+func (m *SubmissionMockRepositoryWithError) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return nil, errors.New("repository get error")
+}
+
 func (m *SubmissionMockRepositoryWithError) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	return nil, errors.New("repository get error")
 }
@@ -188,10 +210,19 @@ func (m *AssignmentMockRepository) GetByID(ctx context.Context, id string) (*mod
 			DueDate:     dueDate,
 			GracePeriod: 30, // 30 minutes grace period
 			Status:      "published",
+			Questions:   []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 10}},
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}, nil
 	}
+	if id == "existing-assignment" || id == "new-assignment" {
+		return &model.Assignment{
+			ID:       primitive.NewObjectID(),
+			CourseID: "course123",
+			Type:     "homework",
+			DueDate:  time.Now().Add(24 * time.Hour),
+		}, nil
+	}
 	if id == "nonexistent-assignment" {
 		return nil, nil
 	}
@@ -323,7 +354,7 @@ func (m *CourseMockService) UpdateCourse(id string, updateCourseRequest schemas.
 	return nil, nil
 }
 
-func (m *CourseMockService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string) (*model.Course, error) {
+func (m *CourseMockService) AddAuxTeacherToCourse(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
 	return nil, nil
 }
 
@@ -331,6 +362,10 @@ func (m *CourseMockService) RemoveAuxTeacherFromCourse(id string, titularTeacher
 	return nil, nil
 }
 
+func (m *CourseMockService) UpdateAuxTeacherPermissions(id string, titularTeacherId string, auxTeacherId string, permissions []model.AuxTeacherPermission) (*model.Course, error) {
+	return nil, nil
+}
+
 func (m *CourseMockService) GetFavouriteCourses(studentId string) ([]*model.Course, error) {
 	return nil, nil
 }
@@ -345,19 +380,19 @@ type MockAiClient struct {
 	shouldSucceed bool
 }
 
-func (m *MockAiClient) SummarizeCourseFeedbacks(feedbacks []*model.CourseFeedback) (string, error) {
+func (m *MockAiClient) SummarizeCourseFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (string, error) {
 	return "test summary", nil
 }
 
-func (m *MockAiClient) SummarizeStudentFeedbacks(feedbacks []*model.StudentFeedback) (string, error) {
+func (m *MockAiClient) SummarizeStudentFeedbacks(ctx context.Context, feedbacks []*model.StudentFeedback) (string, error) {
 	return "test summary", nil
 }
 
-func (m *MockAiClient) SummarizeSubmissionFeedback(score *float64, feedback string) (string, error) {
+func (m *MockAiClient) SummarizeSubmissionFeedback(ctx context.Context, score *float64, feedback string) (string, error) {
 	return "test summary", nil
 }
 
-func (m *MockAiClient) CorrectSubmission(assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
+func (m *MockAiClient) CorrectSubmission(ctx context.Context, assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
 	if !m.shouldSucceed {
 		return nil, errors.New("AI correction failed")
 	}
@@ -368,6 +403,18 @@ func (m *MockAiClient) CorrectSubmission(assignment *model.Assignment, submissio
 	}, nil
 }
 
+func (m *MockAiClient) GenerateQuestions(ctx context.Context, module *model.Module, resources []string, request schemas.GenerateQuestionsRequest) (*schemas.GeneratedQuestionsResponse, error) {
+	return &schemas.GeneratedQuestionsResponse{}, nil
+}
+
+func (m *MockAiClient) SuggestForumAnswer(ctx context.Context, question *model.ForumQuestion, modules []model.Module, resolved []model.ForumQuestion) (*schemas.ForumAnswerSuggestion, error) {
+	return &schemas.ForumAnswerSuggestion{}, nil
+}
+
+func (m *MockAiClient) AnalyzeFeedbacks(ctx context.Context, feedbacks []*model.CourseFeedback) (*schemas.FeedbackAnalysisResponse, error) {
+	return &schemas.FeedbackAnalysisResponse{}, nil
+}
+
 // SubmissionMockRepositoryWithFileAnswers for testing file submissions
 type SubmissionMockRepositoryWithFileAnswers struct{}
 
@@ -403,6 +450,14 @@ func (m *SubmissionMockRepositoryWithFileAnswers) GetByAssignmentAndStudent(ctx
 	return nil, nil
 }
 
+func (m *SubmissionMockRepositoryWithFileAnswers) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
+func (m *SubmissionMockRepositoryWithFileAnswers) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
 func (m *SubmissionMockRepositoryWithFileAnswers) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	return nil, nil
 }
@@ -467,6 +522,14 @@ func (m *SubmissionMockRepositoryWithURLAnswers) GetByAssignmentAndStudent(ctx c
 	return nil, nil
 }
 
+func (m *SubmissionMockRepositoryWithURLAnswers) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
+func (m *SubmissionMockRepositoryWithURLAnswers) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return []model.Submission{}, nil
+}
+
 func (m *SubmissionMockRepositoryWithURLAnswers) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	return nil, nil
 }
@@ -533,6 +596,10 @@ func (m *SubmissionMockRepositoryCustom) GetByAssignmentAndStudent(ctx context.C
 	return originalMock.GetByAssignmentAndStudent(ctx, assignmentID, studentUUID)
 }
 
+func (m *SubmissionMockRepositoryCustom) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return m.SubmissionMockRepository.GetAttemptsByAssignmentAndStudent(ctx, assignmentID, studentUUID)
+}
+
 func (m *SubmissionMockRepositoryCustom) GetByAssignment(ctx context.Context, assignmentID string) ([]model.Submission, error) {
 	originalMock := &SubmissionMockRepository{}
 	return originalMock.GetByAssignment(ctx, assignmentID)
@@ -591,7 +658,7 @@ func TestCreateSubmission(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		AssignmentID: "assignment123",
@@ -618,7 +685,7 @@ func TestCreateSubmissionWithNonexistentAssignment(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		AssignmentID: "nonexistent-assignment",
@@ -635,7 +702,7 @@ func TestCreateSubmissionWithRepositoryError(t *testing.T) {
 	submissionRepo := &SubmissionMockRepositoryWithError{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		AssignmentID: "assignment123",
@@ -653,7 +720,7 @@ func TestGetSubmission(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission, err := submissionService.GetSubmission(context.TODO(), "valid-submission-id")
 	assert.NoError(t, err)
@@ -666,7 +733,7 @@ func TestGetSubmissionWithNonexistentID(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission, err := submissionService.GetSubmission(context.TODO(), "nonexistent")
 	assert.NoError(t, err)
@@ -678,7 +745,7 @@ func TestGetOrCreateSubmissionExisting(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "existing-assignment", "existing-student", "Existing Student")
 	assert.NoError(t, err)
@@ -692,7 +759,7 @@ func TestGetOrCreateSubmissionNew(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "new-assignment", "new-student", "New Student")
 	assert.NoError(t, err)
@@ -709,12 +776,12 @@ func TestGradeSubmission(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	score := 85.5
 	feedback := "Great work!"
 
-	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", &score, feedback)
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{Score: &score, Feedback: feedback})
 	assert.NoError(t, err)
 	assert.NotNil(t, gradedSubmission)
 	assert.Equal(t, &score, gradedSubmission.Score)
@@ -726,23 +793,66 @@ func TestGradeSubmissionWithNonexistentSubmission(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	score := 85.5
 	feedback := "Great work!"
 
-	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "nonexistent", &score, feedback)
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "nonexistent", "teacher123", schemas.GradeSubmissionRequest{Score: &score, Feedback: feedback})
 	assert.Error(t, err)
 	assert.Nil(t, gradedSubmission)
 	assert.Equal(t, service.ErrSubmissionNotFound, err)
 }
 
+func TestGradeSubmissionWithAnswerGradesDerivesScore(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithChoiceAnswers{
+		answers: []model.Answer{
+			{QuestionID: "q1", Content: "b", Type: "multiple_choice"},
+			{QuestionID: "q2", Content: []string{"a", "b"}, Type: "multiple_choice"},
+		},
+	}
+	assignmentRepo := &AssignmentMockRepositoryWithChoiceQuestions{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	ignoredScore := 1.0
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		Score:    &ignoredScore,
+		Feedback: "Good",
+		AnswerGrades: []schemas.AnswerGradeRequest{
+			{QuestionID: "q1", Points: 3, Comment: "Almost"},
+			{QuestionID: "q2", Points: 6},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 9.0, *gradedSubmission.Score)
+	assert.Len(t, gradedSubmission.AnswerGrades, 2)
+	assert.Equal(t, "teacher123", gradedSubmission.AnswerGrades[0].GradedBy)
+	assert.Equal(t, 4.0, gradedSubmission.AnswerGrades[0].MaxPoints)
+	assert.Equal(t, "Almost", gradedSubmission.AnswerGrades[0].Comment)
+}
+
+func TestGradeSubmissionWithInvalidAnswerGrade(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithChoiceAnswers{}
+	assignmentRepo := &AssignmentMockRepositoryWithChoiceQuestions{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	_, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{QuestionID: "q1", Points: 5}},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidAnswerGrade)
+
+	_, err = submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{QuestionID: "unknown", Points: 1}},
+	})
+	assert.ErrorIs(t, err, service.ErrInvalidAnswerGrade)
+}
+
 // Tests for ValidateTeacherPermissions
 func TestValidateTeacherPermissionsMainTeacher(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.ValidateTeacherPermissions(context.TODO(), "assignment123", "teacher123")
 	assert.NoError(t, err)
@@ -752,7 +862,7 @@ func TestValidateTeacherPermissionsAuxTeacher(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.ValidateTeacherPermissions(context.TODO(), "assignment123", "aux-teacher1")
 	assert.NoError(t, err)
@@ -762,7 +872,7 @@ func TestValidateTeacherPermissionsUnauthorized(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.ValidateTeacherPermissions(context.TODO(), "assignment123", "unauthorized-teacher")
 	assert.Error(t, err)
@@ -774,7 +884,7 @@ func TestValidateTeacherPermissionsWithNonexistentAssignment(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.ValidateTeacherPermissions(context.Background(), "nonexistent-assignment", "teacher123")
 	assert.Error(t, err)
@@ -787,7 +897,7 @@ func TestUpdateSubmission(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		ID:           mustParseSubmissionObjectID("valid-submission-id"),
@@ -814,7 +924,7 @@ func TestUpdateSubmissionWithNonexistentSubmission(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		ID:           mustParseSubmissionObjectID("nonexistent"),
@@ -829,12 +939,26 @@ func TestUpdateSubmissionWithNonexistentSubmission(t *testing.T) {
 	assert.Equal(t, service.ErrSubmissionNotFound, err)
 }
 
+func TestUpdateSubmissionOfAnotherStudent(t *testing.T) {
+	submissionService := service.NewSubmissionService(&SubmissionMockRepository{}, &AssignmentMockRepository{}, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission := &model.Submission{
+		ID:           mustParseSubmissionObjectID("valid-submission-id"),
+		AssignmentID: "assignment123",
+		StudentUUID:  "student456",
+		Answers:      []model.Answer{{QuestionID: "q1", Content: "Not my answer", Type: "text"}},
+	}
+
+	err := submissionService.UpdateSubmission(context.Background(), submission)
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
 func TestUpdateSubmissionWithRepositoryError(t *testing.T) {
 	submissionRepo := &SubmissionMockRepositoryWithError{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submission := &model.Submission{
 		ID:           mustParseSubmissionObjectID("valid-submission-id"),
@@ -855,7 +979,7 @@ func TestSubmitSubmission(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.SubmitSubmission(context.Background(), "valid-submission-id")
 	assert.NoError(t, err)
@@ -866,7 +990,7 @@ func TestSubmitSubmissionWithNonexistentSubmission(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.SubmitSubmission(context.Background(), "nonexistent")
 	assert.Error(t, err)
@@ -879,7 +1003,7 @@ func TestSubmitSubmissionWithNonexistentAssignment(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepoCustom, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepoCustom, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.SubmitSubmission(context.Background(), "submission-with-bad-assignment")
 	assert.Error(t, err)
@@ -891,7 +1015,7 @@ func TestSubmitSubmissionWithRepositoryError(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	err := submissionService.SubmitSubmission(context.Background(), "valid-submission-id")
 	assert.Error(t, err)
@@ -904,7 +1028,7 @@ func TestGetSubmissionsByAssignment(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submissions, err := submissionService.GetSubmissionsByAssignment(context.Background(), "assignment123")
 	assert.NoError(t, err)
@@ -919,7 +1043,7 @@ func TestGetSubmissionsByAssignmentWithRepositoryError(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submissions, err := submissionService.GetSubmissionsByAssignment(context.Background(), "assignment123")
 	assert.Error(t, err)
@@ -933,7 +1057,7 @@ func TestGetSubmissionsByStudent(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submissions, err := submissionService.GetSubmissionsByStudent(context.Background(), "student123")
 	assert.NoError(t, err)
@@ -948,7 +1072,7 @@ func TestGetSubmissionsByStudentWithRepositoryError(t *testing.T) {
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
 
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	submissions, err := submissionService.GetSubmissionsByStudent(context.Background(), "student123")
 	assert.Error(t, err)
@@ -961,7 +1085,7 @@ func TestAutoCorrectSubmissionWithTextAnswers(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	// Should not crash with nil AI client and should return no error (silently skipped)
 	err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
@@ -972,7 +1096,7 @@ func TestAutoCorrectSubmissionWithFileAnswers(t *testing.T) {
 	submissionRepo := &SubmissionMockRepositoryWithFileAnswers{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	// Should return nil (ignored) for file submissions
 	err := submissionService.AutoCorrectSubmission(context.TODO(), "submission-with-files")
@@ -983,7 +1107,7 @@ func TestAutoCorrectSubmissionWithURLAnswers(t *testing.T) {
 	submissionRepo := &SubmissionMockRepositoryWithURLAnswers{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
 	// Should return nil (ignored) for URL submissions
 	err := submissionService.AutoCorrectSubmission(context.TODO(), "submission-with-urls")
@@ -994,10 +1118,794 @@ func TestAutoCorrectSubmissionWithNonexistentSubmission(t *testing.T) {
 	submissionRepo := &SubmissionMockRepository{}
 	assignmentRepo := &AssignmentMockRepository{}
 	courseService := &CourseMockService{}
-	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, courseService, nil)
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, courseService, nil, nil, nil)
 
-	// When AI client is nil, it returns early without checking submission existence
-	// So this will not return the expected ErrSubmissionNotFound
+	// Multiple choice answers are graded locally, so the submission is looked up even without an AI client
 	err := submissionService.AutoCorrectSubmission(context.TODO(), "nonexistent")
-	assert.NoError(t, err) // No error because AI client check happens first
+	assert.Equal(t, service.ErrSubmissionNotFound, err)
+}
+
+// SubmissionMockRepositoryWithChoiceAnswers returns a submission with multiple choice answers
+// and keeps the last updated submission for assertions
+type SubmissionMockRepositoryWithChoiceAnswers struct {
+	*SubmissionMockRepository
+	answers []model.Answer
+	updated *model.Submission
+}
+
+func (m *SubmissionMockRepositoryWithChoiceAnswers) GetByID(ctx context.Context, id string) (*model.Submission, error) {
+	return &model.Submission{
+		ID:           mustParseSubmissionObjectID(id),
+		AssignmentID: "choice-assignment",
+		StudentUUID:  "student123",
+		Status:       model.SubmissionStatusSubmitted,
+		Answers:      m.answers,
+	}, nil
+}
+
+func (m *SubmissionMockRepositoryWithChoiceAnswers) Update(ctx context.Context, submission *model.Submission) error {
+	m.updated = submission
+	return nil
+}
+
+type AssignmentMockRepositoryWithChoiceQuestions struct {
+	*AssignmentMockRepository
+}
+
+func (m *AssignmentMockRepositoryWithChoiceQuestions) GetByID(ctx context.Context, id string) (*model.Assignment, error) {
+	return &model.Assignment{
+		ID:          primitive.NewObjectID(),
+		Title:       "Quiz",
+		CourseID:    "course123",
+		DueDate:     time.Now().Add(24 * time.Hour),
+		TotalPoints: 10,
+		Questions: []model.Question{
+			{ID: "q1", Type: model.QuestionTypeMultipleChoice, Options: []string{"a", "b", "c"}, CorrectAnswers: []string{"a"}, Points: 4},
+			{ID: "q2", Type: model.QuestionTypeMultipleChoice, Options: []string{"a", "b", "c"}, CorrectAnswers: []string{"a", "b"}, Points: 6, PartialCredit: true},
+		},
+	}, nil
+}
+
+func TestAutoCorrectSubmissionGradesMultipleChoiceWithoutAI(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithChoiceAnswers{
+		answers: []model.Answer{
+			{QuestionID: "q1", Content: "a", Type: "multiple_choice"},
+			{QuestionID: "q2", Content: []interface{}{"b"}, Type: "multiple_choice"},
+		},
+	}
+	assignmentRepo := &AssignmentMockRepositoryWithChoiceQuestions{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.NotNil(t, submissionRepo.updated)
+	assert.Equal(t, 7.0, *submissionRepo.updated.AIScore)
+	assert.False(t, *submissionRepo.updated.NeedsManualReview)
+	assert.Len(t, submissionRepo.updated.AnswerGrades, 2)
+	assert.Equal(t, model.GraderAutomatic, submissionRepo.updated.AnswerGrades[1].GradedBy)
+	assert.Equal(t, 3.0, submissionRepo.updated.AnswerGrades[1].PointsAwarded)
+}
+
+func TestAutoCorrectSubmissionKeepsChoiceGradesWithFileAnswers(t *testing.T) {
+	assignment := &model.Assignment{
+		CourseID:    "course123",
+		TotalPoints: 15,
+		Questions: []model.Question{
+			{ID: "q1", Type: model.QuestionTypeMultipleChoice, Points: 5, Options: []string{"3", "4"}, CorrectAnswers: []string{"4"}},
+			{ID: "q2", Type: model.QuestionTypeFile, Points: 10},
+		},
+	}
+	aiClients := map[string]ai.Provider{
+		"without AI": nil,
+		"with AI":    &InvalidCorrectionAiClient{},
+	}
+
+	for name, aiClient := range aiClients {
+		t.Run(name, func(t *testing.T) {
+			submission := draftSubmission()
+			submission.Status = model.SubmissionStatusSubmitted
+			submission.Answers = []model.Answer{
+				{QuestionID: "q1", Type: string(model.QuestionTypeMultipleChoice), Content: "4"},
+				{QuestionID: "q2", Type: string(model.QuestionTypeFile), Content: primitive.NewObjectID().Hex()},
+			}
+			submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+			assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+			submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, aiClient, nil, nil)
+
+			err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+			assert.NoError(t, err)
+			assert.NotNil(t, submissionRepo.updated)
+			assert.Equal(t, 5.0, *submissionRepo.updated.AIScore)
+			assert.True(t, *submissionRepo.updated.NeedsManualReview)
+			assert.Contains(t, submissionRepo.updated.AIFeedback, "requiere revisión manual")
+			assert.Len(t, submissionRepo.updated.AnswerGrades, 1)
+			assert.Equal(t, "q1", submissionRepo.updated.AnswerGrades[0].QuestionID)
+			assert.Equal(t, model.GraderAutomatic, submissionRepo.updated.AnswerGrades[0].GradedBy)
+		})
+	}
+}
+
+func TestAutoCorrectSubmissionMultipleChoiceScoreIsNeverNegative(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithChoiceAnswers{
+		answers: []model.Answer{
+			{QuestionID: "q1", Content: "c", Type: "multiple_choice"},
+		},
+	}
+	assignmentRepo := &AssignmentMockRepositoryWithChoiceQuestions{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, *submissionRepo.updated.AIScore)
+}
+
+// SubmissionMockRepositoryWithAttempts keeps the attempts of a single student in memory
+type SubmissionMockRepositoryWithAttempts struct {
+	*SubmissionMockRepository
+	attempts []model.Submission
+}
+
+func (m *SubmissionMockRepositoryWithAttempts) Create(ctx context.Context, submission *model.Submission) error {
+	submission.ID = primitive.NewObjectID()
+	m.attempts = append(m.attempts, *submission)
+	return nil
+}
+
+func (m *SubmissionMockRepositoryWithAttempts) GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) (*model.Submission, error) {
+	if len(m.attempts) == 0 {
+		return nil, nil
+	}
+	latest := m.attempts[len(m.attempts)-1]
+	return &latest, nil
+}
+
+func (m *SubmissionMockRepositoryWithAttempts) GetAttemptsByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) ([]model.Submission, error) {
+	return m.attempts, nil
+}
+
+type AssignmentMockRepositoryWithAttempts struct {
+	*AssignmentMockRepository
+	maxAttempts   int
+	scoringPolicy model.AttemptScoringPolicy
+}
+
+func (m *AssignmentMockRepositoryWithAttempts) GetByID(ctx context.Context, id string) (*model.Assignment, error) {
+	return &model.Assignment{
+		ID:            mustParseSubmissionObjectID("assignment123"),
+		CourseID:      "course123",
+		DueDate:       time.Now().Add(24 * time.Hour),
+		MaxAttempts:   m.maxAttempts,
+		ScoringPolicy: m.scoringPolicy,
+	}, nil
+}
+
+func gradedAttempt(attempt int, score float64) model.Submission {
+	return model.Submission{
+		AssignmentID: "assignment123",
+		StudentUUID:  "student123",
+		Attempt:      attempt,
+		Status:       model.SubmissionStatusSubmitted,
+		Score:        &score,
+	}
+}
+
+func TestStartNewAttempt(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithAttempts{attempts: []model.Submission{gradedAttempt(1, 5)}}
+	assignmentRepo := &AssignmentMockRepositoryWithAttempts{maxAttempts: 2}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.StartNewAttempt(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, submission.Attempt)
+	assert.Equal(t, model.SubmissionStatusDraft, submission.Status)
+	assert.Len(t, submissionRepo.attempts, 2)
+}
+
+func TestStartNewAttemptWithAttemptInProgress(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithAttempts{attempts: []model.Submission{{Attempt: 1, Status: model.SubmissionStatusDraft}}}
+	assignmentRepo := &AssignmentMockRepositoryWithAttempts{maxAttempts: 3}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	_, err := submissionService.StartNewAttempt(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.Equal(t, service.ErrAttemptInProgress, err)
+}
+
+func TestStartNewAttemptWithMaxAttemptsReached(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithAttempts{attempts: []model.Submission{gradedAttempt(1, 5)}}
+	// Without max attempts configured only one attempt is allowed
+	assignmentRepo := &AssignmentMockRepositoryWithAttempts{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	_, err := submissionService.StartNewAttempt(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.Equal(t, service.ErrMaxAttemptsReached, err)
+}
+
+func TestGetAttemptHistoryScoringPolicies(t *testing.T) {
+	attempts := []model.Submission{gradedAttempt(1, 8), gradedAttempt(2, 4), {Attempt: 3, Status: model.SubmissionStatusDraft}}
+	expected := map[model.AttemptScoringPolicy]float64{
+		model.AttemptScoringHighest: 8,
+		model.AttemptScoringLatest:  4,
+		model.AttemptScoringAverage: 6,
+		"":                          4,
+	}
+
+	for policy, expectedScore := range expected {
+		submissionRepo := &SubmissionMockRepositoryWithAttempts{attempts: attempts}
+		assignmentRepo := &AssignmentMockRepositoryWithAttempts{maxAttempts: 3, scoringPolicy: policy}
+		submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+		history, err := submissionService.GetAttemptHistory(context.TODO(), "assignment123", "student123")
+		assert.NoError(t, err)
+		assert.Len(t, history.Attempts, 3)
+		assert.Equal(t, 3, history.MaxAttempts)
+		assert.Equal(t, expectedScore, *history.FinalScore, "policy %q", policy)
+	}
+}
+
+func TestSubmitSubmissionAlreadySubmitted(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithURLAnswers{}
+	assignmentRepo := &AssignmentMockRepository{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "submission-with-urls")
+	assert.Equal(t, service.ErrAlreadySubmitted, err)
+}
+
+// SubmissionMockRepositoryWithSubmission always returns the configured submission and keeps the last update
+type SubmissionMockRepositoryWithSubmission struct {
+	*SubmissionMockRepository
+	submission *model.Submission
+	updated    *model.Submission
+}
+
+func (m *SubmissionMockRepositoryWithSubmission) GetByID(ctx context.Context, id string) (*model.Submission, error) {
+	submission := *m.submission
+	return &submission, nil
+}
+
+func (m *SubmissionMockRepositoryWithSubmission) Update(ctx context.Context, submission *model.Submission) error {
+	m.updated = submission
+	return nil
+}
+
+// AssignmentMockRepositoryWithAssignment always returns the configured assignment
+type AssignmentMockRepositoryWithAssignment struct {
+	*AssignmentMockRepository
+	assignment *model.Assignment
+}
+
+func (m *AssignmentMockRepositoryWithAssignment) GetByID(ctx context.Context, id string) (*model.Assignment, error) {
+	return m.assignment, nil
+}
+
+func draftSubmission() *model.Submission {
+	return &model.Submission{
+		ID:           mustParseSubmissionObjectID("valid-submission-id"),
+		AssignmentID: "assignment123",
+		StudentUUID:  "student123",
+		Attempt:      1,
+		Status:       model.SubmissionStatusDraft,
+	}
+}
+
+func TestSubmitSubmissionLateWithRejectPolicy(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate:     time.Now().Add(-2 * time.Hour),
+		GracePeriod: 30,
+		LatePolicy:  model.LatePolicyReject,
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.Equal(t, service.ErrLateSubmission, err)
+	assert.Nil(t, submissionRepo.updated)
+}
+
+func TestSubmitSubmissionWithinGracePeriodIsNotLate(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate:     time.Now().Add(-10 * time.Minute),
+		GracePeriod: 30,
+		LatePolicy:  model.LatePolicyReject,
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusSubmitted, submissionRepo.updated.Status)
+}
+
+func TestSubmitSubmissionLateWithPenaltyPolicy(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		// Thirty hours late counts as two days
+		DueDate:     time.Now().Add(-30 * time.Hour),
+		LatePolicy:  model.LatePolicyPenalty,
+		LatePenalty: 10,
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusLate, submissionRepo.updated.Status)
+	assert.Equal(t, 20.0, submissionRepo.updated.LatePenalty)
+}
+
+func TestGradeSubmissionAppliesLatePenaltyToDerivedScore(t *testing.T) {
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusLate
+	submission.LatePenalty = 25
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		CourseID:  "course123",
+		Questions: []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 8}},
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{QuestionID: "q1", Points: 8}},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 6.0, *gradedSubmission.Score)
+}
+
+func TestUpdateSubmissionLockedAfterSubmit(t *testing.T) {
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusSubmitted
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{DueDate: time.Now().Add(time.Hour)}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.UpdateSubmission(context.TODO(), draftSubmission())
+	assert.Equal(t, service.ErrSubmissionLocked, err)
+}
+
+func TestUpdateSubmissionLockedAfterHardDeadline(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate:    time.Now().Add(-time.Hour),
+		LatePolicy: model.LatePolicyReject,
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.UpdateSubmission(context.TODO(), draftSubmission())
+	assert.Equal(t, service.ErrLateSubmission, err)
+}
+
+func TestUpdateSubmissionKeepsGradingFields(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate:   time.Now().Add(time.Hour),
+		Questions: []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 10}},
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	forgedScore := 100.0
+	update := draftSubmission()
+	update.Status = model.SubmissionStatusSubmitted
+	update.Score = &forgedScore
+	update.Answers = []model.Answer{{QuestionID: "q1", Content: "new answer", Type: "text"}}
+
+	err := submissionService.UpdateSubmission(context.TODO(), update)
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusDraft, submissionRepo.updated.Status)
+	assert.Nil(t, submissionRepo.updated.Score)
+	assert.Equal(t, "new answer", submissionRepo.updated.Answers[0].Content)
+}
+
+type SubmissionMockRepositoryWithTimedExam struct {
+	*SubmissionMockRepository
+	latest  *model.Submission
+	expired []model.Submission
+	created *model.Submission
+	updated []*model.Submission
+}
+
+func (m *SubmissionMockRepositoryWithTimedExam) GetByID(ctx context.Context, id string) (*model.Submission, error) {
+	submission := *m.latest
+	return &submission, nil
+}
+
+func (m *SubmissionMockRepositoryWithTimedExam) GetByAssignmentAndStudent(ctx context.Context, assignmentID, studentUUID string) (*model.Submission, error) {
+	return m.latest, nil
+}
+
+func (m *SubmissionMockRepositoryWithTimedExam) GetExpiredDrafts(ctx context.Context, now time.Time) ([]model.Submission, error) {
+	return m.expired, nil
+}
+
+func (m *SubmissionMockRepositoryWithTimedExam) Create(ctx context.Context, submission *model.Submission) error {
+	submission.ID = primitive.NewObjectID()
+	m.created = submission
+	return nil
+}
+
+func (m *SubmissionMockRepositoryWithTimedExam) Update(ctx context.Context, submission *model.Submission) error {
+	m.updated = append(m.updated, submission)
+	return nil
+}
+
+func timedExam(timeLimitMinutes int) *model.Assignment {
+	return &model.Assignment{
+		ID:               mustParseSubmissionObjectID("assignment123"),
+		CourseID:         "course123",
+		Type:             "exam",
+		DueDate:          time.Now().Add(24 * time.Hour),
+		TimeLimitMinutes: timeLimitMinutes,
+	}
+}
+
+func expiredExamDraft() *model.Submission {
+	startedAt := time.Now().Add(-90 * time.Minute)
+	expiresAt := startedAt.Add(60 * time.Minute)
+	submission := draftSubmission()
+	submission.StartedAt = &startedAt
+	submission.ExpiresAt = &expiresAt
+	submission.Answers = []model.Answer{{QuestionID: "q1", Content: "saved answer", Type: "text"}}
+	return submission
+}
+
+func TestGetOrCreateSubmissionStartsTimedExam(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: timedExam(60)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.NotNil(t, submission.StartedAt)
+	assert.Equal(t, submission.StartedAt.Add(60*time.Minute), *submission.ExpiresAt)
+}
+
+func TestGetOrCreateSubmissionTimedExamCappedByWindow(t *testing.T) {
+	availableUntil := time.Now().Add(20 * time.Minute)
+	assignment := timedExam(60)
+	assignment.AvailableUntil = &availableUntil
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.Equal(t, availableUntil, *submission.ExpiresAt)
+}
+
+func TestGetOrCreateSubmissionTimedExamNotAvailableYet(t *testing.T) {
+	availableFrom := time.Now().Add(time.Hour)
+	assignment := timedExam(60)
+	assignment.AvailableFrom = &availableFrom
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	_, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.Equal(t, service.ErrExamNotAvailable, err)
+	assert.Nil(t, submissionRepo.created)
+}
+
+func TestGetOrCreateSubmissionHomeworkIsNotTimed(t *testing.T) {
+	assignment := timedExam(0)
+	assignment.Type = "homework"
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: assignment}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.Nil(t, submission.StartedAt)
+	assert.Nil(t, submission.ExpiresAt)
+}
+
+func TestGetOrCreateSubmissionAutoSubmitsExpiredExam(t *testing.T) {
+	draft := expiredExamDraft()
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{latest: draft}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: timedExam(60)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	submission, err := submissionService.GetOrCreateSubmission(context.TODO(), "assignment123", "student123", "Test Student")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusSubmitted, submission.Status)
+	assert.True(t, submission.AutoSubmitted)
+	assert.Equal(t, *draft.ExpiresAt, *submission.SubmittedAt)
+}
+
+func TestUpdateSubmissionAfterExamTimeExpired(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{latest: expiredExamDraft()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: timedExam(60)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	update := draftSubmission()
+	update.Answers = []model.Answer{{QuestionID: "q1", Content: "answer after the deadline", Type: "text"}}
+
+	err := submissionService.UpdateSubmission(context.TODO(), update)
+	assert.Equal(t, service.ErrExamTimeExpired, err)
+	assert.NotEmpty(t, submissionRepo.updated)
+	autoSubmitted := submissionRepo.updated[0]
+	assert.Equal(t, model.SubmissionStatusSubmitted, autoSubmitted.Status)
+	assert.Equal(t, "saved answer", autoSubmitted.Answers[0].Content)
+}
+
+func TestAutoSubmitExpiredExams(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{latest: expiredExamDraft(), expired: []model.Submission{*expiredExamDraft()}}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: timedExam(60)}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	err := submissionService.AutoSubmitExpiredExams(context.TODO())
+	assert.NoError(t, err)
+	assert.NotEmpty(t, submissionRepo.updated)
+	assert.True(t, submissionRepo.updated[0].AutoSubmitted)
+	assert.Equal(t, model.SubmissionStatusSubmitted, submissionRepo.updated[0].Status)
+}
+
+func TestAutoSubmitExpiredExamsIsAudited(t *testing.T) {
+	draft := expiredExamDraft()
+	submissionRepo := &SubmissionMockRepositoryWithTimedExam{latest: draft, expired: []model.Submission{*draft}}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: timedExam(60)}
+	recorder := &MockAuditRecorder{}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, recorder)
+
+	err := submissionService.AutoSubmitExpiredExams(context.TODO())
+	assert.NoError(t, err)
+	assertSystemChanges(t, recorder, model.AuditEntitySubmission, "exam_auto_submit", draft.ID.Hex())
+}
+
+func TestAutoCorrectSubmissionIsAudited(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithChoiceAnswers{
+		answers: []model.Answer{{QuestionID: "q1", Content: "a", Type: "multiple_choice"}},
+	}
+	recorder := &MockAuditRecorder{}
+	submissionService := service.NewSubmissionService(submissionRepo, &AssignmentMockRepositoryWithChoiceQuestions{}, nil, nil, nil, &CourseMockService{}, nil, nil, recorder)
+
+	submissionID := primitive.NewObjectID().Hex()
+	err := submissionService.AutoCorrectSubmission(context.TODO(), submissionID)
+	assert.NoError(t, err)
+	assertSystemChanges(t, recorder, model.AuditEntitySubmission, "ai_correction", submissionID)
+}
+
+func TestMarkCorrectionFailedIsAudited(t *testing.T) {
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusSubmitted
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	recorder := &MockAuditRecorder{}
+	submissionService := service.NewSubmissionService(submissionRepo, &AssignmentMockRepository{}, nil, nil, nil, &CourseMockService{}, nil, nil, recorder)
+
+	err := submissionService.MarkCorrectionFailed(context.TODO(), submission.ID.Hex())
+	assert.NoError(t, err)
+	assert.True(t, *submissionRepo.updated.NeedsManualReview)
+	assertSystemChanges(t, recorder, model.AuditEntitySubmission, "ai_correction_failed", submission.ID.Hex())
+
+	// A submission that can't be flagged is not recorded
+	recorder.records = nil
+	submissionService = service.NewSubmissionService(&SubmissionMockRepository{}, &AssignmentMockRepository{}, nil, nil, nil, &CourseMockService{}, nil, nil, recorder)
+	err = submissionService.MarkCorrectionFailed(context.TODO(), "nonexistent")
+	assert.ErrorIs(t, err, service.ErrSubmissionNotFound)
+	assert.Empty(t, recorder.records)
+}
+
+func TestUpdateSubmissionValidatesTypedAnswers(t *testing.T) {
+	expected := 42.0
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate: time.Now().Add(time.Hour),
+		Questions: []model.Question{
+			{ID: "q1", Type: model.QuestionTypeNumeric, NumericAnswer: &expected, Points: 1},
+			{ID: "q2", Type: model.QuestionTypeTrueFalse, CorrectAnswers: []string{"true"}, Points: 1},
+			{ID: "q3", Type: model.QuestionTypeOrdering, Options: []string{"a", "b"}, CorrectAnswers: []string{"b", "a"}, Points: 1},
+		},
+	}}
+
+	invalidAnswers := [][]model.Answer{
+		{{QuestionID: "q1", Content: "forty two"}},
+		{{QuestionID: "q2", Content: "yes"}},
+		{{QuestionID: "q3", Content: []interface{}{"a", "a"}}},
+		{{QuestionID: "unknown", Content: "answer"}},
+	}
+	for _, answers := range invalidAnswers {
+		submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+		submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+		update := draftSubmission()
+		update.Answers = answers
+		err := submissionService.UpdateSubmission(context.TODO(), update)
+		assert.ErrorIs(t, err, service.ErrInvalidAnswer)
+	}
+
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+	update := draftSubmission()
+	update.Answers = []model.Answer{
+		{QuestionID: "q1", Content: 42.0},
+		{QuestionID: "q2", Content: true},
+		{QuestionID: "q3", Content: []interface{}{"b", "a"}},
+	}
+	err := submissionService.UpdateSubmission(context.TODO(), update)
+	assert.NoError(t, err)
+	// The answer type is taken from the question
+	assert.Equal(t, string(model.QuestionTypeNumeric), submissionRepo.updated.Answers[0].Type)
+}
+
+func essayRubric() *model.Rubric {
+	return &model.Rubric{Criteria: []model.RubricCriterion{
+		{ID: "clarity", Title: "Clarity", Levels: []model.RubricLevel{{ID: "low", Points: 0}, {ID: "high", Points: 2}}},
+		{ID: "depth", Title: "Depth", Levels: []model.RubricLevel{{ID: "low", Points: 1}, {ID: "high", Points: 3}}},
+	}}
+}
+
+func TestGradeSubmissionWithQuestionRubric(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		CourseID:  "course123",
+		Questions: []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 10, Rubric: essayRubric()}},
+	}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		AnswerGrades: []schemas.AnswerGradeRequest{{
+			QuestionID: "q1",
+			Points:     10, // Ignored, the rubric computes the points
+			RubricSelections: []model.RubricSelection{
+				{CriterionID: "clarity", LevelID: "high"},
+				{CriterionID: "depth", LevelID: "low"},
+			},
+		}},
+	})
+	assert.NoError(t, err)
+	// 3 of the 5 rubric points scaled to the 10 points of the question
+	assert.Equal(t, 6.0, *gradedSubmission.Score)
+	assert.Len(t, gradedSubmission.AnswerGrades[0].RubricSelections, 2)
+}
+
+func TestGradeSubmissionWithAssignmentRubric(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{CourseID: "course123", TotalPoints: 5, Rubric: essayRubric()}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	gradedSubmission, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+		RubricSelections: []model.RubricSelection{
+			{CriterionID: "clarity", LevelID: "high"},
+			{CriterionID: "depth", LevelID: "high"},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 5.0, *gradedSubmission.Score)
+}
+
+func TestGradeSubmissionWithIncompleteRubricSelections(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{CourseID: "course123", TotalPoints: 5, Rubric: essayRubric()}}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, nil, nil)
+
+	invalidSelections := [][]model.RubricSelection{
+		{{CriterionID: "clarity", LevelID: "high"}},
+		{{CriterionID: "clarity", LevelID: "high"}, {CriterionID: "depth", LevelID: "unknown"}},
+		{{CriterionID: "clarity", LevelID: "high"}, {CriterionID: "depth", LevelID: "low"}, {CriterionID: "style", LevelID: "low"}},
+	}
+	for _, selections := range invalidSelections {
+		_, err := submissionService.GradeSubmission(context.TODO(), "valid-submission-id", "teacher123", schemas.GradeSubmissionRequest{
+			RubricSelections: selections,
+		})
+		assert.ErrorIs(t, err, service.ErrInvalidAnswerGrade)
+	}
+}
+
+func TestUpdateSubmissionChecksFileAnswers(t *testing.T) {
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		DueDate:   time.Now().Add(time.Hour),
+		Questions: []model.Question{{ID: "q1", Type: model.QuestionTypeFile, Points: 1}},
+	}}
+	ownFile := &model.StoredFile{ID: primitive.NewObjectID(), OwnerUUID: "student123", Purpose: model.FilePurposeSubmission}
+	otherFile := &model.StoredFile{ID: primitive.NewObjectID(), OwnerUUID: "student456", Purpose: model.FilePurposeSubmission}
+	fileRepo := &FileMockRepository{files: map[string]*model.StoredFile{
+		ownFile.ID.Hex():   ownFile,
+		otherFile.ID.Hex(): otherFile,
+	}}
+
+	for _, content := range []interface{}{"https://example.com/tp1.pdf", otherFile.ID.Hex(), primitive.NewObjectID().Hex()} {
+		submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+		submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, fileRepo, &CourseMockService{}, nil, nil, nil)
+		update := draftSubmission()
+		update.Answers = []model.Answer{{QuestionID: "q1", Content: content}}
+		err := submissionService.UpdateSubmission(context.TODO(), update)
+		assert.ErrorIs(t, err, service.ErrInvalidAnswer)
+	}
+
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, fileRepo, &CourseMockService{}, nil, nil, nil)
+	update := draftSubmission()
+	update.Answers = []model.Answer{{QuestionID: "q1", Content: ownFile.ID.Hex()}}
+	err := submissionService.UpdateSubmission(context.TODO(), update)
+	assert.NoError(t, err)
+}
+
+func TestSubmitSubmissionQueuesCorrection(t *testing.T) {
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: draftSubmission()}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		CourseID:  "course123",
+		DueDate:   time.Now().Add(time.Hour),
+		Questions: []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 10}},
+	}}
+	jobRepo := &CorrectionJobMockRepository{}
+	correctionQueue := service.NewCorrectionQueue(jobRepo, nil, testCorrectionSettings())
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, nil, correctionQueue, nil)
+
+	_, err := submissionService.GetCorrectionStatus(context.TODO(), "valid-submission-id", "student123")
+	assert.ErrorIs(t, err, service.ErrCorrectionJobNotFound)
+
+	err = submissionService.SubmitSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, model.SubmissionStatusSubmitted, submissionRepo.updated.Status)
+	// The correction is left to the workers
+	assert.Nil(t, submissionRepo.updated.AIScore)
+
+	submissionID := draftSubmission().ID.Hex()
+	for _, userUUID := range []string{"student123", "teacher123", "aux-teacher1"} {
+		job, err := submissionService.GetCorrectionStatus(context.TODO(), submissionID, userUUID)
+		assert.NoError(t, err)
+		assert.Equal(t, model.CorrectionJobPending, job.Status)
+		assert.Equal(t, "assignment123", job.AssignmentID)
+	}
+
+	_, err = submissionService.GetCorrectionStatus(context.TODO(), submissionID, "student456")
+	assert.ErrorIs(t, err, service.ErrUnauthorized)
+}
+
+func TestAutoCorrectSubmissionWithProvider(t *testing.T) {
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusSubmitted
+	submission.Answers = []model.Answer{{QuestionID: "q1", Type: string(model.QuestionTypeText), Content: "Una respuesta"}}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		CourseID:    "course123",
+		TotalPoints: 10,
+		Questions:   []model.Question{{ID: "q1", Type: model.QuestionTypeText, Points: 10}},
+	}}
+
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, ai.NewFakeProvider(), nil, nil)
+	err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.Equal(t, 8.0, *submissionRepo.updated.AIScore)
+	assert.False(t, *submissionRepo.updated.NeedsManualReview)
+	assert.Equal(t, ai.FakePromptVersion, submissionRepo.updated.AIPromptVersion)
+
+	submissionRepo = &SubmissionMockRepositoryWithSubmission{submission: submission}
+	submissionService = service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, &MockAiClient{shouldSucceed: false}, nil, nil)
+	err = submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+	assert.ErrorIs(t, err, service.ErrAICorrectionFailed)
+	assert.Nil(t, submissionRepo.updated)
+}
+
+// InvalidCorrectionAiClient answers corrections that don't pass the validation
+type InvalidCorrectionAiClient struct {
+	MockAiClient
+}
+
+func (m *InvalidCorrectionAiClient) CorrectSubmission(ctx context.Context, assignment *model.Assignment, submission *model.Submission) (*schemas.AiCorrectionResponse, error) {
+	return nil, fmt.Errorf("%w: score 12 is out of range [0, 10]", ai.ErrInvalidCorrection)
+}
+
+func TestAutoCorrectSubmissionWithInvalidCorrection(t *testing.T) {
+	submission := draftSubmission()
+	submission.Status = model.SubmissionStatusSubmitted
+	submission.Answers = []model.Answer{
+		{QuestionID: "q1", Type: string(model.QuestionTypeText), Content: "Una respuesta"},
+		{QuestionID: "q2", Type: string(model.QuestionTypeMultipleChoice), Content: "4"},
+	}
+	assignmentRepo := &AssignmentMockRepositoryWithAssignment{assignment: &model.Assignment{
+		CourseID:    "course123",
+		TotalPoints: 15,
+		Questions: []model.Question{
+			{ID: "q1", Type: model.QuestionTypeText, Points: 10},
+			{ID: "q2", Type: model.QuestionTypeMultipleChoice, Points: 5, Options: []string{"3", "4"}, CorrectAnswers: []string{"4"}},
+		},
+	}}
+	submissionRepo := &SubmissionMockRepositoryWithSubmission{submission: submission}
+	submissionService := service.NewSubmissionService(submissionRepo, assignmentRepo, nil, nil, nil, &CourseMockService{}, &InvalidCorrectionAiClient{}, nil, nil)
+
+	// The correction is not retried, the submission is left for the teacher
+	err := submissionService.AutoCorrectSubmission(context.TODO(), "valid-submission-id")
+	assert.NoError(t, err)
+	assert.True(t, *submissionRepo.updated.NeedsManualReview)
+	assert.Nil(t, submissionRepo.updated.AIScore)
+	assert.Contains(t, submissionRepo.updated.AIFeedback, "Requiere revisión manual")
+	assert.Len(t, submissionRepo.updated.AnswerGrades, 1)
+	assert.Equal(t, 5.0, submissionRepo.updated.AnswerGrades[0].PointsAwarded)
 }